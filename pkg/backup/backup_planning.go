@@ -112,6 +112,7 @@ func GetRedactedBackupNode(
 		AsOf:           backup.AsOf,
 		Targets:        backup.Targets,
 		AppendToLatest: backup.AppendToLatest,
+		Compact:        backup.Compact,
 	}
 
 	// We set Subdir to the directory resolved during BACKUP planning.
@@ -369,7 +370,7 @@ func backupTypeCheck(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
 ) (matched bool, header colinfo.ResultColumns, _ error) {
 	backupStmt := getBackupStatement(stmt)
-	if backupStmt == nil {
+	if backupStmt == nil || backupStmt.Compact != nil {
 		return false, nil, nil
 	}
 	detached := backupStmt.Options.Detached == tree.DBoolTrue
@@ -405,7 +406,7 @@ func backupPlanHook(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
 ) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
 	backupStmt := getBackupStatement(stmt)
-	if backupStmt == nil {
+	if backupStmt == nil || backupStmt.Compact != nil {
 		return nil, nil, false, nil
 	}
 	if err := featureflag.CheckEnabled(
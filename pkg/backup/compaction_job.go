@@ -0,0 +1,778 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logutil"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	gogotypes "github.com/gogo/protobuf/types"
+)
+
+var compactionWorkerCount = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"bulkio.backup.compaction.max_workers",
+	"maximum number of workers to use for reading and rewriting backup data in a BACKUP COMPACT job",
+	4,
+	settings.PositiveInt,
+)
+
+// compactionChunkSize is the amount of key and value bytes a compaction worker
+// buffers before handing an SST to its sink. The sink merges adjacent chunks
+// into files of backup.file_size, so this only bounds worker memory.
+const compactionChunkSize = 16 << 20
+
+// compactedLayerStartFormat is the layout of the start time suffix of the
+// directory a compacted incremental layer is written to. The directory is named
+// after the end time of the layer using backupbase.DateBasedIncFolderName, so
+// that it sorts right after the last layer it replaces and is picked by
+// backupdest.ElideSkippedLayers in place of the layers it compacts.
+const compactedLayerStartFormat = "20060102-150405.00"
+
+// compactedLayerSubdir returns the directory, relative to the incremental
+// storage of a chain, of an incremental layer compacting [start, end].
+func compactedLayerSubdir(start, end hlc.Timestamp) string {
+	return end.GoTime().Format(backupbase.DateBasedIncFolderName) + "-" +
+		start.GoTime().Format(compactedLayerStartFormat)
+}
+
+// resumeCompaction runs a BACKUP COMPACT job. It merges the layers of a backup
+// chain between the start and end time of the job into a single layer, reading
+// and writing only external storage. If the start time is empty the layers are
+// compacted into a new full backup in the collection, otherwise into a new
+// incremental layer of the same chain that supersedes the layers it compacts.
+func (b *backupResumer) resumeCompaction(
+	ctx context.Context, p sql.JobExecContext, details jobspb.BackupDetails,
+) error {
+	ctx, sp := tracing.ChildSpan(ctx, "backup.resumeCompaction")
+	defer sp.Finish()
+
+	execCfg := p.ExecCfg()
+	user := p.User()
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	dest := details.Destination
+
+	subdir := dest.Subdir
+	if strings.EqualFold(subdir, backupbase.LatestFileName) {
+		latest, err := backupdest.ReadLatestFile(ctx, dest.To[0], mkStore, user)
+		if err != nil {
+			return err
+		}
+		subdir = latest
+	}
+
+	baseDirs, err := backuputils.AppendPaths(dest.To, subdir)
+	if err != nil {
+		return err
+	}
+	incDirs, err := backupdest.ResolveIncrementalsBackupLocation(
+		ctx, user, execCfg, dest.IncrementalStorage, dest.To, subdir)
+	if err != nil {
+		return err
+	}
+	baseStores, cleanupBase, err := backupdest.MakeBackupDestinationStores(ctx, user, mkStore, baseDirs)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanupBase(); err != nil {
+			log.Warningf(ctx, "failed to close backup storage: %+v", err)
+		}
+	}()
+	incStores, cleanupInc, err := backupdest.MakeBackupDestinationStores(ctx, user, mkStore, incDirs)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanupInc(); err != nil {
+			log.Warningf(ctx, "failed to close incremental backup storage: %+v", err)
+		}
+	}()
+
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		execCfg.Settings, &execCfg.ExternalIODirConfig, execCfg.InternalDB, user,
+	)
+	encryption, err := backupencryption.GetEncryptionFromBase(
+		ctx, user, mkStore, baseDirs[0], *details.EncryptionOptions, &kmsEnv,
+	)
+	if err != nil {
+		return err
+	}
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+
+	_, manifests, localityInfo, memSize, err := backupdest.ResolveBackupManifests(
+		ctx, &mem, baseStores, incStores, mkStore, baseDirs, incDirs, hlc.Timestamp{},
+		encryption, &kmsEnv, user, false, /* includeSkipped */
+	)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	first, last, err := selectCompactionLayers(manifests, localityInfo, details.StartTime, details.EndTime)
+	if err != nil {
+		return err
+	}
+	layers := manifests[first : last+1]
+	toFull := first == 0
+
+	var destURI string
+	if toFull {
+		uris, err := backuputils.AppendPaths(
+			dest.To[:1], layers[len(layers)-1].EndTime.GoTime().Format(backupbase.DateBasedIntoFolderName))
+		if err != nil {
+			return err
+		}
+		destURI = uris[0]
+	} else {
+		uris, err := backuputils.AppendPaths(
+			incDirs[:1], compactedLayerSubdir(layers[0].StartTime, layers[len(layers)-1].EndTime))
+		if err != nil {
+			return err
+		}
+		destURI = uris[0]
+	}
+
+	if details.URI == "" {
+		details.URI = destURI
+		if err := b.job.NoTxn().Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Payload.Details = jobspb.WrapPayloadDetails(details)
+			ju.UpdatePayload(md.Payload)
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else if details.URI != destURI {
+		return errors.Newf(
+			"backup chain changed since compaction started: expected to write to %s, now %s",
+			details.URI, destURI)
+	}
+
+	foundLockFile, err := backupinfo.CheckForBackupLock(ctx, execCfg, destURI, b.job.ID(), user)
+	if err != nil {
+		return err
+	}
+	if !foundLockFile {
+		if err := backupinfo.CheckForPreviousBackup(ctx, execCfg, destURI, b.job.ID(), user); err != nil {
+			return err
+		}
+		if err := backupinfo.WriteBackupLock(ctx, execCfg, destURI, b.job.ID(), user); err != nil {
+			return err
+		}
+	}
+
+	destStore, err := mkStore(ctx, destURI, user)
+	if err != nil {
+		return err
+	}
+	defer destStore.Close()
+
+	if toFull && encryption != nil {
+		// The encryption key of a chain is derived from the ENCRYPTION-INFO files
+		// of its full backup, so a new full backup needs its own copy of them.
+		if err := copyEncryptionInfo(ctx, baseStores[0], destStore); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := compactLayers(ctx, p, layers, localityInfo[first:last+1], destStore, encryption, &kmsEnv, toFull)
+	if err != nil {
+		return err
+	}
+	if err := checkCoverage(ctx, manifest.Spans, append(manifests[:first:first], manifest)); err != nil {
+		return errors.Wrap(err, "compacted backup would not cover expected time")
+	}
+
+	if err := backupinfo.WriteBackupManifest(ctx, destStore, backupbase.BackupManifestName,
+		encryption, &kmsEnv, &manifest); err != nil {
+		return err
+	}
+	if backupinfo.WriteMetadataWithExternalSSTsEnabled.Get(&execCfg.Settings.SV) {
+		if err := backupinfo.WriteMetadataWithExternalSSTs(ctx, destStore, encryption,
+			&kmsEnv, &manifest); err != nil {
+			return err
+		}
+	}
+
+	// The compacted layer describes the same descriptors as the last layer it
+	// replaces, so it carries over that layer's table statistics.
+	lastStore, err := execCfg.DistSQLSrv.ExternalStorage(ctx, layers[len(layers)-1].Dir)
+	if err != nil {
+		return err
+	}
+	defer lastStore.Close()
+	statistics, err := backupinfo.GetStatisticsFromBackup(ctx, lastStore, encryption, &kmsEnv, layers[len(layers)-1])
+	if err != nil {
+		return errors.Wrap(err, "reading statistics of the last compacted layer")
+	}
+	if err := backupinfo.WriteTableStatistics(ctx, destStore, encryption, &kmsEnv,
+		&backuppb.StatsTable{Statistics: statistics}); err != nil {
+		return err
+	}
+
+	if err := validateCompactedBackup(ctx, execCfg, &mem, destStore, destURI, encryption, &kmsEnv, manifest); err != nil {
+		return err
+	}
+
+	pkIDs := make(map[uint64]bool)
+	for i := range manifest.Descriptors {
+		if t, _, _, _, _ := descpb.GetDescriptors(&manifest.Descriptors[i]); t != nil {
+			pkIDs[kvpb.BulkOpSummaryID(uint64(t.ID), uint64(t.PrimaryIndex.ID))] = true
+		}
+	}
+	res := countRows(manifest.EntryCounts, pkIDs)
+	b.backupStats = res
+
+	telemetry.Count("backup.compaction.succeeded")
+	log.Infof(ctx, "compacted %d backup layers ending at %s into %s in %s",
+		len(layers), manifest.EndTime, destURI,
+		timeutil.Since(timeutil.FromUnixMicros(b.job.Payload().StartedMicros)))
+	logutil.LogJobCompletion(ctx, b.getTelemetryEventType(), b.job.ID(), true, nil, res.Rows)
+	return nil
+}
+
+// selectCompactionLayers returns the indexes of the first and last layers of
+// the chain that a compaction between start and end merges. An empty start
+// selects the full backup of the chain. Times are compared at microsecond
+// precision, which is the precision of the times shown by SHOW BACKUP.
+func selectCompactionLayers(
+	manifests []backuppb.BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+	start, end hlc.Timestamp,
+) (first, last int, _ error) {
+	sameTime := func(a, b hlc.Timestamp) bool {
+		return a.GoTime().Truncate(time.Microsecond).Equal(b.GoTime().Truncate(time.Microsecond))
+	}
+	first, last = -1, -1
+	if start.IsEmpty() {
+		first = 0
+	}
+	for i := range manifests {
+		if !start.IsEmpty() && i > 0 && sameTime(manifests[i].StartTime, start) {
+			first = i
+		}
+		if sameTime(manifests[i].EndTime, end) {
+			last = i
+		}
+	}
+	if first < 0 {
+		return 0, 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"no incremental backup in the chain starts at %s", start.GoTime())
+	}
+	if last < 0 {
+		return 0, 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"no backup in the chain ends at %s", end.GoTime())
+	}
+	if last <= first {
+		return 0, 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"BACKUP COMPACT requires at least two backups between %s and %s",
+			manifests[first].StartTime.GoTime(), end.GoTime())
+	}
+	for i := first; i <= last; i++ {
+		if manifests[i].MVCCFilter == backuppb.MVCCFilter_All {
+			return 0, 0, unimplemented.New("backup compaction",
+				"compacting backups taken with revision_history is not supported")
+		}
+		if len(localityInfo[i].URIsByOriginalLocalityKV) > 0 {
+			return 0, 0, unimplemented.New("backup compaction",
+				"compacting locality aware backups is not supported")
+		}
+		if manifests[i].ElidedPrefix != manifests[first].ElidedPrefix {
+			return 0, 0, errors.Newf(
+				"cannot compact backups that elide different key prefixes (%s and %s)",
+				manifests[first].ElidedPrefix, manifests[i].ElidedPrefix)
+		}
+	}
+	return first, last, nil
+}
+
+// compactLayers merges the data of the given layers of a chain into the
+// destination and returns the manifest of the compacted layer. If toFull is
+// set, the layers start with the full backup of the chain and deletion
+// tombstones are dropped from the output.
+func compactLayers(
+	ctx context.Context,
+	p sql.JobExecContext,
+	layers []backuppb.BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+	dest cloud.ExternalStorage,
+	encryption *jobspb.BackupEncryptionOptions,
+	kmsEnv cloud.KMSEnv,
+	toFull bool,
+) (backuppb.BackupManifest, error) {
+	execCfg := p.ExecCfg()
+	sv := &execCfg.Settings.SV
+	lastLayer := layers[len(layers)-1]
+
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, layers, encryption, kmsEnv)
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+
+	descIt := layerToIterFactory[len(layers)-1].NewDescIter(ctx)
+	defer descIt.Close()
+	var descs []descpb.Descriptor
+	pkIDs := make(map[uint64]bool)
+	for ; ; descIt.Next() {
+		if ok, err := descIt.Valid(); err != nil {
+			return backuppb.BackupManifest{}, err
+		} else if !ok {
+			break
+		}
+		desc := *descIt.Value()
+		if t, _, _, _, _ := descpb.GetDescriptors(&desc); t != nil {
+			pkIDs[kvpb.BulkOpSummaryID(uint64(t.ID), uint64(t.PrimaryIndex.ID))] = true
+		}
+		descs = append(descs, desc)
+	}
+
+	backupLocalityMap, err := makeBackupLocalityMap(localityInfo, p.User())
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(layers, hlc.Timestamp{})
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	defer introducedSpanFrontier.Release()
+
+	filter, err := makeSpanCoveringFilter(
+		lastLayer.Spans,
+		nil, /* checkpointedSpans */
+		introducedSpanFrontier,
+		targetRestoreSpanSize.Get(sv),
+		maxFileCount.Get(sv),
+	)
+	if err != nil {
+		return backuppb.BackupManifest{}, err
+	}
+	defer filter.close()
+
+	var fileEnc *kvpb.FileEncryptionOptions
+	if encryption != nil {
+		key, err := backupencryption.GetEncryptionKey(ctx, encryption, kmsEnv)
+		if err != nil {
+			return backuppb.BackupManifest{}, err
+		}
+		fileEnc = &kvpb.FileEncryptionOptions{Key: key}
+	}
+	sinkConf := backupsink.SSTSinkConf{
+		ID:        execCfg.NodeInfo.NodeID.SQLInstanceID(),
+		Enc:       fileEnc,
+		Settings:  sv,
+		ElideMode: lastLayer.ElidedPrefix,
+	}
+
+	c := layerCompactor{
+		execCfg:        execCfg,
+		enc:            fileEnc,
+		endTime:        lastLayer.EndTime,
+		pkIDs:          pkIDs,
+		dropTombstones: toFull,
+	}
+
+	entryCh := make(chan execinfrapb.RestoreSpanEntry, 100)
+	progCh := make(chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
+	sinkConf.ProgCh = progCh
+	var files []backuppb.BackupManifest_File
+
+	grp := ctxgroup.WithContext(ctx)
+	grp.GoCtx(func(ctx context.Context) error {
+		defer close(entryCh)
+		return errors.Wrap(generateAndSendImportSpans(
+			ctx,
+			lastLayer.Spans,
+			layers,
+			layerToIterFactory,
+			backupLocalityMap,
+			filter,
+			&exclusiveEndKeyComparator{},
+			entryCh,
+		), "generate and send import spans")
+	})
+	grp.GoCtx(func(ctx context.Context) error {
+		defer close(progCh)
+		workers := int(compactionWorkerCount.Get(sv))
+		return ctxgroup.GroupWorkers(ctx, workers, func(ctx context.Context, _ int) error {
+			sink := backupsink.MakeFileSSTSink(sinkConf, dest, nil /* pacer */)
+			defer func() {
+				if err := sink.Close(); err != nil {
+					log.Warningf(ctx, "failed to close compaction sink: %+v", err)
+				}
+			}()
+			for entry := range entryCh {
+				if err := c.compactEntry(ctx, entry, sink); err != nil {
+					return err
+				}
+			}
+			return sink.Flush(ctx)
+		})
+	})
+	grp.GoCtx(func(ctx context.Context) error {
+		for prog := range progCh {
+			var progDetails backuppb.BackupManifest_Progress
+			if err := gogotypes.UnmarshalAny(&prog.ProgressDetails, &progDetails); err != nil {
+				return err
+			}
+			files = append(files, progDetails.Files...)
+		}
+		return nil
+	})
+	if err := grp.Wait(); err != nil {
+		return backuppb.BackupManifest{}, errors.Wrap(err, "compacting backup data")
+	}
+
+	var entryCounts roachpb.RowCount
+	for i := range files {
+		if files[i].HasRangeKeys {
+			return backuppb.BackupManifest{}, errors.AssertionFailedf(
+				"compacted file %s unexpectedly contains range keys", files[i].Path)
+		}
+		entryCounts.Add(files[i].EntryCounts)
+	}
+
+	m := backuppb.BackupManifest{
+		ID:                 uuid.MakeV4(),
+		EndTime:            lastLayer.EndTime,
+		MVCCFilter:         backuppb.MVCCFilter_Latest,
+		Descriptors:        descs,
+		Tenants:            lastLayer.Tenants,
+		CompleteDbs:        lastLayer.CompleteDbs,
+		Spans:              lastLayer.Spans,
+		Files:              files,
+		EntryCounts:        entryCounts,
+		FormatVersion:      backupinfo.BackupFormatDescriptorTrackingVersion,
+		BuildInfo:          build.GetInfo(),
+		ClusterVersion:     execCfg.Settings.Version.ActiveVersion(ctx).Version,
+		ClusterID:          execCfg.NodeInfo.LogicalClusterID(),
+		DescriptorCoverage: lastLayer.DescriptorCoverage,
+		ElidedPrefix:       lastLayer.ElidedPrefix,
+	}
+	if !toFull {
+		m.StartTime = layers[0].StartTime
+		for i := range layers {
+			m.IntroducedSpans = append(m.IntroducedSpans, layers[i].IntroducedSpans...)
+		}
+		m.IntroducedSpans, _ = roachpb.MergeSpans(&m.IntroducedSpans)
+	}
+	return m, nil
+}
+
+// layerCompactor rewrites the restore span entries covering the layers being
+// compacted into backup files.
+type layerCompactor struct {
+	execCfg *sql.ExecutorConfig
+	enc     *kvpb.FileEncryptionOptions
+	// endTime is the end time of the last compacted layer. Keys are read as of
+	// this time.
+	endTime hlc.Timestamp
+	pkIDs   map[uint64]bool
+	// dropTombstones is set when the output is a full backup, which has no
+	// earlier layer whose keys a tombstone could shadow.
+	dropTombstones bool
+}
+
+// compactEntry writes the latest revision of every key in the entry's span,
+// across all the files of the entry, to the sink.
+func (c *layerCompactor) compactEntry(
+	ctx context.Context, entry execinfrapb.RestoreSpanEntry, sink *backupsink.FileSSTSink,
+) error {
+	if len(entry.Files) == 0 {
+		return nil
+	}
+	storeFiles := make([]storageccl.StoreFile, 0, len(entry.Files))
+	defer func() {
+		for _, f := range storeFiles {
+			if err := f.Store.Close(); err != nil {
+				log.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, file := range entry.Files {
+		if file.HasRangeKeys {
+			return unimplemented.New("backup compaction",
+				"compacting backups that contain range keys is not supported")
+		}
+		dir, err := c.execCfg.DistSQLSrv.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return err
+		}
+		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+	}
+
+	iterOpts := storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsOnly,
+		LowerBound: keys.LocalMax,
+		UpperBound: keys.MaxKey,
+	}
+	sstIter, err := storageccl.ExternalSSTReader(ctx, storeFiles, c.enc, iterOpts)
+	if err != nil {
+		return err
+	}
+	iter, err := storage.NewBackupCompactionIterator(sstIter, c.endTime)
+	if err != nil {
+		sstIter.Close()
+		return err
+	}
+	defer iter.Close()
+
+	elidedPrefix, err := backupsink.ElidedPrefix(entry.Span.Key, entry.ElidedPrefix)
+	if err != nil {
+		return err
+	}
+	startKey := storage.MVCCKey{Key: bytes.TrimPrefix(entry.Span.Key, elidedPrefix)}
+
+	var w chunkWriter
+	defer w.close()
+	chunkStart := entry.Span.Key
+	var keyScratch []byte
+	for iter.SeekGE(startKey); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		keyScratch = append(append(keyScratch[:0], elidedPrefix...), key.Key...)
+		key.Key = keyScratch
+		if key.Key.Compare(entry.Span.EndKey) >= 0 {
+			break
+		}
+
+		_, isTombstone, err := iter.MVCCValueLenAndIsTombstone()
+		if err != nil {
+			return err
+		}
+		if isTombstone && c.dropTombstones {
+			continue
+		}
+
+		if w.size() >= compactionChunkSize {
+			// Only cut a chunk at a row boundary, so that a row never straddles two
+			// files.
+			if boundary, err := keys.EnsureSafeSplitKey(key.Key); err == nil &&
+				boundary.Compare(w.lastKey) > 0 {
+				boundary = boundary.Clone()
+				if err := c.flushChunk(ctx, &w, roachpb.Span{Key: chunkStart, EndKey: boundary}, sink); err != nil {
+					return err
+				}
+				chunkStart = boundary
+			}
+		}
+
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		if err := w.put(ctx, c.execCfg, key, v, isTombstone); err != nil {
+			return err
+		}
+	}
+	return c.flushChunk(ctx, &w, roachpb.Span{Key: chunkStart, EndKey: entry.Span.EndKey}, sink)
+}
+
+// flushChunk hands the keys buffered in w, which all lie in span, to the sink
+// and resets w.
+func (c *layerCompactor) flushChunk(
+	ctx context.Context, w *chunkWriter, span roachpb.Span, sink *backupsink.FileSSTSink,
+) error {
+	if w.sst == nil {
+		return nil
+	}
+	data, summary, err := w.finish()
+	if err != nil {
+		return err
+	}
+	_, err = sink.Write(ctx, backupsink.ExportedSpan{
+		Metadata: backuppb.BackupManifest_File{
+			Span:                    span,
+			EntryCounts:             countRows(summary, c.pkIDs),
+			ApproximatePhysicalSize: uint64(len(data)),
+		},
+		DataSST: data,
+	})
+	return err
+}
+
+// chunkWriter buffers a chunk of compacted keys in an in-memory SST.
+type chunkWriter struct {
+	sst     *storage.SSTWriter
+	data    *storage.MemObject
+	counter storage.RowCounter
+	lastKey roachpb.Key
+}
+
+func (w *chunkWriter) size() int64 {
+	if w.sst == nil {
+		return 0
+	}
+	return w.sst.DataSize
+}
+
+func (w *chunkWriter) put(
+	ctx context.Context, execCfg *sql.ExecutorConfig, key storage.MVCCKey, value []byte, isTombstone bool,
+) error {
+	if w.sst == nil {
+		w.data = &storage.MemObject{}
+		sst := storage.MakeTransportSSTWriter(ctx, execCfg.Settings, w.data)
+		w.sst = &sst
+		w.counter = storage.RowCounter{}
+	}
+	if err := w.sst.PutRawMVCC(key, value); err != nil {
+		return err
+	}
+	if !isTombstone {
+		if err := w.counter.Count(key.Key); err != nil {
+			return err
+		}
+		w.counter.DataSize += int64(len(key.Key) + len(value))
+	}
+	w.lastKey = append(w.lastKey[:0], key.Key...)
+	return nil
+}
+
+func (w *chunkWriter) finish() ([]byte, kvpb.BulkOpSummary, error) {
+	if err := w.sst.Finish(); err != nil {
+		return nil, kvpb.BulkOpSummary{}, err
+	}
+	data, summary := w.data.Data(), w.counter.BulkOpSummary
+	w.close()
+	return data, summary, nil
+}
+
+func (w *chunkWriter) close() {
+	if w.sst != nil {
+		w.sst.Close()
+	}
+	w.sst, w.data = nil, nil
+	w.lastKey = w.lastKey[:0]
+}
+
+// copyEncryptionInfo copies the ENCRYPTION-INFO files of a backup to dest.
+func copyEncryptionInfo(ctx context.Context, src, dest cloud.ExternalStorage) error {
+	files, err := backupencryption.GetEncryptionInfoFiles(ctx, src)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		r, _, err := src.ReadFile(ctx, f, cloud.ReadOptions{NoFileSize: true})
+		if err != nil {
+			return err
+		}
+		data, err := ioctx.ReadAll(ctx, r)
+		r.Close(ctx)
+		if err != nil {
+			return err
+		}
+		if err := cloud.WriteFile(ctx, dest, f, bytes.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCompactedBackup reads back the manifest of a compacted backup and
+// checks that it describes the backup that was written.
+func validateCompactedBackup(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	store cloud.ExternalStorage,
+	uri string,
+	encryption *jobspb.BackupEncryptionOptions,
+	kmsEnv cloud.KMSEnv,
+	expected backuppb.BackupManifest,
+) error {
+	written, memSize, err := backupinfo.ReadBackupManifestFromStore(ctx, mem, store, uri, encryption, kmsEnv)
+	if err != nil {
+		return errors.Wrap(err, "reading compacted backup manifest")
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	if !written.StartTime.Equal(expected.StartTime) || !written.EndTime.Equal(expected.EndTime) {
+		return errors.AssertionFailedf("compacted backup covers [%s, %s], expected [%s, %s]",
+			written.StartTime, written.EndTime, expected.StartTime, expected.EndTime)
+	}
+	spansMatch := len(written.Spans) == len(expected.Spans)
+	for i := 0; spansMatch && i < len(written.Spans); i++ {
+		spansMatch = written.Spans[i].Equal(expected.Spans[i])
+	}
+	if !spansMatch {
+		return errors.AssertionFailedf("compacted backup spans %v, expected %v",
+			written.Spans, expected.Spans)
+	}
+
+	factories, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, []backuppb.BackupManifest{written}, encryption, kmsEnv)
+	if err != nil {
+		return err
+	}
+	fileIt, err := factories[0].NewFileIter(ctx)
+	if err != nil {
+		return err
+	}
+	defer fileIt.Close()
+	var fileCount int
+	var entryCounts roachpb.RowCount
+	for ; ; fileIt.Next() {
+		if ok, err := fileIt.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		fileCount++
+		entryCounts.Add(fileIt.Value().EntryCounts)
+	}
+	if fileCount != len(expected.Files) || entryCounts != expected.EntryCounts {
+		return errors.AssertionFailedf(
+			"compacted backup has %d files with %d rows, expected %d files with %d rows",
+			fileCount, entryCounts.Rows, len(expected.Files), expected.EntryCounts.Rows)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupCompaction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 100
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	for _, tc := range []struct {
+		name string
+		opts []string
+	}{
+		{name: "unencrypted"},
+		{name: "encrypted", opts: []string{"encryption_passphrase = 'abc'"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			collection := "nodelocal://1/compaction-" + tc.name
+			with := func(extra ...string) string {
+				opts := append(append([]string(nil), tc.opts...), extra...)
+				if len(opts) == 0 {
+					return ""
+				}
+				return " WITH " + strings.Join(opts, ", ")
+			}
+
+			// Take a full backup followed by three incremental backups, each at a
+			// known time, modifying the data in between.
+			var ts [4]string
+			for i, mutation := range []string{
+				`UPDATE data.bank SET balance = balance + 1 WHERE id < 50`,
+				`DELETE FROM data.bank WHERE id % 3 = 0`,
+				`UPSERT INTO data.bank VALUES (1000, 1, 'new'), (1, 2, 'updated')`,
+				``,
+			} {
+				sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&ts[i])
+				into := fmt.Sprintf("'%s'", collection)
+				if i > 0 {
+					into = fmt.Sprintf("LATEST IN '%s'", collection)
+				}
+				sqlDB.Exec(t, fmt.Sprintf(`BACKUP DATABASE data INTO %s AS OF SYSTEM TIME %s%s`,
+					into, ts[i], with()))
+				if mutation != "" {
+					sqlDB.Exec(t, mutation)
+				}
+			}
+			expectedAt := func(i int) [][]string {
+				return sqlDB.QueryStr(t, fmt.Sprintf(
+					`SELECT * FROM data.bank AS OF SYSTEM TIME %s ORDER BY id`, ts[i]))
+			}
+
+			sqlDB.ExpectErr(t, "no backup in the chain ends at",
+				fmt.Sprintf(`BACKUP INTO LATEST IN '%s' COMPACT TO '-1h'%s`, collection, with()))
+			sqlDB.ExpectErr(t, "requires at least two backups",
+				fmt.Sprintf(`BACKUP INTO LATEST IN '%s' COMPACT FROM '%s' TO '%s'%s`,
+					collection, ts[0], ts[1], with()))
+
+			// Compact the first two incremental backups into one incremental layer.
+			// A restore to the end of the compacted layer reads it in place of the
+			// layers it compacts, and later layers still apply on top of it.
+			sqlDB.Exec(t, fmt.Sprintf(`BACKUP INTO LATEST IN '%s' COMPACT FROM '%s' TO '%s'%s`,
+				collection, ts[0], ts[2], with()))
+			// SHOW BACKUP lists the compacted layer alongside the layers it replaces.
+			var layers int
+			sqlDB.QueryRow(t, fmt.Sprintf(
+				`SELECT count(DISTINCT (start_time, end_time)) FROM [SHOW BACKUP LATEST IN '%s'%s]`,
+				collection, with()),
+			).Scan(&layers)
+			require.Equal(t, 5, layers)
+
+			restored := "compacted_inc_" + tc.name
+			sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM LATEST IN '%s' AS OF SYSTEM TIME %s%s`,
+				collection, ts[2], with(fmt.Sprintf("new_db_name = '%s'", restored))))
+			sqlDB.CheckQueryResults(t, fmt.Sprintf(`SELECT * FROM %s.bank ORDER BY id`, restored), expectedAt(2))
+
+			restored = "compacted_latest_" + tc.name
+			sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM LATEST IN '%s'%s`,
+				collection, with(fmt.Sprintf("new_db_name = '%s'", restored))))
+			sqlDB.CheckQueryResults(t, fmt.Sprintf(`SELECT * FROM %s.bank ORDER BY id`, restored), expectedAt(3))
+
+			// Compact the whole chain into a new full backup in the collection.
+			var original string
+			sqlDB.QueryRow(t, fmt.Sprintf(`SHOW BACKUPS IN '%s'`, collection)).Scan(&original)
+			sqlDB.Exec(t, fmt.Sprintf(`BACKUP INTO LATEST IN '%s' COMPACT TO '%s'%s`,
+				collection, ts[3], with()))
+			var compacted string
+			for _, row := range sqlDB.QueryStr(t, fmt.Sprintf(`SHOW BACKUPS IN '%s'`, collection)) {
+				if row[0] != original {
+					compacted = row[0]
+				}
+			}
+			require.NotEmpty(t, compacted)
+			sqlDB.CheckQueryResults(t, fmt.Sprintf(
+				`SELECT DISTINCT backup_type FROM [SHOW BACKUP '%s' IN '%s'%s]`, compacted, collection, with()),
+				[][]string{{"full"}})
+
+			restored = "compacted_full_" + tc.name
+			sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM '%s' IN '%s'%s`,
+				compacted, collection, with(fmt.Sprintf("new_db_name = '%s'", restored))))
+			sqlDB.CheckQueryResults(t, fmt.Sprintf(`SELECT * FROM %s.bank ORDER BY id`, restored), expectedAt(3))
+		})
+	}
+}
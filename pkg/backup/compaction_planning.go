@@ -0,0 +1,335 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudprivilege"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/asof"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// getCompactBackupStatement returns the BACKUP statement if it compacts an
+// existing backup chain, and nil otherwise.
+func getCompactBackupStatement(stmt tree.Statement) *annotatedBackupStatement {
+	backupStmt := getBackupStatement(stmt)
+	if backupStmt == nil || backupStmt.Compact == nil {
+		return nil
+	}
+	return backupStmt
+}
+
+func compactBackupTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	backupStmt := getCompactBackupStatement(stmt)
+	if backupStmt == nil {
+		return false, nil, nil
+	}
+	if backupStmt.Options.Detached == tree.DBoolTrue {
+		header = jobs.DetachedJobExecutionResultHeader
+	} else {
+		header = jobs.BackupRestoreJobResultHeader
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "BACKUP COMPACT", p.SemaCtx(),
+		exprutil.Strings{
+			backupStmt.Subdir,
+			backupStmt.Compact.From,
+			backupStmt.Compact.To,
+			backupStmt.Options.EncryptionPassphrase,
+		},
+		exprutil.StringArrays{
+			tree.Exprs(backupStmt.To),
+			tree.Exprs(backupStmt.Options.IncrementalStorage),
+			tree.Exprs(backupStmt.Options.EncryptionKMSURI),
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, header, nil
+}
+
+// compactBackupPlanHook implements PlanHookFn for `BACKUP INTO ... COMPACT`.
+func compactBackupPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	backupStmt := getCompactBackupStatement(stmt)
+	if backupStmt == nil {
+		return nil, nil, false, nil
+	}
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureBackupEnabled,
+		"BACKUP",
+	); err != nil {
+		return nil, nil, false, err
+	}
+	// Nodes running v24.3 don't know about the Compact field of the job details
+	// and would run the job as a regular backup.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_1) {
+		return nil, nil, false, pgerror.New(pgcode.FeatureNotSupported,
+			"BACKUP COMPACT unsupported in mixed-version cluster")
+	}
+
+	if backupStmt.Targets != nil {
+		return nil, nil, false, pgerror.New(pgcode.Syntax,
+			"BACKUP COMPACT compacts all the data in a backup chain and does not accept targets")
+	}
+	opts := backupStmt.Options
+	for _, unsupported := range []struct {
+		name string
+		set  bool
+	}{
+		{name: "revision_history", set: opts.CaptureRevisionHistory != nil},
+		{name: "include_all_virtual_clusters", set: opts.IncludeAllSecondaryTenants != nil},
+		{name: "execution locality", set: opts.ExecutionLocality != nil},
+		{name: "updates_cluster_monitoring_metrics", set: opts.UpdatesClusterMonitoringMetrics != nil},
+	} {
+		if unsupported.set {
+			return nil, nil, false, pgerror.Newf(pgcode.FeatureNotSupported,
+				"option %q is not supported by BACKUP COMPACT", unsupported.name)
+		}
+	}
+
+	detached := opts.Detached == tree.DBoolTrue
+
+	exprEval := p.ExprEvaluator("BACKUP COMPACT")
+
+	var err error
+	var subdir string
+	if backupStmt.Subdir != nil {
+		subdir, err = exprEval.String(ctx, backupStmt.Subdir)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	to, err := exprEval.StringArray(ctx, tree.Exprs(backupStmt.To))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	incrementalStorage, err := exprEval.StringArray(ctx, tree.Exprs(opts.IncrementalStorage))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var from string
+	if backupStmt.Compact.From != nil {
+		from, err = exprEval.String(ctx, backupStmt.Compact.From)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	until, err := exprEval.String(ctx, backupStmt.Compact.To)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	encryptionParams := jobspb.BackupEncryptionOptions{
+		Mode: jobspb.EncryptionMode_None,
+	}
+	if opts.EncryptionPassphrase != nil {
+		pw, err := exprEval.String(ctx, opts.EncryptionPassphrase)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		encryptionParams.Mode = jobspb.EncryptionMode_Passphrase
+		encryptionParams.RawPassphrase = pw
+	}
+	if opts.EncryptionKMSURI != nil {
+		if encryptionParams.Mode != jobspb.EncryptionMode_None {
+			return nil, nil, false,
+				errors.New("cannot have both encryption_passphrase and kms option set")
+		}
+		kms, err := exprEval.StringArray(ctx, tree.Exprs(opts.EncryptionKMSURI))
+		if err != nil {
+			return nil, nil, false, err
+		}
+		encryptionParams.Mode = jobspb.EncryptionMode_KMS
+		encryptionParams.RawKmsUris = kms
+		if err = logAndSanitizeKmsURIs(ctx, kms...); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if !(p.ExtendedEvalContext().TxnIsSingleStmt || detached) {
+			return errors.Errorf("BACKUP COMPACT cannot be used inside a multi-statement transaction without DETACHED option")
+		}
+
+		if len(incrementalStorage) > 0 && (len(incrementalStorage) != len(to)) {
+			return errors.New("the incremental_location option must contain the same number of locality" +
+				" aware URIs as the full backup destination")
+		}
+
+		var startTime hlc.Timestamp
+		if from != "" {
+			var err error
+			if startTime, err = evalCompactionBound(p, from); err != nil {
+				return errors.Wrap(err, "invalid COMPACT FROM time")
+			}
+		}
+		endTime, err := evalCompactionBound(p, until)
+		if err != nil {
+			return errors.Wrap(err, "invalid COMPACT TO time")
+		}
+		if !startTime.IsEmpty() && !startTime.Less(endTime) {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"COMPACT FROM time %s must be before COMPACT TO time %s", startTime, endTime)
+		}
+
+		if err := checkPrivilegesForCompaction(ctx, p, to); err != nil {
+			return err
+		}
+
+		details := jobspb.BackupDetails{
+			Destination: jobspb.BackupDetails_Destination{
+				To:                 to,
+				IncrementalStorage: incrementalStorage,
+				Exists:             true,
+			},
+			StartTime:         startTime,
+			EndTime:           endTime,
+			EncryptionOptions: &encryptionParams,
+			Detached:          detached,
+			ApplicationName:   p.SessionData().ApplicationName,
+			Compact:           true,
+		}
+		if backupStmt.AppendToLatest {
+			details.Destination.Subdir = backupbase.LatestFileName
+		} else {
+			details.Destination.Subdir = "/" + strings.TrimPrefix(subdir, "/")
+		}
+
+		if err := logAndSanitizeBackupDestinations(ctx, to...); err != nil {
+			return errors.Wrap(err, "logging backup destinations")
+		}
+
+		description, err := backupJobDescription(p,
+			backupStmt.Backup, to,
+			encryptionParams.RawKmsUris,
+			details.Destination.Subdir,
+			details.Destination.IncrementalStorage,
+		)
+		if err != nil {
+			return err
+		}
+		jr := jobs.Record{
+			Description: description,
+			Details:     details,
+			Progress:    jobspb.BackupProgress{},
+			Username:    p.User(),
+		}
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+		plannerTxn := p.Txn()
+
+		if detached {
+			_, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+				ctx, jr, jobID, p.InternalSQLTxn())
+			if err != nil {
+				return err
+			}
+			resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+			return nil
+		}
+		var sj *jobs.StartableJob
+		if err := func() (err error) {
+			defer func() {
+				if err == nil || sj == nil {
+					return
+				}
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+				}
+			}()
+			if err := p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(
+				ctx, &sj, jobID, p.InternalSQLTxn(), jr,
+			); err != nil {
+				return err
+			}
+			return plannerTxn.Commit(ctx)
+		}(); err != nil {
+			return err
+		}
+		p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+		if err := sj.Start(ctx); err != nil {
+			return err
+		}
+		if err := sj.AwaitCompletion(ctx); err != nil {
+			return err
+		}
+		return sj.ReportExecutionResults(ctx, resultsCh)
+	}
+
+	if detached {
+		return fn, jobs.DetachedJobExecutionResultHeader, false, nil
+	}
+	return fn, jobs.BackupRestoreJobResultHeader, false, nil
+}
+
+// evalCompactionBound converts the FROM or TO time of a BACKUP COMPACT
+// statement, which may be written in any of the forms accepted by AS OF SYSTEM
+// TIME, into a timestamp.
+func evalCompactionBound(p sql.PlanHookState, s string) (hlc.Timestamp, error) {
+	return asof.DatumToHLC(
+		&p.ExtendedEvalContext().Context, p.ExtendedEvalContext().StmtTimestamp, tree.NewDString(s), asof.AsOf,
+	)
+}
+
+// checkPrivilegesForCompaction checks that the user may compact the backups in
+// the collection at the given URIs. Compaction reads and rewrites the data of
+// every layer it compacts, so like a cluster backup it requires the BACKUP
+// system privilege.
+func checkPrivilegesForCompaction(ctx context.Context, p sql.PlanHookState, to []string) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if hasAdmin {
+		return nil
+	}
+	if err := p.CheckPrivilegeForUser(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.BACKUP, p.User(),
+	); err != nil {
+		return pgerror.Wrapf(
+			err,
+			pgcode.InsufficientPrivilege,
+			"only users with the admin role or the BACKUP system privilege are allowed to compact backups")
+	}
+	return cloudprivilege.CheckDestinationPrivileges(ctx, p, to)
+}
+
+func init() {
+	sql.AddPlanHook(
+		"backup.compactBackupPlanHook",
+		compactBackupPlanHook,
+		compactBackupTypeCheck,
+	)
+}
@@ -182,7 +182,7 @@ func showBackupTypeCheck(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
 ) (matched bool, header colinfo.ResultColumns, _ error) {
 	backup, ok := stmt.(*tree.ShowBackup)
-	if !ok {
+	if !ok || backup.Details == tree.BackupRowDetails {
 		return false, nil, nil
 	}
 	if backup.Path == nil && backup.InCollection != nil {
@@ -220,7 +220,7 @@ func showBackupPlanHook(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
 ) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
 	showStmt, ok := stmt.(*tree.ShowBackup)
-	if !ok {
+	if !ok || showStmt.Details == tree.BackupRowDetails {
 		return nil, nil, false, nil
 	}
 	exprEval := p.ExprEvaluator("SHOW BACKUP")
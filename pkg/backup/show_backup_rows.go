@@ -0,0 +1,578 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudprivilege"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// backupRowsBatchSize is the number of KVs read from a backup that are decoded
+// into rows at a time.
+const backupRowsBatchSize = 10000
+
+// backupRowsSource is a table in a backup chain, resolved as of a time, whose
+// rows are read by SHOW BACKUP ROWS.
+type backupRowsSource struct {
+	manifests    []backuppb.BackupManifest
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo
+	encryption   *jobspb.BackupEncryptionOptions
+	kmsEnv       backupencryption.BackupKMSEnv
+	asOf         hlc.Timestamp
+
+	table catalog.TableDescriptor
+	// spans are the spans of the table's primary index that the backup holds.
+	spans roachpb.Spans
+	spec  fetchpb.IndexFetchSpec
+}
+
+// header returns the result columns of SHOW BACKUP ROWS, which are the stored
+// columns of the table.
+func (s *backupRowsSource) header() colinfo.ResultColumns {
+	var header colinfo.ResultColumns
+	for _, col := range s.table.PublicColumns() {
+		if col.IsVirtual() {
+			continue
+		}
+		header = append(header, colinfo.ResultColumn{
+			Name:   col.GetName(),
+			Typ:    col.GetType(),
+			Hidden: col.IsHidden(),
+		})
+	}
+	return header
+}
+
+func showBackupRowsTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	showStmt, ok := stmt.(*tree.ShowBackup)
+	if !ok || showStmt.Details != tree.BackupRowDetails {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "SHOW BACKUP ROWS", p.SemaCtx(),
+		exprutil.Strings{
+			showStmt.Path,
+			showStmt.Options.EncryptionPassphrase,
+		},
+		exprutil.StringArrays{
+			tree.Exprs(showStmt.InCollection),
+			tree.Exprs(showStmt.Options.IncrementalStorage),
+			tree.Exprs(showStmt.Options.DecryptionKMSURI),
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	header, err := backupRowsHeader(ctx, p, showStmt)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, header, nil
+}
+
+// backupRowsHeader returns the result columns of a SHOW BACKUP ROWS statement.
+// They are the columns of the table in the backup, so the backup has to be
+// read to compute them. Nothing else read here is kept: the rows are read
+// from the backup as it is resolved when the statement is executed.
+func backupRowsHeader(
+	ctx context.Context, p sql.PlanHookState, showStmt *tree.ShowBackup,
+) (colinfo.ResultColumns, error) {
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	src, err := resolveBackupRowsSource(ctx, p, showStmt, &mem)
+	if err != nil {
+		return nil, err
+	}
+	return src.header(), nil
+}
+
+// showBackupRowsPlanHook implements PlanHookFn for SHOW BACKUP ROWS, which
+// reads the rows of a table as of a time directly from a backup, so that
+// individual rows can be recovered with INSERT ... SELECT without restoring
+// the whole table.
+func showBackupRowsPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	showStmt, ok := stmt.(*tree.ShowBackup)
+	if !ok || showStmt.Details != tree.BackupRowDetails {
+		return nil, nil, false, nil
+	}
+	header, err := backupRowsHeader(ctx, p, showStmt)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+		defer mem.Close(ctx)
+		src, err := resolveBackupRowsSource(ctx, p, showStmt, &mem)
+		if err != nil {
+			return err
+		}
+		// The backup is resolved again, so a new backup in the chain, or a new
+		// LATEST backup, may have changed the columns of the table since the
+		// statement was planned.
+		if !sameResultColumns(src.header(), header) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"the columns of table %s in the backup changed since the statement was planned",
+				tree.ErrString(showStmt.Table))
+		}
+		if err := src.readRows(ctx, p.ExecCfg(), p.User(), resultsCh); err != nil {
+			return err
+		}
+		telemetry.Count("show-backup.rows")
+		return nil
+	}
+	return fn, header, false, nil
+}
+
+func sameResultColumns(a, b colinfo.ResultColumns) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || !a[i].Typ.Identical(b[i].Typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveBackupRowsSource resolves the backup chain named by a SHOW BACKUP
+// ROWS statement up to its AS OF SYSTEM TIME, and the table whose rows it
+// reads. The memory of the manifests is reserved in mem, which must outlive
+// the returned source.
+func resolveBackupRowsSource(
+	ctx context.Context, p sql.PlanHookState, showStmt *tree.ShowBackup, mem *mon.BoundAccount,
+) (*backupRowsSource, error) {
+	opts := showStmt.Options
+	if opts.AsJson || opts.CheckFiles || opts.DebugIDs || opts.Privileges || opts.SkipSize ||
+		opts.DebugMetadataSST || opts.EncryptionInfoDir != nil ||
+		opts.CheckConnectionTransferSize != nil || opts.CheckConnectionDuration != nil ||
+		opts.CheckConnectionConcurrency != nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SHOW BACKUP ROWS only supports the incremental_location, encryption_passphrase and kms options")
+	}
+
+	exprEval := p.ExprEvaluator("SHOW BACKUP ROWS")
+	subdir, err := exprEval.String(ctx, showStmt.Path)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := exprEval.StringArray(ctx, tree.Exprs(showStmt.InCollection))
+	if err != nil {
+		return nil, err
+	}
+	var incFrom []string
+	if opts.IncrementalStorage != nil {
+		if incFrom, err = exprEval.StringArray(ctx, tree.Exprs(opts.IncrementalStorage)); err != nil {
+			return nil, err
+		}
+	}
+	encryptionParams := jobspb.BackupEncryptionOptions{Mode: jobspb.EncryptionMode_None}
+	if opts.EncryptionPassphrase != nil {
+		passphrase, err := exprEval.String(ctx, opts.EncryptionPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		encryptionParams.Mode = jobspb.EncryptionMode_Passphrase
+		encryptionParams.RawPassphrase = passphrase
+	} else if opts.DecryptionKMSURI != nil {
+		kms, err := exprEval.StringArray(ctx, tree.Exprs(opts.DecryptionKMSURI))
+		if err != nil {
+			return nil, err
+		}
+		encryptionParams.Mode = jobspb.EncryptionMode_KMS
+		encryptionParams.RawKmsUris = kms
+	}
+
+	var src backupRowsSource
+	if showStmt.AsOf.Expr != nil {
+		asOf, err := p.EvalAsOfTimestamp(ctx, showStmt.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		src.asOf = asOf.Timestamp
+	}
+
+	if err := cloudprivilege.CheckDestinationPrivileges(ctx, p, collection); err != nil {
+		return nil, err
+	}
+
+	execCfg := p.ExecCfg()
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	if strings.EqualFold(subdir, backupbase.LatestFileName) {
+		subdir, err = backupdest.ReadLatestFile(ctx, collection[0], mkStore, p.User())
+		if err != nil {
+			return nil, errors.Wrap(err, "read LATEST path")
+		}
+	}
+	baseDirs, err := backuputils.AppendPaths(collection, subdir)
+	if err != nil {
+		return nil, err
+	}
+	incDirs, err := backupdest.ResolveIncrementalsBackupLocation(
+		ctx, p.User(), execCfg, incFrom, collection, subdir)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrListingUnsupported) {
+			return nil, err
+		}
+		log.Warningf(ctx, "storage sink %v does not support listing, only reading the base backup", incFrom)
+	}
+	baseStores, cleanupBase, err := backupdest.MakeBackupDestinationStores(ctx, p.User(), mkStore, baseDirs)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cleanupBase(); err != nil {
+			log.Warningf(ctx, "failed to close backup store: %+v", err)
+		}
+	}()
+	incStores, cleanupInc, err := backupdest.MakeBackupDestinationStores(ctx, p.User(), mkStore, incDirs)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cleanupInc(); err != nil {
+			log.Warningf(ctx, "failed to close incremental store: %+v", err)
+		}
+	}()
+
+	ioConf := baseStores[0].ExternalIOConf()
+	src.kmsEnv = backupencryption.MakeBackupKMSEnv(
+		execCfg.Settings, &ioConf, execCfg.InternalDB, p.User(),
+	)
+	src.encryption, err = backupencryption.GetEncryptionFromBase(
+		ctx, p.User(), mkStore, baseDirs[0], encryptionParams, &src.kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	_, src.manifests, src.localityInfo, _, err = backupdest.ResolveBackupManifests(
+		ctx, mem, baseStores, incStores, mkStore, baseDirs, incDirs, src.asOf,
+		src.encryption, &src.kmsEnv, p.User(), false, /* includeSkipped */
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkBackupManifestVersionCompatability(
+		ctx, execCfg.Settings.Version, src.manifests, false, /* unsafe */
+	); err != nil {
+		return nil, err
+	}
+
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, src.manifests, src.encryption, &src.kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+	pattern := showStmt.Table.ToUnresolvedName()
+	_, _, descsByTablePattern, _, err := selectTargets(
+		ctx, p, src.manifests, layerToIterFactory,
+		tree.BackupTargetList{Tables: tree.TableAttrs{TablePatterns: tree.TablePatterns{pattern}}},
+		tree.RequestedDescriptors, src.asOf,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err,
+			"failed to resolve the table in the backup, use SHOW BACKUP to find correct targets")
+	}
+	desc, ok := descsByTablePattern[pattern]
+	if !ok {
+		return nil, errors.AssertionFailedf("table %s was resolved but not returned", showStmt.Table)
+	}
+	if src.table, err = catalog.AsTableDescriptor(desc); err != nil {
+		return nil, err
+	}
+	if !src.table.IsPhysicalTable() || src.table.IsSequence() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s is not a table", tree.ErrString(showStmt.Table))
+	}
+	if len(src.table.UserDefinedTypeColumns()) > 0 {
+		return nil, unimplemented.New("show backup rows",
+			"reading rows of tables with user-defined types from a backup is not supported")
+	}
+
+	codec := execCfg.Codec
+	tableSpan := src.table.PrimaryIndexSpan(codec)
+	for _, sp := range src.manifests[len(src.manifests)-1].Spans {
+		if i := sp.Intersect(tableSpan); i.Valid() {
+			src.spans = append(src.spans, i)
+		}
+	}
+	if len(src.spans) == 0 {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"backup contains no data for table %s; it may have been taken by another virtual cluster",
+			tree.ErrString(showStmt.Table))
+	}
+
+	var colIDs []descpb.ColumnID
+	for _, col := range src.table.PublicColumns() {
+		if !col.IsVirtual() {
+			colIDs = append(colIDs, col.GetID())
+		}
+	}
+	if err := rowenc.InitIndexFetchSpec(
+		&src.spec, codec, src.table, src.table.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+// readRows decodes the rows of the table from the backup and sends them on
+// resultsCh.
+func (s *backupRowsSource) readRows(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	resultsCh chan<- tree.Datums,
+) error {
+	sv := &execCfg.Settings.SV
+
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, s.manifests, s.encryption, &s.kmsEnv)
+	if err != nil {
+		return err
+	}
+	backupLocalityMap, err := makeBackupLocalityMap(s.localityInfo, user)
+	if err != nil {
+		return err
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(s.manifests, s.asOf)
+	if err != nil {
+		return err
+	}
+	defer introducedSpanFrontier.Release()
+	filter, err := makeSpanCoveringFilter(
+		s.spans,
+		nil, /* checkpointedSpans */
+		introducedSpanFrontier,
+		targetRestoreSpanSize.Get(sv),
+		maxFileCount.Get(sv),
+	)
+	if err != nil {
+		return err
+	}
+	defer filter.close()
+
+	// See the comment in restore about the end keys of files in layers taken
+	// with revision history before 24.1.
+	var fsc fileSpanComparator = &exclusiveEndKeyComparator{}
+	for _, m := range s.manifests {
+		if m.ClusterVersion.Less(clusterversion.V24_1.Version()) && m.MVCCFilter == backuppb.MVCCFilter_All {
+			fsc = &inclusiveEndKeyComparator{}
+			break
+		}
+	}
+
+	var enc *kvpb.FileEncryptionOptions
+	if s.encryption != nil {
+		key, err := backupencryption.GetEncryptionKey(ctx, s.encryption, &s.kmsEnv)
+		if err != nil {
+			return err
+		}
+		enc = &kvpb.FileEncryptionOptions{Key: key}
+	}
+
+	var rf row.Fetcher
+	if err := rf.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &s.spec,
+	}); err != nil {
+		return err
+	}
+	defer rf.Close(ctx)
+
+	entryCh := make(chan execinfrapb.RestoreSpanEntry, 100)
+	grp := ctxgroup.WithContext(ctx)
+	grp.GoCtx(func(ctx context.Context) error {
+		defer close(entryCh)
+		return errors.Wrap(generateAndSendImportSpans(
+			ctx,
+			s.spans,
+			s.manifests,
+			layerToIterFactory,
+			backupLocalityMap,
+			filter,
+			fsc,
+			entryCh,
+		), "generate and send import spans")
+	})
+	grp.GoCtx(func(ctx context.Context) error {
+		var kvs []roachpb.KeyValue
+		var lastRow roachpb.Key
+		// decode hands the buffered KVs to the fetcher. The buffer is only ever
+		// decoded at a row boundary, since the fetcher does not carry a partially
+		// decoded row over from one batch to the next.
+		decode := func() error {
+			if len(kvs) == 0 {
+				return nil
+			}
+			if err := rf.ConsumeKVProvider(ctx, &row.KVProvider{KVs: kvs}); err != nil {
+				return err
+			}
+			kvs = nil
+			for {
+				datums, err := rf.NextRowDecoded(ctx)
+				if err != nil {
+					return err
+				}
+				if datums == nil {
+					return nil
+				}
+				select {
+				case resultsCh <- append(tree.Datums(nil), datums...):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		for entry := range entryCh {
+			if err := s.readEntry(ctx, execCfg, entry, enc, func(kv roachpb.KeyValue) error {
+				rowKey, err := keys.EnsureSafeSplitKey(kv.Key)
+				if err != nil {
+					rowKey = kv.Key
+				}
+				if len(kvs) >= backupRowsBatchSize && !rowKey.Equal(lastRow) {
+					if err := decode(); err != nil {
+						return err
+					}
+				}
+				lastRow = rowKey
+				kvs = append(kvs, kv)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return decode()
+	})
+	return grp.Wait()
+}
+
+// readEntry calls fn with the latest live revision, as of the time of the
+// source, of every key in the span of the restore span entry.
+func (s *backupRowsSource) readEntry(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	entry execinfrapb.RestoreSpanEntry,
+	enc *kvpb.FileEncryptionOptions,
+	fn func(roachpb.KeyValue) error,
+) error {
+	storeFiles := make([]storageccl.StoreFile, 0, len(entry.Files))
+	defer func() {
+		for _, f := range storeFiles {
+			if err := f.Store.Close(); err != nil {
+				log.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, file := range entry.Files {
+		dir, err := execCfg.DistSQLSrv.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return err
+		}
+		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+	}
+	if len(storeFiles) == 0 {
+		return nil
+	}
+
+	iterOpts := storage.IterOptions{
+		RangeKeyMaskingBelow: s.asOf,
+		KeyTypes:             storage.IterKeyTypePointsAndRanges,
+		LowerBound:           keys.LocalMax,
+		UpperBound:           keys.MaxKey,
+	}
+	sstIter, err := storageccl.ExternalSSTReader(ctx, storeFiles, enc, iterOpts)
+	if err != nil {
+		return err
+	}
+	iter := storage.NewReadAsOfIterator(sstIter, s.asOf)
+	defer iter.Close()
+
+	elidedPrefix, err := backupsink.ElidedPrefix(entry.Span.Key, entry.ElidedPrefix)
+	if err != nil {
+		return err
+	}
+	startKey := storage.MVCCKey{Key: entry.Span.Key}
+	if elidedPrefix != nil {
+		startKey.Key = startKey.Key[len(elidedPrefix):]
+	}
+	for iter.SeekGE(startKey); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		key := iter.UnsafeKey()
+		fullKey := make(roachpb.Key, 0, len(elidedPrefix)+len(key.Key))
+		fullKey = append(append(fullKey, elidedPrefix...), key.Key...)
+		if fullKey.Compare(entry.Span.EndKey) >= 0 {
+			return nil
+		}
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		value, err := storage.DecodeValueFromMVCCValue(append([]byte(nil), v...))
+		if err != nil {
+			return err
+		}
+		value.Timestamp = key.Timestamp
+		if err := fn(roachpb.KeyValue{Key: fullKey, Value: value}); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	sql.AddPlanHook("backup.showBackupRowsPlanHook", showBackupRowsPlanHook, showBackupRowsTypeCheck)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestShowBackupRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 100
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	const collection = "nodelocal://1/show-backup-rows"
+	const enc = "encryption_passphrase = 'abc'"
+
+	var beforeUpdate, beforeDelete, end string
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&beforeUpdate)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 10 WHERE id < 20`)
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&beforeDelete)
+	sqlDB.Exec(t, fmt.Sprintf(
+		`BACKUP DATABASE data INTO '%s' AS OF SYSTEM TIME %s WITH revision_history, %s`,
+		collection, beforeDelete, enc))
+	sqlDB.Exec(t, `DELETE FROM data.bank WHERE id % 2 = 0`)
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&end)
+	sqlDB.Exec(t, fmt.Sprintf(
+		`BACKUP DATABASE data INTO LATEST IN '%s' AS OF SYSTEM TIME %s WITH revision_history, %s`,
+		collection, end, enc))
+	sqlDB.Exec(t, `DELETE FROM data.bank WHERE id >= 50`)
+
+	expectedAt := func(ts, where string) [][]string {
+		return sqlDB.QueryStr(t, fmt.Sprintf(
+			`SELECT * FROM data.bank AS OF SYSTEM TIME %s WHERE %s ORDER BY id`, ts, where))
+	}
+	showRows := func(asOf string) string {
+		return fmt.Sprintf(`SHOW BACKUP ROWS FOR TABLE data.bank FROM LATEST IN '%s'%s WITH %s`,
+			collection, asOf, enc)
+	}
+
+	// Without AS OF SYSTEM TIME, the rows are read as of the end of the chain.
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT * FROM [%s] ORDER BY id`, showRows("")),
+		expectedAt(end, "true"),
+	)
+
+	// A revision history backup can be read as of any time it covers.
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT * FROM [%s] ORDER BY id`, showRows(" AS OF SYSTEM TIME "+beforeUpdate)),
+		expectedAt(beforeUpdate, "true"),
+	)
+
+	// Deleted rows can be recovered by inserting them back into the table.
+	sqlDB.Exec(t, fmt.Sprintf(`INSERT INTO data.bank SELECT * FROM [%s] WHERE id >= 50`,
+		showRows(" AS OF SYSTEM TIME "+beforeDelete)))
+	sqlDB.CheckQueryResults(t, `SELECT * FROM data.bank ORDER BY id`,
+		expectedAt(beforeDelete, "id < 50 AND id % 2 = 1 OR id >= 50"))
+
+	sqlDB.ExpectErr(t, "failed to resolve the table in the backup",
+		fmt.Sprintf(`SHOW BACKUP ROWS FOR TABLE data.nonexistent FROM LATEST IN '%s' WITH %s`,
+			collection, enc))
+	sqlDB.ExpectErr(t, "only supports the incremental_location",
+		fmt.Sprintf(`SHOW BACKUP ROWS FOR TABLE data.bank FROM LATEST IN '%s' WITH check_files, %s`,
+			collection, enc))
+}
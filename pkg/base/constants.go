@@ -44,4 +44,8 @@ const (
 	// InflightTraceDir is the directory name where the job trace dumper stores traces
 	// when a job opts in to dumping its execution traces.
 	InflightTraceDir = "inflight_trace_dump"
+
+	// ActiveSessionHistoryDir is the directory name where the samples of the
+	// active session history are stored when their persistence is enabled.
+	ActiveSessionHistoryDir = "active_session_history"
 )
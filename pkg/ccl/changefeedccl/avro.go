@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -23,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 	"github.com/linkedin/goavro/v2"
 )
 
@@ -336,6 +338,29 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDPGLSN(x.(string))
 			},
 		)
+	case types.MacAddrFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				if typ.Oid() == oidext.T_macaddr8 {
+					return tree.ParseDMacAddr8(x.(string))
+				}
+				return tree.ParseDMacAddr(x.(string))
+			},
+		)
+	case types.MoneyFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.FormatMoney(tree.MustBeDMoney(d).Cents), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDMoney(x.(string))
+			},
+		)
 	case types.RefCursorFamily:
 		setNullable(
 			avroSchemaString,
@@ -585,9 +610,12 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 		setNullable(
 			avroSchemaString,
 			func(d tree.Datum, _ interface{}) (interface{}, error) {
-				return d.(*tree.DIPAddr).IPAddr.String(), nil
+				return tree.MustBeDIPAddr(d).IPAddr.String(), nil
 			},
 			func(x interface{}) (tree.Datum, error) {
+				if typ.Oid() == oid.T_cidr {
+					return tree.ParseDCIDR(x.(string))
+				}
 				return tree.ParseDIPAddrFromINetString(x.(string))
 			},
 		)
@@ -601,6 +629,16 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDJSON(x.(string))
 			},
 		)
+	case types.JsonpathFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DJsonpath).Jsonpath.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDJsonpath(x.(string))
+			},
+		)
 	case types.TSQueryFamily:
 		setNullable(
 			avroSchemaString,
@@ -0,0 +1,139 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdctest
+
+import (
+	"io"
+	"net"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+)
+
+// MockGRPCSink is an in-process implementation of the
+// changefeedpb.ChangefeedSink service used in tests.
+type MockGRPCSink struct {
+	server   *grpc.Server
+	listener net.Listener
+	mu       struct {
+		syncutil.Mutex
+		// rejections is the number of upcoming batches to reject.
+		rejections int
+		numBatches int
+		messages   map[string][]string
+		resolved   map[string][]string
+		notify     chan struct{}
+	}
+}
+
+var _ changefeedpb.ChangefeedSinkServer = (*MockGRPCSink)(nil)
+
+// StartMockGRPCSink starts a mock gRPC sink listening on a local port.
+func StartMockGRPCSink() (*MockGRPCSink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &MockGRPCSink{
+		server:   grpc.NewServer(),
+		listener: listener,
+	}
+	s.mu.messages = make(map[string][]string)
+	s.mu.resolved = make(map[string][]string)
+	changefeedpb.RegisterChangefeedSinkServer(s.server, s)
+	go func() { _ = s.server.Serve(listener) }()
+	return s, nil
+}
+
+// Addr returns the address on which the mock sink is listening.
+func (s *MockGRPCSink) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// RejectNext causes the next n batches to be acknowledged with an error.
+func (s *MockGRPCSink) RejectNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.rejections = n
+}
+
+// NumBatches returns the number of batches received, including rejected
+// ones.
+func (s *MockGRPCSink) NumBatches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.numBatches
+}
+
+// Messages returns the values of the accepted row messages for topic.
+func (s *MockGRPCSink) Messages(topic string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mu.messages[topic]...)
+}
+
+// Resolved returns the resolved payloads received for topic.
+func (s *MockGRPCSink) Resolved(topic string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mu.resolved[topic]...)
+}
+
+// NotifyMessage arranges for channel to be closed when the next batch is
+// accepted.
+func (s *MockGRPCSink) NotifyMessage() chan struct{} {
+	c := make(chan struct{})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.notify != nil {
+		close(s.mu.notify)
+	}
+	s.mu.notify = c
+	return c
+}
+
+// Publish implements the changefeedpb.ChangefeedSinkServer interface.
+func (s *MockGRPCSink) Publish(stream changefeedpb.ChangefeedSink_PublishServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &changefeedpb.PublishResponse{BatchID: req.BatchID}
+		s.mu.Lock()
+		s.mu.numBatches++
+		if s.mu.rejections > 0 {
+			s.mu.rejections--
+			resp.Error = "rejected by mock sink"
+		} else {
+			for _, msg := range req.Messages {
+				if req.Resolved {
+					s.mu.resolved[req.Topic] = append(s.mu.resolved[req.Topic], string(msg.Value))
+				} else {
+					s.mu.messages[req.Topic] = append(s.mu.messages[req.Topic], string(msg.Value))
+				}
+			}
+			if s.mu.notify != nil {
+				close(s.mu.notify)
+				s.mu.notify = nil
+			}
+		}
+		s.mu.Unlock()
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Close stops the mock sink.
+func (s *MockGRPCSink) Close() {
+	s.server.Stop()
+}
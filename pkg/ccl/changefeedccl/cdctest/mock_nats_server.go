@@ -0,0 +1,241 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdctest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// MockNATSServer is an in-process stand-in for a NATS server with a single
+// JetStream stream. It implements just enough of the NATS client protocol for
+// the changefeed NATS sink: CONNECT, PING/PONG, SUB/UNSUB and PUB/HPUB,
+// replying to every publish with a JetStream acknowledgement. Like JetStream,
+// it drops messages whose Nats-Msg-Id it has seen before and rejects those
+// whose Nats-Expected-Stream does not name its stream.
+type MockNATSServer struct {
+	listener net.Listener
+	stream   string
+	mu       struct {
+		syncutil.Mutex
+		conns map[net.Conn]struct{}
+		// failures is the number of publishes to reject once skip further
+		// publishes have been processed.
+		skip       int
+		failures   int
+		seq        uint64
+		seenIDs    map[string]struct{}
+		messages   map[string][]string
+		duplicates int
+		// dropAcks suppresses acknowledgements, leaving publishes pending.
+		dropAcks bool
+	}
+}
+
+// StartMockNATSServer starts a mock NATS server whose JetStream stream is
+// named stream and captures every subject.
+func StartMockNATSServer(stream string) (*MockNATSServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &MockNATSServer{listener: listener, stream: stream}
+	s.mu.conns = make(map[net.Conn]struct{})
+	s.mu.seenIDs = make(map[string]struct{})
+	s.mu.messages = make(map[string][]string)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address on which the mock server is listening.
+func (s *MockNATSServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// FailNext causes the next n publishes to be answered with a JetStream
+// error.
+func (s *MockNATSServer) FailNext(n int) {
+	s.FailNextAfter(0, n)
+}
+
+// FailNextAfter causes n publishes to be answered with a JetStream error once
+// the next skip publishes have been processed normally.
+func (s *MockNATSServer) FailNextAfter(skip, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.skip = skip
+	s.mu.failures = n
+}
+
+// DropAcks controls whether publishes are stored without being acknowledged.
+func (s *MockNATSServer) DropAcks(drop bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.dropAcks = drop
+}
+
+// Messages returns the payloads stored for subject.
+func (s *MockNATSServer) Messages(subject string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mu.messages[subject]...)
+}
+
+// Duplicates returns the number of publishes dropped because their message ID
+// had already been stored.
+func (s *MockNATSServer) Duplicates() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.duplicates
+}
+
+// Close stops the mock server and closes all client connections.
+func (s *MockNATSServer) Close() {
+	_ = s.listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.mu.conns {
+		_ = conn.Close()
+	}
+}
+
+func (s *MockNATSServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.mu.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		go func() {
+			_ = s.handle(conn)
+			_ = conn.Close()
+			s.mu.Lock()
+			delete(s.mu.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *MockNATSServer) handle(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, `INFO {"server_id":"mock","headers":true,"max_payload":1048576}`+"\r\n")
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	// The SID of each subscription, keyed by subject. The mock only supports
+	// subscriptions whose last token is a wildcard, which is what inboxes use.
+	subs := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		fields := strings.Fields(args)
+		switch strings.ToUpper(op) {
+		case "CONNECT":
+		case "PING":
+			fmt.Fprintf(w, "PONG\r\n")
+		case "PONG":
+		case "SUB":
+			if len(fields) != 2 || !strings.HasSuffix(fields[0], ".*") {
+				return errors.Errorf("unsupported subscription %q", args)
+			}
+			subs[strings.TrimSuffix(fields[0], "*")] = fields[1]
+		case "UNSUB":
+			for prefix, sid := range subs {
+				if len(fields) > 0 && sid == fields[0] {
+					delete(subs, prefix)
+				}
+			}
+		case "PUB", "HPUB":
+			var hdrLen, totalLen int
+			if strings.ToUpper(op) == "PUB" && len(fields) == 3 {
+				totalLen, err = strconv.Atoi(fields[2])
+			} else if len(fields) == 4 {
+				if hdrLen, err = strconv.Atoi(fields[2]); err == nil {
+					totalLen, err = strconv.Atoi(fields[3])
+				}
+			} else {
+				return errors.Errorf("unsupported publish %q", args)
+			}
+			if err != nil {
+				return err
+			}
+			buf := make([]byte, totalLen+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return err
+			}
+			hdr := string(buf[:hdrLen])
+			ack := s.store(fields[0], headerValue(hdr, "Nats-Msg-Id"),
+				headerValue(hdr, "Nats-Expected-Stream"), string(buf[hdrLen:totalLen]))
+			s.mu.Lock()
+			dropAck := s.mu.dropAcks
+			s.mu.Unlock()
+			if dropAck {
+				break
+			}
+			reply := fields[1]
+			for prefix, sid := range subs {
+				if strings.HasPrefix(reply, prefix) {
+					fmt.Fprintf(w, "MSG %s %s %d\r\n%s\r\n", reply, sid, len(ack), ack)
+				}
+			}
+		default:
+			fmt.Fprintf(w, "-ERR 'Unknown Protocol Operation'\r\n")
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// store records a published message and returns the acknowledgement to send.
+func (s *MockNATSServer) store(subject, msgID, expectedStream, data string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if expectedStream != "" && expectedStream != s.stream {
+		return `{"error":{"code":400,"err_code":10060,"description":"expected stream does not match"}}`
+	}
+	if s.mu.skip > 0 {
+		s.mu.skip--
+	} else if s.mu.failures > 0 {
+		s.mu.failures--
+		return `{"error":{"code":503,"description":"mock failure"}}`
+	}
+	duplicate := false
+	if msgID != "" {
+		_, duplicate = s.mu.seenIDs[msgID]
+		s.mu.seenIDs[msgID] = struct{}{}
+	}
+	if duplicate {
+		s.mu.duplicates++
+	} else {
+		s.mu.seq++
+		s.mu.messages[subject] = append(s.mu.messages[subject], data)
+	}
+	return fmt.Sprintf(`{"stream":%q,"seq":%d,"duplicate":%t}`, s.stream, s.mu.seq, duplicate)
+}
+
+// headerValue returns the value of the named header in a NATS header block.
+func headerValue(hdr, name string) string {
+	for _, line := range strings.Split(hdr, "\r\n") {
+		if k, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(k, name) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
@@ -511,6 +511,11 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		return kvfeed.Config{}, err
 	}
 
+	initialScanLimits, err := opts.GetInitialScanLimits()
+	if err != nil {
+		return kvfeed.Config{}, err
+	}
+
 	return kvfeed.Config{
 		Writer:              buf,
 		Settings:            cfg.Settings,
@@ -531,6 +536,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		SchemaChangeEvents:  schemaChange.EventClass,
 		SchemaChangePolicy:  schemaChange.Policy,
 		SchemaFeed:          sf,
+		InitialScanLimits:   initialScanLimits,
 		Knobs:               ca.knobs.FeedKnobs,
 		ScopedTimers:        ca.sliMetrics.Timers,
 		MonitoringCfg:       monitoringCfg,
@@ -889,10 +895,11 @@ func (ca *changeAggregator) noteResolvedSpan(resolved jobspb.ResolvedSpan) (retu
 
 	// At a lower frequency we checkpoint specific spans in the job progress
 	// either in backfills or if the highwater mark is excessively lagging behind
+	inBackfill := ca.frontier.InBackfill(resolved)
 	checkpointSpans := ca.spec.JobID != 0 && /* enterprise changefeed */
-		(ca.frontier.InBackfill(resolved) ||
+		(inBackfill ||
 			ca.frontier.hasLaggingSpans(ca.spec.Feed.StatementTime, sv)) &&
-		canCheckpointSpans(sv, ca.lastSpanFlush)
+		canCheckpointSpans(sv, ca.lastSpanFlush, inBackfill)
 
 	if checkpointSpans {
 		defer func() {
@@ -1101,16 +1108,22 @@ func newJobState(
 	}
 }
 
-func canCheckpointSpans(sv *settings.Values, lastCheckpoint time.Time) bool {
+// canCheckpointSpans returns true if enough time has passed since
+// lastCheckpoint to write another span level checkpoint. Backfills may be
+// checkpointed more frequently than other span level checkpoints.
+func canCheckpointSpans(sv *settings.Values, lastCheckpoint time.Time, inBackfill bool) bool {
 	freq := changefeedbase.FrontierCheckpointFrequency.Get(sv)
 	if freq == 0 {
 		return false
 	}
+	if backfillFreq := changefeedbase.BackfillCheckpointFrequency.Get(sv); inBackfill && backfillFreq > 0 && backfillFreq < freq {
+		freq = backfillFreq
+	}
 	return timeutil.Since(lastCheckpoint) > freq
 }
 
-func (j *jobState) canCheckpointSpans() bool {
-	return canCheckpointSpans(&j.settings.SV, j.lastProgressUpdate)
+func (j *jobState) canCheckpointSpans(inBackfill bool) bool {
+	return canCheckpointSpans(&j.settings.SV, j.lastProgressUpdate, inBackfill)
 }
 
 // canCheckpointHighWatermark returns true if we should update job high water mark (i.e. progress).
@@ -1328,11 +1341,6 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 		}
 
 		// Recover highwater information from job progress.
-		// Checkpoint information from job progress will eventually be sent to the
-		// changeFrontier from the changeAggregators.  Note that the changeFrontier
-		// may save a new checkpoint prior to receiving all spans of the
-		// aggregators' frontier, potentially missing spans that were previously
-		// checkpointed, so it is still possible for job progress to regress.
 		p := job.Progress()
 		if ts := p.GetHighWater(); ts != nil {
 			cf.highWaterAtStart.Forward(*ts)
@@ -1348,6 +1356,21 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 			}
 		}
 
+		// Checkpoint information from job progress will eventually be sent to
+		// the changeFrontier from the changeAggregators, but the changeFrontier
+		// may save a new checkpoint before it has heard from all of them. Restore
+		// the checkpointed spans up front so that such a checkpoint does not drop
+		// them and cause a restart to rescan them.
+		if progress := p.GetChangefeed(); progress != nil && progress.Checkpoint != nil {
+			if err := cf.frontier.restoreCheckpoint(*progress.Checkpoint, cf.spec.Feed.StatementTime); err != nil {
+				if log.V(2) {
+					log.Infof(cf.Ctx(), "change frontier moving to draining due to error restoring checkpoint: %v", err)
+				}
+				cf.MoveToDraining(err)
+				return
+			}
+		}
+
 		if p.RunningStatus != "" {
 			// If we had running status set, that means we're probably retrying
 			// due to a transient error.  In that case, keep the previous
@@ -1676,7 +1699,7 @@ func (cf *changeFrontier) maybeCheckpointJob(
 	// also store as many of those leading spans as we can in the job progress
 	updateCheckpoint :=
 		(inBackfill || cf.frontier.hasLaggingSpans(cf.spec.Feed.StatementTime, &cf.js.settings.SV)) &&
-			cf.js.canCheckpointSpans()
+			cf.js.canCheckpointSpans(inBackfill)
 
 	// If the highwater has moved an empty checkpoint will be saved
 	var checkpoint jobspb.ChangefeedProgress_Checkpoint
@@ -6,13 +6,19 @@
 package changefeedccl
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,3 +136,86 @@ func TestSetupSpansAndFrontier(t *testing.T) {
 		})
 	}
 }
+
+// TestFrontierRestoreCheckpoint tests that the change frontier retains the
+// spans of a persisted checkpoint in the checkpoints it makes.
+func TestFrontierRestoreCheckpoint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	spanAB := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	spanBC := roachpb.Span{Key: roachpb.Key("b"), EndKey: roachpb.Key("c")}
+	spanCD := roachpb.Span{Key: roachpb.Key("c"), EndKey: roachpb.Key("d")}
+	statementTime := hlc.Timestamp{WallTime: 10}
+
+	for _, tc := range []struct {
+		name             string
+		initialHighWater hlc.Timestamp
+		checkpointTS     hlc.Timestamp
+		expectedTS       hlc.Timestamp
+	}{
+		{
+			name:         "initial scan",
+			checkpointTS: statementTime,
+			expectedTS:   statementTime,
+		},
+		{
+			name:       "initial scan without checkpoint timestamp",
+			expectedTS: statementTime,
+		},
+		{
+			name:             "schema change backfill without checkpoint timestamp",
+			initialHighWater: hlc.Timestamp{WallTime: 20},
+			expectedTS:       hlc.Timestamp{WallTime: 20}.Next(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newFrontierResolvedSpanFrontier(hlc.Timestamp{}, spanAB, spanBC, spanCD)
+			require.NoError(t, err)
+			if !tc.initialHighWater.IsEmpty() {
+				f.initialHighWater = tc.initialHighWater
+				for _, sp := range []roachpb.Span{spanAB, spanBC, spanCD} {
+					_, err := f.Forward(sp, tc.initialHighWater)
+					require.NoError(t, err)
+				}
+			}
+
+			require.NoError(t, f.restoreCheckpoint(jobspb.ChangefeedProgress_Checkpoint{
+				Spans:     []roachpb.Span{spanAB, spanCD},
+				Timestamp: tc.checkpointTS,
+			}, statementTime))
+
+			// A resolved span from a single aggregator must not cause the other
+			// checkpointed span to be dropped.
+			_, err = f.ForwardResolvedSpan(context.Background(), jobspb.ResolvedSpan{
+				Span: spanAB, Timestamp: tc.expectedTS,
+			})
+			require.NoError(t, err)
+			cp := f.MakeCheckpoint(1 << 20)
+			require.Equal(t, []roachpb.Span{spanAB, spanCD}, cp.Spans)
+			require.Equal(t, tc.expectedTS, cp.Timestamp)
+		})
+	}
+}
+
+func TestCanCheckpointSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	sv := &st.SV
+	lastCheckpoint := timeutil.Now().Add(-time.Minute)
+
+	changefeedbase.FrontierCheckpointFrequency.Override(ctx, sv, 10*time.Minute)
+	require.False(t, canCheckpointSpans(sv, lastCheckpoint, false /* inBackfill */))
+	require.False(t, canCheckpointSpans(sv, lastCheckpoint, true /* inBackfill */))
+
+	changefeedbase.BackfillCheckpointFrequency.Override(ctx, sv, 30*time.Second)
+	require.False(t, canCheckpointSpans(sv, lastCheckpoint, false /* inBackfill */))
+	require.True(t, canCheckpointSpans(sv, lastCheckpoint, true /* inBackfill */))
+
+	// Disabling frontier checkpoints disables backfill checkpoints too.
+	changefeedbase.FrontierCheckpointFrequency.Override(ctx, sv, 0)
+	require.False(t, canCheckpointSpans(sv, lastCheckpoint, true /* inBackfill */))
+}
@@ -9,12 +9,14 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -103,6 +105,8 @@ const (
 	OptLaggingRangesPollingInterval       = `lagging_ranges_polling_interval`
 	OptIgnoreDisableChangefeedReplication = `ignore_disable_changefeed_replication`
 	OptEncodeJSONValueNullAsObject        = `encode_json_value_null_as_object`
+	OptInitialScanParallelism             = `initial_scan_parallelism`
+	OptInitialScanRateLimit               = `initial_scan_rate_limit`
 
 	OptVirtualColumnsOmitted VirtualColumnVisibility = `omitted`
 	OptVirtualColumnsNull    VirtualColumnVisibility = `null`
@@ -175,6 +179,8 @@ const (
 	OptKafkaSinkConfig   = `kafka_sink_config`
 	OptPubsubSinkConfig  = `pubsub_sink_config`
 	OptWebhookSinkConfig = `webhook_sink_config`
+	OptGRPCSinkConfig    = `grpc_sink_config`
+	OptNATSSinkConfig    = `nats_sink_config`
 
 	// OptSink allows users to alter the Sink URI of an existing changefeed.
 	// Note that this option is only allowed for alter changefeed statements.
@@ -206,6 +212,10 @@ const (
 	SinkSchemeWebhookHTTPS          = `webhook-https`
 	SinkSchemePulsar                = `pulsar`
 	SinkSchemeExternalConnection    = `external`
+	SinkSchemeGRPC                  = `grpc`
+	SinkSchemeGRPCS                 = `grpcs`
+	SinkSchemeNATS                  = `nats`
+	SinkParamNATSStream             = `stream`
 	SinkParamSASLEnabled            = `sasl_enabled`
 	SinkParamSASLHandshake          = `sasl_handshake`
 	SinkParamSASLUser               = `sasl_user`
@@ -278,6 +288,12 @@ const (
 	OptionTypeEnum
 
 	OptionTypeJSON
+
+	// OptionTypeInt is a non-negative integer.
+	OptionTypeInt
+
+	// OptionTypeBytes is a byte size such as '64MiB'.
+	OptionTypeBytes
 )
 
 // OptionPermittedValues is used in validations and is meant to be self-documenting.
@@ -330,6 +346,8 @@ var durationOption = OptionPermittedValues{Type: OptionTypeDuration}
 var timestampOption = OptionPermittedValues{Type: OptionTypeTimestamp}
 var flagOption = OptionPermittedValues{Type: OptionTypeFlag}
 var jsonOption = OptionPermittedValues{Type: OptionTypeJSON}
+var intOption = OptionPermittedValues{Type: OptionTypeInt}
+var bytesOption = OptionPermittedValues{Type: OptionTypeBytes}
 
 // ChangefeedOptionExpectValues is used to parse changefeed options using
 // PlanHookState.TypeAsStringOpts().
@@ -361,6 +379,8 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptKafkaSinkConfig:                    jsonOption,
 	OptPubsubSinkConfig:                   jsonOption,
 	OptWebhookSinkConfig:                  jsonOption,
+	OptGRPCSinkConfig:                     jsonOption,
+	OptNATSSinkConfig:                     jsonOption,
 	OptWebhookAuthHeader:                  stringOption,
 	OptWebhookClientTimeout:               durationOption,
 	OptOnError:                            enum("pause", "fail"),
@@ -372,6 +392,8 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptLaggingRangesPollingInterval:       durationOption,
 	OptIgnoreDisableChangefeedReplication: flagOption,
 	OptEncodeJSONValueNullAsObject:        flagOption,
+	OptInitialScanParallelism:             intOption,
+	OptInitialScanRateLimit:               bytesOption,
 }
 
 // CommonOptions is options common to all sinks
@@ -386,6 +408,7 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics, OptExpirePTSAfter,
 	OptExecutionLocality, OptLaggingRangesThreshold, OptLaggingRangesPollingInterval,
 	OptIgnoreDisableChangefeedReplication, OptEncodeJSONValueNullAsObject,
+	OptInitialScanParallelism, OptInitialScanRateLimit,
 )
 
 // SQLValidOptions is options exclusive to SQL sink
@@ -403,13 +426,20 @@ var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTi
 // PubsubValidOptions is options exclusive to pubsub sink
 var PubsubValidOptions = makeStringSet(OptPubsubSinkConfig)
 
+// GRPCValidOptions is options exclusive to the gRPC streaming sink
+var GRPCValidOptions = makeStringSet(OptGRPCSinkConfig)
+
+// NATSValidOptions is options exclusive to the NATS JetStream sink
+var NATSValidOptions = makeStringSet(OptNATSSinkConfig)
+
 // ExternalConnectionValidOptions is options exclusive to the external
 // connection sink.
 //
 // TODO(adityamaru): Some of these options should be supported when creating the
 // external connection rather than when setting up the changefeed. Move them once
 // we support `CREATE EXTERNAL CONNECTION ... WITH <options>`.
-var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions)
+var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions,
+	GRPCValidOptions, NATSValidOptions)
 
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents,
@@ -663,6 +693,36 @@ func (s StatementOptions) getDurationValue(k string) (*time.Duration, error) {
 	}
 }
 
+func (s StatementOptions) getIntValue(k string) (int64, bool, error) {
+	v, ok := s.m[k]
+	if !ok {
+		return 0, false, nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "problem parsing option %s", k)
+	}
+	if i <= 0 {
+		return 0, false, errors.Errorf("option %s must be an integer greater than 0", k)
+	}
+	return i, true, nil
+}
+
+func (s StatementOptions) getBytesValue(k string) (int64, bool, error) {
+	v, ok := s.m[k]
+	if !ok {
+		return 0, false, nil
+	}
+	b, err := humanizeutil.ParseBytes(v)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "problem parsing option %s", k)
+	}
+	if b <= 0 {
+		return 0, false, errors.Errorf("option %s must be a size greater than 0", k)
+	}
+	return b, true, nil
+}
+
 func (s StatementOptions) getJSONValue(k string) SinkSpecificJSONConfig {
 	return SinkSpecificJSONConfig(s.m[k])
 }
@@ -951,6 +1011,18 @@ func (s StatementOptions) GetPubsubConfigJSON() SinkSpecificJSONConfig {
 	return s.getJSONValue(OptPubsubSinkConfig)
 }
 
+// GetGRPCConfigJSON returns arbitrary json to be interpreted
+// by the gRPC sink.
+func (s StatementOptions) GetGRPCConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptGRPCSinkConfig)
+}
+
+// GetNATSConfigJSON returns arbitrary json to be interpreted
+// by the NATS sink.
+func (s StatementOptions) GetNATSConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptNATSSinkConfig)
+}
+
 // GetResolvedTimestampInterval gets the best-effort interval at which resolved timestamps
 // should be emitted. Nil or 0 means emit as often as possible. False means do not emit at all.
 // Returns an error for negative or invalid duration value.
@@ -1012,6 +1084,32 @@ func (s StatementOptions) GetMinCheckpointFrequency() (*time.Duration, error) {
 	return s.getDurationValue(OptMinCheckpointFrequency)
 }
 
+// InitialScanLimits bounds the resources used by the initial scan, and by
+// any backfills, of a single changefeed. Zero values mean the limit is
+// governed by cluster settings.
+type InitialScanLimits struct {
+	// Parallelism is the maximum number of scan requests each aggregator
+	// issues concurrently.
+	Parallelism int
+	// BytesPerSecond is the maximum rate at which each aggregator reads
+	// scanned data.
+	BytesPerSecond int64
+}
+
+// GetInitialScanLimits returns the per-changefeed initial scan limits.
+func (s StatementOptions) GetInitialScanLimits() (InitialScanLimits, error) {
+	var limits InitialScanLimits
+	parallelism, _, err := s.getIntValue(OptInitialScanParallelism)
+	if err != nil {
+		return limits, err
+	}
+	limits.Parallelism = int(parallelism)
+	if limits.BytesPerSecond, _, err = s.getBytesValue(OptInitialScanRateLimit); err != nil {
+		return limits, err
+	}
+	return limits, nil
+}
+
 func (s StatementOptions) GetConfluentSchemaRegistry() string {
 	return s.m[OptConfluentSchemaRegistry]
 }
@@ -1181,6 +1279,14 @@ func (s StatementOptions) validateAgainst(m map[string]OptionPermittedValues) er
 			if _, err := s.getEnumValue(k); err != nil {
 				return err
 			}
+		case OptionTypeInt:
+			if _, _, err := s.getIntValue(k); err != nil {
+				return err
+			}
+		case OptionTypeBytes:
+			if _, _, err := s.getBytesValue(k); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -37,6 +37,11 @@ func TestOptionsValidations(t *testing.T) {
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"key_column": "b"}, false, "requires the unordered option"},
+		{map[string]string{"initial_scan_parallelism": "8", "initial_scan_rate_limit": "64MiB"}, false, ""},
+		{map[string]string{"initial_scan_parallelism": "0"}, false, "must be an integer greater than 0"},
+		{map[string]string{"initial_scan_parallelism": "many"}, false, "problem parsing option initial_scan_parallelism"},
+		{map[string]string{"initial_scan_rate_limit": "fast"}, false, "problem parsing option initial_scan_rate_limit"},
+		{map[string]string{"initial_scan_rate_limit": "0B"}, false, "must be a size greater than 0"},
 	}
 
 	for _, test := range tests {
@@ -51,6 +56,22 @@ func TestOptionsValidations(t *testing.T) {
 	}
 }
 
+func TestInitialScanLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	limits, err := MakeDefaultOptions().GetInitialScanLimits()
+	require.NoError(t, err)
+	require.Equal(t, InitialScanLimits{}, limits)
+
+	limits, err = MakeStatementOptions(map[string]string{
+		OptInitialScanParallelism: "4",
+		OptInitialScanRateLimit:   "1KiB",
+	}).GetInitialScanLimits()
+	require.NoError(t, err)
+	require.Equal(t, InitialScanLimits{Parallelism: 4, BytesPerSecond: 1 << 10}, limits)
+}
+
 func TestEncodingOptionsValidations(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
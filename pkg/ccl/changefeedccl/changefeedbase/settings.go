@@ -76,6 +76,19 @@ var FrontierCheckpointFrequency = settings.RegisterDurationSetting(
 	settings.NonNegativeDuration,
 )
 
+// BackfillCheckpointFrequency controls the frequency of frontier checkpoints
+// while a backfill is in progress. Initial scans of large tables can take a
+// long time, so checkpointing them more often than other span level
+// checkpoints limits the work repeated when the changefeed restarts.
+var BackfillCheckpointFrequency = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"changefeed.backfill.checkpoint_frequency",
+	"controls the frequency with which span level checkpoints will be written during a backfill; "+
+		"if 0, or larger than changefeed.frontier_checkpoint_frequency, the latter is used",
+	0,
+	settings.NonNegativeDuration,
+)
+
 // FrontierHighwaterLagCheckpointThreshold controls the amount the high-water
 // mark is allowed to lag behind the leading edge of the frontier before we
 // begin to attempt checkpointing spans above the high-water mark
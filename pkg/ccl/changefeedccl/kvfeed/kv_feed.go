@@ -90,6 +90,10 @@ type Config struct {
 	// enables filtering out any transactional writes with that flag set to true.
 	WithFiltering bool
 
+	// InitialScanLimits bounds the concurrency and throughput of the scans
+	// this feed performs.
+	InitialScanLimits changefeedbase.InitialScanLimits
+
 	// Knobs are kvfeed testing knobs.
 	Knobs TestingKnobs
 
@@ -108,6 +112,8 @@ func Run(ctx context.Context, cfg Config) error {
 			settings:                cfg.Settings,
 			db:                      cfg.DB,
 			onBackfillRangeCallback: cfg.MonitoringCfg.OnBackfillRangeCallback,
+			parallelism:             cfg.InitialScanLimits.Parallelism,
+			rateLimiter:             makeScanRateLimiter(cfg.InitialScanLimits.BytesPerSecond),
 		}
 	}
 	var pff physicalFeedFactory
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -51,6 +52,24 @@ type scanRequestScanner struct {
 	settings                *cluster.Settings
 	db                      *kv.DB
 	onBackfillRangeCallback func(int64) (func(), func())
+
+	// parallelism, if positive, overrides the number of concurrent scan
+	// requests otherwise derived from cluster settings.
+	parallelism int
+	// rateLimiter, if non-nil, limits the rate at which scanned bytes are
+	// read.
+	rateLimiter *quotapool.RateLimiter
+}
+
+// makeScanRateLimiter returns a limiter admitting bytesPerSecond bytes per
+// second, with a burst of one second's worth of bytes, or nil if
+// bytesPerSecond is not positive.
+func makeScanRateLimiter(bytesPerSecond int64) *quotapool.RateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return quotapool.NewRateLimiter(
+		"changefeedScanRateLimiter", quotapool.Limit(bytesPerSecond), bytesPerSecond)
 }
 
 var _ kvScanner = (*scanRequestScanner)(nil)
@@ -78,7 +97,7 @@ func (p *scanRequestScanner) Scan(ctx context.Context, sink kvevent.Writer, cfg
 		defer backfillClear()
 	}
 
-	maxConcurrentScans := maxConcurrentScanRequests(numNodesHint, &p.settings.SV)
+	maxConcurrentScans := p.maxConcurrentScanRequests(numNodesHint)
 	exportLim := limit.MakeConcurrentRequestLimiter("changefeedScanRequestLimiter", maxConcurrentScans)
 
 	lastScanLimitUserSetting := changefeedbase.ScanRequestLimit.Get(&p.settings.SV)
@@ -92,7 +111,7 @@ func (p *scanRequestScanner) Scan(ctx context.Context, sink kvevent.Writer, cfg
 		// If the user defined scan request limit has changed, recalculate it
 		if currentUserScanLimit := changefeedbase.ScanRequestLimit.Get(&p.settings.SV); currentUserScanLimit != lastScanLimitUserSetting {
 			lastScanLimitUserSetting = currentUserScanLimit
-			exportLim.SetLimit(maxConcurrentScanRequests(numNodesHint, &p.settings.SV))
+			exportLim.SetLimit(p.maxConcurrentScanRequests(numNodesHint))
 		}
 
 		limAlloc, err := exportLim.Begin(ctx)
@@ -218,6 +237,15 @@ func (p *scanRequestScanner) exportSpan(
 		}
 		afterScan := timeutil.Now()
 		res := b.RawResponse().Responses[0].GetScan()
+		if p.rateLimiter != nil {
+			var n int64
+			for _, br := range res.BatchResponses {
+				n += int64(len(br))
+			}
+			if err := p.rateLimiter.WaitN(ctx, n); err != nil {
+				return err
+			}
+		}
 		if err := slurpScanResponse(ctx, sink, res, ts, withDiff, *remaining); err != nil {
 			return err
 		}
@@ -322,6 +350,15 @@ func slurpScanResponse(
 	return nil
 }
 
+// maxConcurrentScanRequests returns the number of concurrent scan requests,
+// preferring the changefeed's own parallelism over cluster settings.
+func (p *scanRequestScanner) maxConcurrentScanRequests(numNodesHint int) int {
+	if p.parallelism > 0 {
+		return p.parallelism
+	}
+	return maxConcurrentScanRequests(numNodesHint, &p.settings.SV)
+}
+
 // maxConcurrentScanRequests returns the number of concurrent scan requests.
 func maxConcurrentScanRequests(numNodesHint int, sv *settings.Values) int {
 	// If the user specified ScanRequestLimit -- use that value.
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -100,3 +101,45 @@ INSERT INTO t VALUES (1), (2), (3);
 	require.Equal(t, span, sink.resolved[2].Span)
 	require.Equal(t, exportTime, sink.resolved[2].Timestamp)
 }
+
+func TestScanRequestScannerLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, kvdb := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+CREATE TABLE t (a INT PRIMARY KEY);
+INSERT INTO t VALUES (1), (2), (3);
+`)
+
+	codec := s.Codec()
+	descr := desctestutils.TestingGetPublicTableDescriptor(kvdb, codec, "defaultdb", "t")
+	span := tableSpan(codec, uint32(descr.GetID()))
+
+	// The changefeed's own parallelism takes precedence over the cluster
+	// setting.
+	changefeedbase.ScanRequestLimit.Override(ctx, &s.ClusterSettings().SV, 10)
+	scanner := &scanRequestScanner{
+		settings:    s.ClusterSettings(),
+		db:          kvdb,
+		parallelism: 2,
+		rateLimiter: makeScanRateLimiter(1 << 20),
+	}
+	require.Equal(t, 2, scanner.maxConcurrentScanRequests(1))
+	require.Equal(t, 10, (&scanRequestScanner{settings: s.ClusterSettings()}).maxConcurrentScanRequests(1))
+	require.Nil(t, makeScanRateLimiter(0))
+
+	exportTime := kvdb.Clock().Now()
+	sink := &recordResolvedWriter{}
+	require.NoError(t, scanner.Scan(ctx, sink, scanConfig{
+		Spans:     []roachpb.Span{span},
+		Timestamp: exportTime,
+	}))
+	require.Equal(t, span, sink.resolved[len(sink.resolved)-1].Span)
+	require.Equal(t, exportTime, sink.resolved[len(sink.resolved)-1].Timestamp)
+}
@@ -97,6 +97,32 @@ func (f *frontierResolvedSpanFrontier) ForwardResolvedSpan(
 	return frontierChanged, nil
 }
 
+// restoreCheckpoint forwards the spans of a previously persisted checkpoint
+// so that checkpoints made before every aggregator has reported its progress
+// retain them.
+func (f *frontierResolvedSpanFrontier) restoreCheckpoint(
+	cp jobspb.ChangefeedProgress_Checkpoint, statementTime hlc.Timestamp,
+) error {
+	ts := cp.Timestamp
+	// Checkpoint records from 21.2 did not store the timestamp, which is either
+	// the statement time for an initial scan or right after the high-water for
+	// a schema change backfill.
+	if ts.IsEmpty() {
+		if f.initialHighWater.IsEmpty() {
+			ts = statementTime
+		} else {
+			ts = f.initialHighWater.Next()
+		}
+	}
+	for _, sp := range cp.Spans {
+		if _, err := f.Forward(sp, ts); err != nil {
+			return err
+		}
+	}
+	f.latestTs.Forward(ts)
+	return nil
+}
+
 // InBackfill returns whether a resolved span is part of an ongoing backfill
 // (either an initial scan backfill or a schema change backfill).
 // NB: Since the frontierResolvedSpanFrontier consolidates the frontiers of
@@ -57,6 +57,8 @@ const (
 	sinkTypeCloudstorage
 	sinkTypeSQL
 	sinkTypePulsar
+	sinkTypeGRPC
+	sinkTypeNATS
 )
 
 // externalResource is the interface common to both EventSink and
@@ -294,6 +296,18 @@ func getSink(
 			} else {
 				return makeDeprecatedPubsubSink(ctx, u, encodingOpts, AllTargets(feedCfg), opts.IsSet(changefeedbase.OptUnordered), metricsBuilder, testingKnobs)
 			}
+		case isGRPCSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.GRPCValidOptions, func() (Sink, error) {
+				return makeGRPCSink(ctx, sinkURL{URL: u}, encodingOpts, opts.GetGRPCConfigJSON(), AllTargets(feedCfg),
+					numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
+					metricsBuilder, serverCfg.Settings)
+			})
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, sinkURL{URL: u}, encodingOpts, opts.GetNATSConfigJSON(), AllTargets(feedCfg),
+					numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
+					metricsBuilder, serverCfg.Settings)
+			})
 		case isCloudStorageSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.CloudStorageValidOptions, func() (Sink, error) {
 				var testingKnobs *TestingKnobs
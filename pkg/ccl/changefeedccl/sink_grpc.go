@@ -0,0 +1,408 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// defaultGRPCSinkMaxInFlight is the default number of batches which may be
+// outstanding (sent but not yet acknowledged) on a gRPC sink stream.
+const defaultGRPCSinkMaxInFlight = 16
+
+func isGRPCSink(u *url.URL) bool {
+	switch u.Scheme {
+	case changefeedbase.SinkSchemeGRPC, changefeedbase.SinkSchemeGRPCS:
+		return true
+	default:
+		return false
+	}
+}
+
+// grpcSinkConfig is the JSON configuration accepted by the grpc_sink_config
+// option in addition to the common Flush and Retry settings.
+type grpcSinkConfig struct {
+	// MaxInFlight bounds the number of unacknowledged batches on the stream.
+	MaxInFlight int `json:",omitempty"`
+}
+
+// grpcSinkClient is a SinkClient which publishes batches over a bidirectional
+// stream to a receiver implementing the changefeedpb.ChangefeedSink service.
+// Each batch is acknowledged individually; the number of unacknowledged
+// batches is bounded by maxInFlight, which gives the receiver a way to apply
+// back pressure.
+type grpcSinkClient struct {
+	ctx      context.Context
+	batchCfg sinkBatchConfig
+	conn     *grpc.ClientConn
+	client   changefeedpb.ChangefeedSinkClient
+
+	// inFlight is a semaphore limiting the number of unacknowledged batches.
+	inFlight chan struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// stream is opened lazily and reset on any stream error, at which point
+		// the next Flush opens a new one.
+		stream  changefeedpb.ChangefeedSink_PublishClient
+		cancel  context.CancelFunc
+		nextID  uint64
+		pending map[uint64]chan error
+	}
+}
+
+var _ SinkClient = (*grpcSinkClient)(nil)
+var _ SinkPayload = (*changefeedpb.PublishRequest)(nil)
+
+func makeGRPCSinkClient(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	batchCfg sinkBatchConfig,
+	maxInFlight int,
+	m metricsRecorder,
+) (*grpcSinkClient, error) {
+	if err := validateGRPCEncodingOpts(encodingOpts); err != nil {
+		return nil, err
+	}
+
+	var creds credentials.TransportCredentials
+	if u.Scheme == changefeedbase.SinkSchemeGRPCS {
+		tlsConfig, err := makeSinkTLSConfig(&u)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	} else {
+		creds = insecure.NewCredentials()
+	}
+
+	if u.Host == "" {
+		return nil, errors.Errorf(`grpc sink URI must specify a host`)
+	}
+
+	dialContext := m.netMetrics().Wrap((&net.Dialer{}).DialContext, "grpc")
+	// The connection is established lazily by the first stream, so there is
+	// no context to dial with here.
+	conn, err := grpc.Dial(u.Host,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialContext(ctx, "tcp", addr)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "dialing grpc sink")
+	}
+
+	if maxInFlight <= 0 {
+		maxInFlight = defaultGRPCSinkMaxInFlight
+	}
+	sc := &grpcSinkClient{
+		ctx:      ctx,
+		batchCfg: batchCfg,
+		conn:     conn,
+		client:   changefeedpb.NewChangefeedSinkClient(conn),
+		inFlight: make(chan struct{}, maxInFlight),
+	}
+	sc.mu.pending = make(map[uint64]chan error)
+	return sc, nil
+}
+
+func validateGRPCEncodingOpts(encodingOpts changefeedbase.EncodingOptions) error {
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON, changefeedbase.OptFormatCSV:
+	default:
+		return errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+
+	switch encodingOpts.Envelope {
+	case changefeedbase.OptEnvelopeWrapped, changefeedbase.OptEnvelopeBare:
+	default:
+		return errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptEnvelope, encodingOpts.Envelope)
+	}
+	return nil
+}
+
+// CheckConnection implements the SinkClient interface.
+func (sc *grpcSinkClient) CheckConnection(ctx context.Context) error {
+	_, err := sc.getOrOpenStream()
+	return err
+}
+
+// getOrOpenStream returns the current stream, opening a new one if there is
+// none.
+func (sc *grpcSinkClient) getOrOpenStream() (changefeedpb.ChangefeedSink_PublishClient, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.getOrOpenStreamLocked()
+}
+
+func (sc *grpcSinkClient) getOrOpenStreamLocked() (
+	changefeedpb.ChangefeedSink_PublishClient,
+	error,
+) {
+	if sc.mu.stream != nil {
+		return sc.mu.stream, nil
+	}
+	streamCtx, cancel := context.WithCancel(sc.ctx)
+	stream, err := sc.client.Publish(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "opening grpc sink stream")
+	}
+	sc.mu.stream = stream
+	sc.mu.cancel = cancel
+	go sc.receiveAcks(stream)
+	return stream, nil
+}
+
+// receiveAcks consumes acknowledgements from the stream and hands them to the
+// Flush calls waiting on them. On a stream error all pending batches are
+// failed and the stream is discarded.
+func (sc *grpcSinkClient) receiveAcks(stream changefeedpb.ChangefeedSink_PublishClient) {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			sc.resetStream(stream, errors.Wrap(err, "grpc sink stream"))
+			return
+		}
+		sc.mu.Lock()
+		waiter, ok := sc.mu.pending[resp.BatchID]
+		delete(sc.mu.pending, resp.BatchID)
+		sc.mu.Unlock()
+		if !ok {
+			log.Warningf(sc.ctx, "grpc sink received acknowledgement for unknown batch %d", resp.BatchID)
+			continue
+		}
+		if resp.Error != "" {
+			waiter <- errors.Newf("grpc sink receiver rejected batch: %s", resp.Error)
+		} else {
+			waiter <- nil
+		}
+	}
+}
+
+// resetStream discards stream, if it is still the current one, and fails all
+// batches awaiting an acknowledgement on it.
+func (sc *grpcSinkClient) resetStream(
+	stream changefeedpb.ChangefeedSink_PublishClient, err error,
+) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.mu.stream != stream {
+		return
+	}
+	sc.resetStreamLocked(err)
+}
+
+func (sc *grpcSinkClient) resetStreamLocked(err error) {
+	sc.mu.cancel()
+	sc.mu.stream = nil
+	sc.mu.cancel = nil
+	for id, waiter := range sc.mu.pending {
+		waiter <- err
+		delete(sc.mu.pending, id)
+	}
+}
+
+// Flush implements the SinkClient interface.
+func (sc *grpcSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	select {
+	case sc.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-sc.inFlight }()
+
+	// Copy the request before assigning it an ID since the same payload is
+	// passed to Flush again on retries.
+	req := *payload.(*changefeedpb.PublishRequest)
+	waiter := make(chan error, 1)
+
+	sc.mu.Lock()
+	stream, err := sc.getOrOpenStreamLocked()
+	if err != nil {
+		sc.mu.Unlock()
+		return err
+	}
+	sc.mu.nextID++
+	req.BatchID = sc.mu.nextID
+	sc.mu.pending[req.BatchID] = waiter
+	// Sends on a gRPC stream must not be concurrent, so they happen under the
+	// lock.
+	err = stream.Send(&req)
+	sc.mu.Unlock()
+	if err != nil {
+		sc.resetStream(stream, err)
+		// The waiter may have been failed by the reset above or by the
+		// receiving goroutine; either way the send error is more useful.
+		return errors.Wrap(err, "sending to grpc sink")
+	}
+
+	select {
+	case err := <-waiter:
+		return err
+	case <-ctx.Done():
+		sc.mu.Lock()
+		delete(sc.mu.pending, req.BatchID)
+		sc.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (sc *grpcSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		req := &changefeedpb.PublishRequest{
+			Topic:    topic,
+			Resolved: true,
+			Messages: []changefeedpb.SinkMessage{{Value: body}},
+		}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return sc.Flush(ctx, req)
+		})
+	})
+}
+
+// Close implements the SinkClient interface. Batches which are still
+// awaiting an acknowledgement are failed.
+func (sc *grpcSinkClient) Close() error {
+	sc.mu.Lock()
+	if sc.mu.stream != nil {
+		// CloseSend lets the receiver observe a clean end of stream.
+		_ = sc.mu.stream.CloseSend()
+		sc.resetStreamLocked(errors.New("grpc sink closed"))
+	}
+	sc.mu.Unlock()
+	return sc.conn.Close()
+}
+
+type grpcBuffer struct {
+	sc       *grpcSinkClient
+	topic    string
+	messages []changefeedpb.SinkMessage
+	numBytes int
+}
+
+var _ BatchBuffer = (*grpcBuffer)(nil)
+
+// Append implements the BatchBuffer interface.
+func (gb *grpcBuffer) Append(key []byte, value []byte, attributes attributes) {
+	gb.messages = append(gb.messages, changefeedpb.SinkMessage{
+		Key:       key,
+		Value:     value,
+		TableName: attributes.tableName,
+	})
+	gb.numBytes += len(key) + len(value)
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (gb *grpcBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(gb.numBytes, len(gb.messages), gb.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (gb *grpcBuffer) Close() (SinkPayload, error) {
+	return &changefeedpb.PublishRequest{
+		Topic:    gb.topic,
+		Messages: gb.messages,
+	}, nil
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (sc *grpcSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	return &grpcBuffer{
+		sc:       sc,
+		topic:    topic,
+		messages: make([]changefeedpb.SinkMessage, 0, sc.batchCfg.Messages),
+	}
+}
+
+func makeGRPCSink(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targets changefeedbase.Targets,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{})
+	if err != nil {
+		return nil, err
+	}
+	var cfg grpcSinkConfig
+	if jsonConfig != `` {
+		if err := json.Unmarshal([]byte(jsonConfig), &cfg); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshalling json")
+		}
+	}
+	if cfg.MaxInFlight < 0 {
+		return nil, errors.Errorf("invalid sink config, MaxInFlight must be non-negative")
+	}
+
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(targets, WithPrefix(topicPrefix), WithSingleName(topicName))
+	if err != nil {
+		return nil, err
+	}
+
+	sinkClient, err := makeGRPCSinkClient(ctx, u, encodingOpts, batchCfg, cfg.MaxInFlight, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		_ = sinkClient.Close()
+		return nil, errors.Errorf(
+			`unknown grpc sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	return makeBatchingSink(
+		ctx,
+		sinkTypeGRPC,
+		sinkClient,
+		time.Duration(batchCfg.Frequency),
+		retryOpts,
+		parallelism,
+		topicNamer,
+		pacerFactory,
+		source,
+		m,
+		settings,
+	), nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestGRPCSink(
+	t *testing.T, uri string, jsonConfig changefeedbase.SinkSpecificJSONConfig, targetNames ...string,
+) Sink {
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	encodingOpts := changefeedbase.EncodingOptions{
+		Format:   changefeedbase.OptFormatJSON,
+		Envelope: changefeedbase.OptEnvelopeWrapped,
+	}
+	s, err := makeGRPCSink(context.Background(), sinkURL{URL: u}, encodingOpts, jsonConfig,
+		makeChangefeedTargets(targetNames...), 2, nilPacerFactory, timeutil.DefaultTimeSource{},
+		nilMetricsRecorderBuilder, cluster.MakeTestingClusterSettings())
+	require.NoError(t, err)
+	require.NoError(t, s.Dial())
+	return s
+}
+
+func TestGRPCSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	receiver, err := cdctest.StartMockGRPCSink()
+	require.NoError(t, err)
+	defer receiver.Close()
+
+	const retryConfig = `{"Retry":{"Backoff":"5ms"}}`
+	for _, tc := range []struct {
+		name          string
+		params        string
+		expectedTopic string
+	}{
+		{name: "default", expectedTopic: "t"},
+		{name: "topic_prefix", params: "?topic_prefix=pre_", expectedTopic: "pre_t"},
+		{name: "topic_name", params: "?topic_name=all", expectedTopic: "all"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			uri := fmt.Sprintf("grpc://%s%s", receiver.Addr(), tc.params)
+			s := makeTestGRPCSink(t, uri, retryConfig, "t")
+			defer func() { require.NoError(t, s.Close()) }()
+
+			var pool testAllocPool
+			require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"after":{"a":1}}`),
+				zeroTS, zeroTS, pool.alloc()))
+			require.NoError(t, s.Flush(ctx))
+			require.Equal(t, []string{`{"after":{"a":1}}`}, receiver.Messages(tc.expectedTopic))
+			testutils.SucceedsSoon(t, func() error {
+				if remaining := pool.used(); remaining != 0 {
+					return errors.Newf("waiting for 0 allocs (%d)", remaining)
+				}
+				return nil
+			})
+
+			var e testEncoder
+			require.NoError(t, s.EmitResolvedTimestamp(ctx, e, zeroTS))
+			require.Len(t, receiver.Resolved(tc.expectedTopic), 1)
+		})
+	}
+
+	t.Run("retries rejected batches", func(t *testing.T) {
+		s := makeTestGRPCSink(t, fmt.Sprintf("grpc://%s?topic_name=retry", receiver.Addr()),
+			retryConfig, "t")
+		defer func() { require.NoError(t, s.Close()) }()
+
+		receiver.RejectNext(2)
+		before := receiver.NumBatches()
+		var pool testAllocPool
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[2]`), []byte(`{"after":{"a":2}}`),
+			zeroTS, zeroTS, pool.alloc()))
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, []string{`{"after":{"a":2}}`}, receiver.Messages("retry"))
+		require.Equal(t, before+3, receiver.NumBatches())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		s := makeTestGRPCSink(t, fmt.Sprintf("grpc://%s?topic_name=fail", receiver.Addr()),
+			`{"Retry":{"Max":1,"Backoff":"5ms"}}`, "t")
+		defer func() { _ = s.Close() }()
+
+		receiver.RejectNext(10)
+		defer receiver.RejectNext(0)
+		var pool testAllocPool
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[3]`), []byte(`{"after":{"a":3}}`),
+			zeroTS, zeroTS, pool.alloc()))
+		require.Regexp(t, "rejected by mock sink", s.Flush(ctx))
+	})
+}
+
+func TestGRPCSinkParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		uri           string
+		format        changefeedbase.FormatType
+		expectedError string
+	}{
+		{uri: "grpc://localhost:1234?foo=bar", expectedError: "unknown grpc sink query parameters: foo"},
+		{uri: "grpc:///", expectedError: "must specify a host"},
+		{uri: "grpc://localhost:1234", format: changefeedbase.OptFormatAvro, expectedError: "incompatible with format=avro"},
+		{uri: "grpcs://localhost:1234?ca_cert=!!", expectedError: "must be base 64 encoded"},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			u, err := url.Parse(tc.uri)
+			require.NoError(t, err)
+			format := changefeedbase.OptFormatJSON
+			if tc.format != "" {
+				format = tc.format
+			}
+			_, err = makeGRPCSink(context.Background(), sinkURL{URL: u},
+				changefeedbase.EncodingOptions{Format: format, Envelope: changefeedbase.OptEnvelopeWrapped},
+				"", makeChangefeedTargets("t"), 1, nilPacerFactory, timeutil.DefaultTimeSource{},
+				nilMetricsRecorderBuilder, cluster.MakeTestingClusterSettings())
+			require.Regexp(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestGRPCSinkReconnects(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	receiver, err := cdctest.StartMockGRPCSink()
+	require.NoError(t, err)
+
+	s := makeTestGRPCSink(t, fmt.Sprintf("grpc://%s", receiver.Addr()), `{"Retry":{"Backoff":"5ms"}}`, "t")
+	defer func() { _ = s.Close() }()
+
+	var pool testAllocPool
+	require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`1`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, s.Flush(ctx))
+
+	// Stopping the receiver breaks the stream; the sink should surface an
+	// error rather than hang.
+	receiver.Close()
+	require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[2]`), []byte(`2`), zeroTS, zeroTS, pool.alloc()))
+	testutils.SucceedsSoon(t, func() error {
+		if err := s.Flush(ctx); err == nil {
+			return errors.New("expected flush to fail")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,397 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	gojson "encoding/json"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+)
+
+// defaultNATSAckTimeout is how long the sink waits for JetStream to
+// acknowledge a published batch before failing (and retrying) it.
+const defaultNATSAckTimeout = 5 * time.Second
+
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// natsSinkConfig is the JSON configuration accepted by the nats_sink_config
+// option in addition to the common Flush and Retry settings.
+type natsSinkConfig struct {
+	AckTimeout jsonDuration `json:",omitempty"`
+}
+
+// SQLNameToNATSSubject converts a topic name into a valid NATS subject by
+// replacing whitespace and the subject wildcard characters with underscores.
+// Periods are preserved; they act as subject token separators.
+func SQLNameToNATSSubject(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '*' || r == '>' || unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// natsSinkClient is a SinkClient which publishes batches to NATS JetStream.
+// Every message carries a Nats-Msg-Id header which is fixed when the batch is
+// built, so a batch that is retried after a partial failure is deduplicated
+// by JetStream rather than written twice.
+type natsSinkClient struct {
+	format      changefeedbase.FormatType
+	batchCfg    sinkBatchConfig
+	opts        nats.Options
+	pubOpts     []nats.PubOpt
+	ackTimeout  time.Duration
+	msgIDPrefix string
+	nextMsgID   atomic.Uint64
+
+	mu struct {
+		syncutil.Mutex
+		// conn is established lazily. The client reconnects on its own after
+		// transient failures; once it gives up and closes, the next Flush
+		// dials again.
+		conn *nats.Conn
+		js   nats.JetStreamContext
+	}
+}
+
+var _ SinkClient = (*natsSinkClient)(nil)
+var _ SinkPayload = (*natsPayload)(nil)
+
+func makeNATSSinkClient(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	batchCfg sinkBatchConfig,
+	ackTimeout time.Duration,
+	m metricsRecorder,
+) (*natsSinkClient, error) {
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON, changefeedbase.OptFormatCSV:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+
+	switch encodingOpts.Envelope {
+	case changefeedbase.OptEnvelopeWrapped, changefeedbase.OptEnvelopeBare:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptEnvelope, encodingOpts.Envelope)
+	}
+
+	if u.Host == "" {
+		return nil, errors.Errorf(`nats sink URI must specify a host`)
+	}
+
+	opts := nats.GetDefaultOptions()
+	opts.Url = (&url.URL{Scheme: "nats", Host: u.Host}).String()
+	opts.Name = "cockroachdb-changefeed"
+	var tlsEnabled bool
+	if _, err := u.consumeBool(changefeedbase.SinkParamTLSEnabled, &tlsEnabled); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := makeSinkTLSConfig(&u)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ServerName = u.Hostname()
+	// The TLS config is also used if the server requires TLS even though the
+	// URI did not ask for it.
+	opts.TLSConfig = tlsConfig
+	opts.Secure = tlsEnabled
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts.User, opts.Password = u.User.Username(), password
+		} else {
+			opts.Token = u.User.Username()
+		}
+	}
+	opts.CustomDialer = &natsDialer{
+		ctx:     ctx,
+		timeout: opts.Timeout,
+		dial:    m.netMetrics().Wrap((&net.Dialer{}).DialContext, "nats"),
+	}
+
+	var pubOpts []nats.PubOpt
+	if stream := u.consumeParam(changefeedbase.SinkParamNATSStream); stream != "" {
+		// JetStream rejects the publish if the subject is bound to any other
+		// stream.
+		pubOpts = append(pubOpts, nats.ExpectStream(stream))
+	}
+
+	if ackTimeout <= 0 {
+		ackTimeout = defaultNATSAckTimeout
+	}
+	return &natsSinkClient{
+		format:      encodingOpts.Format,
+		batchCfg:    batchCfg,
+		opts:        opts,
+		pubOpts:     pubOpts,
+		ackTimeout:  ackTimeout,
+		msgIDPrefix: uuid.MakeV4().String(),
+	}, nil
+}
+
+// natsDialer adapts the sink's metered dial function to nats.CustomDialer.
+type natsDialer struct {
+	ctx     context.Context
+	timeout time.Duration
+	dial    cidr.DialContext
+}
+
+var _ nats.CustomDialer = (*natsDialer)(nil)
+
+// Dial implements the nats.CustomDialer interface.
+func (d *natsDialer) Dial(network, address string) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+	defer cancel()
+	return d.dial(ctx, network, address)
+}
+
+func (sc *natsSinkClient) getJetStream() (nats.JetStreamContext, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.mu.conn != nil && !sc.mu.conn.IsClosed() {
+		return sc.mu.js, nil
+	}
+	conn, err := sc.opts.Connect()
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to nats server")
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "initializing JetStream context")
+	}
+	sc.mu.conn, sc.mu.js = conn, js
+	return js, nil
+}
+
+// CheckConnection implements the SinkClient interface.
+func (sc *natsSinkClient) CheckConnection(ctx context.Context) error {
+	_, err := sc.getJetStream()
+	return err
+}
+
+// Flush implements the SinkClient interface.
+func (sc *natsSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	batch := payload.(*natsPayload)
+	js, err := sc.getJetStream()
+	if err != nil {
+		return err
+	}
+
+	acks := make([]nats.PubAckFuture, len(batch.messages))
+	for i, msg := range batch.messages {
+		opts := append([]nats.PubOpt{nats.MsgId(msg.id)}, sc.pubOpts...)
+		acks[i], err = js.PublishMsgAsync(&nats.Msg{Subject: batch.subject, Data: msg.data}, opts...)
+		if err != nil {
+			return errors.Wrapf(err, "publishing to nats subject %q", batch.subject)
+		}
+	}
+
+	timer := time.NewTimer(sc.ackTimeout)
+	defer timer.Stop()
+	for _, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			return errors.Wrapf(err, "publishing to nats subject %q", batch.subject)
+		case <-timer.C:
+			return errors.Errorf("timed out waiting for JetStream acknowledgement on subject %q",
+				batch.subject)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (sc *natsSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		pl := &natsPayload{
+			subject:  topic,
+			messages: []natsMessage{{id: sc.makeMsgID(), data: body}},
+		}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return sc.Flush(ctx, pl)
+		})
+	})
+}
+
+// Close implements the SinkClient interface. Publishes which are still
+// awaiting an acknowledgement fail rather than waiting out the ack timeout.
+func (sc *natsSinkClient) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.mu.conn != nil {
+		sc.mu.js.CleanupPublisher()
+		sc.mu.conn.Close()
+		sc.mu.conn, sc.mu.js = nil, nil
+	}
+	return nil
+}
+
+func (sc *natsSinkClient) makeMsgID() string {
+	return sc.msgIDPrefix + "-" + strconv.FormatUint(sc.nextMsgID.Add(1), 10)
+}
+
+type natsMessage struct {
+	id   string
+	data []byte
+}
+
+// natsPayload is a batch of messages bound for a single subject.
+type natsPayload struct {
+	subject  string
+	messages []natsMessage
+}
+
+type natsBuffer struct {
+	sc           *natsSinkClient
+	subject      string
+	topicEncoded []byte
+	messages     []natsMessage
+	numBytes     int
+}
+
+var _ BatchBuffer = (*natsBuffer)(nil)
+
+// Append implements the BatchBuffer interface.
+func (nb *natsBuffer) Append(key []byte, value []byte, _ attributes) {
+	var content []byte
+	switch nb.sc.format {
+	case changefeedbase.OptFormatJSON:
+		var buffer bytes.Buffer
+		// Grow all at once to avoid reallocations
+		buffer.Grow(26 /* Key/Value/Topic keys */ + len(key) + len(value) + len(nb.topicEncoded))
+		buffer.WriteString("{\"Key\":")
+		buffer.Write(key)
+		buffer.WriteString(",\"Value\":")
+		buffer.Write(value)
+		buffer.WriteString(",\"Topic\":")
+		buffer.Write(nb.topicEncoded)
+		buffer.WriteString("}")
+		content = buffer.Bytes()
+	case changefeedbase.OptFormatCSV:
+		content = value
+	}
+	// The message ID is assigned here, rather than at flush time, so that it
+	// is stable across retries of the batch.
+	nb.messages = append(nb.messages, natsMessage{id: nb.sc.makeMsgID(), data: content})
+	nb.numBytes += len(content)
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (nb *natsBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(nb.numBytes, len(nb.messages), nb.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (nb *natsBuffer) Close() (SinkPayload, error) {
+	return &natsPayload{subject: nb.subject, messages: nb.messages}, nil
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (sc *natsSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	var topicBuffer bytes.Buffer
+	json.FromString(topic).Format(&topicBuffer)
+	return &natsBuffer{
+		sc:           sc,
+		subject:      topic,
+		topicEncoded: topicBuffer.Bytes(),
+		messages:     make([]natsMessage, 0, sc.batchCfg.Messages),
+	}
+}
+
+func makeNATSSink(
+	ctx context.Context,
+	u sinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targets changefeedbase.Targets,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{})
+	if err != nil {
+		return nil, err
+	}
+	var cfg natsSinkConfig
+	if jsonConfig != `` {
+		if err := gojson.Unmarshal([]byte(jsonConfig), &cfg); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshalling json")
+		}
+	}
+	if cfg.AckTimeout < 0 {
+		return nil, errors.Errorf("invalid sink config, AckTimeout must be non-negative")
+	}
+
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(targets,
+		WithPrefix(topicPrefix), WithSingleName(topicName), WithSanitizeFn(SQLNameToNATSSubject))
+	if err != nil {
+		return nil, err
+	}
+
+	sinkClient, err := makeNATSSinkClient(ctx, u, encodingOpts, batchCfg, time.Duration(cfg.AckTimeout), m)
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown nats sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	return makeBatchingSink(
+		ctx,
+		sinkTypeNATS,
+		sinkClient,
+		time.Duration(batchCfg.Frequency),
+		retryOpts,
+		parallelism,
+		topicNamer,
+		pacerFactory,
+		source,
+		m,
+		settings,
+	), nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestNATSSink(
+	t *testing.T,
+	uri string,
+	format changefeedbase.FormatType,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targetNames ...string,
+) (Sink, error) {
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	encodingOpts := changefeedbase.EncodingOptions{
+		Format:   format,
+		Envelope: changefeedbase.OptEnvelopeWrapped,
+	}
+	return makeNATSSink(context.Background(), sinkURL{URL: u}, encodingOpts, jsonConfig,
+		makeChangefeedTargets(targetNames...), 2, nilPacerFactory, timeutil.DefaultTimeSource{},
+		nilMetricsRecorderBuilder, cluster.MakeTestingClusterSettings())
+}
+
+func TestNATSSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	server, err := cdctest.StartMockNATSServer("CHANGEFEED")
+	require.NoError(t, err)
+	defer server.Close()
+
+	const retryConfig = `{"Retry":{"Backoff":"5ms"}}`
+	for _, tc := range []struct {
+		name            string
+		params          string
+		table           string
+		format          changefeedbase.FormatType
+		expectedSubject string
+		expectedValue   string
+	}{
+		{
+			name:            "json",
+			table:           "t",
+			format:          changefeedbase.OptFormatJSON,
+			expectedSubject: "t",
+			expectedValue:   `{"Key":[1],"Value":{"after":{"a":1}},"Topic":"t"}`,
+		},
+		{
+			name:            "csv",
+			table:           "t",
+			format:          changefeedbase.OptFormatCSV,
+			expectedSubject: "t",
+			expectedValue:   `{"after":{"a":1}}`,
+		},
+		{
+			name:            "topic_prefix",
+			params:          "?topic_prefix=cdc.",
+			table:           "t",
+			format:          changefeedbase.OptFormatCSV,
+			expectedSubject: "cdc.t",
+			expectedValue:   `{"after":{"a":1}}`,
+		},
+		{
+			name:            "topic_name",
+			params:          "?topic_name=all",
+			table:           "t",
+			format:          changefeedbase.OptFormatCSV,
+			expectedSubject: "all",
+			expectedValue:   `{"after":{"a":1}}`,
+		},
+		{
+			name:            "sanitized subject",
+			table:           "a b*",
+			format:          changefeedbase.OptFormatCSV,
+			expectedSubject: "a_b_",
+			expectedValue:   `{"after":{"a":1}}`,
+		},
+		{
+			name:            "expected stream",
+			params:          "?stream=CHANGEFEED&topic_name=stream",
+			table:           "t",
+			format:          changefeedbase.OptFormatCSV,
+			expectedSubject: "stream",
+			expectedValue:   `{"after":{"a":1}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			before := server.Messages(tc.expectedSubject)
+			s, err := makeTestNATSSink(t, fmt.Sprintf("nats://%s%s", server.Addr(), tc.params),
+				tc.format, retryConfig, tc.table)
+			require.NoError(t, err)
+			require.NoError(t, s.Dial())
+			defer func() { require.NoError(t, s.Close()) }()
+
+			var pool testAllocPool
+			require.NoError(t, s.EmitRow(ctx, topic(tc.table), []byte(`[1]`), []byte(`{"after":{"a":1}}`),
+				zeroTS, zeroTS, pool.alloc()))
+			require.NoError(t, s.Flush(ctx))
+			require.Equal(t, append(before, tc.expectedValue), server.Messages(tc.expectedSubject))
+		})
+	}
+
+	t.Run("unexpected stream", func(t *testing.T) {
+		s, err := makeTestNATSSink(t, fmt.Sprintf("nats://%s?stream=OTHER", server.Addr()),
+			changefeedbase.OptFormatCSV, `{"Retry":{"Max":1,"Backoff":"5ms"}}`, "t")
+		require.NoError(t, err)
+		require.NoError(t, s.Dial())
+		defer func() { _ = s.Close() }()
+
+		var pool testAllocPool
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`1`), zeroTS, zeroTS, pool.alloc()))
+		require.Regexp(t, `expected stream does not match`, s.Flush(ctx))
+	})
+
+	t.Run("close fails pending publishes", func(t *testing.T) {
+		u, err := url.Parse(fmt.Sprintf("nats://%s", server.Addr()))
+		require.NoError(t, err)
+		sc, err := makeNATSSinkClient(ctx, sinkURL{URL: u},
+			changefeedbase.EncodingOptions{Format: changefeedbase.OptFormatCSV, Envelope: changefeedbase.OptEnvelopeWrapped},
+			sinkBatchConfig{}, time.Hour, nilMetricsRecorderBuilder(false))
+		require.NoError(t, err)
+
+		server.DropAcks(true)
+		defer server.DropAcks(false)
+		buf := sc.MakeBatchBuffer("pending")
+		buf.Append([]byte(`[1]`), []byte(`1`), attributes{})
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		errCh := make(chan error, 1)
+		go func() { errCh <- sc.Flush(ctx, payload) }()
+
+		testutils.SucceedsSoon(t, func() error {
+			if len(server.Messages("pending")) == 0 {
+				return errors.New("waiting for publish")
+			}
+			return nil
+		})
+		require.NoError(t, sc.Close())
+		require.Regexp(t, `jetstream context closed`, <-errCh)
+	})
+
+	t.Run("retries are deduplicated", func(t *testing.T) {
+		s, err := makeTestNATSSink(t, fmt.Sprintf("nats://%s?topic_name=dedup", server.Addr()),
+			changefeedbase.OptFormatCSV, `{"Flush":{"Messages":2,"Frequency":"1h"},"Retry":{"Backoff":"5ms"}}`, "t")
+		require.NoError(t, err)
+		require.NoError(t, s.Dial())
+		defer func() { require.NoError(t, s.Close()) }()
+
+		// The first message of the batch is stored but the second one fails,
+		// so the whole batch is retried. The retried first message must be
+		// recognized as a duplicate rather than stored twice.
+		duplicatesBefore := server.Duplicates()
+		server.FailNextAfter(1, 1)
+		var pool testAllocPool
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`1`), zeroTS, zeroTS, pool.alloc()))
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`[2]`), []byte(`2`), zeroTS, zeroTS, pool.alloc()))
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, []string{`1`, `2`}, server.Messages("dedup"))
+		require.Equal(t, duplicatesBefore+1, server.Duplicates())
+	})
+}
+
+func TestNATSSinkParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		uri           string
+		format        changefeedbase.FormatType
+		expectedError string
+	}{
+		{uri: "nats://localhost:4222?foo=bar", expectedError: "unknown nats sink query parameters: foo"},
+		{uri: "nats:///", expectedError: "must specify a host"},
+		{uri: "nats://localhost:4222", format: changefeedbase.OptFormatAvro, expectedError: "incompatible with format=avro"},
+		{uri: "nats://localhost:4222?tls_enabled=maybe", expectedError: "param tls_enabled must be a bool"},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			format := changefeedbase.OptFormatJSON
+			if tc.format != "" {
+				format = tc.format
+			}
+			_, err := makeTestNATSSink(t, tc.uri, format, "", "t")
+			require.Regexp(t, tc.expectedError, err)
+		})
+	}
+}
@@ -78,3 +78,51 @@ func newClientFromTLSKeyPair(caCert, clientCert, clientKey []byte) (*httputil.Cl
 
 	return client, nil
 }
+
+// makeSinkTLSConfig consumes the TLS related query parameters of a sink URL
+// (insecure_tls_skip_verify, ca_cert, client_cert and client_key) and returns
+// the corresponding client TLS configuration.
+func makeSinkTLSConfig(u *sinkURL) (*tls.Config, error) {
+	var skipVerify bool
+	var caCert, clientCert, clientKey []byte
+	if _, err := u.consumeBool(changefeedbase.SinkParamSkipTLSVerify, &skipVerify); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipVerify}
+	if caCert != nil {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load system root CA pool")
+		}
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("failed to parse certificate data:%s", string(caCert))
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, errors.Errorf("%s and %s must be provided together",
+			changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+	}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, `invalid client certificate data provided`)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -379,6 +379,13 @@ func TestTenantLogic_check_constraints(
 	runLogicTest(t, "check_constraints")
 }
 
+func TestTenantLogic_cidr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "cidr")
+}
+
 func TestTenantLogic_cluster_settings(
 	t *testing.T,
 ) {
@@ -456,6 +463,13 @@ func TestTenantLogic_comment_on(
 	runLogicTest(t, "comment_on")
 }
 
+func TestTenantLogic_composite_type_columns(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "composite_type_columns")
+}
+
 func TestTenantLogic_composite_types(
 	t *testing.T,
 ) {
@@ -673,6 +687,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -799,6 +820,13 @@ func TestTenantLogic_event_log(
 	runLogicTest(t, "event_log")
 }
 
+func TestTenantLogic_exclude_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclude_constraints")
+}
+
 func TestTenantLogic_exclude_data_from_backup(
 	t *testing.T,
 ) {
@@ -1149,6 +1177,13 @@ func TestTenantLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestTenantLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestTenantLogic_kv_builtin_functions_tenant(
 	t *testing.T,
 ) {
@@ -1184,6 +1219,13 @@ func TestTenantLogic_lookup_join_spans(
 	runLogicTest(t, "lookup_join_spans")
 }
 
+func TestTenantLogic_macaddr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "macaddr")
+}
+
 func TestTenantLogic_manual_retry(
 	t *testing.T,
 ) {
@@ -1205,6 +1247,13 @@ func TestTenantLogic_merge_join(
 	runLogicTest(t, "merge_join")
 }
 
+func TestTenantLogic_money(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "money")
+}
+
 func TestTenantLogic_multi_statement(
 	t *testing.T,
 ) {
@@ -1506,6 +1555,13 @@ func TestTenantLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestTenantLogic_range(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range")
+}
+
 func TestTenantLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1989,6 +2045,13 @@ func TestTenantLogic_srfs(
 	runLogicTest(t, "srfs")
 }
 
+func TestTenantLogic_statement_hints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "statement_hints")
+}
+
 func TestTenantLogic_statement_source(
 	t *testing.T,
 ) {
@@ -2304,6 +2367,13 @@ func TestTenantLogic_udf_in_constraints(
 	runLogicTest(t, "udf_in_constraints")
 }
 
+func TestTenantLogic_udf_in_table_exprs(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_in_table_exprs")
+}
+
 func TestTenantLogic_udf_insert(
 	t *testing.T,
 ) {
@@ -2451,6 +2521,13 @@ func TestTenantLogic_udf_upsert(
 	runLogicTest(t, "udf_upsert")
 }
 
+func TestTenantLogic_udf_variadic(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_variadic")
+}
+
 func TestTenantLogic_union(
 	t *testing.T,
 ) {
@@ -384,6 +384,13 @@ func TestReadCommittedLogic_check_constraints(
 	runLogicTest(t, "check_constraints")
 }
 
+func TestReadCommittedLogic_cidr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "cidr")
+}
+
 func TestReadCommittedLogic_cluster_locks(
 	t *testing.T,
 ) {
@@ -461,6 +468,13 @@ func TestReadCommittedLogic_comment_on(
 	runLogicTest(t, "comment_on")
 }
 
+func TestReadCommittedLogic_composite_type_columns(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "composite_type_columns")
+}
+
 func TestReadCommittedLogic_composite_types(
 	t *testing.T,
 ) {
@@ -678,6 +692,13 @@ func TestReadCommittedLogic_distsql_srfs(
 	runLogicTest(t, "distsql_srfs")
 }
 
+func TestReadCommittedLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestReadCommittedLogic_drop_database(
 	t *testing.T,
 ) {
@@ -1154,6 +1175,13 @@ func TestReadCommittedLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestReadCommittedLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestReadCommittedLogic_kv_builtin_functions(
 	t *testing.T,
 ) {
@@ -1196,6 +1224,13 @@ func TestReadCommittedLogic_lookup_join_spans(
 	runLogicTest(t, "lookup_join_spans")
 }
 
+func TestReadCommittedLogic_macaddr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "macaddr")
+}
+
 func TestReadCommittedLogic_manual_retry(
 	t *testing.T,
 ) {
@@ -1217,6 +1252,13 @@ func TestReadCommittedLogic_merge_join(
 	runLogicTest(t, "merge_join")
 }
 
+func TestReadCommittedLogic_money(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "money")
+}
+
 func TestReadCommittedLogic_multi_statement(
 	t *testing.T,
 ) {
@@ -1511,6 +1553,13 @@ func TestReadCommittedLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestReadCommittedLogic_range(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range")
+}
+
 func TestReadCommittedLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1994,6 +2043,13 @@ func TestReadCommittedLogic_srfs(
 	runLogicTest(t, "srfs")
 }
 
+func TestReadCommittedLogic_statement_hints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "statement_hints")
+}
+
 func TestReadCommittedLogic_statement_source(
 	t *testing.T,
 ) {
@@ -2295,6 +2351,13 @@ func TestReadCommittedLogic_udf_in_constraints(
 	runLogicTest(t, "udf_in_constraints")
 }
 
+func TestReadCommittedLogic_udf_in_table_exprs(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_in_table_exprs")
+}
+
 func TestReadCommittedLogic_udf_insert(
 	t *testing.T,
 ) {
@@ -2442,6 +2505,13 @@ func TestReadCommittedLogic_udf_upsert(
 	runLogicTest(t, "udf_upsert")
 }
 
+func TestReadCommittedLogic_udf_variadic(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_variadic")
+}
+
 func TestReadCommittedLogic_union(
 	t *testing.T,
 ) {
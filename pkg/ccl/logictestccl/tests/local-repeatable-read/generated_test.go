@@ -384,6 +384,13 @@ func TestRepeatableReadLogic_check_constraints(
 	runLogicTest(t, "check_constraints")
 }
 
+func TestRepeatableReadLogic_cidr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "cidr")
+}
+
 func TestRepeatableReadLogic_cluster_settings(
 	t *testing.T,
 ) {
@@ -454,6 +461,13 @@ func TestRepeatableReadLogic_comment_on(
 	runLogicTest(t, "comment_on")
 }
 
+func TestRepeatableReadLogic_composite_type_columns(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "composite_type_columns")
+}
+
 func TestRepeatableReadLogic_composite_types(
 	t *testing.T,
 ) {
@@ -671,6 +685,13 @@ func TestRepeatableReadLogic_distsql_srfs(
 	runLogicTest(t, "distsql_srfs")
 }
 
+func TestRepeatableReadLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestRepeatableReadLogic_drop_database(
 	t *testing.T,
 ) {
@@ -1147,6 +1168,13 @@ func TestRepeatableReadLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestRepeatableReadLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestRepeatableReadLogic_kv_builtin_functions(
 	t *testing.T,
 ) {
@@ -1189,6 +1217,13 @@ func TestRepeatableReadLogic_lookup_join_spans(
 	runLogicTest(t, "lookup_join_spans")
 }
 
+func TestRepeatableReadLogic_macaddr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "macaddr")
+}
+
 func TestRepeatableReadLogic_manual_retry(
 	t *testing.T,
 ) {
@@ -1210,6 +1245,13 @@ func TestRepeatableReadLogic_merge_join(
 	runLogicTest(t, "merge_join")
 }
 
+func TestRepeatableReadLogic_money(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "money")
+}
+
 func TestRepeatableReadLogic_multi_statement(
 	t *testing.T,
 ) {
@@ -1504,6 +1546,13 @@ func TestRepeatableReadLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestRepeatableReadLogic_range(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range")
+}
+
 func TestRepeatableReadLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1987,6 +2036,13 @@ func TestRepeatableReadLogic_srfs(
 	runLogicTest(t, "srfs")
 }
 
+func TestRepeatableReadLogic_statement_hints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "statement_hints")
+}
+
 func TestRepeatableReadLogic_statement_source(
 	t *testing.T,
 ) {
@@ -2288,6 +2344,13 @@ func TestRepeatableReadLogic_udf_in_constraints(
 	runLogicTest(t, "udf_in_constraints")
 }
 
+func TestRepeatableReadLogic_udf_in_table_exprs(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_in_table_exprs")
+}
+
 func TestRepeatableReadLogic_udf_insert(
 	t *testing.T,
 ) {
@@ -2435,6 +2498,13 @@ func TestRepeatableReadLogic_udf_upsert(
 	runLogicTest(t, "udf_upsert")
 }
 
+func TestRepeatableReadLogic_udf_variadic(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_variadic")
+}
+
 func TestRepeatableReadLogic_union(
 	t *testing.T,
 ) {
@@ -1741,6 +1741,48 @@ necessary to support CockroachDB.
 `,
 	}
 
+	ZipRedactionProfile = FlagInfo{
+		Name: "redaction-profile",
+		Description: `
+Comma-separated list of redaction profiles to apply. The built-in profiles are:
+<PRE>
+
+  redact          same as --redact.
+  sql-constants   hide the constants of SQL statements in the collected tables.
+  hostnames       redact hostnames and addresses in the collected tables and
+                  JSON files (e.g. nodes/*/status.json, details.json and
+                  gossip.json).
+  usernames       redact SQL user names in the collected tables and JSON files.
+  strict          all of the above.
+
+</PRE>
+Log files, stack dumps and profiles are only redacted by the redact profile
+(or --redact). Additional profiles can be defined with
+--redaction-profiles-file. When several profiles are given, all their rules
+apply.
+`,
+	}
+
+	ZipRedactionProfilesFile = FlagInfo{
+		Name: "redaction-profiles-file",
+		Description: `
+Path to a YAML file defining custom redaction profiles for use with
+--redaction-profile. The file contains a list of profiles, for example:
+<PRE>
+
+  - name: support
+    hide-sql-constants: true
+    redact-usernames: true
+    include-tables: ["crdb_internal.*", "system.jobs"]
+    exclude-tables: ["crdb_internal.cluster_sessions"]
+
+</PRE>
+Each profile accepts the redact, hide-sql-constants, redact-hostnames and
+redact-usernames options, and glob patterns of the SQL tables to include or
+exclude.
+`,
+	}
+
 	ZipIncludeRangeInfo = FlagInfo{
 		Name: "include-range-info",
 		Description: `
@@ -0,0 +1,352 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package clisqlshell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/errors"
+)
+
+// maxExplainGists is the number of plan gists remembered by \explain, for
+// use by \explain diff.
+const maxExplainGists = 2
+
+// misestimateFactor is the factor by which the actual row count of an
+// operator must differ from its estimated row count for \explain analyze to
+// flag the estimate.
+const misestimateFactor = 10
+
+// handleExplain handles the `\explain` command.
+//
+// The command is given the complete input line, rather than the arguments
+// scanned by scanLocalCmdArgs, so that the SQL query is passed to the server
+// verbatim.
+func (c *cliState) handleExplain(line string, loopState, errState cliStateEnum) cliStateEnum {
+	args := strings.TrimSpace(strings.TrimPrefix(line, `\explain`))
+	sub, rest := splitFirstWord(args)
+
+	var cmdErr error
+	switch strings.ToLower(sub) {
+	case "":
+		return c.invalidSyntax(errState)
+
+	case "diff":
+		gists := strings.Fields(rest)
+		switch len(gists) {
+		case 0:
+			if len(c.explainGists) < 2 {
+				return c.cliError(errState, errors.WithHint(
+					errors.New("no two plans to compare"),
+					`Use \explain on two queries first, or specify the plan gists to compare.`))
+			}
+			gists = c.explainGists
+		case 2:
+		default:
+			return c.invalidSyntax(errState)
+		}
+		cmdErr = c.explainDiff(gists[0], gists[1])
+
+	case "analyze":
+		if rest == "" {
+			return c.invalidSyntax(errState)
+		}
+		cmdErr = c.explainPlan(rest, true /* analyze */)
+
+	default:
+		cmdErr = c.explainPlan(args, false /* analyze */)
+	}
+
+	if cmdErr != nil {
+		fmt.Fprintln(c.iCtx.stderr, cmdErr)
+		c.exitErr = cmdErr
+		return errState
+	}
+	return loopState
+}
+
+// splitFirstWord splits s into its first whitespace-delimited word and the
+// remainder.
+func splitFirstWord(s string) (first, rest string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t\r\n\f"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+// explainPlan prints the plan of the query as a tree with the estimated row
+// count of each operator and, if analyze is set, the actual row count. The
+// plan gist is printed and remembered for \explain diff.
+func (c *cliState) explainPlan(query string, analyze bool) error {
+	stmt := "EXPLAIN " + query
+	if analyze {
+		stmt = "EXPLAIN ANALYZE " + query
+	}
+	lines, err := c.runExplainQuery(stmt)
+	if err != nil {
+		return err
+	}
+	// EXPLAIN (GIST) only plans the query, so retrieving the gist does not
+	// execute the query a second time.
+	gistLines, err := c.runExplainQuery("EXPLAIN (GIST) " + query)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	renderExplainTree(&buf, parseExplainTree(lines), analyze)
+	if len(gistLines) > 0 {
+		gist := gistLines[0]
+		fmt.Fprintf(&buf, "\nplan gist: %s\n", gist)
+		c.explainGists = append(c.explainGists, gist)
+		if len(c.explainGists) > maxExplainGists {
+			c.explainGists = c.explainGists[len(c.explainGists)-maxExplainGists:]
+		}
+	}
+	_, err = buf.WriteTo(c.iCtx.stdout)
+	return err
+}
+
+// explainDiff prints the difference between the plans encoded by the two
+// plan gists.
+func (c *cliState) explainDiff(fromGist, toGist string) error {
+	from, err := c.decodePlanGist(fromGist)
+	if err != nil {
+		return err
+	}
+	to, err := c.decodePlanGist(toGist)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromGist, toGist)
+	if !renderLineDiff(&buf, from, to) {
+		fmt.Fprintln(&buf, "(the plans are identical)")
+	}
+	_, err = buf.WriteTo(c.iCtx.stdout)
+	return err
+}
+
+func (c *cliState) decodePlanGist(gist string) ([]string, error) {
+	lines, err := c.runExplainQuery(
+		"SELECT * FROM crdb_internal.decode_plan_gist($1)", gist)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding plan gist %q", gist)
+	}
+	return lines, nil
+}
+
+// runExplainQuery runs a query whose results have a single column, and
+// returns the values of that column.
+func (c *cliState) runExplainQuery(query string, args ...interface{}) ([]string, error) {
+	var rows [][]string
+	err := c.runWithInterruptableCtx(func(ctx context.Context) (err error) {
+		_, rows, err = c.sqlExecCtx.RunQuery(ctx, c.conn,
+			clisqlclient.MakeQuery(query, args...), true /* showMoreChars */)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 {
+			lines = append(lines, row[0])
+		}
+	}
+	return lines, nil
+}
+
+// explainNode is an operator of a plan, as parsed from the output of EXPLAIN.
+type explainNode struct {
+	name     string
+	depth    int
+	attrs    map[string]string
+	children []*explainNode
+}
+
+// explainTree is a plan parsed from the output of EXPLAIN.
+type explainTree struct {
+	// header contains the lines which precede the operators, such as
+	// "distribution: local".
+	header []string
+	roots  []*explainNode
+}
+
+// explainNodeBullet prefixes the name of each operator in the output of
+// EXPLAIN.
+const explainNodeBullet = "• "
+
+// explainIndentWidth is the width of each level of indentation of the
+// operators in the output of EXPLAIN.
+const explainIndentWidth = 4
+
+// parseExplainTree parses the output of EXPLAIN. Each operator is listed on a
+// line of its own, indented according to its depth in the plan and followed
+// by the lines of its attributes, before its children.
+func parseExplainTree(lines []string) *explainTree {
+	t := &explainTree{}
+	// stack contains the last operator encountered at each depth.
+	var stack []*explainNode
+	for _, line := range lines {
+		if i := strings.Index(line, explainNodeBullet); i >= 0 {
+			n := &explainNode{
+				name:  strings.TrimSpace(line[i+len(explainNodeBullet):]),
+				depth: utf8.RuneCountInString(line[:i]) / explainIndentWidth,
+				attrs: make(map[string]string),
+			}
+			if n.depth > len(stack) {
+				n.depth = len(stack)
+			}
+			stack = stack[:n.depth]
+			if n.depth == 0 {
+				t.roots = append(t.roots, n)
+			} else {
+				parent := stack[n.depth-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+			continue
+		}
+		attr := strings.TrimSpace(strings.TrimLeft(line, " │├└─"))
+		if len(stack) == 0 {
+			if attr != "" {
+				t.header = append(t.header, attr)
+			}
+			continue
+		}
+		if k, v, ok := strings.Cut(attr, ": "); ok {
+			n := stack[len(stack)-1]
+			n.attrs[k] = v
+		}
+	}
+	return t
+}
+
+// parseRowCount parses a row count attribute of EXPLAIN, such as
+// "1,000 (100% of the table; stats collected 1 minute ago)".
+func parseRowCount(s string) (int64, bool) {
+	s, _, _ = strings.Cut(s, " ")
+	n, err := strconv.ParseInt(strings.ReplaceAll(s, ",", ""), 10, 64)
+	return n, err == nil
+}
+
+// isMisestimate returns whether the estimated row count of an operator is
+// off by more than misestimateFactor.
+func isMisestimate(estimated, actual int64) bool {
+	if estimated < 1 {
+		estimated = 1
+	}
+	if actual < 1 {
+		actual = 1
+	}
+	return estimated > actual*misestimateFactor || actual > estimated*misestimateFactor
+}
+
+// renderExplainTree writes the tree of operators, with the estimated and, if
+// analyze is set, the actual row count of each.
+func renderExplainTree(w io.Writer, t *explainTree, analyze bool) {
+	for _, h := range t.header {
+		fmt.Fprintln(w, h)
+	}
+	if len(t.header) > 0 {
+		fmt.Fprintln(w)
+	}
+	tw := tabwriter.NewWriter(w, 4, 0, 2, ' ', 0)
+	if analyze {
+		fmt.Fprint(tw, "operator\testimated rows\tactual rows\n")
+	} else {
+		fmt.Fprint(tw, "operator\testimated rows\n")
+	}
+	var render func(n *explainNode, prefix, childPrefix string)
+	render = func(n *explainNode, prefix, childPrefix string) {
+		name := n.name
+		if table, ok := n.attrs["table"]; ok {
+			name = fmt.Sprintf("%s %s", name, table)
+		}
+		estimated := n.attrs["estimated row count"]
+		if estimated == "" {
+			estimated = "-"
+		} else {
+			estimated, _, _ = strings.Cut(estimated, " ")
+		}
+		fmt.Fprintf(tw, "%s%s%s\t%s", prefix, explainNodeBullet, name, estimated)
+		if analyze {
+			actual := n.attrs["actual row count"]
+			if actual == "" {
+				actual = "-"
+			}
+			fmt.Fprintf(tw, "\t%s", actual)
+			e, eOk := parseRowCount(n.attrs["estimated row count"])
+			a, aOk := parseRowCount(actual)
+			if eOk && aOk && isMisestimate(e, a) {
+				fmt.Fprint(tw, "\t(misestimated)")
+			}
+		}
+		fmt.Fprintln(tw)
+		for i, child := range n.children {
+			if i == len(n.children)-1 {
+				render(child, childPrefix+"└── ", childPrefix+"    ")
+			} else {
+				render(child, childPrefix+"├── ", childPrefix+"│   ")
+			}
+		}
+	}
+	for _, root := range t.roots {
+		render(root, "", "")
+	}
+	_ = tw.Flush()
+}
+
+// renderLineDiff writes the difference between two sequences of lines, with
+// the lines only in from prefixed by "-", the lines only in to prefixed by
+// "+" and the common lines prefixed by a space. It returns whether the
+// sequences differ.
+func renderLineDiff(w io.Writer, from, to []string) (changed bool) {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:]
+	// and to[j:]. Plans are small enough for the quadratic cost not to
+	// matter.
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			fmt.Fprintf(w, "  %s\n", from[i])
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(w, "- %s\n", from[i])
+			changed = true
+			i++
+		default:
+			fmt.Fprintf(w, "+ %s\n", to[j])
+			changed = true
+			j++
+		}
+	}
+	return changed
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package clisqlshell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderExplainTree(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	lines := strings.Split(`planning time: 1ms
+execution time: 2ms
+distribution: local
+vectorized: true
+
+• hash join
+│ estimated row count: 10
+│ actual row count: 500
+│ equality: (a) = (b)
+│
+├── • scan
+│     estimated row count: 1,000 (100% of the table; stats collected 1 minute ago)
+│     actual row count: 1,000
+│     table: t@t_pkey
+│     spans: FULL SCAN
+│
+└── • filter
+    │ estimated row count: 3
+    │ actual row count: 2
+    │
+    └── • scan
+          estimated row count: 30
+          actual row count: 30
+          table: u@u_pkey
+          spans: FULL SCAN`, "\n")
+
+	var buf bytes.Buffer
+	renderExplainTree(&buf, parseExplainTree(lines), true /* analyze */)
+	assert.Equal(t, `planning time: 1ms
+execution time: 2ms
+distribution: local
+vectorized: true
+
+operator                 estimated rows  actual rows
+• hash join              10              500  (misestimated)
+├── • scan t@t_pkey      1,000           1,000
+└── • filter             3               2
+    └── • scan u@u_pkey  30              30
+`, buf.String())
+
+	buf.Reset()
+	renderExplainTree(&buf, parseExplainTree(lines[2:]), false /* analyze */)
+	assert.Equal(t, `distribution: local
+vectorized: true
+
+operator                 estimated rows
+• hash join              10
+├── • scan t@t_pkey      1,000
+└── • filter             3
+    └── • scan u@u_pkey  30
+`, buf.String())
+}
+
+func TestRenderLineDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	from := []string{
+		"• filter",
+		"│",
+		"└── • scan",
+		"      table: t@t_pkey",
+		"      spans: FULL SCAN",
+	}
+	to := []string{
+		"• scan",
+		"  table: t@t_a_idx",
+		"  spans: 1 span",
+	}
+
+	var buf bytes.Buffer
+	assert.True(t, renderLineDiff(&buf, from, to))
+	assert.Equal(t, `- • filter
+- │
+- └── • scan
+-       table: t@t_pkey
+-       spans: FULL SCAN
++ • scan
++   table: t@t_a_idx
++   spans: 1 span
+`, buf.String())
+
+	buf.Reset()
+	assert.False(t, renderLineDiff(&buf, from, from))
+	assert.Equal(t, `  • filter
+  │
+  └── • scan
+        table: t@t_pkey
+        spans: FULL SCAN
+`, buf.String())
+}
@@ -107,6 +107,11 @@ Configuration
   \set [NAME]       set a client-side flag or (without argument) print the current settings.
   \unset NAME       unset a flag.
 
+Query plans
+  \explain [analyze] QUERY  show the plan of a query as a tree with estimated [and actual] row counts.
+  \explain diff [GIST GIST]
+                    compare two plans, by default the last two shown by \explain.
+
 Statement diagnostics
   \statement-diag list                               list available bundles.
   \statement-diag download <bundle-id> [<filename>]  download bundle.
@@ -192,6 +197,10 @@ type cliState struct {
 	// when copy-pasting.
 	forwardLines []string
 
+	// explainGists contains the plan gists of the last queries whose plan
+	// was shown by \explain, oldest first, for use by \explain diff.
+	explainGists []string
+
 	// partialLines is the array of lines accumulated so far in a
 	// multi-line entry.
 	partialLines []string
@@ -1563,6 +1572,9 @@ ORDER BY 1`
 	case `\statement-diag`:
 		return c.handleStatementDiag(cmd[1:], loopState, errState)
 
+	case `\explain`:
+		return c.handleExplain(line, loopState, errState)
+
 	default:
 		return c.invalidSyntax(errState)
 	}
@@ -346,6 +346,13 @@ type zipContext struct {
 	// range key data, which is necessary to support CockroachDB.
 	redact bool
 
+	// redactionProfiles are the names of the redaction profiles to apply,
+	// and redactionProfilesFile the optional YAML file defining custom
+	// profiles. redactionProfile is the resulting combined profile, or nil.
+	redactionProfiles     []string
+	redactionProfilesFile string
+	redactionProfile      *zipRedactionProfile
+
 	// Duration (in seconds) to run CPU profile for.
 	cpuProfDuration time.Duration
 
@@ -381,6 +388,9 @@ func setZipContextDefaults() {
 	zipCtx.files = fileSelection{}
 	zipCtx.redactLogs = false
 	zipCtx.redact = false
+	zipCtx.redactionProfiles = nil
+	zipCtx.redactionProfilesFile = ""
+	zipCtx.redactionProfile = nil
 	// Even though it makes debug.zip heavyweight, range infos are often the best source
 	// of information for range-level issues and so they are opt-out, not opt-in.
 	zipCtx.includeRangeInfo = true
@@ -677,6 +687,8 @@ func setDemoContextDefaults() {
 	demoCtx.Multitenant = clusterversion.DevelopmentBranch
 	demoCtx.DisableServerController = false
 	demoCtx.DefaultEnableRangefeeds = true
+	demoCtx.TimeseriesImportFile = ""
+	demoCtx.TimeseriesImportMappingFile = ""
 
 	demoCtx.pidFile = ""
 	demoCtx.disableEnterpriseFeatures = false
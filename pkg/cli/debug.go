@@ -1429,6 +1429,8 @@ var pebbleToolFS = &autoDecryptFS{}
 
 func init() {
 	debugZipCmd.AddCommand(debugZipUploadCmd)
+	debugZipCmd.AddCommand(debugZipAnalyzeCmd)
+	debugTimeSeriesDumpCmd.AddCommand(debugTimeSeriesServeCmd)
 	DebugCmd.AddCommand(debugCmds...)
 
 	// Note: we hook up FormatValue here in order to avoid a circular dependency
@@ -1595,6 +1597,20 @@ func init() {
 	f.StringVar(&debugZipUploadOpts.gcpProjectID, "gcp-project-id",
 		defaultGCPProjectID, "GCP project ID to use to send debug.zip logs to GCS")
 
+	f = debugZipAnalyzeCmd.Flags()
+	f.DurationVar(&debugZipAnalyzeOpts.clockOffsetThreshold, "clock-offset-threshold",
+		debugZipAnalyzeOpts.clockOffsetThreshold, "report nodes whose mean clock offset is at least this large")
+	f.Float64Var(&debugZipAnalyzeOpts.hotRangeQPS, "hot-range-qps",
+		debugZipAnalyzeOpts.hotRangeQPS, "report ranges serving at least this many queries per second")
+	f.DurationVar(&debugZipAnalyzeOpts.hotRangeCPU, "hot-range-cpu",
+		debugZipAnalyzeOpts.hotRangeCPU, "report ranges using at least this much CPU time per second")
+	f.DurationVar(&debugZipAnalyzeOpts.changefeedLagThreshold, "changefeed-lag-threshold",
+		debugZipAnalyzeOpts.changefeedLagThreshold, "report changefeeds whose high-water mark lags by at least this much")
+	f.IntVar(&debugZipAnalyzeOpts.logErrorsPerMinute, "log-errors-per-minute",
+		debugZipAnalyzeOpts.logErrorsPerMinute, "report minutes in which a node logged at least this many errors")
+	f.IntVar(&debugZipAnalyzeOpts.maxFindings, "max-findings",
+		debugZipAnalyzeOpts.maxFindings, "maximum number of problems to list per check")
+
 	f = debugDecodeKeyCmd.Flags()
 	f.Var(&decodeKeyOptions.encoding, "encoding", "key argument encoding")
 	f.BoolVar(&decodeKeyOptions.userKey, "user-key", false, "key type")
@@ -1632,6 +1648,10 @@ func init() {
 	f.StringVar(&debugTimeSeriesDumpOpts.organizationName, "org-name", "", "organization name to use in datadog upload")
 	f.StringVar(&debugTimeSeriesDumpOpts.userName, "user-name", "", "name of the user to perform datadog upload")
 
+	f = debugTimeSeriesServeCmd.Flags()
+	f.StringVar(&debugTimeSeriesServeOpts.mappingFile, "mapping-file", "",
+		"YAML file mapping the store IDs of the dump to node IDs (default: <file>.yaml)")
+
 	f = debugSendKVBatchCmd.Flags()
 	f.StringVar(&debugSendKVBatchContext.traceFormat, "trace", debugSendKVBatchContext.traceFormat,
 		"which format to use for the trace output (off, text, jaeger)")
@@ -105,6 +105,13 @@ type Context struct {
 	// DisableServerController is true if we want to avoid the server
 	// controller to instantiate tenant secondary servers.
 	DisableServerController bool
+
+	// TimeseriesImportFile, if set, is a file created with `debug tsdump
+	// --format=raw` that the first node loads into its timeseries store,
+	// and TimeseriesImportMappingFile the YAML file mapping its store IDs
+	// to node IDs.
+	TimeseriesImportFile        string
+	TimeseriesImportMappingFile string
 }
 
 // IsInteractive returns true if the demo cluster configuration
@@ -633,6 +633,13 @@ func (c *transientCluster) createAndAddNode(
 
 	serverKnobs := args.Knobs.Server.(*server.TestingKnobs)
 
+	if idx == 0 && c.demoCtx.TimeseriesImportFile != "" {
+		// The first node loads the timeseries when it starts, and stops
+		// recording its own.
+		serverKnobs.ImportTimeseriesFile = c.demoCtx.TimeseriesImportFile
+		serverKnobs.ImportTimeseriesMappingFile = c.demoCtx.TimeseriesImportMappingFile
+	}
+
 	// SignalAfterGettingRPCAddress will be closed by the server startup routine
 	// once it has determined its RPC address.
 	rpcAddrReadyCh = make(chan struct{})
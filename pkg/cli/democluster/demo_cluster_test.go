@@ -7,8 +7,11 @@ package democluster
 
 import (
 	"context"
+	"encoding/gob"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -21,12 +24,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/securityassets"
 	"github.com/cockroachdb/cockroach/pkg/security/securitytest"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlclustersettings"
 	"github.com/cockroachdb/cockroach/pkg/storage/fs"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils/regionlatency"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/ts"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -328,6 +334,106 @@ func TestTransientClusterMultitenant(t *testing.T) {
 	})
 }
 
+// TestTransientClusterTimeseriesImport checks that a demo cluster loads and
+// serves the timeseries of a raw tsdump, including rollups.
+func TestTransientClusterTimeseriesImport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	defer TestingForceRandomizeDemoPorts()()
+
+	// Write a dump with 10s resolution data for a node and a store metric,
+	// and a 30m rollup of the node metric a week earlier.
+	const nodeMetric, storeMetric = "cr.node.sys.uptime", "cr.store.capacity"
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC).UnixNano()
+	rollupStart := start - 7*24*time.Hour.Nanoseconds()
+	samples := roachpb.InternalTimeSeriesData{
+		StartTimestampNanos: start,
+		SampleDurationNanos: ts.Resolution10s.SampleDuration(),
+		Offset:              []int32{0, 1, 2},
+		Last:                []float64{10, 20, 30},
+	}
+	rollup := roachpb.InternalTimeSeriesData{
+		StartTimestampNanos: rollupStart,
+		SampleDurationNanos: ts.Resolution30m.SampleDuration(),
+		Offset:              []int32{0},
+		Last:                []float64{5},
+		Count:               []uint32{180},
+		Sum:                 []float64{900},
+		Max:                 []float64{5},
+		Min:                 []float64{5},
+		First:               []float64{5},
+		Variance:            []float64{0},
+	}
+	tsFile := filepath.Join(t.TempDir(), "tsdump.raw")
+	f, err := os.Create(tsFile)
+	require.NoError(t, err)
+	enc := gob.NewEncoder(f)
+	for _, kv := range []struct {
+		name string
+		r    ts.Resolution
+		data *roachpb.InternalTimeSeriesData
+	}{
+		{nodeMetric, ts.Resolution10s, &samples},
+		{storeMetric, ts.Resolution10s, &samples},
+		{nodeMetric, ts.Resolution30m, &rollup},
+	} {
+		var v roachpb.Value
+		require.NoError(t, v.SetProto(kv.data))
+		key := ts.MakeDataKey(kv.name, "1", kv.r, kv.data.StartTimestampNanos)
+		require.NoError(t, enc.Encode(roachpb.KeyValue{Key: key, Value: v}))
+	}
+	require.NoError(t, f.Close())
+	require.NoError(t, os.WriteFile(tsFile+".yaml", []byte("1: 1\n"), 0644))
+
+	demoCtx := newDemoCtx()
+	demoCtx.TimeseriesImportFile = tsFile
+	demoCtx.TimeseriesImportMappingFile = tsFile + ".yaml"
+
+	securityassets.ResetLoader()
+	certsDir := t.TempDir()
+
+	ctx := context.Background()
+
+	c := transientCluster{
+		demoCtx:           demoCtx,
+		stopper:           stop.NewStopper(),
+		demoDir:           certsDir,
+		stickyVFSRegistry: fs.NewStickyRegistry(),
+		infoLog:           log.Infof,
+		warnLog:           log.Warningf,
+		shoutLog:          log.Ops.Shoutf,
+	}
+	defer c.Close(ctx)
+
+	require.NoError(t, c.generateCerts(ctx, certsDir))
+	require.NoError(t, c.Start(ctx))
+
+	client := tspb.NewTimeSeriesClient(c.firstServer.SystemLayer().RPCClientConn(t, username.RootUserName()))
+	for _, tc := range []struct {
+		name     string
+		start    int64
+		sample   time.Duration
+		expected float64
+	}{
+		{name: "10s", start: start, sample: 10 * time.Second, expected: 10},
+		{name: "rollup", start: rollupStart, sample: 30 * time.Minute, expected: 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := client.Query(ctx, &tspb.TimeSeriesQueryRequest{
+				StartNanos:  tc.start,
+				EndNanos:    tc.start + time.Hour.Nanoseconds(),
+				SampleNanos: tc.sample.Nanoseconds(),
+				Queries:     []tspb.Query{{Name: nodeMetric, Sources: []string{"1"}}},
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 1)
+			require.NotEmpty(t, resp.Results[0].Datapoints)
+			require.Equal(t, tc.expected, resp.Results[0].Datapoints[0].Value)
+		})
+	}
+}
+
 // Ensure that demo clusters are started with privileged tenants.
 func TestTenantCapabilities(t *testing.T) {
 	defer leaktest.AfterTest(t)()
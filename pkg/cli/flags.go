@@ -588,7 +588,7 @@ func init() {
 	}
 
 	// Flags that apply to commands that start servers.
-	telemetryEnabledCmds := append(serverCmds, demoCmd, statementBundleRecreateCmd)
+	telemetryEnabledCmds := append(serverCmds, demoCmd, statementBundleRecreateCmd, debugTimeSeriesServeCmd)
 	telemetryEnabledCmds = append(telemetryEnabledCmds, demoCmd.Commands()...)
 	for _, cmd := range telemetryEnabledCmds {
 		// Report flag usage for server commands in telemetry. We do this
@@ -736,6 +736,8 @@ func init() {
 		cliflagcfg.BoolFlag(f, &zipCtx.redactLogs, cliflags.ZipRedactLogs)
 		_ = f.MarkDeprecated(cliflags.ZipRedactLogs.Name, "use --"+cliflags.ZipRedact.Name+" instead")
 		cliflagcfg.BoolFlag(f, &zipCtx.redact, cliflags.ZipRedact)
+		cliflagcfg.StringSliceFlag(f, &zipCtx.redactionProfiles, cliflags.ZipRedactionProfile)
+		cliflagcfg.StringFlag(f, &zipCtx.redactionProfilesFile, cliflags.ZipRedactionProfilesFile)
 		cliflagcfg.DurationFlag(f, &zipCtx.cpuProfDuration, cliflags.ZipCPUProfileDuration)
 		cliflagcfg.IntFlag(f, &zipCtx.concurrency, cliflags.ZipConcurrency)
 		cliflagcfg.BoolFlag(f, &zipCtx.includeRangeInfo, cliflags.ZipIncludeRangeInfo)
@@ -784,6 +786,7 @@ func init() {
 		doctorExamineFallbackClusterCmd,
 		doctorRecreateClusterCmd,
 		statementBundleRecreateCmd,
+		debugTimeSeriesServeCmd,
 		lsNodesCmd,
 		statusNodeCmd,
 	}
@@ -796,7 +799,7 @@ func init() {
 	for _, cmd := range sqlCmds {
 		clientflags.AddSQLFlags(cmd, &cliCtx.clientOpts, sqlCtx,
 			cmd == sqlShellCmd, /* isShell */
-			cmd == demoCmd || cmd == statementBundleRecreateCmd, /* isDemo */
+			cmd == demoCmd || cmd == statementBundleRecreateCmd || cmd == debugTimeSeriesServeCmd, /* isDemo */
 		)
 	}
 
@@ -832,6 +835,7 @@ func init() {
 			genMetricListCmd,
 			demoCmd,
 			statementBundleRecreateCmd,
+			debugTimeSeriesServeCmd,
 			debugListFilesCmd,
 			debugJobTraceFromClusterCmd,
 			debugZipCmd,
@@ -851,7 +855,7 @@ func init() {
 	}
 
 	// demo command.
-	for _, cmd := range []*cobra.Command{demoCmd, statementBundleRecreateCmd} {
+	for _, cmd := range []*cobra.Command{demoCmd, statementBundleRecreateCmd, debugTimeSeriesServeCmd} {
 		// We use the persistent flag set so that the flags apply to every
 		// workload sub-command. This enables e.g.
 		// ./cockroach demo movr --nodes=3.
@@ -131,7 +131,7 @@ var serverCmds = append(StartCmds, mtStartSQLCmd)
 // customLoggingSetupCmds lists the commands that call setupLogging()
 // after other types of configuration.
 var customLoggingSetupCmds = append(
-	serverCmds, debugCheckLogConfigCmd, demoCmd, statementBundleRecreateCmd,
+	serverCmds, debugCheckLogConfigCmd, demoCmd, statementBundleRecreateCmd, debugTimeSeriesServeCmd,
 )
 
 // RegisterCommandWithCustomLogging is used by cliccl to note commands which
@@ -0,0 +1,66 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugTimeSeriesServeOpts = struct {
+	mappingFile string
+}{}
+
+var debugTimeSeriesServeCmd = &cobra.Command{
+	Use:   "serve <file>",
+	Short: "serve the timeseries of a raw tsdump in the DB Console",
+	Long: `
+Start a temporary, in-memory, single-node cluster, load the timeseries
+of a file created with 'cockroach debug tsdump --format=raw' into it, and
+serve them through the DB Console metrics pages and the /ts/query API, as
+if they had been recorded by this cluster. Both the 10s resolution data
+and the 30m rollups contained in the file are loaded.
+
+The --mapping-file flag points to a YAML file that maps the store IDs
+of the source cluster to their node IDs, one "<store ID>: <node ID>"
+pair per line. It defaults to the input file name followed by ".yaml".
+
+The cluster does not record timeseries of its own, and is discarded when
+the SQL shell started by this command exits.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugTimeSeriesServe),
+}
+
+func runDebugTimeSeriesServe(cmd *cobra.Command, args []string) error {
+	tsFile := args[0]
+	if _, err := os.Stat(tsFile); err != nil {
+		return err
+	}
+	if demoCtx.NumNodes != 1 {
+		return errors.New("the timeseries can only be served by a single node")
+	}
+
+	demoCtx.UseEmptyDatabase = true
+	demoCtx.Multitenant = false
+	demoCtx.TimeseriesImportFile = tsFile
+	demoCtx.TimeseriesImportMappingFile = debugTimeSeriesServeOpts.mappingFile
+	return runDemoInternal(cmd, nil /* gen */, func(ctx context.Context, conn clisqlclient.Conn) error {
+		cliCtx.PrintfUnlessEmbedded(`#
+# Timeseries loaded from %s.
+#
+# Open the DB Console using the (webui) address above, and head to the
+# Metrics page to browse them. The timeseries are only held in memory.
+#
+`, tsFile)
+		return nil
+	})
+}
@@ -197,6 +197,17 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 	if zipCtx.redactLogs {
 		zipCtx.redact = true
 	}
+	profile, err := loadZipRedactionProfile(zipCtx.redactionProfiles, zipCtx.redactionProfilesFile)
+	if err != nil {
+		return err
+	}
+	zipCtx.redactionProfile = profile
+	if profile != nil {
+		zr.info("using redaction profile: %s", profile.Name)
+		if profile.Redact {
+			zipCtx.redact = true
+		}
+	}
 
 	var tenants []*serverpb.Tenant
 	if err := func() error {
@@ -523,7 +534,13 @@ func (zc *debugZipContext) dumpTableDataForZip(
 	const maxRetries = 5
 	suffix := ""
 
-	query := tableQuery.query
+	query, err := zipCtx.redactionProfile.rewriteQuery(ctx, conn, tableQuery.query)
+	if err != nil {
+		// The query results cannot be redacted as requested, so we don't
+		// collect them at all.
+		return zc.z.createError(s, baseName+"."+zc.clusterPrinter.sqlOutputFilenameExtension,
+			errors.Wrap(err, "applying redaction profile"))
+	}
 	fallback := tableQuery.fallback != ""
 
 	for numRetries := 1; numRetries <= maxRetries; numRetries++ {
@@ -577,7 +594,11 @@ func (zc *debugZipContext) dumpTableDataForZip(
 				if fallback {
 					fallback = false
 
-					query = tableQuery.fallback
+					query, err = zipCtx.redactionProfile.rewriteQuery(ctx, conn, tableQuery.fallback)
+					if err != nil {
+						return zc.z.createError(s, baseName+".fallback."+zc.clusterPrinter.sqlOutputFilenameExtension,
+							errors.Wrap(err, "applying redaction profile"))
+					}
 					numRetries = 1 // Reset counter since this is a different query.
 					baseName = baseName + ".fallback"
 					s = zr.start(redact.Sprintf("retrieving SQL data for %s (fallback)", table))
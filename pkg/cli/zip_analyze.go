@@ -0,0 +1,611 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugZipAnalyzeOpts = struct {
+	clockOffsetThreshold   time.Duration
+	hotRangeQPS            float64
+	hotRangeCPU            time.Duration
+	changefeedLagThreshold time.Duration
+	logErrorsPerMinute     int
+	maxFindings            int
+}{
+	// Half of the default maximum clock offset.
+	clockOffsetThreshold: 250 * time.Millisecond,
+	// The defaults of kv.range_split.load_qps_threshold and
+	// kv.range_split.load_cpu_threshold, above which ranges are split.
+	hotRangeQPS:            2500,
+	hotRangeCPU:            500 * time.Millisecond,
+	changefeedLagThreshold: 10 * time.Minute,
+	logErrorsPerMinute:     50,
+	maxFindings:            20,
+}
+
+// runDebugZipAnalyze loads a debug zip, or a directory the zip has been
+// extracted to, and reports the common problems found in it.
+func runDebugZipAnalyze(cmd *cobra.Command, args []string) error {
+	zipFS, cleanup, err := openDebugZip(args[0])
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return analyzeDebugZip(zipFS, os.Stdout)
+}
+
+// openDebugZip returns a file system rooted at the top-level directory of
+// the given debug zip file or directory.
+func openDebugZip(zipPath string) (_ fs.FS, cleanup func(), _ error) {
+	info, err := os.Stat(zipPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var zipFS fs.FS
+	cleanup = func() {}
+	if info.IsDir() {
+		zipFS = os.DirFS(zipPath)
+	} else {
+		r, err := zip.OpenReader(zipPath)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "opening %s", zipPath)
+		}
+		zipFS = r
+		cleanup = func() { _ = r.Close() }
+	}
+	// The zip file has a single top-level "debug" directory. Users may
+	// point at either that directory or its parent.
+	if info, err := fs.Stat(zipFS, debugBase); err == nil && info.IsDir() {
+		sub, err := fs.Sub(zipFS, debugBase)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		zipFS = sub
+	}
+	if _, err := fs.Stat(zipFS, "nodes"); err != nil {
+		cleanup()
+		return nil, nil, errors.WithHint(
+			errors.Newf("%s does not look like a debug zip", zipPath),
+			"pass the path to a file produced by `cockroach debug zip`, or to the directory it was extracted to.")
+	}
+	return zipFS, cleanup, nil
+}
+
+// zipCheck is a check run by `debug zip analyze`. It returns the problems
+// found, one per line. A check returns an error wrapping fs.ErrNotExist when
+// the data it needs was not collected.
+type zipCheck struct {
+	name string
+	fn   func(a *zipAnalyzer) ([]string, error)
+}
+
+var zipChecks = []zipCheck{
+	{"unavailable and underreplicated ranges", (*zipAnalyzer).checkRanges},
+	{"clock offsets", (*zipAnalyzer).checkClockOffsets},
+	{"hot ranges", (*zipAnalyzer).checkHotRanges},
+	{"failing jobs", (*zipAnalyzer).checkFailingJobs},
+	{"lagging changefeeds", (*zipAnalyzer).checkChangefeeds},
+	{"log error spikes", (*zipAnalyzer).checkLogErrors},
+}
+
+// analyzeDebugZip runs all the checks against the debug zip, and against the
+// data of each virtual cluster it contains, and writes the results to w.
+func analyzeDebugZip(zipFS fs.FS, w io.Writer) error {
+	if err := analyzeDebugZipCluster(zipFS, w); err != nil {
+		return err
+	}
+	tenants, err := fs.ReadDir(zipFS, "cluster")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, tenant := range tenants {
+		if !tenant.IsDir() {
+			continue
+		}
+		sub, err := fs.Sub(zipFS, path.Join("cluster", tenant.Name()))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nvirtual cluster %s:\n", tenant.Name())
+		if err := analyzeDebugZipCluster(sub, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func analyzeDebugZipCluster(zipFS fs.FS, w io.Writer) error {
+	a := &zipAnalyzer{fs: zipFS}
+	for _, check := range zipChecks {
+		findings, err := check.fn(a)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			fmt.Fprintf(w, "%s: no data\n", check.name)
+		case err != nil:
+			fmt.Fprintf(w, "%s: error: %v\n", check.name, err)
+		case len(findings) == 0:
+			fmt.Fprintf(w, "%s: ok\n", check.name)
+		default:
+			fmt.Fprintf(w, "%s: %d found\n", check.name, len(findings))
+			for i, f := range findings {
+				if i == debugZipAnalyzeOpts.maxFindings {
+					fmt.Fprintf(w, "  ... and %d more\n", len(findings)-i)
+					break
+				}
+				fmt.Fprintf(w, "  %s\n", f)
+			}
+		}
+	}
+	return nil
+}
+
+// zipAnalyzer reads the files of a debug zip for the checks, caching the
+// ones used by several checks.
+type zipAnalyzer struct {
+	fs fs.FS
+
+	nodeStatuses []zipNodeStatus
+	jobs         []map[string]string
+}
+
+// zipNodeStatus is the subset of statuspb.NodeStatus used by the checks.
+type zipNodeStatus struct {
+	Desc struct {
+		NodeID int32 `json:"node_id"`
+	} `json:"desc"`
+	UpdatedAt     int64              `json:"updated_at"`
+	Metrics       map[string]float64 `json:"metrics"`
+	StoreStatuses []struct {
+		Desc struct {
+			StoreID int32 `json:"store_id"`
+		} `json:"desc"`
+		Metrics map[string]float64 `json:"metrics"`
+	} `json:"store_statuses"`
+}
+
+// zipRangeInfo is the subset of serverpb.RangeInfo used by the checks.
+type zipRangeInfo struct {
+	Span struct {
+		StartKey string `json:"start_key"`
+		EndKey   string `json:"end_key"`
+	} `json:"span"`
+	State struct {
+		State struct {
+			Desc struct {
+				RangeID int64 `json:"range_id"`
+			} `json:"desc"`
+		} `json:"state"`
+	} `json:"state"`
+	SourceNodeID  int32 `json:"source_node_id"`
+	IsLeaseholder bool  `json:"is_leaseholder"`
+	Stats         struct {
+		QueriesPerSecond float64 `json:"queries_per_second"`
+		CPUTimePerSecond float64 `json:"cpu_time_per_second"`
+	} `json:"stats"`
+}
+
+// zipNodeProblems is the subset of serverpb.ProblemRangesResponse_NodeProblems
+// used by the checks.
+type zipNodeProblems struct {
+	ErrorMessage            string  `json:"error_message"`
+	UnavailableRangeIDs     []int64 `json:"unavailable_range_ids"`
+	UnderreplicatedRangeIDs []int64 `json:"underreplicated_range_ids"`
+	NoLeaseRangeIDs         []int64 `json:"no_lease_range_ids"`
+}
+
+func (a *zipAnalyzer) readJSON(name string, v interface{}) error {
+	data, err := fs.ReadFile(a.fs, name)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(data, v), "parsing %s", name)
+}
+
+// nodeDirs returns the per-node directories, in node ID order.
+func (a *zipAnalyzer) nodeDirs() ([]string, error) {
+	entries, err := fs.ReadDir(a.fs, "nodes")
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err == nil && e.IsDir() {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		a, _ := strconv.Atoi(dirs[i])
+		b, _ := strconv.Atoi(dirs[j])
+		return a < b
+	})
+	return dirs, nil
+}
+
+func (a *zipAnalyzer) loadNodeStatuses() ([]zipNodeStatus, error) {
+	if a.nodeStatuses != nil {
+		return a.nodeStatuses, nil
+	}
+	dirs, err := a.nodeDirs()
+	if err != nil {
+		return nil, err
+	}
+	statuses := []zipNodeStatus{}
+	for _, dir := range dirs {
+		var status zipNodeStatus
+		if err := a.readJSON(path.Join("nodes", dir, "status.json"), &status); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if status.Desc.NodeID == 0 {
+			// The nodes of virtual clusters have no KV status.
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		return nil, fs.ErrNotExist
+	}
+	a.nodeStatuses = statuses
+	return statuses, nil
+}
+
+// collectionTime returns the time at which the debug zip was collected,
+// approximated by the most recent node status update.
+func (a *zipAnalyzer) collectionTime() (time.Time, bool) {
+	statuses, err := a.loadNodeStatuses()
+	if err != nil {
+		return time.Time{}, false
+	}
+	var latest int64
+	for _, s := range statuses {
+		if s.UpdatedAt > latest {
+			latest = s.UpdatedAt
+		}
+	}
+	return timeutil.Unix(0, latest), latest != 0
+}
+
+// loadJobs returns the rows of crdb_internal.jobs, keyed by column name.
+func (a *zipAnalyzer) loadJobs() ([]map[string]string, error) {
+	if a.jobs != nil {
+		return a.jobs, nil
+	}
+	f, err := a.fs.Open("crdb_internal.jobs.txt")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comma = '\t'
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fs.ErrNotExist
+		}
+		return nil, errors.Wrap(err, "parsing crdb_internal.jobs.txt")
+	}
+	jobs := []map[string]string{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing crdb_internal.jobs.txt")
+		}
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			if i < len(record) && record[i] != "NULL" {
+				row[col] = record[i]
+			}
+		}
+		jobs = append(jobs, row)
+	}
+	a.jobs = jobs
+	return jobs, nil
+}
+
+func formatRangeIDs(ids []int64) string {
+	const maxIDs = 10
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var b strings.Builder
+	for i, id := range ids {
+		if i == maxIDs {
+			fmt.Fprintf(&b, ", ... (%d total)", len(ids))
+			break
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "r%d", id)
+	}
+	return b.String()
+}
+
+// checkRanges reports the unavailable, underreplicated and leaseless ranges
+// from the problem ranges report, or from the store metrics if the report
+// was not collected.
+func (a *zipAnalyzer) checkRanges() ([]string, error) {
+	var report struct {
+		ProblemsByNodeID map[string]zipNodeProblems `json:"problems_by_node_id"`
+	}
+	err := a.readJSON(problemRangesName[1:]+".json", &report)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a.checkRangesFromMetrics()
+	}
+	if err != nil {
+		return nil, err
+	}
+	nodeIDs := make([]int, 0, len(report.ProblemsByNodeID))
+	for id := range report.ProblemsByNodeID {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing node ID %q", id)
+		}
+		nodeIDs = append(nodeIDs, n)
+	}
+	sort.Ints(nodeIDs)
+	var findings []string
+	for _, id := range nodeIDs {
+		p := report.ProblemsByNodeID[strconv.Itoa(id)]
+		if p.ErrorMessage != "" {
+			findings = append(findings, fmt.Sprintf("n%d: error retrieving problem ranges: %s", id, p.ErrorMessage))
+		}
+		for _, problem := range []struct {
+			name string
+			ids  []int64
+		}{
+			{"unavailable", p.UnavailableRangeIDs},
+			{"underreplicated", p.UnderreplicatedRangeIDs},
+			{"without a lease", p.NoLeaseRangeIDs},
+		} {
+			if len(problem.ids) > 0 {
+				findings = append(findings, fmt.Sprintf("n%d: %d %s ranges: %s",
+					id, len(problem.ids), problem.name, formatRangeIDs(problem.ids)))
+			}
+		}
+	}
+	return findings, nil
+}
+
+func (a *zipAnalyzer) checkRangesFromMetrics() ([]string, error) {
+	statuses, err := a.loadNodeStatuses()
+	if err != nil {
+		return nil, err
+	}
+	var findings []string
+	for _, s := range statuses {
+		for _, ss := range s.StoreStatuses {
+			unavailable := ss.Metrics["ranges.unavailable"]
+			underreplicated := ss.Metrics["ranges.underreplicated"]
+			if unavailable > 0 || underreplicated > 0 {
+				findings = append(findings, fmt.Sprintf("n%d,s%d: %d unavailable and %d underreplicated ranges",
+					s.Desc.NodeID, ss.Desc.StoreID, int64(unavailable), int64(underreplicated)))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// checkClockOffsets reports the nodes whose mean clock offset to the other
+// nodes exceeds the threshold.
+func (a *zipAnalyzer) checkClockOffsets() ([]string, error) {
+	statuses, err := a.loadNodeStatuses()
+	if err != nil {
+		return nil, err
+	}
+	var findings []string
+	for _, s := range statuses {
+		offset := time.Duration(s.Metrics["clock-offset.meannanos"])
+		if offset.Abs() >= debugZipAnalyzeOpts.clockOffsetThreshold {
+			findings = append(findings, fmt.Sprintf("n%d: mean clock offset %s (threshold %s)",
+				s.Desc.NodeID, offset, debugZipAnalyzeOpts.clockOffsetThreshold))
+		}
+	}
+	return findings, nil
+}
+
+// checkHotRanges reports the ranges whose load, as measured by their
+// leaseholders, is above the load-based splitting thresholds, hottest
+// first.
+func (a *zipAnalyzer) checkHotRanges() ([]string, error) {
+	dirs, err := a.nodeDirs()
+	if err != nil {
+		return nil, err
+	}
+	var hot []zipRangeInfo
+	found := false
+	for _, dir := range dirs {
+		var ranges []zipRangeInfo
+		if err := a.readJSON(path.Join("nodes", dir, "ranges.json"), &ranges); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		for _, r := range ranges {
+			if !r.IsLeaseholder {
+				continue
+			}
+			if r.Stats.QueriesPerSecond >= debugZipAnalyzeOpts.hotRangeQPS ||
+				time.Duration(r.Stats.CPUTimePerSecond) >= debugZipAnalyzeOpts.hotRangeCPU {
+				hot = append(hot, r)
+			}
+		}
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+	sort.Slice(hot, func(i, j int) bool {
+		if hot[i].Stats.CPUTimePerSecond != hot[j].Stats.CPUTimePerSecond {
+			return hot[i].Stats.CPUTimePerSecond > hot[j].Stats.CPUTimePerSecond
+		}
+		return hot[i].Stats.QueriesPerSecond > hot[j].Stats.QueriesPerSecond
+	})
+	findings := make([]string, len(hot))
+	for i, r := range hot {
+		findings[i] = fmt.Sprintf("r%d on n%d: %.0f queries/s, %s CPU/s [%s, %s)",
+			r.State.State.Desc.RangeID, r.SourceNodeID, r.Stats.QueriesPerSecond,
+			time.Duration(r.Stats.CPUTimePerSecond), r.Span.StartKey, r.Span.EndKey)
+	}
+	return findings, nil
+}
+
+// checkFailingJobs reports the jobs that failed or are reverting.
+func (a *zipAnalyzer) checkFailingJobs() ([]string, error) {
+	jobs, err := a.loadJobs()
+	if err != nil {
+		return nil, err
+	}
+	var findings []string
+	for _, job := range jobs {
+		switch job["status"] {
+		case "failed", "reverting", "revert-failed":
+		default:
+			continue
+		}
+		f := fmt.Sprintf("job %s (%s): %s", job["job_id"], job["job_type"], job["status"])
+		if t := job["modified"]; t != "" {
+			f += " at " + t
+		}
+		if e := job["error"]; e != "" {
+			f += ": " + e
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// checkChangefeeds reports the running changefeeds whose high-water mark
+// lags the time at which the debug zip was collected by more than the
+// threshold.
+func (a *zipAnalyzer) checkChangefeeds() ([]string, error) {
+	jobs, err := a.loadJobs()
+	if err != nil {
+		return nil, err
+	}
+	now, ok := a.collectionTime()
+	if !ok {
+		return nil, errors.Wrap(fs.ErrNotExist, "unknown collection time")
+	}
+	var findings []string
+	for _, job := range jobs {
+		if job["job_type"] != "CHANGEFEED" || job["status"] != "running" {
+			continue
+		}
+		hwm := job["high_water_timestamp"]
+		if hwm == "" {
+			findings = append(findings, fmt.Sprintf("changefeed %s: no high-water mark", job["job_id"]))
+			continue
+		}
+		ts, err := hlc.ParseHLC(hwm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing high-water mark of job %s", job["job_id"])
+		}
+		if lag := now.Sub(ts.GoTime()); lag >= debugZipAnalyzeOpts.changefeedLagThreshold {
+			findings = append(findings, fmt.Sprintf("changefeed %s: high-water mark lags by %s",
+				job["job_id"], humanizeutil.Duration(lag)))
+		}
+	}
+	return findings, nil
+}
+
+// logEntryMinuteRE matches the severity and the minute of the log entries
+// in the crdb-v1 format used by debug zip, e.g. "E240102 15:04:05.123456".
+var logEntryMinuteRE = regexp.MustCompile(`^([IWEF])(\d{6} \d{2}:\d{2}):\d{2}`)
+
+// checkLogErrors reports the minutes in which a node logged more errors
+// than the threshold.
+func (a *zipAnalyzer) checkLogErrors() ([]string, error) {
+	dirs, err := a.nodeDirs()
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	var findings []string
+	for _, dir := range dirs {
+		files, err := fs.Glob(a.fs, path.Join("nodes", dir, "logs", "*.log"))
+		if err != nil {
+			return nil, err
+		}
+		errorsPerMinute := make(map[string]int)
+		for _, file := range files {
+			found = true
+			if err := a.countLogErrors(file, errorsPerMinute); err != nil {
+				return nil, err
+			}
+		}
+		minutes := make([]string, 0, len(errorsPerMinute))
+		for minute, n := range errorsPerMinute {
+			if n >= debugZipAnalyzeOpts.logErrorsPerMinute {
+				minutes = append(minutes, minute)
+			}
+		}
+		sort.Strings(minutes)
+		for _, minute := range minutes {
+			t, err := time.Parse("060102 15:04", minute)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, fmt.Sprintf("n%s: %d errors at %s",
+				dir, errorsPerMinute[minute], t.Format("2006-01-02 15:04")))
+		}
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+	return findings, nil
+}
+
+func (a *zipAnalyzer) countLogErrors(file string, errorsPerMinute map[string]int) error {
+	f, err := a.fs.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 200*1024*1024)
+	for sc.Scan() {
+		m := logEntryMinuteRE.FindSubmatch(sc.Bytes())
+		if m == nil {
+			continue
+		}
+		if sev := m[1][0]; sev == 'E' || sev == 'F' {
+			errorsPerMinute[string(m[2])]++
+		}
+	}
+	return errors.Wrapf(sc.Err(), "reading %s", file)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/datadriven"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugZipAnalyze(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	c := NewCLITest(TestCLIParams{T: t, NoServer: true})
+	defer c.Cleanup()
+
+	const dir = "testdata/zip_analyze/debugzip"
+	out, err := c.RunWithCapture("debug zip analyze " + dir)
+	require.NoError(t, err)
+
+	// Using datadriven allows TESTFLAGS=-rewrite.
+	datadriven.RunTest(t, datapathutils.TestDataPath(t, "zip_analyze", "test_analyze"), func(t *testing.T, td *datadriven.TestData) string {
+		return out
+	})
+
+	t.Run("zip file", func(t *testing.T) {
+		zipPath := filepath.Join(t.TempDir(), "debug.zip")
+		f, err := os.Create(zipPath)
+		require.NoError(t, err)
+		w := zip.NewWriter(f)
+		require.NoError(t, filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			zf, err := w.Create(filepath.ToSlash(rel))
+			if err != nil {
+				return err
+			}
+			src, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			_, err = io.Copy(zf, src)
+			return err
+		}))
+		require.NoError(t, w.Close())
+		require.NoError(t, f.Close())
+
+		zipOut, err := c.RunWithCapture("debug zip analyze " + zipPath)
+		require.NoError(t, err)
+		// The output only differs in the echoed command line.
+		trim := func(s string) string { return s[strings.Index(s, "\n"):] }
+		require.Equal(t, trim(out), trim(zipOut))
+	})
+
+	t.Run("not a debug zip", func(t *testing.T) {
+		out, err := c.RunWithCapture("debug zip analyze testdata/zip_analyze")
+		require.NoError(t, err)
+		require.Contains(t, out, "does not look like a debug zip")
+	})
+}
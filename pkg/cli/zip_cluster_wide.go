@@ -90,6 +90,10 @@ func (zc *debugZipContext) collectClusterData(
 
 	queryAndDumpTables := func(reg DebugZipTableRegistry) error {
 		for _, table := range reg.GetTables() {
+			if !zipCtx.redactionProfile.includesTable(table) {
+				zc.clusterPrinter.info("skipping table excluded by redaction profile: %s", table)
+				continue
+			}
 			query, err := reg.QueryForTable(table, zipCtx.redact)
 			if err != nil {
 				return err
@@ -36,3 +36,16 @@ var debugZipUploadCmd = &cobra.Command{
 	Hidden: true,
 	RunE:   clierrorplus.MaybeDecorateError(runDebugZipUpload),
 }
+
+var debugZipAnalyzeCmd = &cobra.Command{
+	Use:   "analyze <path to debug zip or dir>",
+	Short: "report common problems found in a debug zip",
+	Long: `
+Load a debug zip, or the directory it was extracted to, and report common
+problems found in it: unavailable and underreplicated ranges, clock offsets,
+hot ranges, failing jobs, lagging changefeeds and spikes of errors in the
+logs. No connection to the cluster is needed.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugZipAnalyze),
+}
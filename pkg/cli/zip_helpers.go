@@ -86,6 +86,11 @@ func (z *zipper) createJSON(s *zipReporter, name string, m interface{}) (err err
 		return s.fail(errors.Errorf("%s does not have .json suffix", name))
 	}
 	s.progress("writing JSON output: %s", name)
+	if zipCtx.redactionProfile.redactsJSON() {
+		if m, err = zipCtx.redactionProfile.redactJSON(m); err != nil {
+			return s.fail(err)
+		}
+	}
 
 	z.Lock()
 	defer z.Unlock()
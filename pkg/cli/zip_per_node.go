@@ -294,6 +294,10 @@ func (zc *debugZipContext) collectPerNodeData(
 	nodePrinter.info("using SQL connection URL: %s", curSQLConn.GetURL())
 
 	for _, table := range zipInternalTablesPerNode.GetTables() {
+		if !zipCtx.redactionProfile.includesTable(table) {
+			nodePrinter.info("skipping table excluded by redaction profile: %s", table)
+			continue
+		}
 		query, err := zipInternalTablesPerNode.QueryForTable(table, zipCtx.redact)
 		if err != nil {
 			return err
@@ -0,0 +1,318 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	"gopkg.in/yaml.v2"
+)
+
+// zipRedactionProfile is a named set of redaction rules applied by `debug
+// zip` on top of (or instead of) the --redact flag. Profiles are selected
+// with --redaction-profile, either among the built-in profiles or among the
+// profiles defined in the file passed to --redaction-profiles-file.
+type zipRedactionProfile struct {
+	Name string `yaml:"name"`
+	// Redact has the same effect as the --redact flag.
+	Redact bool `yaml:"redact"`
+	// HideSQLConstants replaces the constants in columns that contain SQL
+	// statements with placeholders.
+	HideSQLConstants bool `yaml:"hide-sql-constants"`
+	// RedactHostnames redacts columns and JSON fields that contain hostnames
+	// or network addresses.
+	RedactHostnames bool `yaml:"redact-hostnames"`
+	// RedactUsernames redacts columns and JSON fields that contain SQL user
+	// names.
+	RedactUsernames bool `yaml:"redact-usernames"`
+	// IncludeTables, if non-empty, restricts the SQL tables collected to
+	// those matching one of the glob patterns (e.g. "crdb_internal.*jobs").
+	IncludeTables []string `yaml:"include-tables"`
+	// ExcludeTables lists glob patterns of SQL tables not to collect.
+	ExcludeTables []string `yaml:"exclude-tables"`
+}
+
+// builtinZipRedactionProfiles are the redaction profiles that can be
+// selected without a profiles file.
+var builtinZipRedactionProfiles = []zipRedactionProfile{
+	{Name: "redact", Redact: true},
+	{Name: "sql-constants", HideSQLConstants: true},
+	{Name: "hostnames", RedactHostnames: true},
+	{Name: "usernames", RedactUsernames: true},
+	{
+		Name:             "strict",
+		Redact:           true,
+		HideSQLConstants: true,
+		RedactHostnames:  true,
+		RedactUsernames:  true,
+	},
+}
+
+// zipSQLTextColumns, zipHostnameColumns and zipUsernameColumns are the names
+// of the columns of the collected SQL tables that are rewritten by the
+// HideSQLConstants, RedactHostnames and RedactUsernames rules respectively.
+var (
+	zipSQLTextColumns = map[string]struct{}{
+		"query":             {},
+		"last_active_query": {},
+		"active_queries":    {},
+		"statement":         {},
+		"create_statement":  {},
+		"stmt":              {},
+	}
+	zipHostnameColumns = map[string]struct{}{
+		"address":        {},
+		"sql_address":    {},
+		"http_address":   {},
+		"client_address": {},
+		"node_address":   {},
+		"hostname":       {},
+		"host":           {},
+	}
+	zipUsernameColumns = map[string]struct{}{
+		"user":         {},
+		"user_name":    {},
+		"username":     {},
+		"session_user": {},
+		"owner":        {},
+		"grantee":      {},
+		"grantor":      {},
+		"member":       {},
+		"role":         {},
+		"role_name":    {},
+	}
+)
+
+// zipHostnameJSONFields are the fields of the JSON files (e.g.
+// nodes/*/status.json, details.json and gossip.json) that are redacted by the
+// RedactHostnames rule in addition to zipHostnameColumns. The command line
+// arguments and environment of a node may contain --join addresses, and the
+// values of gossip infos are encoded node and store descriptors.
+var zipHostnameJSONFields = map[string]struct{}{
+	"address_field": {},
+	"args":          {},
+	"env":           {},
+	"raw_bytes":     {},
+}
+
+// loadZipRedactionProfile combines the named redaction profiles into a
+// single profile. Profiles are looked up in the profiles file, if any, and
+// then among the built-in profiles. It returns nil if no profile is named.
+func loadZipRedactionProfile(names []string, profilesFile string) (*zipRedactionProfile, error) {
+	available := make(map[string]zipRedactionProfile)
+	for _, p := range builtinZipRedactionProfiles {
+		available[p.Name] = p
+	}
+	if profilesFile != "" {
+		data, err := os.ReadFile(profilesFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading redaction profiles file")
+		}
+		var custom []zipRedactionProfile
+		if err := yaml.UnmarshalStrict(data, &custom); err != nil {
+			return nil, errors.Wrapf(err, "parsing redaction profiles file %s", profilesFile)
+		}
+		for _, p := range custom {
+			if p.Name == "" {
+				return nil, errors.Newf("redaction profile in %s has no name", profilesFile)
+			}
+			if err := p.validatePatterns(); err != nil {
+				return nil, err
+			}
+			available[p.Name] = p
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	combined := &zipRedactionProfile{}
+	for _, name := range names {
+		p, ok := available[name]
+		if !ok {
+			known := make([]string, 0, len(available))
+			for n := range available {
+				known = append(known, n)
+			}
+			sort.Strings(known)
+			return nil, errors.WithHintf(
+				errors.Newf("unknown redaction profile: %q", name),
+				"available profiles: %s", strings.Join(known, ", "))
+		}
+		if combined.Name != "" {
+			combined.Name += ","
+		}
+		combined.Name += p.Name
+		combined.Redact = combined.Redact || p.Redact
+		combined.HideSQLConstants = combined.HideSQLConstants || p.HideSQLConstants
+		combined.RedactHostnames = combined.RedactHostnames || p.RedactHostnames
+		combined.RedactUsernames = combined.RedactUsernames || p.RedactUsernames
+		combined.IncludeTables = append(combined.IncludeTables, p.IncludeTables...)
+		combined.ExcludeTables = append(combined.ExcludeTables, p.ExcludeTables...)
+	}
+	return combined, nil
+}
+
+func (p *zipRedactionProfile) validatePatterns() error {
+	for _, patterns := range [][]string{p.IncludeTables, p.ExcludeTables} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "invalid table pattern %q in redaction profile %s", pattern, p.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// includesTable returns whether the given SQL table should be collected.
+func (p *zipRedactionProfile) includesTable(table string) bool {
+	if p == nil {
+		return true
+	}
+	match := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, table); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(p.IncludeTables) > 0 && !match(p.IncludeTables) {
+		return false
+	}
+	return !match(p.ExcludeTables)
+}
+
+// rewritesColumns returns whether the profile rewrites any columns.
+func (p *zipRedactionProfile) rewritesColumns() bool {
+	return p != nil && (p.HideSQLConstants || p.RedactHostnames || p.RedactUsernames)
+}
+
+// redactsJSON returns whether the profile rewrites the JSON files.
+func (p *zipRedactionProfile) redactsJSON() bool {
+	return p != nil && (p.RedactHostnames || p.RedactUsernames)
+}
+
+// redactJSON returns the JSON representation of m with the fields that
+// contain hostnames or user names replaced by the redaction marker,
+// according to the profile.
+func (p *zipRedactionProfile) redactJSON(m interface{}) (interface{}, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return p.redactJSONValue(v), nil
+}
+
+func (p *zipRedactionProfile) redactJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if e != nil && p.redactsJSONField(k) {
+				t[k] = string(redact.RedactedMarker())
+			} else {
+				t[k] = p.redactJSONValue(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = p.redactJSONValue(e)
+		}
+	}
+	return v
+}
+
+func (p *zipRedactionProfile) redactsJSONField(field string) bool {
+	if p.RedactHostnames {
+		if _, ok := zipHostnameColumns[field]; ok {
+			return true
+		}
+		if _, ok := zipHostnameJSONFields[field]; ok {
+			return true
+		}
+	}
+	if p.RedactUsernames {
+		if _, ok := zipUsernameColumns[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// columnExpr returns the expression that replaces the given column of the
+// query results, or "" if the column is to be left as is.
+func (p *zipRedactionProfile) columnExpr(col string) string {
+	name := lexbase.EscapeSQLIdent(col)
+	if p.HideSQLConstants {
+		if _, ok := zipSQLTextColumns[col]; ok {
+			return fmt.Sprintf("crdb_internal.hide_sql_constants(%s::STRING) AS %s", name, name)
+		}
+	}
+	_, isHostname := zipHostnameColumns[col]
+	_, isUsername := zipUsernameColumns[col]
+	if (isHostname && p.RedactHostnames) || (isUsername && p.RedactUsernames) {
+		marker := lexbase.EscapeSQLString(string(redact.RedactedMarker()))
+		return fmt.Sprintf("IF(%s IS NULL, NULL, %s) AS %s", name, marker, name)
+	}
+	return ""
+}
+
+// rewriteQuery wraps the query so that the columns of its results are
+// rewritten according to the profile. The result columns are not known
+// statically, so they are retrieved by running the query with no rows
+// first.
+func (p *zipRedactionProfile) rewriteQuery(
+	ctx context.Context, conn clisqlclient.Conn, query string,
+) (string, error) {
+	if !p.rewritesColumns() {
+		return query, nil
+	}
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	rows, err := conn.Query(ctx, fmt.Sprintf("SELECT * FROM (%s) AS t LIMIT 0", query))
+	if err != nil {
+		return "", err
+	}
+	cols := rows.Columns()
+	if err := rows.Close(); err != nil {
+		return "", err
+	}
+	return p.rewriteQueryWithColumns(query, cols), nil
+}
+
+func (p *zipRedactionProfile) rewriteQueryWithColumns(query string, cols []string) string {
+	exprs := make([]string, len(cols))
+	rewritten := false
+	for i, col := range cols {
+		if e := p.columnExpr(col); e != "" {
+			exprs[i] = e
+			rewritten = true
+		} else {
+			exprs[i] = lexbase.EscapeSQLIdent(col)
+		}
+	}
+	if !rewritten {
+		return query
+	}
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	return fmt.Sprintf("SELECT %s FROM (%s) AS t", strings.Join(exprs, ", "), query)
+}
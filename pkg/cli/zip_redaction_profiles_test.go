@@ -0,0 +1,129 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipRedactionProfiles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	t.Run("none", func(t *testing.T) {
+		p, err := loadZipRedactionProfile(nil, "")
+		require.NoError(t, err)
+		require.Nil(t, p)
+		require.True(t, p.includesTable("crdb_internal.jobs"))
+		require.False(t, p.rewritesColumns())
+	})
+
+	t.Run("builtin", func(t *testing.T) {
+		p, err := loadZipRedactionProfile([]string{"sql-constants", "usernames"}, "")
+		require.NoError(t, err)
+		require.Equal(t, "sql-constants,usernames", p.Name)
+		require.False(t, p.Redact)
+		require.True(t, p.HideSQLConstants)
+		require.False(t, p.RedactHostnames)
+		require.True(t, p.RedactUsernames)
+
+		p, err = loadZipRedactionProfile([]string{"strict"}, "")
+		require.NoError(t, err)
+		require.True(t, p.Redact && p.HideSQLConstants && p.RedactHostnames && p.RedactUsernames)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := loadZipRedactionProfile([]string{"lenient"}, "")
+		require.ErrorContains(t, err, `unknown redaction profile: "lenient"`)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "profiles.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`
+- name: support
+  redact-hostnames: true
+  include-tables: ["crdb_internal.*", "system.jobs"]
+  exclude-tables: ["crdb_internal.cluster_sessions"]
+`), 0644))
+		p, err := loadZipRedactionProfile([]string{"support", "sql-constants"}, file)
+		require.NoError(t, err)
+		require.True(t, p.RedactHostnames)
+		require.True(t, p.HideSQLConstants)
+		require.True(t, p.includesTable("crdb_internal.jobs"))
+		require.True(t, p.includesTable("system.jobs"))
+		require.False(t, p.includesTable("system.descriptor"))
+		require.False(t, p.includesTable("crdb_internal.cluster_sessions"))
+
+		require.NoError(t, os.WriteFile(file, []byte(`
+- name: broken
+  include-tables: ["crdb_internal.[jobs"]
+`), 0644))
+		_, err = loadZipRedactionProfile([]string{"broken"}, file)
+		require.ErrorContains(t, err, "invalid table pattern")
+
+		require.NoError(t, os.WriteFile(file, []byte(`
+- name: typo
+  redact-hostname: true
+`), 0644))
+		_, err = loadZipRedactionProfile([]string{"typo"}, file)
+		require.ErrorContains(t, err, "parsing redaction profiles file")
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		p := &zipRedactionProfile{HideSQLConstants: true, RedactUsernames: true}
+		require.Equal(t,
+			`SELECT session_id, crdb_internal.hide_sql_constants(active_queries::STRING) AS active_queries, `+
+				`IF(user_name IS NULL, NULL, `+lexbase.EscapeSQLString(string(redact.RedactedMarker()))+`) AS user_name, client_address `+
+				`FROM (TABLE crdb_internal.node_sessions) AS t`,
+			p.rewriteQueryWithColumns("TABLE crdb_internal.node_sessions;",
+				[]string{"session_id", "active_queries", "user_name", "client_address"}),
+		)
+		// Queries without any column to rewrite are left as is.
+		require.Equal(t, "TABLE system.settings",
+			p.rewriteQueryWithColumns("TABLE system.settings", []string{"name", "value"}))
+	})
+	t.Run("json", func(t *testing.T) {
+		type addr struct {
+			AddressField string `json:"address_field"`
+		}
+		type status struct {
+			NodeID  int      `json:"node_id"`
+			Address addr     `json:"address"`
+			Args    []string `json:"args"`
+			User    string   `json:"user"`
+		}
+		in := status{NodeID: 1, Address: addr{"host1:26257"}, Args: []string{"--join=host1"}, User: "root"}
+		marker := string(redact.RedactedMarker())
+
+		p := &zipRedactionProfile{RedactHostnames: true}
+		require.True(t, p.redactsJSON())
+		out, err := p.redactJSON(in)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"node_id": json.Number("1"),
+			"address": marker,
+			"args":    marker,
+			"user":    "root",
+		}, out)
+
+		p = &zipRedactionProfile{RedactUsernames: true}
+		out, err = p.redactJSON(in)
+		require.NoError(t, err)
+		require.Equal(t, marker, out.(map[string]interface{})["user"])
+		require.Equal(t, map[string]interface{}{"address_field": "host1:26257"},
+			out.(map[string]interface{})["address"])
+
+		require.False(t, (&zipRedactionProfile{HideSQLConstants: true}).redactsJSON())
+		require.False(t, (*zipRedactionProfile)(nil).redactsJSON())
+	})
+}
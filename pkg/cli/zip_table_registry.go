@@ -706,6 +706,22 @@ var zipInternalTablesPerNode = DebugZipTableRegistry{
 			"crdb_region",
 		},
 	},
+	"crdb_internal.node_active_session_history": {
+		// `contended_key` column contains the contended key, which may contain
+		// sensitive row-level data.
+		nonSensitiveCols: NonSensitiveColumns{
+			"sample_time",
+			"node_id",
+			"session_id",
+			"query_id",
+			"txn_id",
+			"user_name",
+			"application_name",
+			"fingerprint",
+			"phase",
+			"wait_state",
+		},
+	},
 	"crdb_internal.node_build_info": {
 		nonSensitiveCols: NonSensitiveColumns{
 			"node_id",
@@ -760,6 +776,8 @@ var zipInternalTablesPerNode = DebugZipTableRegistry{
 			"kv_node_ids",
 			"error_code",
 			"crdb_internal.redact(last_error_redactable) as last_error_redactable",
+			"previous_plan_gist",
+			"previous_plan_mean_latency",
 		},
 	},
 	"crdb_internal.node_inflight_trace_spans": {
@@ -1451,6 +1469,16 @@ var zipSystemTables = DebugZipTableRegistry{
 			"redacted",
 		},
 	},
+	"system.statement_hints": {
+		nonSensitiveCols: NonSensitiveColumns{
+			"row_id",
+			"fingerprint",
+			"hint_type",
+			"table_name",
+			"hint_value",
+			"created_at",
+		},
+	},
 	// statement_statistics can have over 100k rows in just the last hour.
 	// Select all the statements that are part of a transaction where one of
 	// the statements is in the 100 by cpu usage, and all the transaction
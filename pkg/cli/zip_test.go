@@ -71,6 +71,7 @@ FROM [ SELECT table_name FROM [ SHOW TABLES FROM crdb_internal ] ]
 WHERE
 table_name NOT IN (
 	-- allowlisted tables that don't need to be in debug zip
+	'active_session_history',
 	'backward_dependencies',
 	'builtin_functions',
 	'cluster_contended_keys',
@@ -111,6 +112,7 @@ table_name NOT IN (
 	'tables',
 	'cluster_statement_statistics',
 	'statement_activity',
+	'statement_hints',
 	'statement_statistics_persisted',
 	'statement_statistics_persisted_v22_2',
 	'store_liveness_support_for',
@@ -220,6 +220,14 @@ const (
 	// V25_1_JobsBackfill backfills the new jobs tables and columns.
 	V25_1_JobsBackfill
 
+	// V25_1_StatementHintsTable adds the system.statement_hints table, which
+	// stores the plan hints attached to statement fingerprints.
+	V25_1_StatementHintsTable
+
+	// V25_1_SQLEventAggregatesTable adds the system.sql_event_aggregates table,
+	// which stores the aggregations of SQL events computed by each node.
+	V25_1_SQLEventAggregatesTable
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -267,6 +275,8 @@ var versionTable = [numKeys]roachpb.Version{
 	V25_1_AddJobsColumns:            {Major: 24, Minor: 3, Internal: 14},
 	V25_1_JobsWritesFence:           {Major: 24, Minor: 3, Internal: 16},
 	V25_1_JobsBackfill:              {Major: 24, Minor: 3, Internal: 18},
+	V25_1_StatementHintsTable:       {Major: 24, Minor: 3, Internal: 20},
+	V25_1_SQLEventAggregatesTable:   {Major: 24, Minor: 3, Internal: 22},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
@@ -624,6 +624,20 @@ var specs = []stmtSpec{
 		unlink:  []string{"backup_targets", "collectionURI", "destination", "timestamp", "localityURI", "subdirectory"},
 		exclude: []*regexp.Regexp{regexp.MustCompile("'IN'")},
 	},
+	{
+		name:   "backup_compact",
+		stmt:   "backup_stmt",
+		inline: []string{"opt_backup_targets"},
+		match:  []*regexp.Regexp{regexp.MustCompile("'COMPACT'")},
+		replace: map[string]string{
+			"'COMPACT' opt_compact_from 'TO' sconst_or_placeholder": "'COMPACT' ( | 'FROM' start_backup ) 'TO' end_backup",
+			"backup_targets":                 "( | 'TABLE' table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* )",
+			"opt_with_backup_options":        "( | 'WITH' ( backup_options ( ( ',' backup_options ) )* | 'OPTIONS' '(' backup_options ( ( ',' backup_options ) )* ')' ) )",
+			"sconst_or_placeholder":          "subdirectory",
+			"string_or_placeholder_opt_list": "( collectionURI | '(' localityURI ( ',' localityURI )* ')' )",
+		},
+		unlink: []string{"backup_targets", "collectionURI", "localityURI", "subdirectory", "start_backup", "end_backup"},
+	},
 	{
 		name: "legacy_begin_stmt",
 		inline: []string{
@@ -1492,6 +1506,8 @@ var specs = []stmtSpec{
 			"'BACKUP' 'SCHEMAS' 'FROM' string_or_placeholder 'IN' string_or_placeholder_opt_list": "'BACKUP' 'SCHEMAS' 'FROM' subdirectory 'IN' collectionURI",
 			"'BACKUP' string_or_placeholder":                                                      "'BACKUP' collectionURI_path",
 			"'BACKUP' 'CONNECTION' string_or_placeholder":                                         "'BACKUP' 'CONNECTION' collectionURI",
+			"table_name 'FROM' string_or_placeholder":                                             "table_name 'FROM' subdirectory",
+			"string_or_placeholder_opt_list opt_as_of_clause":                                     "collectionURI opt_as_of_clause",
 		},
 		unlink: []string{"subdirectory", "collectionURI", "collectionURI_path"},
 	},
@@ -25,6 +25,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:alter_database_to_schema_stmt.bnf",
     "//docs/generated/sql/bnf:alter_ddl_stmt.bnf",
     "//docs/generated/sql/bnf:alter_default_privileges_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_domain_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_dep_extension_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_options_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_owner_stmt.bnf",
@@ -71,6 +72,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:alter_zone_table_stmt.bnf",
     "//docs/generated/sql/bnf:analyze_stmt.bnf",
     "//docs/generated/sql/bnf:backup.bnf",
+    "//docs/generated/sql/bnf:backup_compact.bnf",
     "//docs/generated/sql/bnf:backup_options.bnf",
     "//docs/generated/sql/bnf:begin_transaction.bnf",
     "//docs/generated/sql/bnf:call.bnf",
@@ -125,6 +127,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:drop_constraint.bnf",
     "//docs/generated/sql/bnf:drop_database.bnf",
     "//docs/generated/sql/bnf:drop_ddl_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_domain_stmt.bnf",
     "//docs/generated/sql/bnf:drop_external_connection_stmt.bnf",
     "//docs/generated/sql/bnf:drop_func_stmt.bnf",
     "//docs/generated/sql/bnf:drop_index.bnf",
@@ -26,6 +26,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:alter_database_to_schema.html",
     "//docs/generated/sql/bnf:alter_ddl.html",
     "//docs/generated/sql/bnf:alter_default_privileges.html",
+    "//docs/generated/sql/bnf:alter_domain.html",
     "//docs/generated/sql/bnf:alter_func.html",
     "//docs/generated/sql/bnf:alter_func_dep_extension.html",
     "//docs/generated/sql/bnf:alter_func_options.html",
@@ -71,6 +72,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:alter_zone_table.html",
     "//docs/generated/sql/bnf:analyze.html",
     "//docs/generated/sql/bnf:backup.html",
+    "//docs/generated/sql/bnf:backup_compact.html",
     "//docs/generated/sql/bnf:backup_options.html",
     "//docs/generated/sql/bnf:begin_transaction.html",
     "//docs/generated/sql/bnf:call.html",
@@ -126,6 +128,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:drop_constraint.html",
     "//docs/generated/sql/bnf:drop_database.html",
     "//docs/generated/sql/bnf:drop_ddl.html",
+    "//docs/generated/sql/bnf:drop_domain.html",
     "//docs/generated/sql/bnf:drop_external_connection.html",
     "//docs/generated/sql/bnf:drop_func.html",
     "//docs/generated/sql/bnf:drop_index.html",
@@ -1753,7 +1753,7 @@ func (s *Smither) isOrderable(typ *types.T) bool {
 		return typ.Family() != types.Box2DFamily
 	}
 	switch typ.Family() {
-	case types.TSQueryFamily, types.TSVectorFamily:
+	case types.JsonpathFamily, types.TSQueryFamily, types.TSVectorFamily:
 		// We can't order by these types - see #92165.
 		return false
 	default:
@@ -53,8 +53,8 @@ func (s *Smither) pickAnyType(typ *types.T) *types.T {
 var simpleScalarTypes = func() (typs []*types.T) {
 	for _, t := range types.Scalar {
 		switch t {
-		case types.Box2D, types.Geography, types.Geometry, types.INet, types.PGLSN,
-			types.RefCursor, types.TSQuery, types.TSVector:
+		case types.Box2D, types.Geography, types.Geometry, types.INet, types.MacAddr,
+			types.Money, types.PGLSN, types.RefCursor, types.TSQuery, types.TSVector:
 			// Skip fancy types.
 		default:
 			typs = append(typs, t)
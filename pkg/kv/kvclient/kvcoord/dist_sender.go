@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/ash"
 	"github.com/cockroachdb/cockroach/pkg/util/grpcutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -2752,7 +2753,14 @@ func (ds *DistSender) sendToReplicas(
 			err = cbErr
 			transport.SkipReplica()
 		} else {
+			var waitState ash.WaitStateToken
+			if curReplica.NodeID != ds.nodeIDGetter() {
+				// Local requests record their own wait states while they are
+				// evaluated.
+				waitState = ash.SetWaitState(ctx, ash.Network)
+			}
 			br, err = transport.SendNext(sendCtx, requestToSend)
+			waitState.Restore()
 			tEnd := crtime.NowMono()
 			if cancelErr := cbToken.Done(br, err, tEnd); cancelErr != nil {
 				// The request was cancelled by the circuit breaker tripping. If this is
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/ash"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -127,6 +128,7 @@ func (w *lockTableWaiterImpl) WaitOn(
 	tracer := newContentionEventTracer(tracing.SpanFromContext(ctx), w.clock)
 	// Make sure the contention time info is finalized when exiting the function.
 	defer tracer.notify(ctx, waitingState{kind: doneWaiting})
+	defer ash.SetWaitState(ctx, ash.LockWait).Restore()
 
 	for {
 		select {
@@ -143,6 +145,7 @@ func (w *lockTableWaiterImpl) WaitOn(
 			}
 			log.VEventf(ctx, 3, "lock wait-queue event: %s", state)
 			tracer.notify(ctx, state)
+			ash.SetContendedKey(ctx, state.key)
 			switch state.kind {
 			case waitFor:
 				// waitFor indicates that the request is waiting on another
@@ -7,6 +7,7 @@ package eventagg
 
 import (
 	"context"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -55,3 +56,55 @@ func (l *LogWriteConsumer[K, V]) onFlush(ctx context.Context, aggInfo AggInfo, m
 		log.Structured(ctx, l.EventMeta, KVLog)
 	}
 }
+
+// TopKConsumer is a flushConsumer which only passes on the aggregations
+// ranking in the top K according to at least one of a set of ranking
+// functions to an underlying flushConsumer. For example, with K=10 and
+// functions ranking statements by CPU time and by contention time, the
+// underlying flushConsumer is given the (up to 20) statements which are among
+// the 10 most CPU-intensive or among the 10 most contended.
+type TopKConsumer[K comparable, V any] struct {
+	kFn     func() int
+	rankFns []func(V) int64
+	next    flushConsumer[K, V]
+}
+
+var _ flushConsumer[int, any] = &TopKConsumer[int, any]{}
+
+// NewTopKConsumer returns a new *TopKConsumer[K, V] instance which passes on
+// the top kFn() aggregations according to each of the rankFns to next. Larger
+// ranks come first; aggregations ranking zero are never passed on.
+func NewTopKConsumer[K comparable, V any](
+	kFn func() int, rankFns []func(V) int64, next flushConsumer[K, V],
+) *TopKConsumer[K, V] {
+	return &TopKConsumer[K, V]{
+		kFn:     kFn,
+		rankFns: rankFns,
+		next:    next,
+	}
+}
+
+// onFlush passes the top K aggregations of the provided map to the underlying
+// flushConsumer.
+func (t *TopKConsumer[K, V]) onFlush(ctx context.Context, aggInfo AggInfo, m map[K]V) {
+	k := t.kFn()
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	top := make(map[K]V)
+	for _, rankFn := range t.rankFns {
+		sort.SliceStable(keys, func(i, j int) bool {
+			return rankFn(m[keys[i]]) > rankFn(m[keys[j]])
+		})
+		for i := 0; i < len(keys) && i < k; i++ {
+			if rankFn(m[keys[i]]) <= 0 {
+				break
+			}
+			top[keys[i]] = m[keys[i]]
+		}
+	}
+	if len(top) > 0 {
+		t.next.onFlush(ctx, aggInfo, top)
+	}
+}
@@ -63,6 +63,41 @@ func TestLogWriteConsumer(t *testing.T) {
 	}
 }
 
+func TestTopKConsumer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	type stats struct {
+		cpu        int64
+		contention int64
+	}
+	next := newTestFlushConsumer[string, stats]()
+	consumer := NewTopKConsumer[string, stats](
+		func() int { return 2 },
+		[]func(stats) int64{
+			func(s stats) int64 { return s.cpu },
+			func(s stats) int64 { return s.contention },
+		},
+		next,
+	)
+	flushed := map[string]stats{
+		"a": {cpu: 10, contention: 0},
+		"b": {cpu: 30, contention: 1},
+		"c": {cpu: 20, contention: 0},
+		"d": {cpu: 1, contention: 5},
+		"e": {cpu: 2, contention: 0},
+	}
+	go consumer.onFlush(ctx, AggInfo{}, flushed)
+	<-next.consumedCh()
+	// b and c are the top 2 by CPU, d and b the top 2 by contention.
+	require.Equal(t, map[string]stats{
+		"b": flushed["b"],
+		"c": flushed["c"],
+		"d": flushed["d"],
+	}, next.flushed)
+}
+
 type KVStructuredLogInterceptor[K any, V any] struct {
 	testState *testing.T
 
@@ -37,7 +37,10 @@ type MapReduceAggregator[E any, K comparable, Agg any] struct {
 	newFn   func() Agg
 	keyFn   func(e E) K
 	mergeFn func(e E, agg Agg)
-	mu      struct {
+	// enabledFn, if set, determines whether events are aggregated instead of
+	// the COCKROACH_ENABLE_STRUCTURED_EVENTS envvar.
+	enabledFn func() bool
+	mu        struct {
 		syncutil.Mutex
 		cache        map[K]Agg
 		flushTrigger FlushTrigger
@@ -66,13 +69,28 @@ func NewMapReduceAggregator[E any, K comparable, Agg any](
 	return m
 }
 
+// SetEnabledFn sets the function which determines whether events are
+// aggregated, typically based on a cluster setting, in lieu of the
+// COCKROACH_ENABLE_STRUCTURED_EVENTS envvar. It must be called before any
+// call to Add.
+func (m *MapReduceAggregator[E, K, Agg]) SetEnabledFn(enabledFn func() bool) {
+	m.enabledFn = enabledFn
+}
+
+func (m *MapReduceAggregator[E, K, Agg]) enabled() bool {
+	if m.enabledFn != nil {
+		return m.enabledFn()
+	}
+	return envEnableStructuredEvents
+}
+
 // Add processes the provided event e, aggregating it based on the provided keyFn and mergeFn.
 //
 // Add may trigger an asynchronous flush of the current batch of aggregated data *prior to*
 // consuming the given event, meaning the event will be aggregated into a fresh aggregation
 // window. This flush criteria is determined by the FlushTrigger provided at construction.
 func (m *MapReduceAggregator[E, K, Agg]) Add(ctx context.Context, e E) {
-	if !envEnableStructuredEvents {
+	if !m.enabled() {
 		return
 	}
 	k := m.keyFn(e)
@@ -92,6 +110,20 @@ func (m *MapReduceAggregator[E, K, Agg]) Add(ctx context.Context, e E) {
 	m.mergeFn(e, v)
 }
 
+// MaybeFlush triggers an asynchronous flush of the current batch of aggregated
+// data if the FlushTrigger provided at construction has been tripped.
+//
+// Since Add only checks the FlushTrigger when consuming an event, MaybeFlush
+// should be called periodically by users that want an aggregation window to
+// be flushed in a timely manner even if no new events are added.
+func (m *MapReduceAggregator[E, K, Agg]) MaybeFlush(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if shouldFlush, aggInfo := m.mu.flushTrigger.shouldFlush(); shouldFlush {
+		m.flushAsync(ctx, aggInfo, m.getAndResetCacheLocked())
+	}
+}
+
 // flushAsync triggers an asynchronous flush of the provided aggregate data, which resets the
 // underlying cache for a new aggregation window.
 //
@@ -97,6 +97,12 @@ func (a tenantAuthorizer) authorize(
 	case "/cockroach.server.serverpb.Status/ListLocalContentionEvents":
 		return a.authTenant(tenID)
 
+	case "/cockroach.server.serverpb.Status/ListActiveSessionHistory":
+		return a.authTenant(tenID)
+
+	case "/cockroach.server.serverpb.Status/ListLocalActiveSessionHistory":
+		return a.authTenant(tenID)
+
 	case "/cockroach.server.serverpb.Status/ListSessions":
 		return a.authTenant(tenID)
 
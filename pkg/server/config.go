@@ -185,6 +185,10 @@ type BaseConfig struct {
 	// InflightTraceDirName is the directory name for job traces.
 	InflightTraceDirName string
 
+	// ActiveSessionHistoryDirName is the directory name for the persisted
+	// samples of the active session history.
+	ActiveSessionHistoryDirName string
+
 	// DefaultZoneConfig is used to set the default zone config inside the server.
 	// It can be overridden during tests by setting the DefaultZoneConfigOverride
 	// server testing knob. Whatever is installed here is in turn used to
@@ -70,6 +70,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/pprofutil"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/startup"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -92,6 +93,9 @@ const (
 
 	graphiteIntervalKey = "external.graphite.interval"
 	maxGraphiteInterval = 15 * time.Minute
+
+	otlpMetricsIntervalKey = "external.otlp.metrics.interval"
+	maxOTLPMetricsInterval = 15 * time.Minute
 )
 
 // Metric names.
@@ -227,6 +231,60 @@ var (
 		settings.NonNegativeDurationWithMaximum(maxGraphiteInterval),
 		settings.WithPublic)
 
+	// otlpMetricsEndpoint is the address, if any, of the OpenTelemetry
+	// collector that server metrics are pushed to.
+	otlpMetricsEndpoint = settings.RegisterStringSetting(
+		settings.ApplicationLevel,
+		"external.otlp.metrics.endpoint",
+		"if nonempty, push server metrics to the OpenTelemetry collector at the specified "+
+			"host:port (for the grpc protocol) or URL (for the http protocol)",
+		"",
+		settings.WithPublic)
+
+	otlpMetricsProtocol = settings.RegisterEnumSetting(
+		settings.ApplicationLevel,
+		"external.otlp.metrics.protocol",
+		"the OTLP transport used to push metrics to the OpenTelemetry collector",
+		"grpc",
+		map[metric.OTLPProtocol]string{
+			metric.OTLPProtocolGRPC: "grpc",
+			metric.OTLPProtocolHTTP: "http",
+		},
+		settings.WithPublic)
+
+	// otlpMetricsInterval is how often metrics are pushed to the OpenTelemetry
+	// collector, if enabled.
+	otlpMetricsInterval = settings.RegisterDurationSetting(
+		settings.ApplicationLevel,
+		otlpMetricsIntervalKey,
+		"the interval at which metrics are pushed to the OpenTelemetry collector (if enabled)",
+		10*time.Second,
+		settings.NonNegativeDurationWithMaximum(maxOTLPMetricsInterval),
+		settings.WithPublic)
+
+	otlpMetricsBatchSize = settings.RegisterIntSetting(
+		settings.ApplicationLevel,
+		"external.otlp.metrics.batch_size",
+		"the maximum number of metrics sent to the OpenTelemetry collector in one request; "+
+			"0 sends all metrics in a single request",
+		1000,
+		settings.NonNegativeInt)
+
+	otlpMetricsTimeout = settings.RegisterDurationSetting(
+		settings.ApplicationLevel,
+		"external.otlp.metrics.timeout",
+		"the timeout of each request that pushes metrics to the OpenTelemetry collector",
+		10*time.Second,
+		settings.PositiveDuration)
+
+	otlpMetricsMaxRetries = settings.RegisterIntSetting(
+		settings.ApplicationLevel,
+		"external.otlp.metrics.max_retries",
+		"the number of times a failed request that pushes metrics to the OpenTelemetry "+
+			"collector is retried",
+		3,
+		settings.NonNegativeInt)
+
 	RedactServerTracesForSecondaryTenants = settings.RegisterBoolSetting(
 		settings.SystemOnly,
 		"server.secondary_tenants.redact_trace.enabled",
@@ -1447,6 +1505,56 @@ func startGraphiteStatsExporter(
 	})
 }
 
+func startOTLPMetricsExporter(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	recorder *status.MetricsRecorder,
+	st *cluster.Settings,
+) {
+	ctx = logtags.AddTag(ctx, "otlp metrics exporter", nil)
+	pm := metric.MakePrometheusExporter()
+	exporter := metric.MakeOTLPExporter(&pm)
+
+	_ = stopper.RunAsyncTask(ctx, "otlp-metrics-exporter", func(ctx context.Context) {
+		defer func() {
+			if err := exporter.Close(); err != nil {
+				log.Warningf(ctx, "error closing OTLP metrics exporter: %v", err)
+			}
+		}()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(otlpMetricsInterval.Get(&st.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+				endpoint := otlpMetricsEndpoint.Get(&st.SV)
+				if endpoint == "" {
+					continue
+				}
+				opts := metric.OTLPExporterOptions{
+					Endpoint:  endpoint,
+					Protocol:  otlpMetricsProtocol.Get(&st.SV),
+					BatchSize: int(otlpMetricsBatchSize.Get(&st.SV)),
+					Timeout:   otlpMetricsTimeout.Get(&st.SV),
+					Retry: retry.Options{
+						InitialBackoff: 100 * time.Millisecond,
+						MaxBackoff:     5 * time.Second,
+						Multiplier:     2,
+						MaxRetries:     int(otlpMetricsMaxRetries.Get(&st.SV)),
+						Closer:         stopper.ShouldQuiesce(),
+					},
+				}
+				if err := recorder.ExportToOTLP(ctx, &pm, &exporter, opts); err != nil {
+					log.Infof(ctx, "error pushing metrics to OpenTelemetry collector: %s", err)
+				}
+			}
+		}
+	})
+}
+
 // startWriteNodeStatus begins periodically persisting status summaries for the
 // node and its stores.
 func (n *Node) startWriteNodeStatus(frequency time.Duration) error {
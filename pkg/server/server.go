@@ -1902,6 +1902,17 @@ func (s *topLevelServer) PreStart(ctx context.Context) error {
 		}
 	})
 
+	// Export statistics to an OpenTelemetry collector, if enabled by
+	// configuration.
+	var otlpMetricsOnce sync.Once
+	otlpMetricsEndpoint.SetOnChange(&s.st.SV, func(context.Context) {
+		if otlpMetricsEndpoint.Get(&s.st.SV) != "" {
+			otlpMetricsOnce.Do(func() {
+				startOTLPMetricsExporter(workersCtx, s.stopper, s.recorder, s.st)
+			})
+		}
+	})
+
 	// Start the protected timestamp subsystem. Note that this needs to happen
 	// before the modeOperational switch below, as the protected timestamps
 	// subsystem will crash if accessed before being Started (and serving general
@@ -309,6 +309,16 @@ func makeSharedProcessTenantServerConfig(
 		baseCfg.InflightTraceDirName = traceDir
 	}
 
+	// Likewise for the persisted active session history, whose samples
+	// are specific to each tenant.
+	if kvServerCfg.BaseConfig.ActiveSessionHistoryDirName != "" {
+		ashDir := filepath.Join(kvServerCfg.BaseConfig.ActiveSessionHistoryDirName, "tenant-"+tenantID.String())
+		if err := os.MkdirAll(ashDir, 0755); err != nil {
+			return BaseConfig{}, SQLConfig{}, err
+		}
+		baseCfg.ActiveSessionHistoryDirName = ashDir
+	}
+
 	tempStorageCfg := base.InheritTempStorageConfig(ctx, st, kvServerCfg.SQLConfig.TempStorageConfig)
 	if !tempStorageCfg.InMemory {
 		useStore := tempStorageCfg.Spec
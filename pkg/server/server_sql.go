@@ -100,6 +100,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlevents"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlinstance"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlinstance/instancestorage"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
@@ -109,6 +110,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/insights"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilegecache"
 	tablemetadatacacheutil "github.com/cockroachdb/cockroach/pkg/sql/tablemetadatacache/util"
 	"github.com/cockroachdb/cockroach/pkg/storage"
@@ -172,6 +174,7 @@ type SQLServer struct {
 	statsRefresher                 *stats.Refresher
 	temporaryObjectCleaner         *sql.TemporaryObjectCleaner
 	stmtDiagnosticsRegistry        *stmtdiagnostics.Registry
+	statementHintsCache            *stmthints.Cache
 	sqlLivenessSessionID           sqlliveness.SessionID
 	sqlLivenessProvider            sqlliveness.Provider
 	sqlInstanceReader              *instancestorage.Reader
@@ -1229,6 +1232,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		sql.ValidateForwardIndexes,
 		sql.ValidateInvertedIndexes,
 		sql.ValidateConstraint,
+		sql.ValidateDomainConstraint,
 		sql.NewInternalSessionData,
 	)
 
@@ -1258,6 +1262,24 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	)
 	execCfg.StmtDiagnosticsRecorder = stmtDiagnosticsRegistry
 
+	statementHintsCache := stmthints.NewCache(cfg.internalDB, cfg.Settings)
+	execCfg.StatementHints = statementHintsCache
+
+	ashDir := cfg.ActiveSessionHistoryDirName
+	if ashDir != "" {
+		if err := os.MkdirAll(ashDir, 0755); err != nil {
+			log.Warningf(ctx, "cannot create active session history dir; samples will not be persisted: %v", err)
+			ashDir = ""
+		}
+	}
+	execCfg.ActiveSessionHistory = sql.NewActiveSessionHistory(
+		cfg.Settings, cfg.nodeIDContainer, cfg.sessionRegistry, ashDir,
+	)
+	execCfg.SQLEventAggregator = sqlevents.NewAggregator(
+		cfg.Settings, cfg.stopper, cfg.internalDB, cfg.nodeIDContainer, codec.TenantID, timeutil.DefaultTimeSource{},
+	)
+	pgServer.SQLServer.GetPlanRegressionDetector().SetPlanPinner(statementHintsCache.PinPlan)
+
 	var upgradeMgr *upgrademanager.Manager
 	{
 		var c upgrade.Cluster
@@ -1424,6 +1446,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		statsRefresher:                 statsRefresher,
 		temporaryObjectCleaner:         temporaryObjectCleaner,
 		stmtDiagnosticsRegistry:        stmtDiagnosticsRegistry,
+		statementHintsCache:            statementHintsCache,
 		sqlLivenessProvider:            cfg.sqlLivenessProvider,
 		sqlInstanceStorage:             cfg.sqlInstanceStorage,
 		sqlInstanceReader:              cfg.sqlInstanceReader,
@@ -1754,6 +1777,13 @@ func (s *SQLServer) preStart(
 		return err
 	}
 	s.stmtDiagnosticsRegistry.Start(ctx, stopper)
+	s.statementHintsCache.Start(ctx, stopper)
+	if err := s.execCfg.ActiveSessionHistory.Start(ctx, stopper); err != nil {
+		return err
+	}
+	if err := s.execCfg.SQLEventAggregator.Start(ctx, stopper); err != nil {
+		return err
+	}
 	if err := s.execCfg.TableStatsCache.Start(ctx, s.execCfg.Codec, s.execCfg.RangeFeedFactory); err != nil {
 		return err
 	}
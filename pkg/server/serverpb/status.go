@@ -22,6 +22,8 @@ type SQLStatusServer interface {
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
 	ListContentionEvents(context.Context, *ListContentionEventsRequest) (*ListContentionEventsResponse, error)
 	ListLocalContentionEvents(context.Context, *ListContentionEventsRequest) (*ListContentionEventsResponse, error)
+	ListActiveSessionHistory(context.Context, *ListActiveSessionHistoryRequest) (*ListActiveSessionHistoryResponse, error)
+	ListLocalActiveSessionHistory(context.Context, *ListActiveSessionHistoryRequest) (*ListActiveSessionHistoryResponse, error)
 	ResetSQLStats(context.Context, *ResetSQLStatsRequest) (*ResetSQLStatsResponse, error)
 	CombinedStatementStats(context.Context, *CombinedStatementsStatsRequest) (*StatementsResponse, error)
 	Statements(context.Context, *StatementsRequest) (*StatementsResponse, error)
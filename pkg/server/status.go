@@ -348,6 +348,25 @@ func (b *baseStatusServer) ListLocalContentionEvents(
 	}, nil
 }
 
+// ListLocalActiveSessionHistory returns the active session history samples
+// retained on this node.
+func (b *baseStatusServer) ListLocalActiveSessionHistory(
+	ctx context.Context, _ *serverpb.ListActiveSessionHistoryRequest,
+) (*serverpb.ListActiveSessionHistoryResponse, error) {
+	ctx = authserver.ForwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = b.AnnotateCtx(ctx)
+
+	if err := b.privilegeChecker.RequireViewActivityOrViewActivityRedactedPermission(ctx); err != nil {
+		// NB: not using srverrors.ServerError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	return &serverpb.ListActiveSessionHistoryResponse{
+		Samples: b.sqlServer.execCfg.ActiveSessionHistory.Samples(),
+	}, nil
+}
+
 func (b *baseStatusServer) ListLocalDistSQLFlows(
 	ctx context.Context, _ *serverpb.ListDistSQLFlowsRequest,
 ) (*serverpb.ListDistSQLFlowsResponse, error) {
@@ -3648,6 +3667,52 @@ func (s *statusServer) ListDistSQLFlows(
 	return &response, nil
 }
 
+// ListActiveSessionHistory returns the active session history samples
+// retained on all nodes in the cluster.
+func (s *statusServer) ListActiveSessionHistory(
+	ctx context.Context, req *serverpb.ListActiveSessionHistoryRequest,
+) (*serverpb.ListActiveSessionHistoryResponse, error) {
+	ctx = authserver.ForwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	// Check permissions early to avoid fan-out to all nodes.
+	if err := s.privilegeChecker.RequireViewActivityOrViewActivityRedactedPermission(ctx); err != nil {
+		// NB: not using srverrors.ServerError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	var response serverpb.ListActiveSessionHistoryResponse
+	nodeFn := func(ctx context.Context, statusClient serverpb.StatusClient, _ roachpb.NodeID) (*serverpb.ListActiveSessionHistoryResponse, error) {
+		resp, err := statusClient.ListLocalActiveSessionHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Errors) > 0 {
+			return nil, errors.Errorf("%s", resp.Errors[0].Message)
+		}
+		return resp, nil
+	}
+	responseFn := func(_ roachpb.NodeID, resp *serverpb.ListActiveSessionHistoryResponse) {
+		if resp == nil {
+			return
+		}
+		response.Samples = append(response.Samples, resp.Samples...)
+	}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		errResponse := serverpb.ListActivityError{NodeID: nodeID, Message: err.Error()}
+		response.Errors = append(response.Errors, errResponse)
+	}
+
+	if err := iterateNodes(ctx, s.serverIterator, s.stopper, "active session history list", noTimeout,
+		s.dialNode,
+		nodeFn,
+		responseFn, errorFn); err != nil {
+		return nil, srverrors.ServerError(ctx, err)
+	}
+	return &response, nil
+}
+
 func (s *statusServer) ListExecutionInsights(
 	ctx context.Context, req *serverpb.ListExecutionInsightsRequest,
 ) (*serverpb.ListExecutionInsightsResponse, error) {
@@ -372,6 +372,44 @@ func (mr *MetricsRecorder) ExportToGraphite(
 	return graphiteExporter.Push(ctx, endpoint)
 }
 
+// ExportToOTLP sends the current metric values to an OpenTelemetry collector
+// using the given exporter, which must scrape pm. The metrics are described by
+// resource attributes for the node ID, tenant and locality of this server.
+func (mr *MetricsRecorder) ExportToOTLP(
+	ctx context.Context,
+	pm *metric.PrometheusExporter,
+	exporter *metric.OTLPExporter,
+	opts metric.OTLPExporterOptions,
+) error {
+	mr.ScrapeIntoPrometheus(pm)
+	opts.ResourceAttributes = mr.otlpResourceAttributes()
+	return exporter.Push(ctx, opts)
+}
+
+// otlpResourceAttributes returns the OTLP resource attributes that identify
+// the server whose metrics are exported.
+func (mr *MetricsRecorder) otlpResourceAttributes() map[string]string {
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+	attrs := map[string]string{
+		"cockroachdb.tenant": catconstants.SystemTenantName,
+	}
+	if mr.tenantNameContainer != nil {
+		attrs["cockroachdb.tenant"] = mr.tenantNameContainer.String()
+	}
+	if nodeID := mr.mu.desc.NodeID; nodeID != 0 {
+		attrs["cockroachdb.node_id"] = strconv.Itoa(int(nodeID))
+		attrs["service.instance.id"] = strconv.Itoa(int(nodeID))
+	}
+	if locality := mr.mu.desc.Locality; len(locality.Tiers) > 0 {
+		attrs["cockroachdb.locality"] = locality.String()
+		for _, tier := range locality.Tiers {
+			attrs["cockroachdb.locality."+tier.Key] = tier.Value
+		}
+	}
+	return attrs
+}
+
 // GetTimeSeriesData serializes registered metrics for consumption by
 // CockroachDB's time series system. GetTimeSeriesData implements the DataSource
 // interface of the ts package.
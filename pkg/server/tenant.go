@@ -754,6 +754,15 @@ func (s *SQLServerWrapper) PreStart(ctx context.Context) error {
 				})
 			}
 		})
+		// Likewise for the OpenTelemetry collector.
+		var otlpMetricsOnce sync.Once
+		otlpMetricsEndpoint.SetOnChange(&s.ClusterSettings().SV, func(context.Context) {
+			if otlpMetricsEndpoint.Get(&s.ClusterSettings().SV) != "" {
+				otlpMetricsOnce.Do(func() {
+					startOTLPMetricsExporter(workersCtx, s.stopper, s.recorder, s.ClusterSettings())
+				})
+			}
+		})
 	}
 
 	if !s.sqlServer.cfg.DisableRuntimeStatsMonitor {
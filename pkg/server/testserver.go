@@ -281,6 +281,9 @@ func makeTestConfigFromParams(params base.TestServerArgs) Config {
 			if cfg.CPUProfileDirName == "" {
 				cfg.CPUProfileDirName = filepath.Join(storeSpec.Path, "logs", base.CPUProfileDir)
 			}
+			if cfg.ActiveSessionHistoryDirName == "" {
+				cfg.ActiveSessionHistoryDirName = filepath.Join(storeSpec.Path, "logs", base.ActiveSessionHistoryDir)
+			}
 		}
 	}
 	cfg.Stores = base.StoreSpecList{Specs: params.StoreSpecs}
@@ -0,0 +1,255 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/dumpstore"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/container/ring"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var activeSessionHistoryEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.enabled",
+	"if set, the active statements of the sessions connected to each node are "+
+		"sampled periodically to record what they are doing, see "+
+		"crdb_internal.active_session_history; statements are only sampled on "+
+		"their gateway node, not on the nodes running the remote parts of "+
+		"distributed plans",
+	true,
+	settings.WithPublic,
+)
+
+var activeSessionHistorySampleInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.sample_interval",
+	"the interval at which the active statements are sampled",
+	time.Second,
+	settings.DurationWithMinimum(10*time.Millisecond),
+	settings.WithPublic,
+)
+
+var activeSessionHistoryMaxSamples = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.max_samples",
+	"the number of samples of the active session history retained in memory "+
+		"on each node, for the sessions connected to that node; the oldest "+
+		"samples are discarded first",
+	50000,
+	settings.NonNegativeInt,
+	settings.WithPublic,
+)
+
+var activeSessionHistoryPersistenceEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.persistence.enabled",
+	"if set, the samples of the active session history are also written "+
+		"periodically to files in the active_session_history directory of the "+
+		"log directory of each node",
+	false,
+	settings.WithPublic,
+)
+
+var activeSessionHistoryPersistenceInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.persistence.interval",
+	"the interval at which the new samples of the active session history are "+
+		"written to a file, when persistence is enabled",
+	time.Minute,
+	settings.DurationWithMinimum(time.Second),
+)
+
+var activeSessionHistoryTotalDumpSizeLimit = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.active_session_history.persistence.total_size_limit",
+	"total size of the persisted active session history files to be kept; "+
+		"the oldest files are removed first",
+	256<<20, // 256MiB
+)
+
+const (
+	activeSessionHistoryFilePrefix = "active_session_history"
+	activeSessionHistoryTimeFormat = "2006-01-02T15_04_05.000"
+)
+
+// ActiveSessionHistory samples periodically the active statements of the
+// sessions on this node, recording what each statement is doing (see
+// ash.WaitState). The samples are retained in a ring buffer and optionally
+// written to files, so that brief incidents can be diagnosed after the fact.
+type ActiveSessionHistory struct {
+	st       *cluster.Settings
+	nodeID   *base.SQLIDContainer
+	registry *SessionRegistry
+	// store is nil if the samples cannot be persisted.
+	store *dumpstore.DumpStore
+
+	mu struct {
+		syncutil.Mutex
+		samples ring.Buffer[serverpb.ActiveSessionHistorySample]
+		// numUnpersisted is the number of samples at the end of samples which
+		// haven't been persisted yet.
+		numUnpersisted int
+	}
+}
+
+// NewActiveSessionHistory returns a new ActiveSessionHistory sampling the
+// sessions of the given registry. If dir is not empty, the samples are
+// persisted to files in that directory when enabled by the cluster setting.
+func NewActiveSessionHistory(
+	st *cluster.Settings, nodeID *base.SQLIDContainer, registry *SessionRegistry, dir string,
+) *ActiveSessionHistory {
+	h := &ActiveSessionHistory{
+		st:       st,
+		nodeID:   nodeID,
+		registry: registry,
+	}
+	if dir != "" {
+		h.store = dumpstore.NewStore(dir, activeSessionHistoryTotalDumpSizeLimit, st)
+	}
+	return h
+}
+
+// Start starts the sampling loop.
+func (h *ActiveSessionHistory) Start(ctx context.Context, stopper *stop.Stopper) error {
+	return stopper.RunAsyncTask(ctx, "active-session-history", func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		lastPersisted := timeutil.Now()
+		for {
+			timer.Reset(activeSessionHistorySampleInterval.Get(&h.st.SV))
+			select {
+			case <-timer.C:
+				timer.Read = true
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+			if !activeSessionHistoryEnabled.Get(&h.st.SV) {
+				continue
+			}
+			now := timeutil.Now()
+			h.sample(now)
+			if h.store != nil && activeSessionHistoryPersistenceEnabled.Get(&h.st.SV) &&
+				now.Sub(lastPersisted) >= activeSessionHistoryPersistenceInterval.Get(&h.st.SV) {
+				lastPersisted = now
+				if err := h.persist(ctx, now); err != nil {
+					log.Warningf(ctx, "unable to persist the active session history: %v", err)
+				}
+			}
+		}
+	})
+}
+
+// sample adds a sample for each active statement of the node.
+func (h *ActiveSessionHistory) sample(now time.Time) {
+	var samples []serverpb.ActiveSessionHistorySample
+	nodeID := roachpb.NodeID(h.nodeID.SQLInstanceID())
+	for _, s := range h.registry.getSessions() {
+		s.sampleActiveQueries(now, nodeID, func(sample serverpb.ActiveSessionHistorySample) {
+			samples = append(samples, sample)
+		})
+	}
+	h.add(samples, int(activeSessionHistoryMaxSamples.Get(&h.st.SV)))
+}
+
+func (h *ActiveSessionHistory) add(
+	samples []serverpb.ActiveSessionHistorySample, maxSamples int,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, s := range samples {
+		h.mu.samples.Push(s)
+	}
+	h.mu.numUnpersisted += len(samples)
+	if n := h.mu.samples.Length() - maxSamples; n > 0 {
+		h.mu.samples.Pop(n)
+	}
+	h.mu.numUnpersisted = min(h.mu.numUnpersisted, h.mu.samples.Length())
+}
+
+// Samples returns the samples retained in memory, ordered by sample time.
+func (h *ActiveSessionHistory) Samples() []serverpb.ActiveSessionHistorySample {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	samples := make([]serverpb.ActiveSessionHistorySample, h.mu.samples.Length())
+	for i := range samples {
+		samples[i] = h.mu.samples.At(i)
+	}
+	return samples
+}
+
+// persist writes the samples that haven't been persisted yet to a new file,
+// as gzipped JSON with one sample per line, and removes the oldest files
+// beyond the size limit.
+func (h *ActiveSessionHistory) persist(ctx context.Context, now time.Time) (retErr error) {
+	var samples []serverpb.ActiveSessionHistorySample
+	func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		n := h.mu.samples.Length()
+		for i := n - h.mu.numUnpersisted; i < n; i++ {
+			samples = append(samples, h.mu.samples.At(i))
+		}
+		h.mu.numUnpersisted = 0
+	}()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	name := fmt.Sprintf("%s.%s.json.gz", activeSessionHistoryFilePrefix, now.Format(activeSessionHistoryTimeFormat))
+	f, err := os.Create(h.store.GetFullPath(name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = errors.CombineErrors(retErr, f.Close())
+	}()
+	w := gzip.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := range samples {
+		if err := enc.Encode(&samples[i]); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	h.store.GC(ctx, now, h)
+	return nil
+}
+
+// PreFilter is part of the dumpstore.Dumper interface.
+func (h *ActiveSessionHistory) PreFilter(
+	ctx context.Context, files []os.FileInfo, cleanupFn func(fileName string) error,
+) (preserved map[int]bool, err error) {
+	return nil, nil
+}
+
+// CheckOwnsFile is part of the dumpstore.Dumper interface.
+func (h *ActiveSessionHistory) CheckOwnsFile(ctx context.Context, fi os.FileInfo) bool {
+	return strings.HasPrefix(fi.Name(), activeSessionHistoryFilePrefix)
+}
+
+var _ dumpstore.Dumper = (*ActiveSessionHistory)(nil)
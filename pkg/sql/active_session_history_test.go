@@ -0,0 +1,90 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveSessionHistory(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	dir := t.TempDir()
+	h := NewActiveSessionHistory(st, nil /* nodeID */, nil /* registry */, dir)
+
+	makeSamples := func(fingerprints ...string) []serverpb.ActiveSessionHistorySample {
+		samples := make([]serverpb.ActiveSessionHistorySample, len(fingerprints))
+		for i, f := range fingerprints {
+			samples[i] = serverpb.ActiveSessionHistorySample{Fingerprint: f, WaitState: "CPU"}
+		}
+		return samples
+	}
+	fingerprints := func(samples []serverpb.ActiveSessionHistorySample) []string {
+		res := make([]string, len(samples))
+		for i := range samples {
+			res[i] = samples[i].Fingerprint
+		}
+		return res
+	}
+	readFile := func(name string) []string {
+		f, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer f.Close()
+		r, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		var res []string
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			var s serverpb.ActiveSessionHistorySample
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &s))
+			res = append(res, s.Fingerprint)
+		}
+		require.NoError(t, scanner.Err())
+		return res
+	}
+
+	// The oldest samples are discarded beyond the limit.
+	h.add(makeSamples("a", "b"), 3 /* maxSamples */)
+	h.add(makeSamples("c", "d"), 3 /* maxSamples */)
+	require.Equal(t, []string{"b", "c", "d"}, fingerprints(h.Samples()))
+
+	// Only the samples which haven't been persisted yet are written.
+	t1 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, h.persist(ctx, t1))
+	require.Equal(t, []string{"b", "c", "d"}, readFile("active_session_history.2024-01-02T03_04_05.000.json.gz"))
+
+	h.add(makeSamples("e"), 3 /* maxSamples */)
+	t2 := t1.Add(time.Minute)
+	require.NoError(t, h.persist(ctx, t2))
+	require.Equal(t, []string{"e"}, readFile("active_session_history.2024-01-02T03_05_05.000.json.gz"))
+
+	// Nothing is written if there are no new samples.
+	require.NoError(t, h.persist(ctx, t2.Add(time.Minute)))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// The samples are retained in memory regardless of persistence.
+	require.Equal(t, []string{"c", "d", "e"}, fingerprints(h.Samples()))
+	var nilHistory *ActiveSessionHistory
+	require.Empty(t, nilHistory.Samples())
+}
@@ -144,6 +144,9 @@ func (p *planner) addColumnImpl(
 		if err != nil {
 			return err
 		}
+		if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(serializedExpr, tree.StoredComputedColumnExpr); err != nil {
+			return err
+		}
 		col.ComputeExpr = &serializedExpr
 	}
 
@@ -0,0 +1,264 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	zeroInputPlanNode
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+// AlterDomain adds or drops a domain's CHECK and NOT NULL constraints.
+// Privileges: ownership of the domain.
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	var err error
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainAddConstraint:
+		err = params.p.addDomainConstraint(params.ctx, n.desc, &t.Constraint)
+	case *tree.AlterDomainDropConstraint:
+		var dropped bool
+		dropped, err = params.p.dropDomainConstraint(params.ctx, n.desc, t)
+		if err == nil && !dropped {
+			return nil
+		}
+	case *tree.AlterDomainSetNotNull:
+		if n.desc.Domain.NotNull {
+			return nil
+		}
+		if err = params.p.validateDomainConstraint(params.ctx, n.desc, "" /* checkExpr */); err == nil {
+			n.desc.Domain.NotNull = true
+		}
+	case *tree.AlterDomainDropNotNull:
+		if !n.desc.Domain.NotNull {
+			return nil
+		}
+		n.desc.Domain.NotNull = false
+	default:
+		err = errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+	if err != nil {
+		return err
+	}
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx, n.desc.ID, &eventpb.AlterType{
+		TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+	})
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
+
+// addDomainConstraint adds a CHECK constraint to the domain after verifying
+// that the values stored in table columns of the domain's type satisfy it.
+func (p *planner) addDomainConstraint(
+	ctx context.Context, desc *typedesc.Mutable, c *tree.DomainConstraint,
+) error {
+	if c.Check == nil {
+		return errors.AssertionFailedf("expected a CHECK constraint")
+	}
+	domain := desc.Domain
+	checkExpr, err := schemaexpr.ValidateDomainCheckExpr(ctx, c.Check, domain.BaseType, &p.semaCtx)
+	if err != nil {
+		return err
+	}
+	name := string(c.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s_check", desc.Name)
+		for i := 1; findDomainConstraintByName(desc, name) != -1; i++ {
+			name = fmt.Sprintf("%s_check%d", desc.Name, i)
+		}
+	} else if findDomainConstraintByName(desc, name) != -1 {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, desc.Name)
+	}
+	if err := p.validateDomainConstraint(ctx, desc, checkExpr); err != nil {
+		return err
+	}
+	domain.Constraints = append(domain.Constraints, descpb.TypeDescriptor_Domain_Constraint{
+		ConstraintID: domain.NextConstraintID,
+		Name:         name,
+		Expr:         checkExpr,
+		Validity:     descpb.ConstraintValidity_Validated,
+	})
+	domain.NextConstraintID++
+	return nil
+}
+
+// validateDomainConstraint checks, in the current transaction, that every
+// value stored in a table column of the domain type satisfies the serialized
+// CHECK expression, or is not NULL if checkExpr is empty.
+func (p *planner) validateDomainConstraint(
+	ctx context.Context, desc *typedesc.Mutable, checkExpr string,
+) error {
+	return validateDomainValues(
+		ctx, p.InternalSQLTxn(), desc, checkExpr, sessiondata.NodeUserSessionDataOverride,
+	)
+}
+
+// ValidateDomainConstraint checks that every value stored in a table column
+// of the domain type satisfies the serialized CHECK expression, or is not NULL
+// if checkExpr is empty. The tables are scanned at a historical timestamp. It
+// is used by the declarative schema changer.
+func ValidateDomainConstraint(
+	ctx context.Context,
+	domain catalog.TypeDescriptor,
+	checkExpr string,
+	runHistoricalTxn descs.HistoricalInternalExecTxnRunner,
+	execOverride sessiondata.InternalExecutorOverride,
+) error {
+	return runHistoricalTxn.Exec(ctx, func(ctx context.Context, txn descs.Txn) error {
+		return validateDomainValues(ctx, txn, domain, checkExpr, execOverride)
+	})
+}
+
+// validateDomainValues implements validateDomainConstraint and
+// ValidateDomainConstraint. Columns of arrays of the domain do not need to be
+// checked because they cannot be created (see colinfo.ValidateColumnDefType).
+func validateDomainValues(
+	ctx context.Context,
+	txn descs.Txn,
+	domain catalog.TypeDescriptor,
+	checkExpr string,
+	execOverride sessiondata.InternalExecutorOverride,
+) error {
+	predicate := checkExpr
+	if predicate == "" {
+		predicate = string(schemaexpr.DomainValueName) + " IS NOT NULL"
+	}
+	domainOID := catid.TypeIDToOID(domain.GetID())
+	baseType := domain.AsDomainTypeDescriptor().DomainBaseType()
+	for i := 0; i < domain.NumReferencingDescriptors(); i++ {
+		d, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutDropped().Get().Desc(
+			ctx, domain.GetReferencingDescriptorID(i),
+		)
+		if err != nil {
+			return err
+		}
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() || tbl.IsSequence() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.GetType().Oid() != domainOID {
+				continue
+			}
+			queryStr := fmt.Sprintf(
+				`SELECT value FROM (SELECT %s::%s AS value FROM [%d AS t]) WHERE NOT (%s) LIMIT 1`,
+				tree.NameString(col.GetName()), baseType.SQLString(), tbl.GetID(), predicate,
+			)
+			log.Infof(ctx, "validating domain constraint with query %q", queryStr)
+			row, err := txn.QueryRowEx(
+				ctx,
+				"validate domain constraint",
+				txn.KV(),
+				execOverride,
+				queryStr,
+			)
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				continue
+			}
+			if checkExpr == "" {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values",
+					col.GetName(), tbl.GetName())
+			}
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+	}
+	return nil
+}
+
+// dropDomainConstraint removes a CHECK constraint from the domain. It returns
+// false if the constraint does not exist and IF EXISTS was specified.
+func (p *planner) dropDomainConstraint(
+	ctx context.Context, desc *typedesc.Mutable, n *tree.AlterDomainDropConstraint,
+) (dropped bool, _ error) {
+	idx := findDomainConstraintByName(desc, string(n.Constraint))
+	if idx == -1 {
+		if n.IfExists {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"constraint %q of domain %q does not exist, skipping", n.Constraint, desc.Name))
+			return false, nil
+		}
+		return false, pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q of domain %q does not exist", n.Constraint, desc.Name)
+	}
+	constraints := desc.Domain.Constraints
+	desc.Domain.Constraints = append(constraints[:idx:idx], constraints[idx+1:]...)
+	return true, nil
+}
+
+// findDomainConstraintByName returns the index of the domain constraint with
+// the given name, or -1 if there is none.
+func findDomainConstraintByName(desc *typedesc.Mutable, name string) int {
+	for i := range desc.Domain.Constraints {
+		if desc.Domain.Constraints[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
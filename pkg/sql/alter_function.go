@@ -97,6 +97,9 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	if err := setFuncOptions(params, fnDesc, n.n.Options); err != nil {
 		return err
 	}
+	if err := params.p.validateFunctionVolatilityForReferences(params.ctx, fnDesc); err != nil {
+		return err
+	}
 
 	if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
 		return err
@@ -538,6 +541,9 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		if tree.IsInParamClass(class) {
 			ret.ArgTypes = append(ret.ArgTypes, param.Type)
 		}
+		if class == tree.RoutineParamVariadic {
+			ret.Variadic = true
+		}
 		if class == tree.RoutineParamOut {
 			ret.OutParamOrdinals = append(ret.OutParamOrdinals, int32(paramIdx))
 			ret.OutParamTypes = append(ret.OutParamTypes, param.Type)
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -47,11 +48,15 @@ type alterPrimaryKeyLocalitySwap struct {
 	newColumnName *tree.Name
 }
 
+// AlterPrimaryKey queues up a primary key swap on the table. If
+// newPartitionAllBy is set, the primary index and all secondary indexes are
+// rewritten with the given PARTITION ALL BY partitioning.
 func (p *planner) AlterPrimaryKey(
 	ctx context.Context,
 	tableDesc *tabledesc.Mutable,
 	alterPKNode tree.AlterTableAlterPrimaryKey,
 	alterPrimaryKeyLocalitySwap *alterPrimaryKeyLocalitySwap,
+	newPartitionAllBy *tree.PartitionBy,
 ) error {
 	if err := paramparse.ValidateUniqueConstraintParams(
 		alterPKNode.StorageParams,
@@ -81,6 +86,19 @@ func (p *planner) AlterPrimaryKey(
 		}
 	}
 
+	// The secondary indexes are rebuilt by a backfill, which cannot evaluate
+	// user-defined functions in their predicates.
+	for _, idx := range tableDesc.PublicNonPrimaryIndexes() {
+		if !idx.IsPartial() {
+			continue
+		}
+		if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(
+			idx.GetPredicate(), tree.IndexPredicateExpr,
+		); err != nil {
+			return err
+		}
+	}
+
 	// Ensure that other schema changes on this table are not currently
 	// executing, and that other schema changes have not been performed
 	// in the current transaction.
@@ -149,7 +167,9 @@ func (p *planner) AlterPrimaryKey(
 	// primary index, which would mean nothing needs to be modified
 	// here.
 	{
-		requiresIndexChange, err := p.shouldCreateIndexes(ctx, tableDesc, &alterPKNode, alterPrimaryKeyLocalitySwap)
+		requiresIndexChange, err := p.shouldCreateIndexes(
+			ctx, tableDesc, &alterPKNode, alterPrimaryKeyLocalitySwap, newPartitionAllBy,
+		)
 		if err != nil {
 			return err
 		}
@@ -321,6 +341,13 @@ func (p *planner) AlterPrimaryKey(
 				localityConfigSwap.NewLocalityConfig.Locality,
 			)
 		}
+	} else if newPartitionAllBy != nil {
+		// The implicitly partitioned columns change, so the old partitioning is
+		// dropped from every index before the new one is added.
+		dropPartitionAllBy = true
+		isNewPartitionAllBy = true
+		allowImplicitPartitioning = true
+		partitionAllBy = newPartitionAllBy
 	} else if tableDesc.IsPartitionAllBy() {
 		allowImplicitPartitioning = true
 		partitionAllBy, err = partitionByFromTableDesc(p.ExecCfg().Codec, tableDesc)
@@ -371,9 +398,9 @@ func (p *planner) AlterPrimaryKey(
 	// We have to rewrite all indexes that either:
 	// * depend on uniqueness from the old primary key (inverted, non-unique, or unique with nulls).
 	// * don't store or index all columns in the new primary key.
-	// * is affected by a locality config swap.
+	// * is affected by a locality config swap or a PARTITION ALL BY change.
 	shouldRewriteIndex := func(idx catalog.Index) (bool, error) {
-		if alterPrimaryKeyLocalitySwap != nil {
+		if alterPrimaryKeyLocalitySwap != nil || newPartitionAllBy != nil {
 			return true, nil
 		}
 		colIDs := idx.CollectKeyColumnIDs()
@@ -529,7 +556,8 @@ func (p *planner) AlterPrimaryKey(
 
 	// Create a new index that indexes everything the old primary index
 	// does, but doesn't store anything.
-	if shouldCopyPrimaryKey(tableDesc, newPrimaryIndexDesc, alterPrimaryKeyLocalitySwap) {
+	if newPartitionAllBy == nil &&
+		shouldCopyPrimaryKey(tableDesc, newPrimaryIndexDesc, alterPrimaryKeyLocalitySwap) {
 		newUniqueIdx := tableDesc.GetPrimaryIndex().IndexDescDeepCopy()
 		// Clear the following fields so that they get generated by AllocateIDs.
 		newUniqueIdx.ID = 0
@@ -627,9 +655,16 @@ func (p *planner) shouldCreateIndexes(
 	desc *tabledesc.Mutable,
 	alterPKNode *tree.AlterTableAlterPrimaryKey,
 	alterPrimaryKeyLocalitySwap *alterPrimaryKeyLocalitySwap,
+	newPartitionAllBy *tree.PartitionBy,
 ) (requiresIndexChange bool, err error) {
 	oldPK := desc.GetPrimaryIndex()
 
+	// A change to the implicitly partitioned columns always rewrites the
+	// indexes.
+	if newPartitionAllBy != nil {
+		return true, nil
+	}
+
 	// Validate if basic properties between the two match.
 	if oldPK.NumKeyColumns() != len(alterPKNode.Columns) ||
 		oldPK.IsSharded() != (alterPKNode.Sharded != nil) {
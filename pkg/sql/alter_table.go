@@ -10,6 +10,7 @@ import (
 	"context"
 	gojson "encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/auditlogging/auditevents"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
@@ -41,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/tablestorageparam"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -244,6 +247,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 						n.tableDesc,
 						*alterPK,
 						nil, /* localityConfigSwap */
+						nil, /* newPartitionAllBy */
 					); err != nil {
 						return err
 					}
@@ -316,6 +320,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					if err != nil {
 						return err
 					}
+					if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(expr, tree.IndexPredicateExpr); err != nil {
+						return err
+					}
 					idx.Predicate = expr
 				}
 
@@ -362,6 +369,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExcludeConstraintTableDef:
+				if err := addExclusionTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -493,6 +514,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 				n.tableDesc,
 				*t,
 				nil, /* localityConfigSwap */
+				nil, /* newPartitionAllBy */
 			); err != nil {
 				return err
 			}
@@ -645,17 +667,28 @@ func (n *alterTableNode) startExec(params runParams) error {
 			descriptorChanged = true
 
 		case *tree.AlterTablePartitionByTable:
-			if t.All {
-				return unimplemented.NewWithIssue(58736, "PARTITION ALL BY not yet implemented")
-			}
 			if n.tableDesc.GetLocalityConfig() != nil {
 				return pgerror.Newf(
 					pgcode.FeatureNotSupported,
 					"cannot set PARTITION BY on a table in a multi-region enabled database",
 				)
 			}
+			if t.All {
+				changed, err := params.p.alterTablePartitionAllBy(params, n.tableDesc, t.PartitionBy)
+				if err != nil {
+					return err
+				}
+				descriptorChanged = descriptorChanged || changed
+				continue
+			}
 			if n.tableDesc.IsPartitionAllBy() {
-				return unimplemented.NewWithIssue(58736, "changing partition of table with PARTITION ALL BY not yet implemented")
+				return errors.WithHint(
+					pgerror.New(
+						pgcode.FeatureNotSupported,
+						"cannot set PARTITION BY on a table with PARTITION ALL BY",
+					),
+					"use ALTER TABLE ... PARTITION ALL BY to change the partitioning of all indexes",
+				)
 			}
 			if n.tableDesc.GetPrimaryIndex().IsSharded() {
 				return pgerror.New(
@@ -926,6 +959,220 @@ func (p *planner) setAuditMode(
 	return desc.SetAuditMode(auditMode)
 }
 
+// alterTablePartitionAllBy applies a PARTITION ALL BY clause to the primary
+// index and all secondary indexes of the table. If the implicitly partitioned
+// columns of the indexes stay the same, only the partitioning metadata is
+// changed. Otherwise, all the indexes are rewritten with a primary key swap.
+// The zone configs of partitions that keep their name are carried over, and
+// those of removed partitions are deleted. Returns false iff the partitioning
+// was unchanged.
+func (p *planner) alterTablePartitionAllBy(
+	params runParams, tableDesc *tabledesc.Mutable, partBy *tree.PartitionBy,
+) (bool, error) {
+	if !p.SessionData().ImplicitColumnPartitioningEnabled {
+		return false, errors.WithHint(
+			pgerror.New(
+				pgcode.ExperimentalFeature,
+				"PARTITION ALL BY LIST/RANGE is currently experimental",
+			),
+			"to enable, use SET experimental_enable_implicit_column_partitioning = true",
+		)
+	}
+	if tableDesc.GetPrimaryIndex().IsSharded() {
+		return false, pgerror.New(
+			pgcode.FeatureNotSupported,
+			"cannot set explicit partitioning with PARTITION ALL BY on hash sharded primary key",
+		)
+	}
+	for _, mut := range tableDesc.AllMutations() {
+		if mut.AsIndex() != nil || mut.AsPrimaryKeySwap() != nil {
+			return false, pgerror.Newf(
+				pgcode.ObjectNotInPrerequisiteState,
+				"cannot set PARTITION ALL BY on table %q while an index is being added or dropped",
+				tableDesc.GetName(),
+			)
+		}
+	}
+
+	activeIndexes := tableDesc.ActiveIndexes()
+	newIndexDescs := make([]descpb.IndexDescriptor, len(activeIndexes))
+	requiresRewrite := false
+	for i, idx := range activeIndexes {
+		newIndexDescs[i] = idx.IndexDescDeepCopy()
+		newImplicitCols, newPartitioning, err := CreatePartitioning(
+			params.ctx, p.ExecCfg().Settings,
+			params.EvalContext(),
+			tableDesc,
+			newIndexDescs[i],
+			partBy,
+			nil,  /* allowedNewColumnNames */
+			true, /* allowImplicitPartitioning */
+		)
+		if err != nil {
+			return false, err
+		}
+		if !sameImplicitPartitioningColumns(idx, newImplicitCols) {
+			requiresRewrite = true
+		}
+		tabledesc.UpdateIndexPartitioning(&newIndexDescs[i], idx.Primary(), newImplicitCols, newPartitioning)
+	}
+	if requiresRewrite {
+		return true, p.alterTablePartitionAllByWithRewrite(params, tableDesc, partBy)
+	}
+
+	descriptorChanged := !tableDesc.PartitionAllBy
+	tableDesc.PartitionAllBy = true
+	oldPartitionings := make(map[descpb.IndexID]catalog.Partitioning)
+	for i, idx := range activeIndexes {
+		if idx.GetPartitioning().PartitioningDesc().Equal(newIndexDescs[i].Partitioning) {
+			continue
+		}
+		descriptorChanged = true
+		oldPartitionings[idx.GetID()] = idx.GetPartitioning().DeepCopy()
+		if idx.Primary() {
+			tableDesc.SetPrimaryIndex(newIndexDescs[i])
+		} else {
+			tableDesc.SetPublicNonPrimaryIndex(idx.Ordinal(), newIndexDescs[i])
+		}
+	}
+
+	// Delete the zone configs of removed partitions once all the indexes are
+	// repartitioned, so that the subzone spans match the new partitioning.
+	for _, idx := range tableDesc.ActiveIndexes() {
+		oldPartitioning, ok := oldPartitionings[idx.GetID()]
+		if !ok {
+			continue
+		}
+		if err := deleteRemovedPartitionZoneConfigs(
+			params.ctx,
+			p.InternalSQLTxn(),
+			tableDesc,
+			idx.GetID(),
+			oldPartitioning,
+			idx.GetPartitioning(),
+			params.extendedEvalCtx.ExecCfg,
+			params.extendedEvalCtx.Tracing.KVTracingEnabled(),
+		); err != nil {
+			return false, err
+		}
+	}
+	return descriptorChanged, nil
+}
+
+// alterTablePartitionAllByWithRewrite applies a PARTITION ALL BY clause which
+// changes the implicitly partitioned columns of the indexes. The primary index
+// keeps its explicit columns, and it is rewritten along with all secondary
+// indexes by a primary key swap, in the same way as a change to REGIONAL BY
+// ROW. The zone configs of the partitions which keep their name are copied to
+// the new indexes when the swap completes.
+func (p *planner) alterTablePartitionAllByWithRewrite(
+	params runParams, tableDesc *tabledesc.Mutable, partBy *tree.PartitionBy,
+) error {
+	// Until the swap completes, the existing indexes must satisfy the
+	// PARTITION ALL BY invariant on their own.
+	if !tableDesc.PartitionAllBy {
+		primaryIndex := tableDesc.GetPrimaryIndex()
+		for _, idx := range tableDesc.PublicNonPrimaryIndexes() {
+			if !samePartitioningColumns(primaryIndex, idx) {
+				return unimplemented.NewWithIssuef(
+					58731,
+					"cannot ALTER TABLE PARTITION ALL BY and change the implicitly partitioned "+
+						"columns while index %q is partitioned differently from the primary index",
+					idx.GetName(),
+				)
+			}
+		}
+		tableDesc.PartitionAllBy = true
+	}
+
+	primaryIndex := tableDesc.GetPrimaryIndex()
+	explicitColStart := primaryIndex.ExplicitColumnStartIdx()
+	cols := make([]tree.IndexElem, 0, primaryIndex.NumKeyColumns()-explicitColStart)
+	for i := explicitColStart; i < primaryIndex.NumKeyColumns(); i++ {
+		elem := tree.IndexElem{Column: tree.Name(primaryIndex.GetKeyColumnName(i))}
+		switch dir := primaryIndex.GetKeyColumnDirection(i); dir {
+		case catenumpb.IndexColumn_ASC:
+			elem.Direction = tree.Ascending
+		case catenumpb.IndexColumn_DESC:
+			elem.Direction = tree.Descending
+		default:
+			return errors.AssertionFailedf("unknown direction: %v", dir)
+		}
+		cols = append(cols, elem)
+	}
+	oldPartitionings := make(map[descpb.IndexID]catalog.Partitioning)
+	for _, idx := range tableDesc.ActiveIndexes() {
+		oldPartitionings[idx.GetID()] = idx.GetPartitioning().DeepCopy()
+	}
+	if err := p.AlterPrimaryKey(
+		params.ctx,
+		tableDesc,
+		tree.AlterTableAlterPrimaryKey{
+			Name:    tree.Name(primaryIndex.GetName()),
+			Columns: cols,
+		},
+		nil, /* localityConfigSwap */
+		partBy,
+	); err != nil {
+		return err
+	}
+
+	// All the new indexes share the partitioning of the new primary index.
+	var newPrimaryIndexID descpb.IndexID
+	for _, mut := range tableDesc.AllMutations() {
+		if pkSwap := mut.AsPrimaryKeySwap(); pkSwap != nil {
+			newPrimaryIndexID = pkSwap.PrimaryKeySwapDesc().NewPrimaryIndexId
+		}
+	}
+	newPrimaryIndex, err := catalog.MustFindIndexByID(tableDesc, newPrimaryIndexID)
+	if err != nil {
+		return err
+	}
+	for indexID, oldPartitioning := range oldPartitionings {
+		if err := deleteRemovedPartitionZoneConfigs(
+			params.ctx,
+			p.InternalSQLTxn(),
+			tableDesc,
+			indexID,
+			oldPartitioning,
+			newPrimaryIndex.GetPartitioning(),
+			params.extendedEvalCtx.ExecCfg,
+			params.extendedEvalCtx.Tracing.KVTracingEnabled(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameImplicitPartitioningColumns returns whether the given implicitly
+// partitioned columns are the ones the index is already partitioned by.
+func sameImplicitPartitioningColumns(idx catalog.Index, implicitCols []catalog.Column) bool {
+	if idx.GetPartitioning().NumImplicitColumns() != len(implicitCols) {
+		return false
+	}
+	for i, col := range implicitCols {
+		if idx.GetKeyColumnID(i) != col.GetID() {
+			return false
+		}
+	}
+	return true
+}
+
+// samePartitioningColumns returns whether the two indexes are partitioned by
+// the same columns.
+func samePartitioningColumns(a, b catalog.Index) bool {
+	if a.PartitioningColumnCount() != b.PartitioningColumnCount() {
+		return false
+	}
+	for i := 0; i < a.PartitioningColumnCount(); i++ {
+		if a.GetKeyColumnID(i) != b.GetKeyColumnID(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *alterTableNode) Next(runParams) (bool, error) { return false, nil }
 func (n *alterTableNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *alterTableNode) Close(context.Context)        {}
@@ -1698,6 +1945,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -2094,6 +2344,21 @@ func dropColumnImpl(
 	return droppedViews, validateDescriptor(params.ctx, params.p, tableDesc)
 }
 
+// checkTTLArchivePrivilege checks that the user may use the external
+// connection, if any, to which the TTL job archives deleted rows.
+func (p *planner) checkTTLArchivePrivilege(ctx context.Context, ttl *catpb.RowLevelTTL) error {
+	if ttl.ArchiveURI == "" {
+		return nil
+	}
+	u, err := url.Parse(ttl.ArchiveURI)
+	if err != nil {
+		return err
+	}
+	return p.CheckPrivilege(ctx, &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: u.Host,
+	}, privilege.USAGE)
+}
+
 // handleTTLStorageParamChange changes TTL storage parameters. descriptorChanged
 // must be true if the descriptor was modified directly. The caller
 // (alterTableNode), has a separate check to see if any mutations were
@@ -2104,6 +2369,12 @@ func handleTTLStorageParamChange(
 
 	before := tableDesc.GetRowLevelTTL()
 
+	if after != nil && (before == nil || before.ArchiveURI != after.ArchiveURI) {
+		if err := params.p.checkTTLArchivePrivilege(params.ctx, after); err != nil {
+			return false, err
+		}
+	}
+
 	// Update existing config.
 	if before != nil && after != nil {
 
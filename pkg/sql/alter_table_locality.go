@@ -430,6 +430,7 @@ func (n *alterTableSetLocalityNode) alterTableLocalityFromOrToRegionalByRow(
 			mutationIdxAllowedInSameTxn: mutationIdxAllowedInSameTxn,
 			newColumnName:               newColumnName,
 		},
+		nil, /* newPartitionAllBy */
 	); err != nil {
 		return err
 	}
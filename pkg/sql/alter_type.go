@@ -8,6 +8,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -18,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -82,6 +84,17 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 		)
 	}
 
+	switch n.Cmd.(type) {
+	case *tree.AlterTypeAddAttribute, *tree.AlterTypeDropAttribute, *tree.AlterTypeRenameAttribute:
+		// Nodes running v24.3 neither refresh the composite types embedded in
+		// table descriptors nor decode values with a different number of
+		// attributes.
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_1) {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"ALTER TYPE ... ATTRIBUTE unsupported in mixed-version cluster")
+		}
+	}
+
 	return &alterTypeNode{
 		n:      n,
 		prefix: prefix,
@@ -126,6 +139,12 @@ func (n *alterTypeNode) startExec(params runParams) error {
 		eventLogDone = true // done inside alterTypeOwner().
 	case *tree.AlterTypeDropValue:
 		err = params.p.dropEnumValue(params.ctx, n.desc, t.Val)
+	case *tree.AlterTypeAddAttribute:
+		err = params.p.addCompositeAttribute(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
+	case *tree.AlterTypeDropAttribute:
+		err = params.p.dropCompositeAttribute(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
+	case *tree.AlterTypeRenameAttribute:
+		err = params.p.renameCompositeAttribute(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
 	default:
 		err = errors.AssertionFailedf("unknown alter type cmd %s", t)
 	}
@@ -213,6 +232,154 @@ func (p *planner) dropEnumValue(
 	return p.writeTypeSchemaChange(ctx, desc, desc.Name)
 }
 
+// findCompositeElementByLabel returns the ordinal of the element of the
+// composite type with the given label, or -1 if there is none.
+func findCompositeElementByLabel(desc *typedesc.Mutable, label tree.Name) int {
+	for i := range desc.Composite.Elements {
+		if desc.Composite.Elements[i].ElementLabel == string(label) {
+			return i
+		}
+	}
+	return -1
+}
+
+// addCompositeAttribute appends an attribute to a composite type. Values of
+// the type which were stored before the attribute was added are read with a
+// NULL value for it.
+func (p *planner) addCompositeAttribute(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterTypeAddAttribute, jobDesc string,
+) error {
+	if desc.Kind != descpb.TypeDescriptor_COMPOSITE {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a composite type", desc.Name)
+	}
+	if findCompositeElementByLabel(desc, node.Name) != -1 {
+		return pgerror.Newf(pgcode.DuplicateColumn,
+			"attribute %q of type %q already exists", node.Name, desc.Name)
+	}
+	typ, err := p.resolveCompositeElementType(ctx, node.Type)
+	if err != nil {
+		return err
+	}
+	// Index keys of the type are not decodable once an attribute is added, and
+	// the keys of equal values encoded before and after the attribute is added
+	// would differ.
+	if err := p.checkCompositeTypeNotIndexed(ctx, desc); err != nil {
+		return err
+	}
+	desc.Composite.Elements = append(desc.Composite.Elements,
+		descpb.TypeDescriptor_Composite_CompositeElement{
+			ElementType:  typ,
+			ElementLabel: string(node.Name),
+		})
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+// dropCompositeAttribute removes an attribute from a composite type. Since the
+// values of the type are encoded positionally, the type may not be used by
+// other objects.
+func (p *planner) dropCompositeAttribute(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterTypeDropAttribute, jobDesc string,
+) error {
+	if desc.Kind != descpb.TypeDescriptor_COMPOSITE {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a composite type", desc.Name)
+	}
+	idx := findCompositeElementByLabel(desc, node.Name)
+	if idx == -1 {
+		if node.IfExists {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"attribute %q of type %q does not exist, skipping", node.Name, desc.Name))
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedColumn,
+			"attribute %q of type %q does not exist", node.Name, desc.Name)
+	}
+	if len(desc.Composite.Elements) == 1 {
+		return pgerror.Newf(pgcode.InvalidTableDefinition,
+			"cannot drop the only attribute of type %q", desc.Name)
+	}
+	if err := p.checkCompositeTypeNotReferenced(ctx, desc, "drop attribute", node.Name); err != nil {
+		return err
+	}
+	elts := desc.Composite.Elements
+	desc.Composite.Elements = append(elts[:idx:idx], elts[idx+1:]...)
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+// renameCompositeAttribute renames an attribute of a composite type. Since
+// expressions stored in other objects may refer to the attribute by name, the
+// type may not be used by other objects.
+func (p *planner) renameCompositeAttribute(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterTypeRenameAttribute, jobDesc string,
+) error {
+	if desc.Kind != descpb.TypeDescriptor_COMPOSITE {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a composite type", desc.Name)
+	}
+	idx := findCompositeElementByLabel(desc, node.OldName)
+	if idx == -1 {
+		return pgerror.Newf(pgcode.UndefinedColumn,
+			"attribute %q of type %q does not exist", node.OldName, desc.Name)
+	}
+	if node.OldName == node.NewName {
+		return nil
+	}
+	if findCompositeElementByLabel(desc, node.NewName) != -1 {
+		return pgerror.Newf(pgcode.DuplicateColumn,
+			"attribute %q of type %q already exists", node.NewName, desc.Name)
+	}
+	if err := p.checkCompositeTypeNotReferenced(ctx, desc, "rename attribute", node.OldName); err != nil {
+		return err
+	}
+	desc.Composite.Elements[idx].ElementLabel = string(node.NewName)
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+// checkCompositeTypeNotReferenced returns an error if other objects depend on
+// the composite type.
+func (p *planner) checkCompositeTypeNotReferenced(
+	ctx context.Context, desc *typedesc.Mutable, op string, attr tree.Name,
+) error {
+	if len(desc.ReferencingDescriptorIDs) == 0 {
+		return nil
+	}
+	dependentNames, err := p.getFullyQualifiedNamesFromIDs(ctx, desc.ReferencingDescriptorIDs)
+	if err != nil {
+		return errors.Wrapf(err, "type %q has dependent objects", desc.Name)
+	}
+	return pgerror.Newf(pgcode.DependentObjectsStillExist,
+		"cannot %s %q of type %q because other objects (%v) still depend on it",
+		op, attr, desc.Name, dependentNames)
+}
+
+// checkCompositeTypeNotIndexed returns an error if a column of the composite
+// type is a key column of an index.
+func (p *planner) checkCompositeTypeNotIndexed(ctx context.Context, desc *typedesc.Mutable) error {
+	typOID := catid.TypeIDToOID(desc.ID)
+	for _, id := range desc.ReferencingDescriptorIDs {
+		d, err := p.Descriptors().ByIDWithoutLeased(p.txn).WithoutDropped().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok {
+			continue
+		}
+		for _, idx := range tbl.AllIndexes() {
+			for i := 0; i < idx.NumKeyColumns(); i++ {
+				col, err := catalog.MustFindColumnByID(tbl, idx.GetKeyColumnID(i))
+				if err != nil {
+					return err
+				}
+				if col.GetType().Oid() == typOID {
+					return pgerror.Newf(pgcode.FeatureNotSupported,
+						"cannot alter type %q because column %q of table %q is used in index %q",
+						desc.Name, col.GetName(), tbl.GetName(), idx.GetName())
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (p *planner) renameType(ctx context.Context, n *alterTypeNode, newName string) error {
 	err := descs.CheckObjectNameCollision(
 		ctx,
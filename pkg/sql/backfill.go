@@ -1598,15 +1598,13 @@ func ValidateConstraint(
 						indexIDForValidation)
 				},
 			)
-		case catconstants.ConstraintTypeUniqueWithoutIndex:
+		case catconstants.ConstraintTypeUniqueWithoutIndex, catconstants.ConstraintTypeExclusion:
 			uwi := constraint.AsUniqueWithoutIndex()
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(
-						ctx, tableDesc, uwi.GetName(),
-						uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(),
+					return validateUniqueWithoutIndexConstraint(
+						ctx, tableDesc, uwi,
 						indexIDForValidation,
 						txn,
 						sessionData.User(),
@@ -2808,10 +2806,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
 		syntheticDescs = append(syntheticDescs, tableDesc)
 	}
-	var uc *descpb.UniqueWithoutIndexConstraint
+	var uc catalog.UniqueWithoutIndexConstraint
 	for _, uwi := range tableDesc.UniqueConstraintsWithoutIndex() {
 		if uwi.GetName() == constraintName {
-			uc = uwi.UniqueWithoutIndexDesc()
+			uc = uwi
 			break
 		}
 	}
@@ -2822,12 +2820,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
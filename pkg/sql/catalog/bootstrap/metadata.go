@@ -459,6 +459,8 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 	target.AddDescriptor(systemschema.SystemJobStatusTable)
 	target.AddDescriptor(systemschema.SystemJobMessageTable)
 	target.AddDescriptor(systemschema.PreparedTransactionsTable)
+	target.AddDescriptor(systemschema.StatementHintsTable)
+	target.AddDescriptor(systemschema.SQLEventAggregatesTable)
 
 	// Adding a new system table? It should be added here to the metadata schema,
 	// and also created as a migration for older clusters.
@@ -79,6 +79,8 @@ var (
 		catconstants.JobsProgressHistoryTableName,
 		catconstants.JobsStatusTableName,
 		catconstants.JobsMessageTableName,
+		catconstants.StatementHintsTableName,
+		catconstants.SQLEventAggregatesTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
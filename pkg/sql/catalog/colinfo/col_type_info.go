@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -95,18 +96,33 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 			return unimplemented.NewWithIssueDetailf(23468, t.String(),
 				"arrays of JSON unsupported as column type")
 		}
+		if t.ArrayContents().IsDomain() {
+			// The elements of the array would not be checked against the
+			// domain's constraints.
+			return unimplemented.NewWithIssue(27796, "arrays of domains are not yet supported")
+		}
 		if err := types.CheckArrayElementType(t.ArrayContents()); err != nil {
 			return err
 		}
 		return ValidateColumnDefType(ctx, st, t.ArrayContents())
 
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
-		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
+		types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
 		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily,
 		types.TSQueryFamily, types.TSVectorFamily, types.PGLSNFamily, types.RefCursorFamily:
 	// These types are OK.
 
+	case types.INetFamily, types.RangeFamily, types.MacAddrFamily, types.MoneyFamily,
+		types.JsonpathFamily:
+		// Nodes running v24.3 cannot decode the values of these types. CIDR
+		// shares the INET encoding, but v24.3 nodes do not know its OID.
+		if (t.Family() != types.INetFamily || t.Oid() == oid.T_cidr) &&
+			!st.Version.IsActive(ctx, clusterversion.V25_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s unsupported in mixed-version cluster", t.SQLString())
+		}
+
 	case types.TupleFamily:
 		if !t.UserDefined() {
 			return pgerror.New(pgcode.InvalidTableDefinition, "cannot use anonymous record type as table column")
@@ -131,10 +147,24 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 // ColumnTypeIsIndexable returns whether the type t is valid as an indexed column.
 func ColumnTypeIsIndexable(t *types.T) bool {
 	// NB: .IsAmbiguous checks the content type of array types.
-	if t.IsAmbiguous() || t.Family() == types.TupleFamily || t.Family() == types.RefCursorFamily {
+	if t.IsAmbiguous() || t.Family() == types.RefCursorFamily {
 		return false
 	}
 
+	// Composite types are indexable if all of their elements are. Anonymous
+	// tuples cannot be used as column types.
+	if t.Family() == types.TupleFamily {
+		if !t.UserDefined() || t.TypeMeta.ImplicitRecordType {
+			return false
+		}
+		for _, contents := range t.TupleContents() {
+			if !ColumnTypeIsIndexable(contents) {
+				return false
+			}
+		}
+		return true
+	}
+
 	// If the type is an array, check its content type as well.
 	if unwrapped := t.ArrayContents(); unwrapped != nil {
 		if unwrapped.Family() == types.TupleFamily || unwrapped.Family() == types.RefCursorFamily {
@@ -153,7 +183,7 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
 	case types.ArrayFamily:
 		return t.ArrayContents().Family() != types.RefCursorFamily
-	case types.JsonFamily, types.StringFamily:
+	case types.JsonFamily, types.StringFamily, types.RangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -191,7 +221,7 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		}
 	case types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
 		return true
-	case types.TSVectorFamily, types.TSQueryFamily:
+	case types.TSVectorFamily, types.TSQueryFamily, types.JsonpathFamily:
 		return true
 	case types.PGVectorFamily:
 		return true
@@ -52,6 +52,8 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
@@ -79,9 +81,12 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.Box2DFamily,
 		types.PGLSNFamily,
 		types.PGVectorFamily,
+		types.MacAddrFamily,
+		types.MoneyFamily,
 		types.RefCursorFamily,
 		types.VoidFamily,
 		types.EncodedKeyFamily,
+		types.JsonpathFamily,
 		types.TSQueryFamily,
 		types.TSVectorFamily:
 		return false
@@ -671,6 +671,12 @@ type TableDescriptor interface {
 		colID descpb.ColumnID,
 	) (DescriptorIDSet, error)
 
+	// GetAllReferencedFunctionIDsInIndex returns descriptor IDs of all user
+	// defined functions referenced by the partial predicate of this index.
+	GetAllReferencedFunctionIDsInIndex(
+		indexID descpb.IndexID,
+	) (DescriptorIDSet, error)
+
 	// ForeachDependedOnBy runs a function on all indexes, including those being
 	// added in the mutations.
 	ForeachDependedOnBy(f func(dep *descpb.TableDescriptor_Reference) error) error
@@ -887,6 +893,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -974,6 +984,22 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains, which are
+// built-in types with additional constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// DomainBaseType returns the type over which the domain is defined.
+	DomainBaseType() *types.T
+
+	// DomainNotNull returns whether the domain disallows NULL values.
+	DomainNotNull() bool
+
+	// DomainConstraints returns the CHECK constraints of the domain, including
+	// constraints which are still being validated.
+	DomainConstraints() []descpb.TypeDescriptor_Domain_Constraint
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
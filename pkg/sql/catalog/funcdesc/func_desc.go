@@ -383,8 +383,18 @@ func (desc *immutable) validateInboundTableRef(
 			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have an index with ID %d",
 				backRefTbl.GetName(), by.ID, idxID)
 		}
-		// TODO(chengxiong): add logic to validate reference in index expressions
-		// when UDF usage is allowed in indexes.
+		fnIDs, err := backRefTbl.GetAllReferencedFunctionIDsInIndex(idxID)
+		if err != nil {
+			return err
+		}
+		if fnIDs.Contains(desc.GetID()) {
+			foundInTable = true
+			continue
+		}
+		return errors.AssertionFailedf(
+			"index %d in depended-on-by relation %q (%d) does not have reference to function %q (%d)",
+			idxID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
+		)
 	}
 
 	for _, cstID := range by.ConstraintIDs {
@@ -741,6 +751,51 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 	}
 }
 
+// AddIndexReference adds back reference to an index to the function.
+func (desc *Mutable) AddIndexReference(id descpb.ID, indexID descpb.IndexID) error {
+	for _, dep := range desc.DependsOn {
+		if dep == id {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot add dependency from descriptor %d to function %s (%d) because there will be a dependency cycle", id, desc.GetName(), desc.GetID(),
+			)
+		}
+	}
+	defer sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, prevID := range desc.DependedOnBy[i].IndexIDs {
+				if prevID == indexID {
+					return nil
+				}
+			}
+			desc.DependedOnBy[i].IndexIDs = append(desc.DependedOnBy[i].IndexIDs, indexID)
+			return nil
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{ID: id, IndexIDs: []descpb.IndexID{indexID}},
+	)
+	return nil
+}
+
+// RemoveIndexReference removes back reference to an index from the function.
+func (desc *Mutable) RemoveIndexReference(id descpb.ID, indexID descpb.IndexID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			dep := &desc.DependedOnBy[i]
+			for j := range dep.IndexIDs {
+				if dep.IndexIDs[j] == indexID {
+					dep.IndexIDs = append(dep.IndexIDs[:j], dep.IndexIDs[j+1:]...)
+					desc.maybeRemoveTableReference(id)
+					return
+				}
+			}
+		}
+	}
+}
+
 // AddTriggerReference adds back reference to a constraint to the function.
 func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) error {
 	for _, dep := range desc.DependsOn {
@@ -883,6 +938,9 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, tree.ParamType{Name: param.Name, Typ: param.Type})
 		}
+		if class == tree.RoutineParamVariadic {
+			ret.Variadic = true
+		}
 		routineParam := tree.RoutineParam{
 			Name:  tree.Name(param.Name),
 			Type:  param.Type,
@@ -15,6 +15,9 @@ var schemaExprContextAllowingUDF = map[tree.SchemaExprContext]clusterversion.Key
 	tree.CheckConstraintExpr:           clusterversion.MinSupported,
 	tree.ColumnDefaultExprInNewTable:   clusterversion.MinSupported,
 	tree.ColumnDefaultExprInSetDefault: clusterversion.MinSupported,
+	tree.ColumnOnUpdateExpr:            clusterversion.V25_1,
+	tree.StoredComputedColumnExpr:      clusterversion.V25_1,
+	tree.IndexPredicateExpr:            clusterversion.V25_1,
 }
 
 // MaybeFailOnUDFUsage returns an error if the given expression or any
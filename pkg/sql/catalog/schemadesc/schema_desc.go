@@ -595,6 +595,7 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			Type:                     routineType,
 			UDFContainsOnlySignature: true,
 			OutParamOrdinals:         sig.OutParamOrdinals,
+			Variadic:                 sig.Variadic,
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
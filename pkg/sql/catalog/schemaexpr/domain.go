@@ -0,0 +1,89 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// DomainValueName is the name used by a domain CHECK constraint to refer to
+// the value being checked.
+const DomainValueName = tree.Name("value")
+
+// ReplaceDomainValue replaces the references to VALUE in a domain CHECK
+// expression with the given expression. It errs with pgcode.UndefinedColumn
+// if the expression references any other column.
+func ReplaceDomainValue(rootExpr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(rootExpr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return true, expr, nil
+		}
+		if c.TableName != nil || c.ColumnName != DomainValueName {
+			return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q does not exist", tree.ErrString(c))
+		}
+		return false, value, nil
+	})
+}
+
+// ValidateDomainCheckExpr validates that the given expression is a valid CHECK
+// constraint for a domain over the given base type. The type-checked and
+// serialized expression is returned, if valid.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	replacedExpr, err := ReplaceDomainValue(expr, &dummyColumn{typ: baseType, name: DomainValueName})
+	if err != nil {
+		return "", err
+	}
+
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require(
+		string(tree.DomainCheckConstraintExpr), tree.RejectSpecial|tree.RejectSubqueries,
+	)
+	typedExpr, err := tree.TypeCheck(ctx, replacedExpr, semaCtx, types.Bool)
+	if err != nil {
+		return "", err
+	}
+	if typ := typedExpr.ResolvedType(); typ.Family() != types.BoolFamily && typedExpr != tree.DNull {
+		return "", pgerror.Newf(pgcode.DatatypeMismatch,
+			"argument of CHECK must be type boolean, not type %s", typ.SQLStringForError())
+	}
+
+	// Domains do not track references to other descriptors, so user-defined
+	// functions and types cannot be used in their constraints.
+	var udfVisitor tree.UDFDisallowanceVisitor
+	tree.WalkExpr(&udfVisitor, typedExpr)
+	if udfVisitor.FoundUDF {
+		return "", unimplemented.NewWithIssue(83234,
+			"usage of user-defined function from domain constraints not supported")
+	}
+	if _, err := tree.SimpleVisit(typedExpr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if e, ok := expr.(tree.TypedExpr); ok && e.ResolvedType().UserDefined() {
+			return false, nil, unimplemented.NewWithIssue(27796,
+				"usage of user-defined type from domain constraints not supported")
+		}
+		return true, expr, nil
+	}); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
@@ -696,6 +696,23 @@ func GetUDFIDsFromExprStr(exprStr string) (catalog.DescriptorIDSet, error) {
 	return GetUDFIDs(expr)
 }
 
+// ValidateNoUDFsInBackfilledExpr returns an error if the given serialized
+// expression references a user-defined function. It is used for expressions
+// that are evaluated by a backfill, such as a stored computed column added to
+// an existing table or the predicate of an index built on one, since backfills
+// cannot evaluate user-defined functions.
+func ValidateNoUDFsInBackfilledExpr(exprStr string, context tree.SchemaExprContext) error {
+	fnIDs, err := GetUDFIDsFromExprStr(exprStr)
+	if err != nil {
+		return err
+	}
+	if !fnIDs.Empty() {
+		return unimplemented.NewWithIssuef(83234,
+			"usage of user-defined function from %s on an existing table not supported", context)
+	}
+	return nil
+}
+
 func validateExpressionDoesNotDependOnColumn(
 	tableDesc catalog.TableDescriptor, expirationExpr string, dependentColID descpb.ColumnID,
 ) (bool, error) {
@@ -10,7 +10,10 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -50,3 +53,28 @@ func ValidateUniqueWithoutIndexPredicate(
 	}
 	return expr, nil
 }
+
+// ValidateExclusionOperator verifies that the given operator can be used to
+// compare the values of a column of the given type in an exclusion
+// constraint.
+//
+// The operator must be commutative so that the constraint is violated by a
+// pair of rows regardless of the order in which they are compared, and it
+// must be defined for the type of the column.
+func ValidateExclusionOperator(op treecmp.ComparisonOperator, typ *types.T) error {
+	switch op.Symbol {
+	case treecmp.EQ, treecmp.NE, treecmp.Overlaps, treecmp.Adjacent:
+	default:
+		return pgerror.Newf(pgcode.WrongObjectType, "operator %s is not commutative", op)
+	}
+	foldedOp, _, _, _, _ := tree.FoldComparisonExpr(op, nil, nil)
+	overloads, ok := tree.CmpOps[foldedOp.Symbol]
+	if ok {
+		_, ok = overloads.LookupImpl(typ, typ)
+	}
+	if !ok {
+		return pgerror.Newf(pgcode.UndefinedFunction,
+			"operator does not exist: %s %s %s", typ.SQLString(), op, typ.SQLString())
+	}
+	return nil
+}
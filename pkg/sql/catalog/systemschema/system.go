@@ -1334,6 +1334,36 @@ CREATE TABLE system.prepared_transactions (
   CONSTRAINT "primary" PRIMARY KEY (global_id),
   FAMILY "primary" (global_id, transaction_id, transaction_key, prepared, owner, database, heuristic)
 );`
+
+	// StatementHintsTableSchema stores the hints attached by operators to
+	// statement fingerprints, which the optimizer applies whenever a statement
+	// with that fingerprint is planned.
+	StatementHintsTableSchema = `
+CREATE TABLE system.statement_hints (
+  row_id       INT8         NOT NULL DEFAULT unique_rowid(),
+  fingerprint  STRING       NOT NULL,
+  hint_type    STRING       NOT NULL,
+  -- Null unless the hint applies to a single table.
+  table_name   STRING       NULL,
+  hint_value   STRING       NOT NULL,
+  created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+  CONSTRAINT "primary" PRIMARY KEY (row_id),
+  FAMILY "primary" (row_id, fingerprint, hint_type, table_name, hint_value, created_at)
+);`
+
+	// SQLEventAggregatesTableSchema stores the per-window aggregations of SQL
+	// events computed by each node, such as its top statements and the
+	// activity of each user.
+	SQLEventAggregatesTableSchema = `
+CREATE TABLE system.sql_event_aggregates (
+  aggregated_ts TIMESTAMPTZ  NOT NULL,
+  node_id       INT8         NOT NULL,
+  event_type    STRING       NOT NULL,
+  key           STRING       NOT NULL,
+  value         JSONB        NOT NULL,
+  CONSTRAINT "primary" PRIMARY KEY (aggregated_ts, node_id, event_type, key),
+  FAMILY "primary" (aggregated_ts, node_id, event_type, key, value)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1386,7 +1416,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_1_JobsBackfill.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_1_SQLEventAggregatesTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1583,6 +1613,8 @@ func MakeSystemTables() []SystemTable {
 		SystemJobStatusTable,
 		SystemJobMessageTable,
 		PreparedTransactionsTable,
+		StatementHintsTable,
+		SQLEventAggregatesTable,
 	}
 }
 
@@ -5273,6 +5305,63 @@ var (
 			pk("global_id"),
 		),
 	)
+
+	StatementHintsTable = makeSystemTable(
+		StatementHintsTableSchema,
+		systemTable(
+			catconstants.StatementHintsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "row_id", ID: 1, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "fingerprint", ID: 2, Type: types.String},
+				{Name: "hint_type", ID: 3, Type: types.String},
+				{Name: "table_name", ID: 4, Type: types.String, Nullable: true},
+				{Name: "hint_value", ID: 5, Type: types.String},
+				{Name: "created_at", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"row_id", "fingerprint", "hint_type", "table_name", "hint_value", "created_at"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			pk("row_id"),
+		),
+	)
+
+	SQLEventAggregatesTable = makeSystemTable(
+		SQLEventAggregatesTableSchema,
+		systemTable(
+			catconstants.SQLEventAggregatesTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "aggregated_ts", ID: 1, Type: types.TimestampTZ},
+				{Name: "node_id", ID: 2, Type: types.Int},
+				{Name: "event_type", ID: 3, Type: types.String},
+				{Name: "key", ID: 4, Type: types.String},
+				{Name: "value", ID: 5, Type: types.Jsonb},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"aggregated_ts", "node_id", "event_type", "key", "value"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           tabledesc.LegacyPrimaryKeyIndexName,
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"aggregated_ts", "node_id", "event_type", "key"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2, 3, 4},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
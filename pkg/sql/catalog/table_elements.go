@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -594,6 +595,16 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusion returns true iff this is an exclusion constraint rather than
+	// a uniqueness constraint.
+	IsExclusion() bool
+
+	// GetExclusionOperator returns the operator used to compare the values of
+	// the column at ordinal `columnOrdinal` of two rows. The constraint is
+	// violated by any two rows for which all of the operators return true. For
+	// uniqueness constraints, this is always the equality operator.
+	GetExclusionOperator(columnOrdinal int) treecmp.ComparisonOperator
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -1020,6 +1031,9 @@ func GetConstraintType(c Constraint) catconstants.ConstraintType {
 	} else if c.AsForeignKey() != nil {
 		return catconstants.ConstraintTypeFK
 	} else if c.AsUniqueWithoutIndex() != nil {
+		if c.AsUniqueWithoutIndex().IsExclusion() {
+			return catconstants.ConstraintTypeExclusion
+		}
 		return catconstants.ConstraintTypeUniqueWithoutIndex
 	} else if c.AsUniqueWithIndex() != nil {
 		if c.AsUniqueWithIndex().GetEncodingType() == catenumpb.PrimaryIndexEncoding {
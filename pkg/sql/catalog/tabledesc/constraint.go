@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/util"
 )
 
@@ -141,12 +142,30 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 	return c.desc.TableID
 }
 
+// IsExclusion implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(c.desc.ExclusionOperators) > 0
+}
+
+// GetExclusionOperator implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) GetExclusionOperator(
+	columnOrdinal int,
+) treecmp.ComparisonOperator {
+	if !c.IsExclusion() {
+		return treecmp.MakeComparisonOperator(treecmp.EQ)
+	}
+	// The operators are checked during validation of the table descriptor.
+	symbol, _ := treecmp.ComparisonOperatorSymbolFromName(c.desc.ExclusionOperators[columnOrdinal])
+	return treecmp.MakeComparisonOperator(symbol)
+}
+
 // IsValidReferencedUniqueConstraint implements the catalog.UniqueConstraint
 // interface.
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
 	fk catalog.ForeignKeyConstraint,
 ) bool {
-	return !c.IsPartial() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+	return !c.IsPartial() && !c.IsExclusion() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
 }
 
 // NumKeyColumns implements the catalog.UniqueConstraint interface.
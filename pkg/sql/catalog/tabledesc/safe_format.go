@@ -353,6 +353,16 @@ func formatSafeUniqueWithoutIndexConstraint(
 	w.Printf("{TableID: %d", c.TableID)
 	w.Printf(", Columns: ")
 	formatSafeColumnIDs(w, c.ColumnIDs)
+	if len(c.ExclusionOperators) > 0 {
+		w.Printf(", ExclusionOperators: [")
+		for i, op := range c.ExclusionOperators {
+			if i > 0 {
+				w.Printf(", ")
+			}
+			w.Printf("%s", redact.SafeString(op))
+		}
+		w.Printf("]")
+	}
 	w.Printf(", Validity: %s", c.Validity.String())
 	if m != nil {
 		w.Printf(", State: %s, MutationID: %d", m.Direction, m.MutationID)
@@ -424,12 +424,17 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for _, idx := range desc.AllIndexes() {
+		ids, err := desc.GetAllReferencedFunctionIDsInIndex(idx.GetID())
+		if err != nil {
+			return catalog.DescriptorIDSet{}, err
+		}
+		ret = ret.Union(ids)
+	}
 	// Add routine dependencies from triggers.
 	for i := range desc.Triggers {
 		ret = ret.Union(catalog.MakeDescriptorIDSet(desc.Triggers[i].DependsOnRoutines...))
 	}
-	// TODO(chengxiong): add logic to extract references from indexes when UDFs
-	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
 }
 
@@ -473,23 +478,32 @@ func (desc *wrapper) GetAllReferencedFunctionIDsInColumnExprs(
 	}
 
 	var ret catalog.DescriptorIDSet
-	// TODO(chengxiong): add support for computed columns when UDFs are allowed in
-	// them.
-	if !col.IsComputed() {
-		if col.HasDefault() {
-			ids, err := schemaexpr.GetUDFIDsFromExprStr(col.GetDefaultExpr())
-			if err != nil {
-				return catalog.DescriptorIDSet{}, err
-			}
-			ret = ret.Union(ids)
+	d := col.ColumnDesc()
+	for _, expr := range [...]*string{d.ComputeExpr, d.DefaultExpr, d.OnUpdateExpr} {
+		if expr == nil {
+			continue
+		}
+		ids, err := schemaexpr.GetUDFIDsFromExprStr(*expr)
+		if err != nil {
+			return catalog.DescriptorIDSet{}, err
 		}
-		// TODO(chengxiong): add support for ON UPDATE expressions when UDFs are
-		// allowed in them.
+		ret = ret.Union(ids)
 	}
-
 	return ret, nil
 }
 
+// GetAllReferencedFunctionIDsInIndex implements the TableDescriptor
+// interface.
+func (desc *wrapper) GetAllReferencedFunctionIDsInIndex(
+	indexID descpb.IndexID,
+) (fnIDs catalog.DescriptorIDSet, err error) {
+	idx := catalog.FindIndexByID(desc, indexID)
+	if idx == nil || !idx.IsPartial() {
+		return catalog.DescriptorIDSet{}, nil
+	}
+	return schemaexpr.GetUDFIDsFromExprStr(idx.GetPredicate())
+}
+
 // getAllReferencedTypesInTableColumns returns a map of all user defined
 // type descriptor IDs that this table references. Consider using
 // GetAllReferencedTypeIDs when constructing the list of type descriptor IDs
@@ -2521,6 +2535,12 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 		if ttl.DisableChangefeedReplication {
 			appendStorageParam(`ttl_disable_changefeed_replication`, fmt.Sprintf("%t", ttl.DisableChangefeedReplication))
 		}
+		if uri := ttl.ArchiveURI; uri != "" {
+			appendStorageParam(`ttl_archive_uri`, lexbase.EscapeSQLString(uri))
+		}
+		if format := ttl.ArchiveFormat; format != "" {
+			appendStorageParam(`ttl_archive_format`, lexbase.EscapeSQLString(format))
+		}
 	}
 	if exclude := desc.GetExcludeDataFromBackup(); exclude {
 		appendStorageParam(`exclude_data_from_backup`, `true`)
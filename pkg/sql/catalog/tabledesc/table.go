@@ -202,6 +202,11 @@ func MakeColumnDefDescs(
 			return nil, err
 		}
 
+		ret.OnUpdateExpr, err = schemaexpr.MaybeReplaceUDFNameWithOIDReferenceInTypedExpr(ret.OnUpdateExpr)
+		if err != nil {
+			return nil, err
+		}
+
 		d.OnUpdateExpr.Expr = ret.OnUpdateExpr
 		s := tree.Serialize(d.OnUpdateExpr.Expr)
 		col.OnUpdateExpr = &s
@@ -6,6 +6,7 @@
 package tabledesc
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -59,6 +60,16 @@ func ValidateRowLevelTTL(ttl *catpb.RowLevelTTL) error {
 			return err
 		}
 	}
+	if ttl.ArchiveURI != "" {
+		if err := ValidateTTLArchiveURI("ttl_archive_uri", ttl.ArchiveURI); err != nil {
+			return err
+		}
+	}
+	if ttl.ArchiveFormat != "" {
+		if err := ValidateTTLArchiveFormat("ttl_archive_format", ttl.ArchiveFormat); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -178,3 +189,40 @@ func ValidateTTLRateLimit(key string, val int64) error {
 	}
 	return nil
 }
+
+// ValidateTTLArchiveURI validates the archive destination of TTL. Only
+// external connections are accepted so that storage credentials are not
+// stored in, and displayed from, the table descriptor.
+func ValidateTTLArchiveURI(key string, uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.InvalidParameterValue, `invalid URI for "%s"`, key)
+	}
+	if u.Scheme != "external" || u.Host == "" {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`"%s" must reference an external connection, such as 'external://<name>'`,
+			key,
+		)
+	}
+	return nil
+}
+
+// TTL archive formats.
+const (
+	TTLArchiveFormatCSV     = "csv"
+	TTLArchiveFormatParquet = "parquet"
+)
+
+// ValidateTTLArchiveFormat validates the archive file format of TTL.
+func ValidateTTLArchiveFormat(key string, format string) error {
+	switch format {
+	case TTLArchiveFormatCSV, TTLArchiveFormatParquet:
+		return nil
+	}
+	return pgerror.Newf(
+		pgcode.InvalidParameterValue,
+		`"%s" must be '%s' or '%s'`,
+		key, TTLArchiveFormatCSV, TTLArchiveFormatParquet,
+	)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -208,6 +209,17 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all functions referenced by partial index predicates exist.
+	for _, idx := range desc.NonDropIndexes() {
+		fnIDs, err := desc.GetAllReferencedFunctionIDsInIndex(idx.GetID())
+		if err != nil {
+			vea.Report(errors.Wrap(err, "invalid referenced functions IDs in index"))
+		}
+		for _, fnID := range fnIDs.Ordered() {
+			vea.Report(desc.validateOutboundFuncRef(fnID, vdg))
+		}
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -217,8 +229,10 @@ func (desc *wrapper) ValidateForwardReferences(
 	// We only check these for active indexes, as inactive indexes may be in the
 	// process of being backfilled without PartitionAllBy.
 	// This check cannot be performed in ValidateSelf due to a conflict with
-	// AllocateIDs.
-	if desc.PartitionAllBy {
+	// AllocateIDs. It is also skipped during a declarative schema change, since
+	// repartitioning a table with PARTITION ALL BY makes the new primary index
+	// public before the recreated secondary indexes replace the old ones.
+	if desc.PartitionAllBy && desc.DeclarativeSchemaChangerState == nil {
 		for _, indexI := range desc.ActiveIndexes() {
 			if !desc.matchingPartitionbyAll(indexI) {
 				vea.Report(errors.AssertionFailedf(
@@ -286,6 +300,22 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in functions referenced by partial index predicates.
+	for _, idx := range desc.NonDropIndexes() {
+		fnIDs, err := desc.GetAllReferencedFunctionIDsInIndex(idx.GetID())
+		if err != nil {
+			vea.Report(errors.Wrap(err, "invalid referenced functions IDs in index"))
+		}
+		for _, fnID := range fnIDs.Ordered() {
+			fn, err := vdg.GetFunctionDescriptor(fnID)
+			if err != nil {
+				vea.Report(err)
+				continue
+			}
+			vea.Report(desc.validateOutboundFuncRefBackReferenceForIndex(fn, idx.GetID()))
+		}
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -399,6 +429,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForIndex(
+	ref catalog.FunctionDescriptor, indexID descpb.IndexID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.IndexIDs {
+			if id == indexID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -1500,6 +1547,23 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		// Verify that an exclusion constraint has a known operator per column.
+		if ops := c.UniqueWithoutIndexDesc().ExclusionOperators; len(ops) > 0 {
+			if len(ops) != c.NumKeyColumns() {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.GetName(), len(ops), c.NumKeyColumns(),
+				)
+			}
+			for _, op := range ops {
+				if _, ok := treecmp.ComparisonOperatorSymbolFromName(op); !ok {
+					return errors.Newf(
+						"exclusion constraint %q contains unknown operator %q", c.GetName(), op,
+					)
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -1613,6 +1677,12 @@ func (desc *wrapper) validateTableIndexes(
 			if col.Dropped() && idx.GetEncodingType() != catenumpb.PrimaryIndexEncoding {
 				return errors.Newf("secondary index %q contains dropped key column %q", idx.GetName(), col.ColName())
 			}
+			// Nodes running v24.3 use a different key encoding for tuples.
+			if col.GetType().Family() == types.TupleFamily && !isActive(clusterversion.V25_1) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"index %q on column %q of composite type unsupported in mixed-version cluster",
+					idx.GetName(), col.ColName())
+			}
 			if validateIndexDup.Contains(colID) {
 				if col.IsExpressionIndexColumn() {
 					return pgerror.Newf(pgcode.FeatureNotSupported,
@@ -97,11 +97,32 @@ func ensureTypeIsHydratedRecursive(
 			}
 			maybeName = &name
 		}
+		if c := maybeDesc.AsCompositeTypeDescriptor(); c != nil &&
+			t.TypeMeta.Version != uint32(maybeDesc.GetVersion()) {
+			hydrateCompositeElements(t, c)
+		}
 	}
 	ensureTypeMetadataIsHydrated(&t.TypeMeta, maybeName, maybeDesc)
 	return nil
 }
 
+// hydrateCompositeElements sets the element types and labels of t, a
+// composite type, to those in its type descriptor. A composite type is
+// embedded along with its elements in the descriptors that reference it, so
+// the elements are hydrated from the type descriptor to reflect attributes
+// which were added or renamed since.
+func hydrateCompositeElements(t *types.T, desc catalog.CompositeTypeDescriptor) {
+	n := desc.NumElements()
+	contents := make([]*types.T, n)
+	labels := make([]string, n)
+	for i := 0; i < n; i++ {
+		contents[i] = desc.GetElementType(i).CopyForHydrate()
+		labels[i] = desc.GetElementLabel(i)
+	}
+	t.InternalType.TupleContents = contents
+	t.InternalType.TupleLabels = labels
+}
+
 func ensureTypeMetadataIsHydrated(
 	tm *types.UserDefinedTypeMetadata, maybeName *tree.TypeName, maybeDesc catalog.TypeDescriptor,
 ) {
@@ -154,4 +175,19 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		// Constraints which are still being validated are enforced on new
+		// values as well.
+		constraints := d.DomainConstraints()
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:     d.DomainNotNull(),
+			Constraints: make([]types.DomainConstraint, len(constraints)),
+		}
+		for i := range constraints {
+			tm.DomainData.Constraints[i] = types.DomainConstraint{
+				Name: constraints[i].Name,
+				Expr: constraints[i].Expr,
+			}
+		}
+	}
 }
@@ -38,6 +38,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -498,6 +499,16 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+			break
+		}
+		if desc.Domain.BaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has user-defined base type %d",
+				desc.Domain.BaseType.Oid()))
+		}
+		desc.validateDomainConstraints(vea)
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -549,6 +560,45 @@ func (desc *immutable) validateEnumMembers(vea catalog.ValidationErrorAccumulato
 	return isSorted
 }
 
+// validateDomainConstraints performs domain constraint checks.
+func (desc *immutable) validateDomainConstraints(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Domain.Constraints))
+	ids := make(map[descpb.ConstraintID]struct{}, len(desc.Domain.Constraints))
+	for _, c := range desc.Domain.Constraints {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("domain constraint %d has empty name", c.ConstraintID))
+		}
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain constraint name %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+		if c.ConstraintID == 0 || c.ConstraintID >= desc.Domain.NextConstraintID {
+			vea.Report(errors.AssertionFailedf("domain constraint %q has invalid ID %d",
+				c.Name, c.ConstraintID))
+		}
+		if _, ok := ids[c.ConstraintID]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain constraint ID %d", c.ConstraintID))
+		}
+		ids[c.ConstraintID] = struct{}{}
+		switch c.Validity {
+		case descpb.ConstraintValidity_Validated, descpb.ConstraintValidity_Validating,
+			descpb.ConstraintValidity_Dropping:
+		default:
+			vea.Report(errors.AssertionFailedf("domain constraint %q has invalid validity %s",
+				c.Name, c.Validity))
+		}
+	}
+	switch v := desc.Domain.NotNullValidity; v {
+	case descpb.ConstraintValidity_Validated:
+	case descpb.ConstraintValidity_Validating, descpb.ConstraintValidity_Dropping:
+		if !desc.Domain.NotNull {
+			vea.Report(errors.AssertionFailedf("domain NOT NULL validity is %s but NOT NULL is not set", v))
+		}
+	default:
+		vea.Report(errors.AssertionFailedf("domain NOT NULL has invalid validity %s", v))
+	}
+}
+
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
 // this descriptor, including itself.
 func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
@@ -765,6 +815,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -1019,6 +1075,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1045,6 +1109,21 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// DomainBaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainBaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// DomainNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// DomainConstraints implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainConstraints() []descpb.TypeDescriptor_Domain_Constraint {
+	return desc.Domain.Constraints
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
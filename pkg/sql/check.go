@@ -500,12 +500,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -580,12 +578,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -697,6 +693,188 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX or exclusion constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc, indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.GetName(),
+		uc.CollectKeyColumnIDs().Ordered(),
+		uc.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// exclusionViolationQuery generates and returns a query for a pair of rows
+// that violate the specified exclusion constraint. The query returns the
+// constraint columns of both rows.
+//
+// For example, an exclusion constraint EXCLUDE (a WITH =, b WITH &&) on the
+// table "tbl" with primary key k would require the following query:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT a, b, k FROM tbl) AS l
+// JOIN (SELECT a, b, k FROM tbl) AS r
+// ON l.a = r.a AND l.b && r.b AND (l.k) != (r.k)
+// LIMIT 1
+//
+// The predicate of a partial exclusion constraint is applied to both sides of
+// the join. `indexIDForValidation`, if non-zero, will be used to force the sql
+// query to use this particular primary index by hinting the query.
+func exclusionViolationQuery(
+	srcTbl catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colIDs := uc.UniqueWithoutIndexDesc().ColumnIDs
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, colIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Rows are identified by the key columns of the primary index.
+	primaryIndex := srcTbl.GetPrimaryIndex()
+	if indexIDForValidation != 0 {
+		if primaryIndex, err = catalog.MustFindIndexByID(srcTbl, indexIDForValidation); err != nil {
+			return "", nil, err
+		}
+	}
+	keyColIDs := make([]descpb.ColumnID, primaryIndex.NumKeyColumns())
+	for i := range keyColIDs {
+		keyColIDs[i] = primaryIndex.GetKeyColumnID(i)
+	}
+	keyColNames, err := catalog.ColumnNamesForIDs(srcTbl, keyColIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The inner queries project the constraint columns and the primary key
+	// columns, without duplicates.
+	var innerCols, outerCols, on []string
+	var seen catalog.TableColSet
+	addInnerCols := func(ids []descpb.ColumnID, names []string) {
+		for i, id := range ids {
+			if !seen.Contains(id) {
+				seen.Add(id)
+				innerCols = append(innerCols, tree.NameString(names[i]))
+			}
+		}
+	}
+	addInnerCols(colIDs, colNames)
+	addInnerCols(keyColIDs, keyColNames)
+	for _, side := range []string{"l", "r"} {
+		for _, n := range colNames {
+			outerCols = append(outerCols, fmt.Sprintf("%s.%s", side, tree.NameString(n)))
+		}
+	}
+	for i, n := range colNames {
+		name := tree.NameString(n)
+		on = append(on, fmt.Sprintf("l.%[1]s %[2]s r.%[1]s", name, uc.GetExclusionOperator(i)))
+	}
+	var lKey, rKey []string
+	for _, n := range keyColNames {
+		lKey = append(lKey, fmt.Sprintf("l.%s", tree.NameString(n)))
+		rKey = append(rKey, fmt.Sprintf("r.%s", tree.NameString(n)))
+	}
+	on = append(on, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(lKey, ", "), strings.Join(rKey, ", "),
+	))
+
+	src := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("%s@[%d]", src, indexIDForValidation)
+	}
+	inner := fmt.Sprintf("SELECT %s FROM %s", strings.Join(innerCols, ", "), src)
+	if uc.IsPartial() {
+		inner = fmt.Sprintf("%s WHERE (%s)", inner, uc.GetPredicate())
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS l JOIN (%[2]s) AS r ON %[3]s LIMIT 1`,
+		strings.Join(outerCols, ", "), // 1
+		inner,                         // 2
+		strings.Join(on, " AND "),     // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := exclusionViolationQuery(srcTable, uc, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := txn.QueryRowEx(ctx, "validate exclusion constraint", txn.KV(), sessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.GetName(),
+				),
+				uc.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%[1]s)=(%[2]s) conflicts with key (%[1]s)=(%[3]s).",
+				strings.Join(colNames, ","),
+				strings.Join(valuesStr[:n], ","),
+				strings.Join(valuesStr[n:], ","),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
@@ -57,6 +57,12 @@ func isIdentityCast(fromType, toType *types.T) bool {
 	if fromType.Identical(toType) {
 		return true
 	}
+	if (fromType.IsDomain() || toType.IsDomain()) &&
+		fromType.DomainBaseType().Identical(toType.DomainBaseType()) {
+		// Values of a domain are represented in the same way as values of its
+		// base type.
+		return true
+	}
 	if fromType.Family() == types.FloatFamily && toType.Family() == types.FloatFamily {
 		// Casts between floats are identical because all floats are represented
 		// by float64 physically.
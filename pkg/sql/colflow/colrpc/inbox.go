@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/ash"
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
@@ -329,7 +330,9 @@ func (i *Inbox) Next() coldata.Batch {
 	defer i.deserializationStopWatch.Stop()
 	for {
 		i.deserializationStopWatch.Stop()
+		waitState := ash.SetWaitState(i.Ctx, ash.Network)
 		m, err := i.stream.Recv()
+		waitState.Restore()
 		i.deserializationStopWatch.Start()
 		atomic.AddInt64(&i.statsAtomics.numMessages, 1)
 		if err != nil {
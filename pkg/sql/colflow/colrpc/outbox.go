@@ -329,6 +329,11 @@ func (o *Outbox) sendMetadata(ctx context.Context, stream flowStreamClient, errT
 		for _, meta := range o.inputMetaInfo.MetadataSources.DrainMeta() {
 			msg.Data.Metadata = append(msg.Data.Metadata, execinfrapb.LocalMetaToRemoteProducerMeta(ctx, meta))
 		}
+		// The CPU profile, if any, is stopped by getStats of the last outbox
+		// of the flow, and is sent only once.
+		if meta := o.flowCtx.TakeCPUProfileMeta(); meta != nil {
+			msg.Data.Metadata = append(msg.Data.Metadata, execinfrapb.LocalMetaToRemoteProducerMeta(ctx, *meta))
+		}
 	}
 	if !o.flowCtx.Gateway {
 		if trace := tracing.SpanFromContext(ctx).GetConfiguredRecording(); trace != nil {
@@ -7,6 +7,7 @@ package colflow
 
 import (
 	"context"
+	"runtime/pprof"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -64,10 +65,31 @@ type batchInfoCollector struct {
 	// childStatsCollectors contains the stats collectors for all of the inputs
 	// to the wrapped operator.
 	childStatsCollectors []childStatsCollector
+
+	// cpuProfileLabels, if set, is a context with the pprof labels attributing
+	// the CPU usage of the wrapped operator to its component, and
+	// consumerCPUProfileLabels is a context with the labels of the consumer of
+	// the component, which are restored once the wrapped operator returns.
+	// They are only set when a CPU profile is collected for the flow.
+	cpuProfileLabels, consumerCPUProfileLabels context.Context
 }
 
 var _ colexecop.Operator = &batchInfoCollector{}
 
+// cpuProfileLabeler is implemented by the stats collectors, which attribute
+// the CPU usage of the wrapped operator to its component via pprof labels when
+// a CPU profile is collected for the flow.
+type cpuProfileLabeler interface {
+	// setCPUProfileLabels sets the labels of the component along with the
+	// ones of its consumer.
+	setCPUProfileLabels(labels, consumerLabels context.Context)
+	// setConsumerCPUProfileLabels overrides the labels of the consumer of the
+	// component.
+	setConsumerCPUProfileLabels(consumerLabels context.Context)
+}
+
+var _ cpuProfileLabeler = &batchInfoCollector{}
+
 func makeBatchInfoCollector(
 	op colexecop.Operator,
 	id execinfrapb.ComponentID,
@@ -89,14 +111,43 @@ func (bic *batchInfoCollector) init() {
 	bic.Input.Init(bic.ctx)
 }
 
+// setCPUProfileLabels implements the cpuProfileLabeler interface.
+func (bic *batchInfoCollector) setCPUProfileLabels(labels, consumerLabels context.Context) {
+	bic.cpuProfileLabels = labels
+	bic.consumerCPUProfileLabels = consumerLabels
+}
+
+// setConsumerCPUProfileLabels implements the cpuProfileLabeler interface.
+func (bic *batchInfoCollector) setConsumerCPUProfileLabels(consumerLabels context.Context) {
+	bic.consumerCPUProfileLabels = consumerLabels
+}
+
+// enter attributes the CPU usage of the current goroutine to the component, if
+// a CPU profile is collected.
+func (bic *batchInfoCollector) enter() {
+	if bic.cpuProfileLabels != nil {
+		pprof.SetGoroutineLabels(bic.cpuProfileLabels)
+	}
+}
+
+// exit attributes the CPU usage of the current goroutine back to the consumer
+// of the component, if a CPU profile is collected.
+func (bic *batchInfoCollector) exit() {
+	if bic.consumerCPUProfileLabels != nil {
+		pprof.SetGoroutineLabels(bic.consumerCPUProfileLabels)
+	}
+}
+
 // Init is part of the colexecop.Operator interface.
 func (bic *batchInfoCollector) Init(ctx context.Context) {
 	bic.ctx = ctx
+	bic.enter()
 	bic.stopwatch.Start()
 	// Wrap the call to Init() with a panic catcher in order to get the correct
 	// execution time (e.g. in the statement bundle).
 	err := colexecerror.CatchVectorizedRuntimeError(bic.init)
 	bic.stopwatch.Stop()
+	bic.exit()
 	if err != nil {
 		colexecerror.InternalError(err)
 	}
@@ -115,11 +166,13 @@ func (bic *batchInfoCollector) next() {
 
 // Next is part of the colexecop.Operator interface.
 func (bic *batchInfoCollector) Next() coldata.Batch {
+	bic.enter()
 	bic.stopwatch.Start()
 	// Wrap the call to Next() with a panic catcher in order to get the correct
 	// execution time (e.g. in the statement bundle).
 	err := colexecerror.CatchVectorizedRuntimeError(bic.next)
 	bic.stopwatch.Stop()
+	bic.exit()
 	if err != nil {
 		colexecerror.InternalError(err)
 	}
@@ -305,6 +305,7 @@ func (f *vectorizedFlow) Run(ctx context.Context, noWait bool) {
 	if !noWait {
 		defer f.Wait()
 	}
+	defer f.SetCPUProfileLabels(ctx)()
 
 	if err := f.StartInternal(ctx, nil /* processors */, nil /* outputs */); err != nil {
 		f.GetRowSyncFlowConsumer().Push(nil /* row */, &execinfrapb.ProducerMetadata{Err: err})
@@ -441,6 +442,7 @@ func (s *vectorizedFlowCreator) wrapWithVectorizedStatsCollectorBase(
 		op.Root, kvReader, columnarizer, component, inputWatch,
 		memMonitors, diskMonitors, inputStatsCollectors,
 	)
+	s.maybeSetCPUProfileLabels(vsc, component, inputStatsCollectors)
 	op.Root = vsc
 	op.StatsCollectors = append(op.StatsCollectors, vsc)
 	maybeAddStatsInvariantChecker(op)
@@ -457,11 +459,37 @@ func (s *vectorizedFlowCreator) wrapWithNetworkVectorizedStatsCollector(
 ) {
 	inputWatch := timeutil.NewStopWatch()
 	nvsc := newNetworkVectorizedStatsCollector(op.Root, component, inputWatch, inbox, latency)
+	s.maybeSetCPUProfileLabels(nvsc, component, nil /* inputs */)
 	op.Root = nvsc
 	op.StatsCollectors = []colexecop.VectorizedStatsCollector{nvsc}
 	maybeAddStatsInvariantChecker(op)
 }
 
+// maybeSetCPUProfileLabels sets up the given stats collector to attribute the
+// CPU usage of the operator it wraps to its component, if a CPU profile is
+// collected for the flow. The stats collector becomes the consumer of the stats
+// collectors of its inputs; until it gets a consumer of its own, the CPU usage
+// is attributed back to the flow once the wrapped operator returns.
+func (s *vectorizedFlowCreator) maybeSetCPUProfileLabels(
+	sc colexecop.VectorizedStatsCollector,
+	component execinfrapb.ComponentID,
+	inputs []childStatsCollector,
+) {
+	flowCtx := s.f.GetFlowCtx()
+	if !flowCtx.CollectCPUProfile {
+		return
+	}
+	labels := flowCtx.CPUProfileLabels(context.Background(), &component)
+	if l, ok := sc.(cpuProfileLabeler); ok {
+		l.setCPUProfileLabels(labels, flowCtx.CPUProfileLabels(context.Background(), nil /* component */))
+	}
+	for _, input := range inputs {
+		if l, ok := input.(cpuProfileLabeler); ok {
+			l.setConsumerCPUProfileLabels(labels)
+		}
+	}
+}
+
 // makeGetStatsFnForOutbox creates a function that will retrieve all execution
 // statistics that the outbox is responsible for, nil is returned if stats are
 // not being collected.
@@ -493,6 +521,8 @@ func (s *vectorizedFlowCreator) makeGetStatsFnForOutbox(
 					ConsumedRU:   optional.MakeUint(uint64(flowCtx.TenantCPUMonitor.EndCollection(ctx))),
 				},
 			})
+			// The profile is sent as metadata by the outbox.
+			flowCtx.CPUProfile.EndCollection(ctx)
 		}
 		return result
 	}
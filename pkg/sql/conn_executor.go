@@ -493,6 +493,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 		ClusterID:               s.cfg.NodeInfo.LogicalClusterID,
 		SQLIDContainer:          cfg.NodeInfo.NodeID,
 		JobRegistry:             s.cfg.JobRegistry,
+		PlanRegressions:         insightsProvider.PlanRegressions(),
 		Knobs:                   cfg.SQLStatsTestingKnobs,
 		FlushesSuccessful:       serverMetrics.StatsMetrics.SQLStatsFlushesSuccessful,
 		FlushDoneSignalsIgnored: serverMetrics.StatsMetrics.SQLStatsFlushDoneSignalsIgnored,
@@ -684,6 +685,12 @@ func (s *Server) GetInsightsReader() *insights.LockingStore {
 	return s.insights.Store()
 }
 
+// GetPlanRegressionDetector returns the insights detector of plan regressions
+// for the current sql.Server.
+func (s *Server) GetPlanRegressionDetector() *insights.PlanRegressionDetector {
+	return s.insights.PlanRegressions()
+}
+
 // GetSQLStatsProvider returns the provider for the sqlstats subsystem.
 func (s *Server) GetSQLStatsProvider() sqlstats.Provider {
 	return s.sqlStats
@@ -2952,7 +2959,9 @@ func (ex *connExecutor) execCopyOut(
 	var cancelQuery context.CancelFunc
 	ctx, cancelQuery = ctxlog.WithCancel(ctx)
 	queryID := ex.server.cfg.GenerateID()
-	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ctx = ex.addActiveQuery(
+		ctx, cmd.ParsedStmt, formatStatementHideConstants(cmd.Stmt), nil /* placeholders */, queryID, cancelQuery,
+	)
 	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
 
 	defer func() {
@@ -3164,7 +3173,9 @@ func (ex *connExecutor) execCopyIn(
 	var cancelQuery context.CancelFunc
 	ctx, cancelQuery = ctxlog.WithCancel(ctx)
 	queryID := ex.server.cfg.GenerateID()
-	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ctx = ex.addActiveQuery(
+		ctx, cmd.ParsedStmt, formatStatementHideConstants(cmd.Stmt), nil /* placeholders */, queryID, cancelQuery,
+	)
 	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
 
 	defer func() {
@@ -4416,6 +4427,38 @@ func (ex *connExecutor) serialize() serverpb.Session {
 	}
 }
 
+// sampleActiveQueries is part of the RegistrySession interface.
+func (ex *connExecutor) sampleActiveQueries(
+	sampleTime time.Time, nodeID roachpb.NodeID, add func(serverpb.ActiveSessionHistorySample),
+) {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+	if len(ex.mu.ActiveQueries) == 0 {
+		return
+	}
+	sd := ex.sessionDataStack.Base()
+	sessionID := ex.planner.extendedEvalCtx.SessionID.GetBytes()
+	for id, query := range ex.mu.ActiveQueries {
+		if query.hidden || query.activity == nil {
+			continue
+		}
+		waitState, contendedKey := query.activity.State()
+		add(serverpb.ActiveSessionHistorySample{
+			SampleTime:      sampleTime,
+			NodeID:          nodeID,
+			SessionID:       sessionID,
+			QueryID:         id.String(),
+			TxnID:           query.txnID,
+			Username:        sd.SessionUser().Normalized(),
+			ApplicationName: ex.applicationName.Load().(string),
+			Fingerprint:     query.activity.Fingerprint,
+			Phase:           strings.ToLower(serverpb.ActiveQuery_Phase(query.phase).String()),
+			WaitState:       waitState.String(),
+			ContendedKey:    contendedKey,
+		})
+	}
+}
+
 func (ex *connExecutor) getPrepStmtsAccessor() preparedStatementsAccessor {
 	return connExPrepStmtsAccessor{
 		ex: ex,
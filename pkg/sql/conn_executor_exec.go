@@ -50,6 +50,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/ash"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
@@ -356,7 +357,7 @@ func (ex *connExecutor) execStmtInOpenState(
 	ex.state.mu.Lock()
 	ex.state.mu.stmtCount++
 	ex.state.mu.Unlock()
-	ex.addActiveQuery(parserStmt, pinfo, queryID, cancelQuery)
+	ctx = ex.addActiveQuery(ctx, parserStmt, stmt.StmtNoConstants, pinfo, queryID, cancelQuery)
 	defer func() {
 		if retErr == nil && !payloadHasError(retPayload) {
 			ex.incrementExecutedStmtCounter(ast)
@@ -1283,7 +1284,7 @@ func (ex *connExecutor) execStmtInOpenStateWithPausablePortal(
 		ex.state.mu.Lock()
 		ex.state.mu.stmtCount++
 		ex.state.mu.Unlock()
-		ex.addActiveQuery(parserStmt, pinfo, queryID, vars.cancelQuery)
+		ctx = ex.addActiveQuery(ctx, parserStmt, vars.stmt.StmtNoConstants, pinfo, queryID, vars.cancelQuery)
 
 		if portal.isPausable() {
 			portal.pauseInfo.execStmtInOpenState.cancelQueryFunc = vars.cancelQuery
@@ -3269,6 +3270,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 		&ex.sessionTracing,
 	)
 	recv.measureClientTime = planner.instrumentation.ShouldCollectExecStats()
+	recv.cpuProfiles = planner.instrumentation.cpuProfiles
 	recv.progressAtomic = progressAtomic
 	if ex.server.cfg.TestingKnobs.DistSQLReceiverPushCallbackFactory != nil {
 		recv.testingKnobs.pushCallback = ex.server.cfg.TestingKnobs.DistSQLReceiverPushCallbackFactory(ctx, planner.stmt.SQL)
@@ -3933,14 +3935,23 @@ func (ex *connExecutor) enableTracing(modes []string) error {
 	return ex.sessionTracing.StartTracing(recordingType, traceKV, showResults)
 }
 
-// addActiveQuery adds a running query to the list of running queries.
+// addActiveQuery registers the query as active in the session. If the active
+// session history is enabled, the returned context carries the ash.Activity
+// that records what the query is doing.
 func (ex *connExecutor) addActiveQuery(
+	ctx context.Context,
 	stmt statements.Statement[tree.Statement],
+	fingerprint string,
 	placeholders *tree.PlaceholderInfo,
 	queryID clusterunique.ID,
 	cancelQuery context.CancelFunc,
-) {
+) context.Context {
 	_, hidden := stmt.AST.(tree.HiddenFromShowQueries)
+	var activity *ash.Activity
+	if !hidden && activeSessionHistoryEnabled.Get(&ex.server.cfg.Settings.SV) {
+		activity = ash.NewActivity(fingerprint)
+		ctx = ash.ContextWithActivity(ctx, activity)
+	}
 	qm := &queryMeta{
 		txnID:         ex.state.mu.txn.ID(),
 		start:         ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived),
@@ -3951,10 +3962,12 @@ func (ex *connExecutor) addActiveQuery(
 		isFullScan:    false,
 		cancelQuery:   cancelQuery,
 		hidden:        hidden,
+		activity:      activity,
 	}
 	ex.mu.Lock()
 	defer ex.mu.Unlock()
 	ex.mu.ActiveQueries[queryID] = qm
+	return ctx
 }
 
 func (ex *connExecutor) removeActiveQuery(queryID clusterunique.ID, ast tree.Statement) {
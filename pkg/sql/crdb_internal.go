@@ -98,6 +98,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/lib/pq/oid"
@@ -231,6 +232,9 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalFullyQualifiedNamesViewID:          crdbInternalFullyQualifiedNamesView,
 		catconstants.CrdbInternalStoreLivenessSupportFrom:           crdbInternalStoreLivenessSupportFromTable,
 		catconstants.CrdbInternalStoreLivenessSupportFor:            crdbInternalStoreLivenessSupportForTable,
+		catconstants.CrdbInternalStatementHintsTableID:              crdbInternalStatementHintsTable,
+		catconstants.CrdbInternalNodeActiveSessionHistoryTableID:    crdbInternalNodeActiveSessionHistoryTable,
+		catconstants.CrdbInternalClusterActiveSessionHistoryTableID: crdbInternalClusterActiveSessionHistoryTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -8454,7 +8458,9 @@ CREATE TABLE crdb_internal.%s (
 	implicit_txn               BOOL NOT NULL,
 	cpu_sql_nanos              INT8,
 	error_code                 STRING,
-	last_error_redactable      STRING
+	last_error_redactable      STRING,
+	previous_plan_gist         STRING,
+	previous_plan_mean_latency INTERVAL
 )`
 
 var crdbInternalClusterExecutionInsightsTable = virtualSchemaTable{
@@ -8581,6 +8587,16 @@ func populateStmtInsights(
 				}
 			}
 
+			previousPlanGist := tree.DNull
+			previousPlanLatency := tree.DNull
+			if s.PreviousPlanGist != "" {
+				previousPlanGist = tree.NewDString(s.PreviousPlanGist)
+				previousPlanLatency = tree.NewDInterval(
+					duration.MakeDuration(int64(s.PreviousPlanLatencyInSeconds*float64(time.Second)), 0, 0),
+					types.DefaultIntervalTypeMetadata,
+				)
+			}
+
 			err = errors.CombineErrors(err, addRow(
 				tree.NewDString(hex.EncodeToString(insight.Session.ID.GetBytes())),
 				tree.NewDUuid(tree.DUuid{UUID: insight.Transaction.ID}),
@@ -8611,6 +8627,8 @@ func populateStmtInsights(
 				tree.NewDInt(tree.DInt(s.CPUSQLNanos)),
 				errorCode,
 				errorMsg,
+				previousPlanGist,
+				previousPlanLatency,
 			))
 		}
 	}
@@ -9646,6 +9664,153 @@ CREATE TABLE crdb_internal.store_liveness_support_for (
 	},
 }
 
+var crdbInternalStatementHintsTable = virtualSchemaTable{
+	comment: `statement hints of system.statement_hints and their usage (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.statement_hints (
+  row_id        INT NOT NULL,
+  fingerprint   STRING NOT NULL,
+  hint_type     STRING NOT NULL,
+  table_name    STRING,
+  hint_value    STRING NOT NULL,
+  created_at    TIMESTAMPTZ NOT NULL,
+  error         STRING, -- set if the hint is malformed, in which case it is never applied
+  applied_count INT NOT NULL,
+  last_applied  TIMESTAMPTZ
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		hasRoleOption, _, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasRoleOption {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+
+		cache := p.extendedEvalCtx.ExecCfg.StatementHints
+		if cache == nil {
+			return nil
+		}
+		for _, entry := range cache.Entries() {
+			tableName, errString, lastApplied := tree.DNull, tree.DNull, tree.DNull
+			if entry.Table != "" {
+				tableName = tree.NewDString(entry.Table)
+			}
+			if entry.Err != nil {
+				errString = tree.NewDString(entry.Err.Error())
+			}
+			if !entry.LastApplied.IsZero() {
+				lastApplied = tree.MustMakeDTimestampTZ(entry.LastApplied, time.Microsecond)
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(entry.RowID)),
+				tree.NewDString(entry.Fingerprint),
+				tree.NewDString(string(entry.Type)),
+				tableName,
+				tree.NewDString(entry.Value),
+				tree.MustMakeDTimestampTZ(entry.CreatedAt, time.Microsecond),
+				errString,
+				tree.NewDInt(tree.DInt(entry.AppliedCount)),
+				lastApplied,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// This is the table structure for both {node_,}active_session_history.
+const activeSessionHistorySchemaPattern = `
+CREATE TABLE crdb_internal.%s (
+  sample_time      TIMESTAMPTZ NOT NULL,
+  node_id          INT NOT NULL,
+  session_id       STRING NOT NULL,
+  query_id         STRING NOT NULL,
+  txn_id           UUID,
+  user_name        STRING NOT NULL,
+  application_name STRING NOT NULL,
+  fingerprint      STRING NOT NULL,
+  phase            STRING NOT NULL,
+  wait_state       STRING NOT NULL, -- one of CPU, LockWait, AdmissionQueue, Network or IO
+  contended_key    BYTES            -- the key waited on in the LockWait state, if known
+)`
+
+const activeSessionHistoryCommentPattern = `periodic samples of the active statements %s`
+
+var crdbInternalNodeActiveSessionHistoryTable = virtualSchemaTable{
+	schema:  fmt.Sprintf(activeSessionHistorySchemaPattern, "node_active_session_history"),
+	comment: fmt.Sprintf(activeSessionHistoryCommentPattern, "(RAM; local node only)"),
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		response, err := p.extendedEvalCtx.SQLStatusServer.ListLocalActiveSessionHistory(ctx, &serverpb.ListActiveSessionHistoryRequest{})
+		if err != nil {
+			return err
+		}
+		return populateActiveSessionHistoryTable(ctx, p, addRow, response)
+	},
+}
+
+var crdbInternalClusterActiveSessionHistoryTable = virtualSchemaTable{
+	schema:  fmt.Sprintf(activeSessionHistorySchemaPattern, "active_session_history"),
+	comment: fmt.Sprintf(activeSessionHistoryCommentPattern, "(cluster RPC; expensive!)"),
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		response, err := p.extendedEvalCtx.SQLStatusServer.ListActiveSessionHistory(ctx, &serverpb.ListActiveSessionHistoryRequest{})
+		if err != nil {
+			return err
+		}
+		return populateActiveSessionHistoryTable(ctx, p, addRow, response)
+	},
+}
+
+func populateActiveSessionHistoryTable(
+	ctx context.Context,
+	p *planner,
+	addRow func(...tree.Datum) error,
+	response *serverpb.ListActiveSessionHistoryResponse,
+) error {
+	hasPermission, shouldRedactKey, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasPermission {
+		return noViewActivityOrViewActivityRedactedRoleError(p.User())
+	}
+	for i := range response.Samples {
+		s := &response.Samples[i]
+		sampleTime, err := tree.MakeDTimestampTZ(s.SampleTime, time.Microsecond)
+		if err != nil {
+			return err
+		}
+		txnID := tree.DNull
+		if s.TxnID != uuid.Nil {
+			txnID = tree.NewDUuid(tree.DUuid{UUID: s.TxnID})
+		}
+		key := tree.DNull
+		if len(s.ContendedKey) > 0 && !shouldRedactKey {
+			key = tree.NewDBytes(tree.DBytes(s.ContendedKey))
+		}
+		if err := addRow(
+			sampleTime,
+			tree.NewDInt(tree.DInt(s.NodeID)),
+			tree.NewDString(hex.EncodeToString(s.SessionID)),
+			tree.NewDString(s.QueryID),
+			txnID,
+			tree.NewDString(s.Username),
+			tree.NewDString(s.ApplicationName),
+			tree.NewDString(s.Fingerprint),
+			tree.NewDString(s.Phase),
+			tree.NewDString(s.WaitState),
+			key,
+		); err != nil {
+			return err
+		}
+	}
+	for _, rpcErr := range response.Errors {
+		log.Warningf(ctx, "%v", rpcErr.Message)
+	}
+	return nil
+}
+
 func populateStoreLivenessSupportResponse(
 	resp *slpb.InspectStoreLivenessResponse, addRow func(...tree.Datum) error,
 ) error {
@@ -155,11 +155,15 @@ func (n *createFunctionNode) createNewFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var variadic bool
 	for paramIdx, param := range udfDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, param.Type)
 		}
+		if class == tree.RoutineParamVariadic {
+			variadic = true
+		}
 		if class == tree.RoutineParamOut {
 			outParamOrdinals = append(outParamOrdinals, int32(paramIdx))
 			outParamTypes = append(outParamTypes, param.Type)
@@ -179,6 +183,7 @@ func (n *createFunctionNode) createNewFunction(
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
 			DefaultExprs:     defaultExprs,
+			Variadic:         variadic,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -251,11 +256,15 @@ func (n *createFunctionNode) replaceFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var variadic bool
 	for i, p := range n.cf.Params {
 		udfDesc.Params[i], err = makeFunctionParam(params.ctx, params.p.SemaCtx(), p, params.p)
 		if err != nil {
 			return err
 		}
+		if p.Class == tree.RoutineParamVariadic {
+			variadic = true
+		}
 		if p.Class == tree.RoutineParamOut {
 			outParamOrdinals = append(outParamOrdinals, int32(i))
 			outParamTypes = append(outParamTypes, udfDesc.Params[i].Type)
@@ -272,6 +281,9 @@ func (n *createFunctionNode) replaceFunction(
 	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
 		return err
 	}
+	if err := params.p.validateFunctionVolatilityForReferences(params.ctx, udfDesc); err != nil {
+		return err
+	}
 
 	// Removing all existing references before adding new references.
 	for _, id := range udfDesc.DependsOn {
@@ -310,7 +322,7 @@ func (n *createFunctionNode) replaceFunction(
 	}
 
 	signatureChanged := len(existing.OutParamOrdinals) != len(outParamOrdinals) ||
-		len(existing.DefaultExprs) != len(defaultExprs)
+		len(existing.DefaultExprs) != len(defaultExprs) || existing.Variadic != variadic
 	for i := 0; !signatureChanged && i < len(outParamOrdinals); i++ {
 		signatureChanged = existing.OutParamOrdinals[i] != outParamOrdinals[i] ||
 			!existing.OutParamTypes.GetAt(i).Equivalent(outParamTypes[i])
@@ -338,6 +350,7 @@ func (n *createFunctionNode) replaceFunction(
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
 				DefaultExprs:     defaultExprs,
+				Variadic:         variadic,
 			},
 		); err != nil {
 			return err
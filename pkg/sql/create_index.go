@@ -267,6 +267,9 @@ func makeIndexDescriptor(
 		if err != nil {
 			return nil, err
 		}
+		if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(expr, tree.IndexPredicateExpr); err != nil {
+			return nil, err
+		}
 		indexDesc.Predicate = expr
 	}
 
@@ -417,6 +420,12 @@ func populateInvertedIndexDescriptor(
 		default:
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
+	case types.RangeFamily:
+		switch invCol.OpClass {
+		case "range_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	default:
 		return tabledesc.NewInvalidInvertedColumnError(column.GetName(), column.GetType().Name())
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
@@ -366,6 +367,15 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	var desc *tabledesc.Mutable
 	var affected map[descpb.ID]*tabledesc.Mutable
+	// Collect the LIKE table definitions before they are replaced in n.n.Defs
+	// by newTableDesc, since some of the source tables' properties are only
+	// copied once the new descriptor has been written.
+	var likeDefs []*tree.LikeTableDef
+	for _, def := range n.n.Defs {
+		if d, ok := def.(*tree.LikeTableDef); ok {
+			likeDefs = append(likeDefs, d)
+		}
+	}
 	// creationTime is initialized to a zero value and populated at read time.
 	// See the comment in desc.MaybeIncrementVersion.
 	//
@@ -406,8 +416,9 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 
 		// If we have a single statement txn we want to run CTAS async, and
-		// consequently ensure it gets queued as a SchemaChange.
-		if params.extendedEvalCtx.TxnIsSingleStmt {
+		// consequently ensure it gets queued as a SchemaChange. There is nothing
+		// to backfill for CREATE TABLE ... AS ... WITH NO DATA.
+		if params.extendedEvalCtx.TxnIsSingleStmt && !n.n.WithNoData {
 			desc.State = descpb.DescriptorState_ADD
 		}
 	} else {
@@ -455,6 +466,13 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	// Update back references in functions used by partial index predicates.
+	for _, idx := range desc.NonDropIndexes() {
+		if err := params.p.updateFunctionReferencesForIndex(params.ctx, desc, idx.GetID()); err != nil {
+			return err
+		}
+	}
+
 	// Descriptor written to store here.
 	if err := params.p.createDescriptor(
 		params.ctx,
@@ -480,6 +498,10 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.copyLikeTableProperties(params.ctx, desc, likeDefs); err != nil {
+		return err
+	}
+
 	if err := validateDescriptor(params.ctx, params.p, desc); err != nil {
 		return err
 	}
@@ -538,7 +560,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	// If we are in a multi-statement txn or the source has placeholders, we
 	// execute the CTAS query synchronously.
-	if n.n.As() && !params.extendedEvalCtx.TxnIsSingleStmt {
+	if n.n.As() && !n.n.WithNoData && !params.extendedEvalCtx.TxnIsSingleStmt {
 		err = func() error {
 			// The data fill portion of CREATE AS must operate on a read snapshot,
 			// so that it doesn't end up observing its own writes.
@@ -774,6 +796,64 @@ func addUniqueWithoutIndexTableDef(
 	return nil
 }
 
+// addExclusionTableDef runs various checks on the given
+// ExcludeConstraintTableDef before adding it as an exclusion constraint to the
+// given table descriptor.
+func addExclusionTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	// Nodes running v24.3 ignore the operators of exclusion constraints.
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V25_1) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"EXCLUDE constraints unsupported in mixed-version cluster")
+	}
+	colNames := make([]string, len(d.Elems))
+	ops := make([]string, len(d.Elems))
+	for i := range d.Elems {
+		col, err := desc.FindActiveOrNewColumnByName(d.Elems[i].Column)
+		if err != nil {
+			return err
+		}
+		if err := schemaexpr.ValidateExclusionOperator(d.Elems[i].Operator, col.GetType()); err != nil {
+			return err
+		}
+		colNames[i] = col.GetName()
+		ops[i] = d.Elems[i].Operator.Symbol.String()
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	constraintName := string(d.Name)
+	if constraintName == "" {
+		constraintName = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", desc.GetName(), strings.Join(colNames, "_")),
+			func(p string) bool {
+				return catalog.FindConstraintByName(desc, p) != nil
+			},
+		)
+	}
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, desc, constraintName, colNames, ops, predicate, ts, validationBehavior,
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -789,6 +869,24 @@ func ResolveUniqueWithoutIndexConstraint(
 	predicate string,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
+) error {
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, tbl, constraintName, colNames, nil /* exclusionOps */, predicate, ts, validationBehavior,
+	)
+}
+
+// resolveUniqueWithoutIndexConstraint is like
+// ResolveUniqueWithoutIndexConstraint, but it adds an exclusion constraint if
+// exclusionOps is non-empty.
+func resolveUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	tbl *tabledesc.Mutable,
+	constraintName string,
+	colNames []string,
+	exclusionOps []string,
+	predicate string,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
 ) error {
 	var colSet catalog.TableColSet
 	cols := make([]catalog.Column, len(colNames))
@@ -835,12 +933,13 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:               constraintName,
+		TableID:            tbl.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		ConstraintID:       tbl.NextConstraintID,
+		ExclusionOperators: exclusionOps,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1321,6 +1420,11 @@ func newTableDescIfAs(
 	if err != nil {
 		return nil, err
 	}
+	if p.WithNoData {
+		// The table is not populated, so there is no query to store for the
+		// schema changer to backfill from.
+		return desc, nil
+	}
 	createQuery, err := getFinalSourceQuery(params, p.AsSource, evalContext)
 	if err != nil {
 		return nil, err
@@ -2113,7 +2217,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2284,6 +2389,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExclusionTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
@@ -2491,6 +2603,9 @@ func newTableDesc(
 		); err != nil {
 			return nil, err
 		}
+		if err := params.p.checkTTLArchivePrivilege(params.ctx, ttl); err != nil {
+			return nil, err
+		}
 
 		params.p.Txn()
 		j, err := CreateRowLevelTTLScheduledJob(
@@ -2601,7 +2716,8 @@ func rowLevelTTLAutomaticColumnExpr(intervalExpr tree.Expr) tree.Expr {
 // searching for LikeTableDefs. If any are found, each LikeTableDef will be
 // replaced in the output tree.TableDefs (which will be a copy of the input
 // node's TableDefs) by an equivalent set of TableDefs pulled from the
-// LikeTableDef's target table.
+// LikeTableDef's target table. Storage parameters copied from the target
+// table are appended to the input node's StorageParams.
 // If no LikeTableDefs are found, the output tree.TableDefs will be nil.
 func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs, error) {
 	var newDefs tree.TableDefs
@@ -2622,15 +2738,17 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		if err != nil {
 			return nil, err
 		}
-		opts := tree.LikeTableOpt(0)
-		// Process ons / offs.
-		for _, opt := range d.Options {
-			if opt.Excluded {
-				opts &^= opt.Opt
-			} else {
-				opts |= opt.Opt
-			}
+		opts := likeTableOpts(d)
+		storageParams, err := likeTableStorageParams(td, opts, n.StorageParams)
+		if err != nil {
+			return nil, err
 		}
+		n.StorageParams = append(n.StorageParams, storageParams...)
+
+		// When the row-level TTL settings are copied, the TTL expiration column
+		// is created along with them, so it is not copied from the target table.
+		skipTTLColumn := opts.Has(tree.LikeTableOptStorage) && td.HasRowLevelTTL() &&
+			td.GetRowLevelTTL().HasDurationExpr()
 
 		// Copy defaults of implicitly created columns if they are needed by indexes.
 		// This is required to ensure the newly created table still works as expected
@@ -2661,6 +2779,9 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				// Don't add system-created implicit columns.
 				continue
 			}
+			if skipTTLColumn && c.Name == catpb.TTLDefaultExpirationColumnName {
+				continue
+			}
 			def := tree.ColumnTableDef{
 				Name:   tree.Name(c.Name),
 				Type:   c.Type,
@@ -2671,7 +2792,22 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 			} else {
 				def.Nullable.Nullability = tree.NotNull
 			}
-			if c.DefaultExpr != nil {
+			if c.GeneratedAsIdentityType != catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+				// The DEFAULT expression of an identity column refers to the sequence
+				// owned by the target table, so it is never copied. Instead, a new
+				// sequence is created for the column if identities are included.
+				if opts.Has(tree.LikeTableOptIdentity) {
+					def.GeneratedIdentity.IsGeneratedAsIdentity = true
+					def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedByDefault
+					if c.GeneratedAsIdentityType == catpb.GeneratedAsIdentityType_GENERATED_ALWAYS {
+						def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedAlways
+					}
+					def.GeneratedIdentity.SeqOptions, err = parseIdentitySequenceOptions(c)
+					if err != nil {
+						return nil, err
+					}
+				}
+			} else if c.DefaultExpr != nil {
 				_, shouldCopyColumnDefault := shouldCopyColumnDefaultSet[c.Name]
 				if opts.Has(tree.LikeTableOptDefaults) || shouldCopyColumnDefault {
 					def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr)
@@ -2713,6 +2849,31 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if len(c.ExclusionOperators) > 0 {
+					def := tree.ExcludeConstraintTableDef{
+						Name:  tree.Name(c.Name),
+						Elems: make(tree.ExcludeElemList, len(c.ColumnIDs)),
+					}
+					colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
+					if err != nil {
+						return nil, err
+					}
+					for i := range colNames {
+						symbol, _ := treecmp.ComparisonOperatorSymbolFromName(c.ExclusionOperators[i])
+						def.Elems[i] = tree.ExcludeElem{
+							Column:   tree.Name(colNames[i]),
+							Operator: treecmp.MakeComparisonOperator(symbol),
+						}
+					}
+					if c.IsPartial() {
+						def.Predicate, err = parser.ParseExpr(c.Predicate)
+						if err != nil {
+							return nil, err
+						}
+					}
+					defs = append(defs, &def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
@@ -2811,6 +2972,196 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 	return newDefs, nil
 }
 
+// likeTableOpts returns the options enabled by the INCLUDING and EXCLUDING
+// clauses of a LIKE table definition.
+func likeTableOpts(d *tree.LikeTableDef) tree.LikeTableOpt {
+	opts := tree.LikeTableOpt(0)
+	// Process ons / offs.
+	for _, opt := range d.Options {
+		if opt.Excluded {
+			opts &^= opt.Opt
+		} else {
+			opts |= opt.Opt
+		}
+	}
+	return opts
+}
+
+// likeTableStorageParams returns the storage parameters of the target table of
+// a LIKE table definition that are copied by the given options. Statistics
+// settings are copied by INCLUDING STATISTICS, and all other storage parameters,
+// including the row-level TTL settings, by INCLUDING STORAGE. Parameters that
+// are explicitly set on the new table take precedence over the copied ones.
+func likeTableStorageParams(
+	td catalog.TableDescriptor, opts tree.LikeTableOpt, explicit tree.StorageParams,
+) (tree.StorageParams, error) {
+	var ret tree.StorageParams
+	for _, param := range td.GetStorageParams(false /* spaceBetweenEqual */) {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.HasPrefix(key, "sql_stats_") {
+			if !opts.Has(tree.LikeTableOptStatistics) {
+				continue
+			}
+		} else if !opts.Has(tree.LikeTableOptStorage) {
+			continue
+		}
+		if explicit.GetVal(key) != nil {
+			continue
+		}
+		expr, err := parser.ParseExpr(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected value for storage parameter %s", key)
+		}
+		ret = append(ret, tree.StorageParam{Key: key, Value: expr})
+	}
+	return ret, nil
+}
+
+// parseIdentitySequenceOptions returns the sequence options of an identity
+// column, which are stored in the column descriptor in serialized form.
+func parseIdentitySequenceOptions(c *descpb.ColumnDescriptor) (tree.SequenceOptions, error) {
+	if c.GeneratedAsIdentitySequenceOption == nil ||
+		strings.TrimSpace(*c.GeneratedAsIdentitySequenceOption) == "" {
+		return nil, nil
+	}
+	stmt, err := parser.ParseOne("CREATE SEQUENCE fake_seq " + *c.GeneratedAsIdentitySequenceOption)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse sequence option")
+	}
+	createSeq, ok := stmt.AST.(*tree.CreateSequence)
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected statement %T for sequence options", stmt.AST)
+	}
+	return createSeq.Options, nil
+}
+
+// copyLikeTableProperties copies the properties of the target tables of LIKE
+// table definitions that are not stored in the table descriptor, namely
+// comments and zone configurations, onto the newly created table.
+func (p *planner) copyLikeTableProperties(
+	ctx context.Context, desc *tabledesc.Mutable, likeDefs []*tree.LikeTableDef,
+) error {
+	for _, d := range likeDefs {
+		opts := likeTableOpts(d)
+		if !opts.Has(tree.LikeTableOptComments) && !opts.Has(tree.LikeTableOptStorage) {
+			continue
+		}
+		_, td, err := p.ResolveMutableTableDescriptor(ctx, &d.Name, true, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		if opts.Has(tree.LikeTableOptComments) {
+			if err := p.copyLikeTableComments(ctx, td, desc); err != nil {
+				return err
+			}
+		}
+		// The zone configurations of multi-region tables are derived from their
+		// locality, so they are not copied.
+		if opts.Has(tree.LikeTableOptStorage) && desc.LocalityConfig == nil {
+			if err := p.copyLikeTableZoneConfig(ctx, td, desc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyLikeTableComments copies the comments on the source table, and on those
+// of its columns, indexes and constraints that were copied onto the new table,
+// matching them by name.
+func (p *planner) copyLikeTableComments(
+	ctx context.Context, src catalog.TableDescriptor, dst *tabledesc.Mutable,
+) error {
+	if cmt, ok := p.Descriptors().GetTableComment(src.GetID()); ok {
+		if err := p.updateComment(ctx, dst.GetID(), 0, catalogkeys.TableCommentType, cmt); err != nil {
+			return err
+		}
+	}
+	for _, srcCol := range src.PublicColumns() {
+		cmt, ok := p.Descriptors().GetColumnComment(src.GetID(), srcCol.GetPGAttributeNum())
+		if !ok {
+			continue
+		}
+		if dstCol := catalog.FindColumnByName(dst, srcCol.GetName()); dstCol != nil {
+			if err := p.updateComment(
+				ctx, dst.GetID(), uint32(dstCol.GetPGAttributeNum()), catalogkeys.ColumnCommentType, cmt,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	for _, srcIdx := range src.NonDropIndexes() {
+		cmt, ok := p.Descriptors().GetIndexComment(src.GetID(), srcIdx.GetID())
+		if !ok {
+			continue
+		}
+		if dstIdx := catalog.FindIndexByName(dst, srcIdx.GetName()); dstIdx != nil {
+			if err := p.updateComment(
+				ctx, dst.GetID(), uint32(dstIdx.GetID()), catalogkeys.IndexCommentType, cmt,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	for _, srcConstraint := range src.AllConstraints() {
+		cmt, ok := p.Descriptors().GetConstraintComment(src.GetID(), srcConstraint.GetConstraintID())
+		if !ok {
+			continue
+		}
+		if dstConstraint := catalog.FindConstraintByName(dst, srcConstraint.GetName()); dstConstraint != nil {
+			if err := p.updateComment(
+				ctx, dst.GetID(), uint32(dstConstraint.GetConstraintID()), catalogkeys.ConstraintCommentType, cmt,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyLikeTableZoneConfig copies the zone configuration of the source table
+// onto the new table. Zone configurations of the source table's indexes are
+// copied for the indexes that were copied onto the new table, matching them by
+// name. Partitions are never copied, so neither are their zone configurations.
+func (p *planner) copyLikeTableZoneConfig(
+	ctx context.Context, src catalog.TableDescriptor, dst *tabledesc.Mutable,
+) error {
+	zc, err := p.Descriptors().GetZoneConfig(ctx, p.txn, src.GetID())
+	if err != nil || zc == nil {
+		return err
+	}
+	zone := zc.Clone().ZoneConfigProto()
+	subzones := zone.Subzones
+	zone.Subzones = nil
+	for _, s := range subzones {
+		if s.PartitionName != "" {
+			continue
+		}
+		srcIdx := catalog.FindNonDropIndex(src, func(idx catalog.Index) bool {
+			return idx.GetID() == descpb.IndexID(s.IndexID)
+		})
+		if srcIdx == nil {
+			continue
+		}
+		if dstIdx := catalog.FindIndexByName(dst, srcIdx.GetName()); dstIdx != nil {
+			s.IndexID = uint32(dstIdx.GetID())
+			zone.Subzones = append(zone.Subzones, s)
+		}
+	}
+	_, err = writeZoneConfig(
+		ctx,
+		p.InternalSQLTxn(),
+		dst.GetID(),
+		dst,
+		zone,
+		nil, /* expectedExistingRawBytes */
+		p.ExecCfg(),
+		len(zone.Subzones) > 0, /* hasNewSubzones */
+		p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+	)
+	return err
+}
+
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
 // based on all the `colNames` and the bucket count. It delegates to one of
 // makeHashShardComputeExpr.
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -16,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/enum"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -233,6 +235,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -314,6 +318,8 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		return params.p.createDomainWithID(params, id, n.n, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -391,6 +397,32 @@ func CreateEnumTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// resolveCompositeElementType resolves the type of an element of a composite
+// type and checks that it is supported.
+func (p *planner) resolveCompositeElementType(
+	ctx context.Context, ref tree.ResolvableTypeReference,
+) (*types.T, error) {
+	typ, err := tree.ResolveType(ctx, ref, p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Identical(types.Trigger) {
+		return nil, tree.CannotAcceptTriggerErr
+	}
+	if err = tree.CheckUnsupportedType(ctx, &p.semaCtx, typ); err != nil {
+		return nil, err
+	}
+	if typ.UserDefined() {
+		return nil, unimplemented.NewWithIssue(91779,
+			"composite types that reference user-defined types not yet supported")
+	}
+	if typ.TypeMeta.ImplicitRecordType {
+		return nil, unimplemented.NewWithIssue(70099,
+			"cannot use table record type as part of composite type")
+	}
+	return typ, nil
+}
+
 // createCompositeTypeDesc creates a new composite type descriptor.
 func createCompositeTypeDesc(
 	params runParams,
@@ -410,24 +442,10 @@ func createCompositeTypeDesc(
 				"composite type definition contains duplicate label %q", value)
 		}
 		elts[i].ElementLabel = string(value.Label)
-		typ, err := tree.ResolveType(params.ctx, value.Type, params.p.semaCtx.TypeResolver)
+		typ, err := params.p.resolveCompositeElementType(params.ctx, value.Type)
 		if err != nil {
 			return nil, err
 		}
-		if typ.Identical(types.Trigger) {
-			return nil, tree.CannotAcceptTriggerErr
-		}
-		if err = tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, typ); err != nil {
-			return nil, err
-		}
-		if typ.UserDefined() {
-			return nil, unimplemented.NewWithIssue(91779,
-				"composite types that reference user-defined types not yet supported")
-		}
-		if typ.TypeMeta.ImplicitRecordType {
-			return nil, unimplemented.NewWithIssue(70099,
-				"cannot use table record type as part of composite type")
-		}
 		elts[i].ElementType = typ
 		seenLabels[value.Label] = struct{}{}
 	}
@@ -457,6 +475,112 @@ func createCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// createDomainTypeDesc creates a new domain type descriptor.
+func createDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	// Nodes running v24.3 cannot read domain type descriptors.
+	if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V25_1) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE DOMAIN unsupported in mixed-version cluster")
+	}
+	baseType, err := tree.ResolveType(params.ctx, n.DomainType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err = tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, baseType); err != nil {
+		return nil, err
+	}
+	switch baseType.Family() {
+	case types.AnyFamily, types.UnknownFamily, types.VoidFamily, types.TriggerFamily,
+		types.EncodedKeyFamily:
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", baseType.SQLStringForError())
+	case types.ArrayFamily, types.TupleFamily:
+		return nil, unimplemented.NewWithIssuef(27796,
+			"domains over %s types are not yet supported", baseType.Family().Name())
+	}
+	if baseType.UserDefined() {
+		return nil, unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType:         baseType,
+		NextConstraintID: 1,
+	}
+	var seenNull, seenNotNull bool
+	seenNames := make(map[tree.Name]struct{})
+	for i := range n.DomainConstraints {
+		c := &n.DomainConstraints[i]
+		switch c.Nullability {
+		case tree.NotNull:
+			seenNotNull = true
+		case tree.Null:
+			seenNull = true
+		default:
+			checkExpr, err := schemaexpr.ValidateDomainCheckExpr(
+				params.ctx, c.Check, baseType, &params.p.semaCtx,
+			)
+			if err != nil {
+				return nil, err
+			}
+			name := c.Name
+			if name == "" {
+				name = tree.Name(fmt.Sprintf("%s_check", typeName.Type()))
+				for j := 1; ; j++ {
+					if _, ok := seenNames[name]; !ok {
+						break
+					}
+					name = tree.Name(fmt.Sprintf("%s_check%d", typeName.Type(), j))
+				}
+			} else if _, ok := seenNames[name]; ok {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", name, typeName.Type())
+			}
+			seenNames[name] = struct{}{}
+			domain.Constraints = append(domain.Constraints, descpb.TypeDescriptor_Domain_Constraint{
+				ConstraintID: domain.NextConstraintID,
+				Name:         string(name),
+				Expr:         checkExpr,
+				Validity:     descpb.ConstraintValidity_Validated,
+			})
+			domain.NextConstraintID++
+		}
+	}
+	if seenNull && seenNotNull {
+		return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+	}
+	domain.NotNull = seenNotNull
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
 func (p *planner) createEnumWithID(
 	ctx context.Context,
 	evalCtx *eval.Context,
@@ -510,6 +634,26 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params.ctx, p, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := createDomainTypeDesc(params, id, n, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+	return p.finishCreateType(params.ctx, params.EvalContext(), typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	ctx context.Context,
 	evalCtx *eval.Context,
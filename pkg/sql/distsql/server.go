@@ -183,6 +183,7 @@ func (ds *ServerImpl) setupFlow(
 	var sp *tracing.Span                       // will be Finish()ed by Flow.Cleanup()
 	var monitor, diskMonitor *mon.BytesMonitor // will be closed in Flow.Cleanup()
 	var onFlowCleanupEnd func(context.Context) // will be called at the very end of Flow.Cleanup()
+	var cpuProfile *execinfra.CPUProfileHelper // will be stopped in Flow.Cleanup()
 	// Make sure that we clean up all resources (which in the happy case are
 	// cleaned up in Flow.Cleanup()) if an error is encountered.
 	defer func() {
@@ -193,6 +194,7 @@ func (ds *ServerImpl) setupFlow(
 			if diskMonitor != nil {
 				diskMonitor.Stop(ctx)
 			}
+			cpuProfile.EndCollection(ctx)
 			if onFlowCleanupEnd != nil {
 				onFlowCleanupEnd(ctx)
 			} else {
@@ -352,6 +354,16 @@ func (ds *ServerImpl) setupFlow(
 		ctx, req.Flow.FlowID, evalCtx, monitor, diskMonitor, makeLeaf, req.TraceKV,
 		req.CollectStats, localState, req.Flow.Gateway == ds.NodeID.SQLInstanceID(),
 	)
+	if req.CollectStats && req.CollectCPUProfile {
+		// The gateway flow is profiled by the connExecutor, which only requests
+		// the profile if it is collecting it. Remote flows collect the profile
+		// themselves, unless another one is already in progress on this node.
+		if !flowCtx.Gateway {
+			cpuProfile = execinfra.StartCPUProfile(ctx, ds.Settings)
+			flowCtx.CPUProfile = cpuProfile
+		}
+		flowCtx.CollectCPUProfile = flowCtx.Gateway || cpuProfile != nil
+	}
 
 	// req always contains the desired vectorize mode, regardless of whether we
 	// have non-nil localState.EvalContext. We don't want to update EvalContext
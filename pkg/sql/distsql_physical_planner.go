@@ -965,6 +965,10 @@ type PlanningCtx struct {
 	// If set, statement execution stats should be collected.
 	collectExecStats bool
 
+	// If set, a CPU profile labeled by flow and operator should be collected
+	// while the flows execute. Only used along with collectExecStats.
+	collectCPUProfile bool
+
 	// parallelizeScansIfLocal indicates whether we might want to create
 	// multiple table readers if the physical plan ends up being fully local.
 	// This value is determined based on whether there are any mutations in the
@@ -1050,6 +1054,7 @@ func (p *PlanningCtx) setUpForMainQuery(
 	}
 	p.associateNodeWithComponents = planner.instrumentation.getAssociateNodeWithComponentsFn()
 	p.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	p.collectCPUProfile = planner.instrumentation.ShouldCollectCPUProfile()
 }
 
 // SaveFlowsFunc is the signature for a function used to examine the physical
@@ -410,6 +410,7 @@ func (dsp *DistSQLPlanner) setupFlows(
 		Version:           execinfra.Version,
 		TraceKV:           evalCtx.Tracing.KVTracingEnabled(),
 		CollectStats:      planCtx.collectExecStats,
+		CollectCPUProfile: planCtx.collectCPUProfile,
 		StatementSQL:      statementSQL,
 	}
 	if localState.IsLocal {
@@ -1007,6 +1008,10 @@ type DistSQLReceiver struct {
 
 	stats topLevelQueryStats
 
+	// cpuProfiles, if set, accumulates the CPU profiles sent by the flows for
+	// EXPLAIN ANALYZE (DEBUG, PROFILE).
+	cpuProfiles *stmtCPUProfiles
+
 	// isTenantExplainAnalyze is used to indicate that network egress should be
 	// collected in order to estimate RU consumption for a tenant that is running
 	// a query with EXPLAIN ANALYZE.
@@ -1334,6 +1339,7 @@ func (r *DistSQLReceiver) clone() *DistSQLReceiver {
 		clockUpdater:      r.clockUpdater,
 		stmtType:          tree.Rows,
 		tracing:           r.tracing,
+		cpuProfiles:       r.cpuProfiles,
 	}
 	return ret
 }
@@ -1443,6 +1449,9 @@ func (r *DistSQLReceiver) pushMeta(meta *execinfrapb.ProducerMetadata) execinfra
 		}
 		meta.Metrics.Release()
 	}
+	if meta.CPUProfile != nil {
+		r.cpuProfiles.add(meta.CPUProfile)
+	}
 	// Release the meta object. It is unsafe for use after this call.
 	meta.Release()
 	return r.status
@@ -1844,6 +1853,7 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	}
 	subqueryPlanCtx.associateNodeWithComponents = planner.instrumentation.getAssociateNodeWithComponentsFn()
 	subqueryPlanCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	subqueryPlanCtx.collectCPUProfile = planner.instrumentation.ShouldCollectCPUProfile()
 	subqueryPhysPlan, physPlanCleanup, err := dsp.createPhysPlan(ctx, subqueryPlanCtx, subqueryPlan.plan)
 	defer physPlanCleanup()
 	if err != nil {
@@ -2456,6 +2466,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	}
 	postqueryPlanCtx.associateNodeWithComponents = associateNodeWithComponents
 	postqueryPlanCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	postqueryPlanCtx.collectCPUProfile = planner.instrumentation.ShouldCollectCPUProfile()
 	postqueryPlanCtx.mustUseLeafTxn = parallelCheck
 
 	postqueryPhysPlan, physPlanCleanup, err := dsp.createPhysPlan(ctx, postqueryPlanCtx, postqueryPlan)
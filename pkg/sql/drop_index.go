@@ -447,6 +447,10 @@ func (p *planner) dropIndexByName(
 	idxEntry := *foundIndex.IndexDesc()
 	idxOrdinal := foundIndex.Ordinal()
 
+	if err := p.removeIndexBackReferenceInFunctions(ctx, tableDesc, idxEntry.ID); err != nil {
+		return err
+	}
+
 	// the idx we picked up with FindIndexByID at the top may not
 	// contain the same field any more due to other schema changes
 	// intervening since the initial lookup. So we send the recent
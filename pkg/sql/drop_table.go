@@ -619,6 +619,26 @@ func (p *planner) removeColumnBackReferenceInFunctions(
 	return nil
 }
 
+func (p *planner) removeIndexBackReferenceInFunctions(
+	ctx context.Context, tableDesc *tabledesc.Mutable, indexID descpb.IndexID,
+) error {
+	fnIDs, err := tableDesc.GetAllReferencedFunctionIDsInIndex(indexID)
+	if err != nil {
+		return err
+	}
+	for _, id := range fnIDs.Ordered() {
+		fnDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveIndexReference(tableDesc.GetID(), indexID)
+		if err := p.writeFuncSchemaChange(ctx, fnDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func removeCheckBackReferenceInFunctions(
 	ctx context.Context,
 	tableDesc *tabledesc.Mutable,
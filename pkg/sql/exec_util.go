@@ -96,17 +96,20 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlevents"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/insights"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilegecache"
 	tablemetadatacache_util "github.com/cockroachdb/cockroach/pkg/sql/tablemetadatacache/util"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/upgrade"
 	"github.com/cockroachdb/cockroach/pkg/upgrade/upgradebase"
+	"github.com/cockroachdb/cockroach/pkg/util/ash"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -1407,6 +1410,16 @@ type ExecutorConfig struct {
 	// StmtDiagnosticsRecorder deals with recording statement diagnostics.
 	StmtDiagnosticsRecorder *stmtdiagnostics.Registry
 
+	// StatementHints is the cache of the hints of system.statement_hints.
+	StatementHints *stmthints.Cache
+
+	// ActiveSessionHistory samples the active statements of this node.
+	ActiveSessionHistory *ActiveSessionHistory
+
+	// SQLEventAggregator aggregates the statement executions and logins of
+	// this node.
+	SQLEventAggregator *sqlevents.Aggregator
+
 	ExternalIODirConfig base.ExternalIODirConfig
 
 	GCJobNotifier *gcjobnotifier.Notifier
@@ -2140,11 +2153,15 @@ func checkResultType(typ *types.T, fmtCode pgwirebase.FormatCode) error {
 	case types.TSVectorFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
+	case types.JsonpathFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
+	case types.MacAddrFamily:
+	case types.MoneyFamily:
 	case types.OidFamily:
 	case types.PGLSNFamily:
 	case types.PGVectorFamily:
+	case types.RangeFamily:
 	case types.RefCursorFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
@@ -2303,6 +2320,10 @@ type queryMeta struct {
 
 	// The database the statement was executed on.
 	database string
+
+	// activity records what the query is doing for the active session history.
+	// It is nil if the active session history is disabled.
+	activity *ash.Activity
 }
 
 // SessionDefaults mirrors fields in Session, for restoring default
@@ -2445,6 +2466,9 @@ type RegistrySession interface {
 	// serialize serializes a Session into a serverpb.Session
 	// that can be served over RPC.
 	serialize() serverpb.Session
+	// sampleActiveQueries adds an active session history sample for each
+	// active query of the session.
+	sampleActiveQueries(sampleTime time.Time, nodeID roachpb.NodeID, add func(serverpb.ActiveSessionHistorySample))
 }
 
 // SerializeAll returns a slice of all sessions in the registry converted to
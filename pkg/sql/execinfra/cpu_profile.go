@@ -0,0 +1,236 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execinfra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/google/pprof/profile"
+)
+
+// These are the pprof label keys that attribute CPU samples to flows and to
+// their components when collecting a CPU profile for EXPLAIN ANALYZE (DEBUG,
+// PROFILE).
+const (
+	CPUProfileFlowLabel      = "flow"
+	CPUProfileComponentLabel = "component"
+)
+
+// CPUProfileLabels returns a context with the pprof labels that attribute CPU
+// samples to this flow and, if component is non-nil, to the given component of
+// the flow.
+func (flowCtx *FlowCtx) CPUProfileLabels(
+	ctx context.Context, component *execinfrapb.ComponentID,
+) context.Context {
+	labels := []string{CPUProfileFlowLabel, flowCtx.ID.String()}
+	if component != nil {
+		labels = append(labels, CPUProfileComponentLabel, CPUProfileComponentName(*component))
+	}
+	return pprof.WithLabels(ctx, pprof.Labels(labels...))
+}
+
+// CPUProfileLabeler is implemented by the processors, which attribute the CPU
+// usage of the goroutine running them to themselves when a CPU profile is
+// collected for the flow.
+type CPUProfileLabeler interface {
+	// CPUProfileLabels returns a context with the pprof labels of the
+	// processor, or nil if no CPU profile is collected.
+	CPUProfileLabels() context.Context
+}
+
+// CPUProfileLabels implements the CPUProfileLabeler interface.
+func (pb *ProcessorBaseNoHelper) CPUProfileLabels() context.Context {
+	if pb.FlowCtx == nil || !pb.FlowCtx.CollectCPUProfile {
+		return nil
+	}
+	component := pb.FlowCtx.ProcessorComponentID(pb.ProcessorID)
+	return pb.FlowCtx.CPUProfileLabels(context.Background(), &component)
+}
+
+// CPUProfileComponentName returns the value of the CPUProfileComponentLabel
+// for the given component, e.g. "processor 3". It matches the processor and
+// stream IDs shown in the DistSQL diagram of the statement.
+func CPUProfileComponentName(c execinfrapb.ComponentID) string {
+	return fmt.Sprintf("%s %d", strings.ToLower(c.Type.String()), c.ID)
+}
+
+// CPUProfileHelper collects a CPU profile of the flows of a single statement on
+// a node, along with a heap profile of the allocations made on the node while
+// the statement runs. The Go runtime supports a single CPU profile per
+// process, so at most one statement is profiled at a time on a given node. All
+// methods are safe for concurrent use and can be called on a nil receiver.
+type CPUProfileHelper struct {
+	st *cluster.Settings
+	mu struct {
+		syncutil.Mutex
+		// buf is nil once the profile has been stopped.
+		buf *bytes.Buffer
+		// heapBase is the heap profile at the start of the collection, if it
+		// could be taken.
+		heapBase *profile.Profile
+		// profile and heapProfile are the results of the collection until they
+		// are taken.
+		profile, heapProfile []byte
+	}
+}
+
+// StartCPUProfile starts collecting a CPU profile. It returns nil if another
+// CPU profile is already in progress.
+func StartCPUProfile(ctx context.Context, st *cluster.Settings) *CPUProfileHelper {
+	if err := st.SetCPUProfiling(cluster.CPUProfileWithLabels); err != nil {
+		log.VEventf(ctx, 1, "not collecting the CPU profile: %v", err)
+		return nil
+	}
+	h := &CPUProfileHelper{st: st}
+	h.mu.buf = &bytes.Buffer{}
+	heapBase, err := takeHeapProfile()
+	if err != nil {
+		log.VEventf(ctx, 1, "not collecting the heap profile: %v", err)
+	}
+	h.mu.heapBase = heapBase
+	if err := pprof.StartCPUProfile(h.mu.buf); err != nil {
+		_ = st.SetCPUProfiling(cluster.CPUProfileNone)
+		log.VEventf(ctx, 1, "not collecting the CPU profile: %v", err)
+		return nil
+	}
+	return h
+}
+
+// takeHeapProfile returns the heap profile of the node. The heap profile only
+// accounts for the allocations up to the last garbage collection. A collection
+// isn't forced, since that would stop the world on every node for every
+// profiled statement.
+func takeHeapProfile() (*profile.Profile, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&buf, 0 /* debug */); err != nil {
+		return nil, err
+	}
+	return profile.Parse(&buf)
+}
+
+// EndCollection stops the CPU profile and keeps the samples that are
+// attributed to a flow, along with the allocations made since the start of
+// the collection, to be retrieved with TakeProfile. It is a no-op if the
+// profile has already been stopped.
+func (h *CPUProfileHelper) EndCollection(ctx context.Context) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.mu.buf == nil {
+		return
+	}
+	pprof.StopCPUProfile()
+	_ = h.st.SetCPUProfiling(cluster.CPUProfileNone)
+	buf := h.mu.buf
+	h.mu.buf = nil
+	h.mu.heapProfile = h.heapProfileDeltaLocked(ctx)
+
+	p, err := profile.Parse(buf)
+	if err != nil {
+		log.Warningf(ctx, "unable to parse the CPU profile: %v", err)
+		return
+	}
+	samples := p.Sample[:0]
+	for _, s := range p.Sample {
+		if len(s.Label[CPUProfileFlowLabel]) > 0 {
+			samples = append(samples, s)
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+	p.Sample = samples
+	var out bytes.Buffer
+	if err := p.Compact().Write(&out); err != nil {
+		log.Warningf(ctx, "unable to write the CPU profile: %v", err)
+		return
+	}
+	h.mu.profile = out.Bytes()
+}
+
+// heapProfileDeltaLocked returns the allocations made on the node since the
+// start of the collection, in the gzipped pprof format, or nil if the heap
+// profiles couldn't be taken. Both profiles are as of the last garbage
+// collection before they were taken, so the delta is approximate. Heap profiles
+// aren't labeled by the Go runtime, so the allocations aren't attributed to the
+// flows.
+func (h *CPUProfileHelper) heapProfileDeltaLocked(ctx context.Context) []byte {
+	base := h.mu.heapBase
+	h.mu.heapBase = nil
+	if base == nil {
+		return nil
+	}
+	end, err := takeHeapProfile()
+	if err != nil {
+		log.Warningf(ctx, "unable to take the heap profile: %v", err)
+		return nil
+	}
+	base.Scale(-1)
+	delta, err := profile.Merge([]*profile.Profile{end, base})
+	if err != nil {
+		log.Warningf(ctx, "unable to compute the heap profile: %v", err)
+		return nil
+	}
+	samples := delta.Sample[:0]
+	for _, s := range delta.Sample {
+		for _, v := range s.Value {
+			if v != 0 {
+				samples = append(samples, s)
+				break
+			}
+		}
+	}
+	delta.Sample = samples
+	var out bytes.Buffer
+	if err := delta.Compact().Write(&out); err != nil {
+		log.Warningf(ctx, "unable to write the heap profile: %v", err)
+		return nil
+	}
+	return out.Bytes()
+}
+
+// TakeProfile returns the CPU and heap profiles kept by EndCollection, in the
+// gzipped pprof format, and forgets about them, so that they are only returned
+// once. The CPU profile is nil if EndCollection hasn't been called or if no
+// samples were attributed to a flow, and the heap profile is nil if it
+// couldn't be taken.
+func (h *CPUProfileHelper) TakeProfile() (cpu, heap []byte) {
+	if h == nil {
+		return nil, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cpu, heap = h.mu.profile, h.mu.heapProfile
+	h.mu.profile, h.mu.heapProfile = nil, nil
+	return cpu, heap
+}
+
+// TakeCPUProfileMeta returns the CPU and heap profiles collected for this
+// remote flow, if any, as metadata to be sent to the gateway. Like
+// TakeProfile, it only returns the profiles once.
+func (flowCtx *FlowCtx) TakeCPUProfileMeta() *execinfrapb.ProducerMetadata {
+	cpu, heap := flowCtx.CPUProfile.TakeProfile()
+	if cpu == nil && heap == nil {
+		return nil
+	}
+	return &execinfrapb.ProducerMetadata{
+		CPUProfile: &execinfrapb.RemoteProducerMetadata_CPUProfile{
+			SQLInstanceID: flowCtx.NodeID.SQLInstanceID(),
+			Profile:       cpu,
+			HeapProfile:   heap,
+		},
+	}
+}
@@ -97,6 +97,16 @@ type FlowCtx struct {
 	// running EXPLAIN ANALYZE. Currently, it is only used by remote flows.
 	// The gateway flow is handled by the connExecutor.
 	TenantCPUMonitor multitenantcpu.CPUUsageHelper
+
+	// CollectCPUProfile is true if a CPU profile of the flow was requested by
+	// EXPLAIN ANALYZE (DEBUG, PROFILE) and is being collected on this node. If
+	// set, the components of the flow attribute their CPU usage to themselves
+	// via pprof labels (see CPUProfileLabels).
+	CollectCPUProfile bool
+
+	// CPUProfile, if set, collects the CPU profile of a remote flow. The
+	// gateway flow is handled by the connExecutor.
+	CPUProfile *CPUProfileHelper
 }
 
 // NewEvalCtx returns a modifiable copy of the FlowCtx's eval.Context.
@@ -59,11 +59,11 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 71
+const Version execinfrapb.DistSQLVersion = 72
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
-const MinAcceptedVersion execinfrapb.DistSQLVersion = 71
+const MinAcceptedVersion execinfrapb.DistSQLVersion = 72
 
 /*
 
@@ -71,6 +71,10 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 71
 
 Please add new entries at the top.
 
+- Version: 72 (MinAcceptedVersion: 72)
+  - The key encoding of tuples has changed. The encoded elements are now
+    wrapped as bytes so that tuples can be decoded.
+
 - Version: 71 (MinAcceptedVersion: 71)
   - On-wire representation of booleans and bytes-like values in the Arrow format
     has changed.
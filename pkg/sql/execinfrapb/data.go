@@ -202,6 +202,9 @@ type ProducerMetadata struct {
 	Changefeed *ChangefeedMeta
 	// AggregatorEvents contains information from a tracing aggregator.
 	AggregatorEvents *TracingAggregatorEvents
+	// CPUProfile contains the CPU profile of a remote flow collected for
+	// EXPLAIN ANALYZE (DEBUG, PROFILE).
+	CPUProfile *RemoteProducerMetadata_CPUProfile
 }
 
 var (
@@ -274,6 +277,8 @@ func RemoteProducerMetaToLocalMeta(
 		meta.Changefeed = v.Changefeed
 	case *RemoteProducerMetadata_TracingAggregatorEvents:
 		meta.AggregatorEvents = v.TracingAggregatorEvents
+	case *RemoteProducerMetadata_CPUProfile_:
+		meta.CPUProfile = v.CPUProfile
 	default:
 		return *meta, false
 	}
@@ -330,6 +335,10 @@ func LocalMetaToRemoteProducerMeta(
 		rpm.Value = &RemoteProducerMetadata_TracingAggregatorEvents{
 			TracingAggregatorEvents: meta.AggregatorEvents,
 		}
+	} else if meta.CPUProfile != nil {
+		rpm.Value = &RemoteProducerMetadata_CPUProfile_{
+			CPUProfile: meta.CPUProfile,
+		}
 	} else if buildutil.CrdbTestBuild {
 		panic("unhandled field in local meta or all fields are nil")
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxrecommendations"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlevents"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -250,6 +251,20 @@ func (ex *connExecutor) recordStatementSummary(
 		ex.statsCollector.ObserveStatement(stmtFingerprintID, recordedStmtStats)
 	}
 
+	if agg := ex.server.cfg.SQLEventAggregator; agg.Enabled() && ex.executorType != executorTypeInternal {
+		event := &sqlevents.Statement{
+			FingerprintID: stmtFingerprintID,
+			Fingerprint:   stmt.StmtNoConstants,
+			User:          planner.SessionData().SessionUser().Normalized(),
+			Failed:        stmtErr != nil,
+		}
+		if queryLevelStatsOk {
+			event.CPUTime = queryLevelStats.CPUTime
+			event.ContentionTime = queryLevelStats.ContentionTime
+		}
+		agg.RecordStatement(ctx, event)
+	}
+
 	// Do some transaction level accounting for the transaction this statement is
 	// a part of.
 
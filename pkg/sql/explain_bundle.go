@@ -11,16 +11,19 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
@@ -38,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
+	"github.com/google/pprof/profile"
 	"github.com/lib/pq/oid"
 )
 
@@ -146,6 +150,7 @@ func buildStatementBundle(
 	queryErr, payloadErr, commErr error,
 	sv *settings.Values,
 	c inFlightTraceCollector,
+	cpuProfiles *stmtCPUProfiles,
 ) diagnosticsBundle {
 	if plan == nil {
 		return diagnosticsBundle{collectionErr: errors.AssertionFailedf("execution terminated early")}
@@ -162,6 +167,7 @@ func buildStatementBundle(
 	b.addExplainVec()
 	b.addTrace()
 	b.addInFlightTrace(c)
+	b.addCPUProfile(cpuProfiles)
 	b.addEnv(ctx)
 	b.addErrors(queryErr, payloadErr, commErr)
 
@@ -527,6 +533,157 @@ func (b *stmtBundleBuilder) addInFlightTrace(c inFlightTraceCollector) {
 	}
 }
 
+// addCPUProfile adds three files to the bundle when collecting a CPU profile
+// for EXPLAIN ANALYZE (DEBUG, PROFILE): the CPU profiles collected on all nodes
+// merged into a single pprof file, a flame graph in the "folded" format (one
+// line per stack with its number of samples), as accepted by most flame graph
+// tools, and the heap profiles of the allocations made on all nodes while the
+// statement ran. The CPU samples are attributed to the node and to the EXPLAIN
+// plan node that was running (see "plan node id" in plan.txt), via labels and
+// the first frames of each stack. Plan nodes executed by the same processor
+// share their ID, and the samples of the processors and streams that don't
+// correspond to a plan node (such as the first stage of a distributed
+// aggregation) are attributed to the processor or stream of the DistSQL
+// diagram instead. The heap samples are only attributed to the node.
+func (b *stmtBundleBuilder) addCPUProfile(cpuProfiles *stmtCPUProfiles) {
+	if !b.flags.CPUProfile {
+		return
+	}
+	var cpu, heap []*profile.Profile
+	for _, cp := range cpuProfiles.get() {
+		node := fmt.Sprintf("n%d", cp.SQLInstanceID)
+		if p := b.parseProfile(cp.Profile, "CPU", cp.SQLInstanceID); p != nil {
+			for _, s := range p.Sample {
+				labelCPUProfileSample(s, node, cpuProfiles)
+			}
+			cpu = append(cpu, p)
+		}
+		if p := b.parseProfile(cp.HeapProfile, "heap", cp.SQLInstanceID); p != nil {
+			for _, s := range p.Sample {
+				setProfileLabel(s, cpuProfileNodeLabel, node)
+			}
+			heap = append(heap, p)
+		}
+	}
+	if len(cpu) == 0 {
+		b.errorStrings = append(b.errorStrings,
+			"the CPU profile was not collected: either another CPU profile was in progress or no samples were taken")
+	} else if merged := b.addMergedProfile("cpu-profile.pb.gz", "CPU", cpu); merged != nil {
+		b.z.AddFile("flamegraph.txt", foldedCPUProfile(merged))
+	}
+	b.addMergedProfile("heap-profile.pb.gz", "heap", heap)
+}
+
+// parseProfile parses a profile collected on the given node, or returns nil
+// if it is empty or cannot be parsed.
+func (b *stmtBundleBuilder) parseProfile(
+	data []byte, kind string, node base.SQLInstanceID,
+) *profile.Profile {
+	if len(data) == 0 {
+		return nil
+	}
+	p, err := profile.ParseData(data)
+	if err != nil {
+		b.errorStrings = append(b.errorStrings,
+			fmt.Sprintf("error parsing the %s profile from n%d: %v", kind, node, err))
+		return nil
+	}
+	return p
+}
+
+// addMergedProfile merges the given profiles and adds the result to the
+// bundle. It returns the merged profile, or nil if there is none.
+func (b *stmtBundleBuilder) addMergedProfile(
+	name, kind string, profiles []*profile.Profile,
+) *profile.Profile {
+	if len(profiles) == 0 {
+		return nil
+	}
+	merged, err := profile.Merge(profiles)
+	if err != nil {
+		b.errorStrings = append(b.errorStrings, fmt.Sprintf("error merging the %s profiles: %v", kind, err))
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := merged.Write(&buf); err != nil {
+		b.errorStrings = append(b.errorStrings, fmt.Sprintf("error writing the %s profile: %v", kind, err))
+		return nil
+	}
+	b.z.AddFile(name, buf.String())
+	return merged
+}
+
+// cpuProfileNodeLabel and cpuProfilePlanNodeLabel are the pprof labels that
+// attribute the samples of the merged profiles to the node that collected
+// them and to the EXPLAIN plan node that was running, respectively.
+const (
+	cpuProfileNodeLabel     = "node"
+	cpuProfilePlanNodeLabel = "plan node"
+)
+
+// labelCPUProfileSample labels the given CPU sample collected on the given
+// node with the node and, if known, the EXPLAIN plan node that was running.
+func labelCPUProfileSample(s *profile.Sample, node string, cpuProfiles *stmtCPUProfiles) {
+	setProfileLabel(s, cpuProfileNodeLabel, node)
+	flow, component := s.Label[execinfra.CPUProfileFlowLabel], s.Label[execinfra.CPUProfileComponentLabel]
+	if len(flow) == 0 || len(component) == 0 {
+		return
+	}
+	if id := cpuProfiles.planNodeID(flow[0], component[0]); id != 0 {
+		setProfileLabel(s, cpuProfilePlanNodeLabel, strconv.Itoa(id))
+	}
+}
+
+func setProfileLabel(s *profile.Sample, key, value string) {
+	if s.Label == nil {
+		s.Label = make(map[string][]string)
+	}
+	s.Label[key] = []string{value}
+}
+
+// foldedCPUProfile returns the given CPU profile in the "folded" format, with
+// the node and the plan node (or the component, or the flow, for the samples
+// not attributed to a plan node or a single component) as the outermost frames
+// of each stack.
+func foldedCPUProfile(p *profile.Profile) string {
+	counts := make(map[string]int64)
+	var frames []string
+	for _, s := range p.Sample {
+		frames = frames[:0]
+		frames = append(frames, s.Label[cpuProfileNodeLabel]...)
+		if id := s.Label[cpuProfilePlanNodeLabel]; len(id) > 0 {
+			frames = append(frames, cpuProfilePlanNodeLabel+" "+id[0])
+		} else if c := s.Label[execinfra.CPUProfileComponentLabel]; len(c) > 0 {
+			frames = append(frames, c...)
+		} else {
+			frames = append(frames, "flow")
+		}
+		// Locations are ordered from the leaf to the root, and the lines of a
+		// location from the innermost inlined function outwards.
+		for i := len(s.Location) - 1; i >= 0; i-- {
+			lines := s.Location[i].Line
+			for j := len(lines) - 1; j >= 0; j-- {
+				if lines[j].Function != nil {
+					frames = append(frames, lines[j].Function.Name)
+				}
+			}
+		}
+		if len(s.Value) > 0 {
+			counts[strings.Join(frames, ";")] += s.Value[0]
+		}
+	}
+	stacks := make([]string, 0, len(counts))
+	for stack := range counts {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	var sb strings.Builder
+	for _, stack := range stacks {
+		fmt.Fprintf(&sb, "%s %d\n", stack, counts[stack])
+	}
+	return sb.String()
+}
+
 // printError writes the given error string into buf (with a newline appended)
 // as well as accumulates the string into b.errorStrings. The method should only
 // be used for non-critical errors.
@@ -533,6 +533,30 @@ CREATE TABLE users(id UUID DEFAULT gen_random_uuid() PRIMARY KEY, promo_id INT R
 		)
 	})
 
+	t.Run("cpu profile", func(t *testing.T) {
+		// Use a CPU-heavy query so that the profile includes some samples.
+		rows := r.QueryStr(t, "EXPLAIN ANALYZE (DEBUG, PROFILE) SELECT count(*) FROM generate_series(1, 3000000) AS g(i) WHERE i % 7 = 0")
+		checkBundle(
+			t, fmt.Sprint(rows), "" /* tableName */, func(name, contents string) error {
+				switch name {
+				case "flamegraph.txt":
+					if !strings.HasPrefix(contents, "n1;") {
+						return errors.Newf("expected the stacks to be attributed to n1:\n%s", contents)
+					}
+					if !strings.Contains(contents, ";plan node ") {
+						return errors.Newf("expected the stacks to be attributed to plan nodes:\n%s", contents)
+					}
+				case "plan.txt":
+					if !strings.Contains(contents, "plan node id: ") {
+						return errors.Newf("expected the plan to include the plan node IDs:\n%s", contents)
+					}
+				}
+				return nil
+			}, false, /* expectErrors */
+			base, plans, "distsql.html vec.txt vec-v.txt cpu-profile.pb.gz flamegraph.txt heap-profile.pb.gz",
+		)
+	})
+
 	t.Run("virtual table", func(t *testing.T) {
 		rows := r.QueryStr(t, "EXPLAIN ANALYZE (DEBUG) SELECT count(*) FROM pg_catalog.pg_class;")
 		// tableName is empty since we expect that the table is not included
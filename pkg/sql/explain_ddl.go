@@ -7,15 +7,20 @@ package sql
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/errors"
 )
@@ -82,10 +87,100 @@ func (n *explainDDLNode) startExec(params runParams) error {
 			return explainNotPossibleError
 		}
 	}
+	if n.options.Flags[tree.ExplainFlagRanges] {
+		return n.setRangeValues(params, scNode.plannedState)
+	}
 	return n.setExplainValues(params.ctx, params.ExecCfg().Settings,
 		scNode.plannedState, &params.p.ExtendedEvalContext().SchemaChangerState.memAcc)
 }
 
+// indexRewrite is an index which is replaced by a new index by the schema
+// change, and whose data is therefore moved to new ranges.
+type indexRewrite struct {
+	tableID    descpb.ID
+	oldIndexID descpb.IndexID
+	newIndexID descpb.IndexID
+}
+
+// setRangeValues lists the ranges of the indexes which are rewritten by the
+// schema change, along with the indexes their data moves to, and the zone
+// configs which are carried over to the new indexes.
+func (n *explainDDLNode) setRangeValues(params runParams, scState scpb.CurrentState) error {
+	oldPrimaryIndexIDs := make(map[descpb.ID]descpb.IndexID)
+	for i, t := range scState.Targets {
+		if e, ok := t.Element().(*scpb.PrimaryIndex); ok &&
+			t.TargetStatus == scpb.Status_ABSENT && scState.Initial[i] == scpb.Status_PUBLIC {
+			oldPrimaryIndexIDs[e.TableID] = e.IndexID
+		}
+	}
+	var rewrites []indexRewrite
+	newIndexIDs := make(map[descpb.ID]map[descpb.IndexID]bool)
+	for i, t := range scState.Targets {
+		if t.TargetStatus != scpb.Status_PUBLIC || scState.Initial[i] != scpb.Status_ABSENT {
+			continue
+		}
+		var r indexRewrite
+		switch e := t.Element().(type) {
+		case *scpb.PrimaryIndex:
+			r = indexRewrite{tableID: e.TableID, oldIndexID: oldPrimaryIndexIDs[e.TableID], newIndexID: e.IndexID}
+		case *scpb.SecondaryIndex:
+			r = indexRewrite{tableID: e.TableID, oldIndexID: e.RecreateSourceIndexID, newIndexID: e.IndexID}
+		}
+		if r.oldIndexID == 0 {
+			continue
+		}
+		rewrites = append(rewrites, r)
+		if newIndexIDs[r.tableID] == nil {
+			newIndexIDs[r.tableID] = make(map[descpb.IndexID]bool)
+		}
+		newIndexIDs[r.tableID][r.newIndexID] = true
+	}
+
+	names := scState.NameMappings
+	for _, r := range rewrites {
+		start := roachpb.Key(rowenc.MakeIndexKeyPrefix(params.ExecCfg().Codec, r.tableID, r.oldIndexID))
+		rows, err := params.p.InternalSQLTxn().QueryBufferedEx(
+			params.ctx, "explain-ddl-ranges", params.p.txn, sessiondata.NodeUserSessionDataOverride,
+			`SELECT range_id, start_pretty, end_pretty FROM crdb_internal.ranges_no_leases
+WHERE start_key < $2 AND end_key > $1 ORDER BY start_key`,
+			[]byte(start), []byte(start.PrefixEnd()),
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			n.values = append(n.values, tree.Datums{tree.NewDString(fmt.Sprintf(
+				"%s@%s: range r%d [%s, %s) moves to index %d",
+				names.Name(r.tableID), names.IndexName(r.tableID, r.oldIndexID),
+				tree.MustBeDInt(row[0]), tree.MustBeDString(row[1]), tree.MustBeDString(row[2]),
+				r.newIndexID,
+			))})
+		}
+	}
+	for i, t := range scState.Targets {
+		if t.TargetStatus != scpb.Status_PUBLIC || scState.Initial[i] != scpb.Status_ABSENT {
+			continue
+		}
+		switch e := t.Element().(type) {
+		case *scpb.IndexZoneConfig:
+			if newIndexIDs[e.TableID][e.IndexID] {
+				n.values = append(n.values, tree.Datums{tree.NewDString(fmt.Sprintf(
+					"%s: index zone config is carried over to index %d",
+					names.Name(e.TableID), e.IndexID,
+				))})
+			}
+		case *scpb.PartitionZoneConfig:
+			if newIndexIDs[e.TableID][e.IndexID] {
+				n.values = append(n.values, tree.Datums{tree.NewDString(fmt.Sprintf(
+					"%s: zone config of partition %s is carried over to index %d",
+					names.Name(e.TableID), e.PartitionName, e.IndexID,
+				))})
+			}
+		}
+	}
+	return nil
+}
+
 func (n *explainDDLNode) setExplainValues(
 	ctx context.Context,
 	settings *cluster.Settings,
@@ -7,6 +7,7 @@ package flowinfra
 
 import (
 	"context"
+	"runtime/pprof"
 	"sync"
 	"unsafe"
 
@@ -505,6 +506,7 @@ func (f *FlowBase) StartInternal(
 	for i := 0; i < len(processors); i++ {
 		f.waitGroup.Add(1)
 		go func(i int) {
+			f.setProcessorCPUProfileLabels(processors[i])
 			processors[i].Run(ctx, outputs[i])
 			f.waitGroup.Done()
 		}(i)
@@ -522,8 +524,37 @@ func (f *FlowBase) IsLocal() bool {
 	return f.Local
 }
 
+// SetCPUProfileLabels sets the pprof labels of the flow on the current
+// goroutine if a CPU profile is being collected for it, so that the goroutines
+// started by the flow inherit them. The returned function restores the labels
+// of ctx.
+func (f *FlowBase) SetCPUProfileLabels(ctx context.Context) (undo func()) {
+	if !f.CollectCPUProfile {
+		return func() {}
+	}
+	pprof.SetGoroutineLabels(f.FlowCtx.CPUProfileLabels(ctx, nil /* component */))
+	return func() {
+		pprof.SetGoroutineLabels(ctx)
+	}
+}
+
+// setProcessorCPUProfileLabels attributes the CPU usage of the current
+// goroutine to the given processor if a CPU profile is being collected for the
+// flow. The inputs fused into the processor are attributed to it as well.
+func (f *FlowBase) setProcessorCPUProfileLabels(p execinfra.Processor) {
+	if !f.CollectCPUProfile {
+		return
+	}
+	if l, ok := p.(execinfra.CPUProfileLabeler); ok {
+		if labels := l.CPUProfileLabels(); labels != nil {
+			pprof.SetGoroutineLabels(labels)
+		}
+	}
+}
+
 // Start is part of the Flow interface.
 func (f *FlowBase) Start(ctx context.Context) error {
+	defer f.SetCPUProfileLabels(ctx)()
 	return f.StartInternal(ctx, f.processors, f.outputs)
 }
 
@@ -550,6 +581,7 @@ func (f *FlowBase) Run(ctx context.Context, noWait bool) {
 	if !noWait {
 		defer f.Wait()
 	}
+	defer f.SetCPUProfileLabels(ctx)()
 
 	if len(f.processors) == 0 {
 		f.rowSyncFlowConsumer.Push(nil /* row */, &execinfrapb.ProducerMetadata{Err: errors.AssertionFailedf("no processors in flow")})
@@ -571,6 +603,7 @@ func (f *FlowBase) Run(ctx context.Context, noWait bool) {
 	f.resumeCtx = ctx
 	log.VEventf(ctx, 1, "running %T in the flow's goroutine", headProc)
 	f.headProcStarted = true
+	f.setProcessorCPUProfileLabels(headProc)
 	headProc.Run(ctx, headOutput)
 }
 
@@ -678,6 +711,10 @@ func (f *FlowBase) Cleanup(ctx context.Context) {
 		proc.Close(ctx)
 	}
 
+	// Stop the CPU profile of remote flows if it hasn't been stopped by the
+	// last outbox (e.g. because the flow was canceled).
+	f.CPUProfile.EndCollection(ctx)
+
 	// Release any descriptors accessed by this flow.
 	if f.Descriptors != nil && f.IsDescriptorsCleanupRequired {
 		f.Descriptors.ReleaseAll(ctx)
@@ -300,6 +300,7 @@ func (m *Outbox) mainLoop(ctx context.Context, wg *sync.WaitGroup) (retErr error
 						m.flowStats.FlowStats.MaxMemUsage.Set(uint64(m.flowCtx.Mon.MaximumBytes()))
 						m.flowStats.FlowStats.MaxDiskUsage.Set(uint64(m.flowCtx.DiskMonitor.MaximumBytes()))
 						m.flowStats.FlowStats.ConsumedRU.Set(uint64(m.flowCtx.TenantCPUMonitor.EndCollection(ctx)))
+						m.flowCtx.CPUProfile.EndCollection(ctx)
 					}
 					span.RecordStructured(&m.streamStats)
 					span.RecordStructured(&m.flowStats)
@@ -310,6 +311,11 @@ func (m *Outbox) mainLoop(ctx context.Context, wg *sync.WaitGroup) (retErr error
 								return err
 							}
 						}
+						if meta := m.flowCtx.TakeCPUProfileMeta(); meta != nil {
+							if err := m.AddRow(ctx, nil, meta); err != nil {
+								return err
+							}
+						}
 					}
 				}
 				return m.flush(ctx)
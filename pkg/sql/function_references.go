@@ -9,7 +9,10 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
 func (p *planner) updateFunctionReferencesForCheck(
@@ -70,3 +73,60 @@ func (p *planner) maybeUpdateFunctionReferencesForColumn(
 	}
 	return nil
 }
+
+func (p *planner) updateFunctionReferencesForIndex(
+	ctx context.Context, tblDesc catalog.TableDescriptor, indexID descpb.IndexID,
+) error {
+	udfIDs, err := tblDesc.GetAllReferencedFunctionIDsInIndex(indexID)
+	if err != nil {
+		return err
+	}
+	for _, id := range udfIDs.Ordered() {
+		fnDesc, err := p.descCollection.MutableByID(p.txn).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fnDesc.AddIndexReference(tblDesc.GetID(), indexID); err != nil {
+			return err
+		}
+		if err := p.writeFuncSchemaChange(ctx, fnDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFunctionVolatilityForReferences returns an error if the function is
+// not immutable but is used by a computed column or a partial index predicate,
+// which may only call immutable functions.
+func (p *planner) validateFunctionVolatilityForReferences(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	if fnDesc.GetVolatility() == catpb.Function_IMMUTABLE {
+		return nil
+	}
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 {
+			continue
+		}
+		tbl, err := p.Descriptors().ByIDWithoutLeased(p.txn).Get().Table(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		for _, colID := range ref.ColumnIDs {
+			if col := catalog.FindColumnByID(tbl, colID); col != nil && col.IsComputed() {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"function %q must be IMMUTABLE because it is used by computed column %q of table %q",
+					fnDesc.GetName(), col.GetName(), tbl.GetName())
+			}
+		}
+		for _, idxID := range ref.IndexIDs {
+			if idx := catalog.FindIndexByID(tbl, idxID); idx != nil {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"function %q must be IMMUTABLE because it is used by the predicate of index %q of table %q",
+					fnDesc.GetName(), idx.GetName(), tbl.GetName())
+			}
+		}
+	}
+	return nil
+}
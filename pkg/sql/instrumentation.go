@@ -39,12 +39,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/sslocal"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
 	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
 	"github.com/cockroachdb/errors"
@@ -143,6 +145,13 @@ type instrumentationHelper struct {
 
 	inFlightTraceCollector
 
+	// cpuProfile, if set, is collecting the CPU profile of the statement on the
+	// gateway for EXPLAIN ANALYZE (DEBUG, PROFILE).
+	cpuProfile *execinfra.CPUProfileHelper
+	// cpuProfiles accumulates the CPU profiles collected on all nodes. It is
+	// nil if cpuProfile is nil.
+	cpuProfiles *stmtCPUProfiles
+
 	// topLevelStats are the statistics collected for every query execution.
 	topLevelStats topLevelQueryStats
 
@@ -183,6 +192,9 @@ type instrumentationHelper struct {
 	// explainIndexRecs contains index recommendations for EXPLAIN statements.
 	explainIndexRecs []indexrec.Rec
 
+	// appliedStatementHints contains the statement hints applied to the plan.
+	appliedStatementHints []stmthints.Hint
+
 	// maxFullScanRows is the maximum number of rows scanned by a full scan, as
 	// estimated by the optimizer.
 	maxFullScanRows float64
@@ -289,6 +301,83 @@ type inFlightTraceCollector struct {
 	timeoutTrace []traceFromSQLInstance
 }
 
+// stmtCPUProfiles accumulates the CPU and heap profiles of a single statement
+// collected on each SQL instance, along with the IDs of the EXPLAIN plan nodes
+// that the CPU samples are attributed to. It is safe for concurrent use and
+// can be used via a nil pointer.
+type stmtCPUProfiles struct {
+	mu       syncutil.Mutex
+	profiles []*execinfrapb.RemoteProducerMetadata_CPUProfile
+	// planNodes maps the processors of the physical plans to the IDs of the
+	// EXPLAIN plan nodes they were planned for. The IDs are shown in the plan
+	// of the statement bundle.
+	planNodes    map[cpuProfileComponent]int
+	numPlanNodes int
+}
+
+// cpuProfileComponent identifies a component in the labels of the CPU profile
+// samples (see execinfra.CPUProfileLabels).
+type cpuProfileComponent struct {
+	flow, component string
+}
+
+func (c *stmtCPUProfiles) add(p *execinfrapb.RemoteProducerMetadata_CPUProfile) {
+	if c == nil || p == nil || (len(p.Profile) == 0 && len(p.HeapProfile) == 0) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiles = append(c.profiles, p)
+}
+
+func (c *stmtCPUProfiles) get() []*execinfrapb.RemoteProducerMetadata_CPUProfile {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.profiles
+}
+
+// addPlanNode assigns an ID to the EXPLAIN plan node that the given processors
+// were planned for, and returns it. The same ID is returned if the plan node
+// was already added.
+func (c *stmtCPUProfiles) addPlanNode(components execComponents) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.planNodes == nil {
+		c.planNodes = make(map[cpuProfileComponent]int)
+	}
+	for _, comp := range components {
+		if id, ok := c.planNodes[makeCPUProfileComponent(comp)]; ok {
+			return id
+		}
+	}
+	c.numPlanNodes++
+	for _, comp := range components {
+		c.planNodes[makeCPUProfileComponent(comp)] = c.numPlanNodes
+	}
+	return c.numPlanNodes
+}
+
+// planNodeID returns the ID of the EXPLAIN plan node that the component with
+// the given CPU profile labels was planned for, or 0 if it is unknown.
+func (c *stmtCPUProfiles) planNodeID(flow, component string) int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.planNodes[cpuProfileComponent{flow: flow, component: component}]
+}
+
+func makeCPUProfileComponent(c execinfrapb.ComponentID) cpuProfileComponent {
+	return cpuProfileComponent{
+		flow:      c.FlowID.String(),
+		component: execinfra.CPUProfileComponentName(c),
+	}
+}
+
 type traceFromSQLInstance struct {
 	nodeID int64
 	trace  string
@@ -406,6 +495,13 @@ func (ih *instrumentationHelper) finalizeSetup(ctx context.Context, cfg *Executo
 		if pollInterval := inFlightTraceCollectorPollInterval.Get(cfg.SV()); pollInterval > 0 {
 			ih.startInFlightTraceCollector(ctx, cfg.InternalDB.Executor(), pollInterval)
 		}
+		if ih.explainFlags.CPUProfile {
+			// If another statement is being profiled, the bundle won't include
+			// the CPU profile.
+			if ih.cpuProfile = execinfra.StartCPUProfile(ctx, cfg.Settings); ih.cpuProfile != nil {
+				ih.cpuProfiles = &stmtCPUProfiles{}
+			}
+		}
 	}
 }
 
@@ -590,6 +686,9 @@ func (ih *instrumentationHelper) Finish(
 	retErr error,
 ) error {
 	ctx := ih.origCtx
+	// Stop the CPU profile first so that it doesn't include the work done to
+	// build the bundle.
+	ih.cpuProfile.EndCollection(ctx)
 	if _, ok := ih.Tracing(); !ok {
 		return retErr
 	}
@@ -632,6 +731,13 @@ func (ih *instrumentationHelper) Finish(
 	var warnings []string
 	if ih.collectBundle {
 		ih.inFlightTraceCollector.finish()
+		if cpu, heap := ih.cpuProfile.TakeProfile(); cpu != nil || heap != nil {
+			ih.cpuProfiles.add(&execinfrapb.RemoteProducerMetadata_CPUProfile{
+				SQLInstanceID: cfg.NodeInfo.NodeID.SQLInstanceID(),
+				Profile:       cpu,
+				HeapProfile:   heap,
+			})
+		}
 		ie := p.extendedEvalCtx.ExecCfg.InternalDB.Executor(
 			isql.WithSessionData(p.SessionData()),
 		)
@@ -719,6 +825,7 @@ func (ih *instrumentationHelper) Finish(
 				bundleCtx, ih.explainFlags, cfg.DB, p, ie.(*InternalExecutor),
 				stmtRawSQL, &p.curPlan, planString, trace, placeholders, res.ErrAllowReleased(),
 				payloadErr, retErr, &p.extendedEvalCtx.Settings.SV, ih.inFlightTraceCollector,
+				ih.cpuProfiles,
 			)
 			// Include all non-critical errors as warnings. Note that these
 			// error strings might contain PII, but the warnings are only shown
@@ -800,6 +907,12 @@ func (ih *instrumentationHelper) ShouldCollectExecStats() bool {
 	return ih.collectExecStats
 }
 
+// ShouldCollectCPUProfile returns true if a CPU profile labeled by flow and
+// operator should be collected on all nodes executing the statement.
+func (ih *instrumentationHelper) ShouldCollectCPUProfile() bool {
+	return ih.cpuProfile != nil
+}
+
 // RecordExplainPlan records the explain.Plan for this query.
 func (ih *instrumentationHelper) RecordExplainPlan(explainPlan *explain.Plan) {
 	ih.explainPlan = explainPlan
@@ -856,6 +969,9 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	ob.AddDistribution(ih.distribution.String())
 	ob.AddVectorized(ih.vectorized)
 	ob.AddPlanType(ih.generic, ih.optimized)
+	if len(ih.appliedStatementHints) > 0 {
+		ob.AddTopLevelField("statement hints", stmthints.FormatApplied(ih.appliedStatementHints))
+	}
 
 	if queryStats != nil {
 		if queryStats.KVRowsRead != 0 {
@@ -1079,6 +1195,9 @@ func (m execNodeTraceMetadata) annotateExplain(
 					nodeStats.KVNodes = append(nodeStats.KVNodes, fmt.Sprintf("n%d", i))
 				}
 				nodeStats.Regions = regions
+				if cp := p.instrumentation.cpuProfiles; cp != nil {
+					nodeStats.PlanNodeID = cp.addPlanNode(components)
+				}
 				n.Annotate(exec.ExecutionStatsID, &nodeStats)
 			}
 		}
@@ -1179,6 +1179,13 @@ func TestLogic_merge_join(
 	runLogicTest(t, "merge_join")
 }
 
+func TestLogic_mixed_version_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "mixed_version_types")
+}
+
 func TestLogic_multi_statement(
 	t *testing.T,
 ) {
@@ -33,8 +33,20 @@ const (
 	T__pgvector  = oid.Oid(90007)
 )
 
-// ExtensionTypeName returns a mapping from extension oids
-// to their type name.
+// OIDs in this block are official postgres OIDs that are missing from
+// `github.com/lib/pq/oid`.
+const (
+	T_macaddr8  = oid.Oid(774)
+	T__macaddr8 = oid.Oid(775)
+	T_jsonpath  = oid.Oid(4072)
+	T__jsonpath = oid.Oid(4073)
+
+	T_anycompatible      = oid.Oid(5077)
+	T_anycompatiblearray = oid.Oid(5078)
+)
+
+// ExtensionTypeName returns a mapping from extension oids, and postgres oids
+// missing from `github.com/lib/pq/oid`, to their type name.
 var ExtensionTypeName = map[oid.Oid]string{
 	T_geometry:   "GEOMETRY",
 	T__geometry:  "_GEOMETRY",
@@ -44,6 +56,13 @@ var ExtensionTypeName = map[oid.Oid]string{
 	T__box2d:     "_BOX2D",
 	T_pgvector:   "VECTOR",
 	T__pgvector:  "_VECTOR",
+	T_macaddr8:   "MACADDR8",
+	T__macaddr8:  "_MACADDR8",
+	T_jsonpath:   "JSONPATH",
+	T__jsonpath:  "_JSONPATH",
+
+	T_anycompatible:      "ANYCOMPATIBLE",
+	T_anycompatiblearray: "ANYCOMPATIBLEARRAY",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -382,6 +383,16 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// ExclusionOperators returns the comparison operator of each column of the
+	// constraint if it is an exclusion constraint, or nil if it is a uniqueness
+	// constraint. An exclusion constraint is violated by any two rows for which
+	// all of the operators return true, so it is never a key of the table and
+	// it is only ever enforced with checks:
+	//
+	//   ALTER TABLE t ADD CONSTRAINT e EXCLUDE USING gist (a WITH =, r WITH &&);
+	//
+	ExclusionOperators() []treecmp.ComparisonOperator
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
 	"github.com/cockroachdb/errors"
@@ -101,11 +102,16 @@ func FormatTable(
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if ops := uniq.ExclusionOperators(); ops != nil {
+			c = child.Childf("EXCLUDE %s", formatExclusionCols(tab, uniq, ops))
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
 		}
@@ -207,6 +213,23 @@ func formatCols(tab Table, numCols int, colOrdinal func(tab Table, i int) int) s
 	return buf.String()
 }
 
+// formatExclusionCols formats the columns of an exclusion constraint along
+// with their operators.
+func formatExclusionCols(tab Table, uniq UniqueConstraint, ops []treecmp.ComparisonOperator) string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i := range ops {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		colName := tab.Column(uniq.ColumnOrdinal(tab, i)).ColName()
+		fmt.Fprintf(&buf, "%s WITH %s", colName.String(), ops[i])
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
 // formatCatalogFKRef nicely formats a catalog foreign key reference using a
 // treeprinter for debugging and testing.
 func formatCatalogFKRef(
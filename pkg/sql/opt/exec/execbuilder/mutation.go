@@ -893,7 +893,17 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	//
+	// Or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (r)=([1,5)) conflicts with an existing key.
+	code := pgcode.UniqueViolation
+	if uc.ExclusionOperators() != nil {
+		code = pgcode.ExclusionViolation
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -912,11 +922,15 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	if code == pgcode.ExclusionViolation {
+		details.WriteString(") conflicts with an existing key.")
+	} else {
+		details.WriteString(") already exists.")
+	}
 
 	return errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
@@ -337,6 +337,13 @@ func TestExecBuild_inverted_index_multi_column(
 	runExecBuildLogicTest(t, "inverted_index_multi_column")
 }
 
+func TestExecBuild_inverted_index_range(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "inverted_index_range")
+}
+
 func TestExecBuild_inverted_join_geospatial(
 	t *testing.T,
 ) {
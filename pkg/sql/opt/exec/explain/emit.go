@@ -554,6 +554,9 @@ func (e *emitter) emitNodeAttributes(ctx context.Context, evalCtx *eval.Context,
 		if s.SQLCPUTime.HasValue() {
 			e.ob.AddField("sql cpu time", string(humanizeutil.Duration(s.SQLCPUTime.Value())))
 		}
+		if s.PlanNodeID != 0 {
+			e.ob.AddField("plan node id", fmt.Sprintf("%d", s.PlanNodeID))
+		}
 		if e.ob.flags.Verbose {
 			if s.StepCount.HasValue() {
 				e.ob.AddField("MVCC step count (ext/int)", fmt.Sprintf("%s/%s",
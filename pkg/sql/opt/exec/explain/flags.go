@@ -27,6 +27,10 @@ type Flags struct {
 	// RedactValues is similar to HideValues but indicates that we should use
 	// redaction markers instead of underscores. Used by EXPLAIN (REDACT).
 	RedactValues bool
+	// CPUProfile indicates that a CPU profile labeled by flow and operator,
+	// along with a heap profile, should be collected while the statement
+	// executes. Used by EXPLAIN ANALYZE (DEBUG, PROFILE).
+	CPUProfile bool
 
 	// Flags to hide various fields for testing purposes.
 	Deflake DeflakeFlags
@@ -80,5 +84,8 @@ func MakeFlags(options *tree.ExplainOptions) Flags {
 	if options.Flags[tree.ExplainFlagRedact] {
 		f.RedactValues = true
 	}
+	if options.Flags[tree.ExplainFlagProfile] {
+		f.CPUProfile = true
+	}
 	return f
 }
@@ -231,6 +231,78 @@ func DecodePlanGistToPlan(s string, cat cat.Catalog) (plan *Plan, retErr error)
 	return plan, nil
 }
 
+// PlanGistIndexes decodes a gist and returns, for each table that the plan
+// reads through a single index, the name of that index. Tables read through
+// several indexes, and tables or indexes that no longer exist in the catalog,
+// are omitted.
+func PlanGistIndexes(gist string, catalog cat.Catalog) (_ map[tree.Name]tree.Name, retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// See DecodePlanGistToRows.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				retErr = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	plan, err := DecodePlanGistToPlan(gist, catalog)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make(map[tree.Name]tree.Name)
+	var ambiguous map[tree.Name]struct{}
+	record := func(table cat.Table, index cat.Index) {
+		if _, ok := table.(*unknownTable); ok {
+			return
+		}
+		if _, ok := index.(*unknownIndex); ok {
+			return
+		}
+		tabName, idxName := table.Name(), index.Name()
+		if _, ok := ambiguous[tabName]; ok {
+			return
+		}
+		if prev, ok := indexes[tabName]; ok && prev != idxName {
+			if ambiguous == nil {
+				ambiguous = make(map[tree.Name]struct{})
+			}
+			ambiguous[tabName] = struct{}{}
+			delete(indexes, tabName)
+			return
+		}
+		indexes[tabName] = idxName
+	}
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n == nil {
+			return
+		}
+		switch a := n.args.(type) {
+		case *scanArgs:
+			record(a.Table, a.Index)
+		case *lookupJoinArgs:
+			record(a.Table, a.Index)
+		case *invertedJoinArgs:
+			record(a.Table, a.Index)
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(plan.Root)
+	for i := range plan.Subqueries {
+		if n, ok := plan.Subqueries[i].Root.(*Node); ok {
+			walk(n)
+		}
+	}
+	for _, c := range plan.Checks {
+		walk(c)
+	}
+	return indexes, nil
+}
+
 func (f *PlanGistFactory) decodeOp() execOperator {
 	val, err := f.buffer.ReadByte()
 	if err != nil || val == 0 {
@@ -8,6 +8,8 @@ package explain_test
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -106,6 +108,17 @@ func TestPlanGistBuilder(t *testing.T) {
 			// Take gist string and display plan
 		case "explain-plan-gist":
 			return explainGist(d.Input, catalog)
+		case "plan-gist-indexes":
+			indexes, err := explain.PlanGistIndexes(makeGist(ot, t).String(), catalog)
+			if err != nil {
+				d.Fatalf(t, "%+v", err)
+			}
+			var rows []string
+			for tab, idx := range indexes {
+				rows = append(rows, fmt.Sprintf("%s@%s\n", tab, idx))
+			}
+			sort.Strings(rows)
+			return strings.Join(rows, "")
 		case "plan":
 			return plan(ot, t)
 		case "hash":
@@ -525,6 +525,9 @@ type ExecutionStats struct {
 	// UsedFollowerRead indicates whether at least some reads were served by the
 	// follower replicas.
 	UsedFollowerRead bool
+	// PlanNodeID, if non-zero, identifies the operator in the CPU profile
+	// collected by EXPLAIN ANALYZE (DEBUG, PROFILE).
+	PlanNodeID int
 }
 
 // BuildPlanForExplainFn builds an execution plan against the given
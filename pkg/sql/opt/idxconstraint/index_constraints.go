@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/errors"
 )
 
@@ -604,6 +605,108 @@ func (c *indexConstraintCtx) makeSpansForTupleIn(
 	return len(tuplePos) == len(lhs.Elems)
 }
 
+// makeSpansForINetContainment creates spans for an INET or CIDR index column
+// from one of the containment operators:
+//
+//	left << right   (contains=false, orEquals=false)
+//	left <<= right  (contains=false, orEquals=true)
+//	left >> right   (contains=true, orEquals=false)
+//	left >>= right  (contains=true, orEquals=true)
+//
+// One side must be the index column and the other a constant. The ok return
+// value is false if the expression does not have this form.
+//
+// Index keys are ordered by family, then netmask length, then address, so the
+// values that satisfy the expression form one span for each possible netmask
+// length of the column value. For example, x <<= '10.0.0.0/30' becomes:
+//
+//	[/'10.0.0.0/30' - /'10.0.0.3/30']
+//	[/'10.0.0.0/31' - /'10.0.0.3/31']
+//	[/'10.0.0.0' - /'10.0.0.3']
+//
+// The <tight> return value indicates if the spans are exactly equivalent to the
+// expression (and not weaker).
+func (c *indexConstraintCtx) makeSpansForINetContainment(
+	offset int, left, right opt.Expr, contains, orEquals bool, out *constraint.Constraint,
+) (tight, ok bool) {
+	if c.colType(offset).Family() != types.INetFamily {
+		return false, false
+	}
+	var val opt.Expr
+	switch {
+	case c.isIndexColumn(left, offset) && opt.IsConstValueOp(right):
+		val = right
+	case c.isIndexColumn(right, offset) && opt.IsConstValueOp(left):
+		// Commute the operator, e.g. y >> x becomes x << y.
+		val = left
+		contains = !contains
+	default:
+		return false, false
+	}
+	datum := memo.ExtractConstDatum(val)
+	if datum == tree.DNull {
+		c.contradiction(offset, out)
+		return true, true
+	}
+	if !c.verifyType(offset, datum.ResolvedType()) {
+		return false, false
+	}
+	ip := tree.MustBeDIPAddr(datum).IPAddr
+
+	// Determine the range of netmask lengths of the column values that can
+	// satisfy the expression.
+	maxMask := 32
+	if ip.Family == ipaddr.IPv6family {
+		maxMask = 128
+	}
+	var minLen, maxLen int
+	if contains {
+		// The column value contains the constant, so its netmask can't be
+		// longer.
+		minLen, maxLen = 0, int(ip.Mask)
+		if !orEquals {
+			maxLen--
+		}
+	} else {
+		// The column value is contained by the constant, so its netmask can't be
+		// shorter.
+		minLen, maxLen = int(ip.Mask), maxMask
+		if !orEquals {
+			minLen++
+		}
+	}
+	if minLen > maxLen {
+		c.contradiction(offset, out)
+		return true, true
+	}
+
+	keyCtx := &c.keyCtx[offset]
+	descending := c.columns[offset].Descending()
+	var spans constraint.Spans
+	var sp constraint.Span
+	spans.Alloc(maxLen - minLen + 1)
+	for maskLen := minLen; maskLen <= maxLen; maskLen++ {
+		// The column values with this netmask length all lie within the network
+		// of the containing address.
+		network := ip
+		if contains {
+			network.Mask = byte(maskLen)
+		}
+		start, end := network.Network(), network.Broadcast()
+		start.Mask, end.Mask = byte(maskLen), byte(maskLen)
+		startKey := constraint.MakeKey(tree.NewDIPAddr(tree.DIPAddr{IPAddr: start}))
+		endKey := constraint.MakeKey(tree.NewDIPAddr(tree.DIPAddr{IPAddr: end}))
+		if descending {
+			startKey, endKey = endKey, startKey
+		}
+		sp.Init(startKey, includeBoundary, endKey, includeBoundary)
+		spans.Append(&sp)
+	}
+	spans.SortAndMerge(keyCtx)
+	out.Init(keyCtx, &spans)
+	return true, true
+}
+
 // makeSpansForExpr creates spans for index columns starting at <offset>
 // from the given expression.
 //
@@ -701,6 +804,42 @@ func (c *indexConstraintCtx) makeSpansForExpr(
 
 	case *memo.RangeExpr:
 		return c.makeSpansForExpr(offset, t.And, out)
+
+	case *memo.LShiftExpr:
+		// Support the INET containment operator (@1 << x).
+		if tight, ok := c.makeSpansForINetContainment(
+			offset, t.Left, t.Right, false /* contains */, false /* orEquals */, out,
+		); ok {
+			return tight
+		}
+
+	case *memo.RShiftExpr:
+		// Support the INET containment operator (@1 >> x).
+		if tight, ok := c.makeSpansForINetContainment(
+			offset, t.Left, t.Right, true /* contains */, false /* orEquals */, out,
+		); ok {
+			return tight
+		}
+
+	case *memo.FunctionExpr:
+		// Support the INET containment operators (@1 <<= x) and (@1 >>= x), which
+		// are parsed as function calls.
+		if len(t.Args) == 2 {
+			var tight, ok bool
+			switch t.Name {
+			case "inet_contained_by_or_equals":
+				tight, ok = c.makeSpansForINetContainment(
+					offset, t.Args[0], t.Args[1], false /* contains */, true /* orEquals */, out,
+				)
+			case "inet_contains_or_equals":
+				tight, ok = c.makeSpansForINetContainment(
+					offset, t.Args[0], t.Args[1], true /* contains */, true /* orEquals */, out,
+				)
+			}
+			if ok {
+				return tight
+			}
+		}
 	}
 
 	// Support e as (c = TRUE) if c is an indexed, boolean, computed expression
@@ -141,6 +141,12 @@ func TryFilterInvertedIndex(
 				index:           index,
 				computedColumns: computedColumns,
 			}
+		case types.RangeFamily:
+			filterPlanner = &rangeFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+			}
 		default:
 			return nil, nil, nil, nil, false
 		}
@@ -472,6 +478,11 @@ func TryJoinInvertedIndex(
 			getSpanExpr: getSpanExprForGeometryIndex,
 		}
 	} else {
+		col := index.InvertedColumn().InvertedSourceColumnOrdinal()
+		if factory.Metadata().Table(tabID).Column(col).DatumType().Family() == types.RangeFamily {
+			// Inverted joins are not supported for range indexes.
+			return nil
+		}
 		joinPlanner = &jsonOrArrayJoinPlanner{
 			factory:   factory,
 			tabID:     tabID,
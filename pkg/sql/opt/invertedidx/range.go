@@ -0,0 +1,110 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	ctx context.Context, evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var left, right opt.ScalarExpr
+	// containedBy is true if the index column must be contained by the
+	// constant, and contains is true if it must contain the constant.
+	var contains, containedBy bool
+	switch t := expr.(type) {
+	case *memo.OverlapsExpr:
+		left, right = t.Left, t.Right
+	case *memo.ContainsExpr:
+		left, right = t.Left, t.Right
+		contains = true
+	case *memo.ContainedByExpr:
+		left, right = t.Left, t.Right
+		containedBy = true
+	default:
+		// Only the above types are supported.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	var col, constantVal opt.ScalarExpr
+	if isIndexColumn(r.tabID, r.index, left, r.computedColumns) && memo.CanExtractConstDatum(right) {
+		col, constantVal = left, right
+	} else if isIndexColumn(r.tabID, r.index, right, r.computedColumns) && memo.CanExtractConstDatum(left) {
+		// Commute the operator so that the index column is on the left.
+		col, constantVal = right, left
+		contains, containedBy = containedBy, contains
+	} else {
+		// Can only accelerate with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	d := memo.ExtractConstDatum(constantVal)
+	if d == tree.DNull {
+		// An operator with a NULL operand results in no rows, but it'd be tricky
+		// to encode that with a span expression, so we simply skip inverted index
+		// acceleration in this case (which should be pretty rare).
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	if d.ResolvedType().Family() != types.RangeFamily {
+		if !contains {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		// A range containing an element contains the range holding only that
+		// element.
+		var err error
+		d, err = tree.NewDRange(col.DataType(), d, d, true /* lowerInc */, true /* upperInc */)
+		if err != nil {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+	}
+
+	var err error
+	switch {
+	case contains:
+		invertedExpr, err = rowenc.EncodeContainingInvertedIndexSpans(ctx, evalCtx, d)
+	case containedBy:
+		invertedExpr, err = rowenc.EncodeContainedInvertedIndexSpans(ctx, evalCtx, d)
+	default:
+		invertedExpr, err = rowenc.EncodeOverlapsInvertedIndexSpans(ctx, evalCtx, d)
+	}
+	if err != nil || invertedExpr == nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// If the extracted inverted expression is not tight then remaining filters
+	// must be applied after the inverted index scan.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
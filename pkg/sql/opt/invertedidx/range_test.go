@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package invertedidx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryFilterRange(t *testing.T) {
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t (r int8range, INVERTED INDEX (r))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn := tree.NewUnqualifiedTableName("t")
+	tab := md.AddTable(tc.Table(tn), tn)
+	rangeOrd := 1
+
+	// If we can create an inverted filter with the given filter expression and
+	// index, ok=true. If the spans in the resulting inverted index constraint
+	// do not have duplicate primary keys, unique=true. If the spans are tight,
+	// tight=true and remainingFilters="". Otherwise, tight is false and
+	// remainingFilters contains some or all of the original filters.
+	testCases := []struct {
+		filters string
+		ok      bool
+		tight   bool
+		unique  bool
+	}{
+		{filters: "r && '[1,10)'", ok: true, tight: false, unique: false},
+		{filters: "'[1,10)' && r", ok: true, tight: false, unique: false},
+		{filters: "r && '(,)'", ok: true, tight: false, unique: false},
+		{filters: "r @> '[1,10)'", ok: true, tight: false, unique: false},
+		{filters: "'[1,10)' <@ r", ok: true, tight: false, unique: false},
+		{filters: "r @> 5", ok: true, tight: false, unique: false},
+		{filters: "5 <@ r", ok: true, tight: false, unique: false},
+		{filters: "r <@ '[1,10)'", ok: true, tight: false, unique: false},
+		{filters: "'[1,10)' @> r", ok: true, tight: false, unique: false},
+
+		// Only the empty range is contained by the empty range.
+		{filters: "r <@ 'empty'", ok: true, tight: true, unique: true},
+
+		// Every range contains the empty range.
+		{filters: "r @> 'empty'", ok: false, tight: false, unique: false},
+
+		// Adjacency cannot be evaluated with the index.
+		{filters: "r -|- '[1,10)'", ok: false, tight: false, unique: false},
+
+		// Conjunctions and disjunctions of supported filters.
+		{filters: "r && '[1,10)' AND r && '[5,20)'", ok: true, tight: false, unique: false},
+		{filters: "r <@ 'empty' OR r @> 5", ok: true, tight: false, unique: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		// We're not testing that the correct SpanExpression is returned here;
+		// that is tested elsewhere. This is just testing that we are constraining
+		// the index when we expect to and we have the correct values for tight,
+		// unique, and remainingFilters.
+		spanExpr, _, remainingFilters, _, ok := invertedidx.TryFilterInvertedIndex(
+			context.Background(),
+			evalCtx,
+			&f,
+			filters,
+			nil, /* optionalFilters */
+			tab,
+			md.Table(tab).Index(rangeOrd),
+			nil,       /* computedColumns */
+			func() {}, /* checkCancellation */
+		)
+		if tc.ok != ok {
+			t.Fatalf("expected %v, got %v", tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if tc.tight != spanExpr.Tight {
+			t.Fatalf("For (%s), expected tight=%v, but got %v", tc.filters, tc.tight, spanExpr.Tight)
+		}
+		if tc.unique != spanExpr.Unique {
+			t.Fatalf("For (%s), expected unique=%v, but got %v", tc.filters, tc.unique, spanExpr.Unique)
+		}
+
+		if tc.tight {
+			require.True(t, remainingFilters.IsTrue())
+		} else {
+			require.Equal(t, filters.String(), remainingFilters.String(),
+				"mismatched remaining filters")
+		}
+	}
+}
@@ -1963,6 +1963,12 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.ExclusionOperators() != nil {
+			// Exclusion constraints do not guarantee that their columns are
+			// distinct, so they cannot be used as keys.
+			continue
+		}
+
 		if _, isPartial := unique.Predicate(); isPartial {
 			// Partial constraints cannot be considered while building functional
 			// dependency keys for the table because their keys are only unique
@@ -141,6 +141,8 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
 	TSMatchesOp:      treecmp.TSMatches,
+	AdjacentOp:       treecmp.Adjacent,
+	JsonPathExistsOp: treecmp.JSONPathExists,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -282,7 +284,8 @@ func ScalarOperatorTransmitsNulls(op Operator) bool {
 	case BitandOp, BitorOp, BitxorOp, PlusOp, MinusOp, MultOp, DivOp, FloorDivOp,
 		ModOp, PowOp, EqOp, NeOp, LtOp, GtOp, LeOp, GeOp, LikeOp, NotLikeOp, ILikeOp,
 		NotILikeOp, SimilarToOp, NotSimilarToOp, RegMatchOp, NotRegMatchOp, RegIMatchOp,
-		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp:
+		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp, AdjacentOp,
+		JsonPathExistsOp:
 		return true
 
 	default:
@@ -298,7 +301,7 @@ func BoolOperatorRequiresNotNullArgs(op Operator) bool {
 		EqOp, LtOp, LeOp, GtOp, GeOp, NeOp,
 		LikeOp, NotLikeOp, ILikeOp, NotILikeOp, SimilarToOp, NotSimilarToOp,
 		RegMatchOp, NotRegMatchOp, RegIMatchOp, NotRegIMatchOp, BBoxCoversOp,
-		BBoxIntersectsOp:
+		BBoxIntersectsOp, AdjacentOp, JsonPathExistsOp:
 		return true
 	}
 	return false
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -72,6 +73,11 @@ type Builder struct {
 	// a statement during session migration.
 	SkipAOST bool
 
+	// StatementHints is a control knob: if set, optbuilder applies the
+	// force_index, join_algorithm and join_order hints stored for the
+	// statement's fingerprint, and records which of them were applied.
+	StatementHints *stmthints.Hints
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
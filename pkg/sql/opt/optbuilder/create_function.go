@@ -154,7 +154,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// When multiple OUT parameters are present, parameter names become the
 	// labels in the output RECORD type.
 	var outParamNames []string
-	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam bool
+	var sawDefaultExpr, sawPolymorphicOutParam, sawVariadicParam bool
+	// sawAnyElementInParam and sawAnyCompatibleInParam track whether there is
+	// an IN parameter from which the concrete type of the polymorphic
+	// "anyelement" and "anycompatible" families, respectively, can be
+	// determined.
+	var sawAnyElementInParam, sawAnyCompatibleInParam bool
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -182,13 +187,33 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(unimplemented.NewWithIssue(121251, "unnamed INOUT parameters are not yet supported"))
 		}
 		if param.IsInParam() {
+			if sawVariadicParam {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter",
+				))
+			}
 			if typ.Family() == types.VoidFamily {
 				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition, "SQL functions cannot have arguments of type VOID"))
 			}
-			if typ.IsPolymorphicType() {
-				sawPolymorphicInParam = true
+			if typ.IsAnyCompatibleType() {
+				sawAnyCompatibleInParam = true
+			} else if typ.IsPolymorphicType() {
+				sawAnyElementInParam = true
 			}
 		}
+		if param.IsOutParam() && cf.IsProcedure && sawVariadicParam {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"VARIADIC parameter must be the last parameter",
+			))
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be an array",
+				))
+			}
+			sawVariadicParam = true
+		}
 		if param.IsOutParam() {
 			outParamTypes = append(outParamTypes, typ)
 			paramName := string(param.Name)
@@ -317,9 +342,22 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	if b.evalCtx.SessionData().OptimizerUsePolymorphicParameterFix &&
 		(funcReturnType.IsPolymorphicType() || sawPolymorphicOutParam) {
 		// The routine return type has or contains a polymorphic type. Validate that
-		// there is at least one polymorphic IN parameter.
-		if !sawPolymorphicInParam {
-			makeErr := func(polyTyp *types.T) {
+		// there is at least one IN parameter of the same polymorphic family.
+		checkPolyTyp := func(polyTyp *types.T) {
+			if !polyTyp.IsPolymorphicType() {
+				return
+			}
+			if polyTyp.IsAnyCompatibleType() {
+				if !sawAnyCompatibleInParam {
+					panic(errors.WithDetailf(
+						pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
+						"A result of type %s requires at least one input of type "+
+							"anycompatible, anycompatiblearray, anycompatiblenonarray, "+
+							"anycompatiblerange, or anycompatiblemultirange.",
+						polyTyp.Name(),
+					))
+				}
+			} else if !sawAnyElementInParam {
 				panic(errors.WithDetailf(
 					pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
 					"A result of type %s requires at least one input of type "+
@@ -327,14 +365,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 					polyTyp.Name(),
 				))
 			}
-			if funcReturnType.IsPolymorphicType() {
-				makeErr(funcReturnType)
-			} else {
-				for _, tc := range funcReturnType.TupleContents() {
-					if tc.IsPolymorphicType() {
-						makeErr(tc)
-					}
-				}
+		}
+		if funcReturnType.IsPolymorphicType() {
+			checkPolyTyp(funcReturnType)
+		} else {
+			for _, tc := range funcReturnType.TupleContents() {
+				checkPolyTyp(tc)
 			}
 		}
 	} else if funcReturnType.Family() == types.UnknownFamily {
@@ -0,0 +1,109 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// buildDomainCheck wraps value, a scalar expression of the domain type typ,
+// with checks of the domain's NOT NULL and CHECK constraints. The returned
+// expression evaluates to value if the constraints are satisfied, and raises
+// an error otherwise.
+//
+// The checks reference value directly, so it is evaluated once for each
+// constraint. Callers should pass a variable or another inexpensive expression.
+func (b *Builder) buildDomainCheck(value opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	// Add the domain to the metadata so that the memo is invalidated when its
+	// constraints are altered.
+	b.factory.Metadata().AddUserDefinedType(typ, nil /* name */)
+	domain := typ.TypeMeta.DomainData
+	if domain == nil {
+		panic(errors.AssertionFailedf("domain %s is not hydrated", typ.SQLString()))
+	}
+	var whens memo.ScalarListExpr
+	if domain.NotNull {
+		whens = append(whens, b.factory.ConstructWhen(
+			b.factory.ConstructIs(value, memo.NullSingleton),
+			b.buildDomainViolation(typ, pgcode.NotNullViolation,
+				fmt.Sprintf("domain %s does not allow null values", typ.Name())),
+		))
+	}
+	if len(domain.Constraints) > 0 {
+		// The check expressions are built with a placeholder column for VALUE,
+		// which is then replaced with value cast to the base type.
+		baseType := typ.DomainBaseType()
+		valueScope := b.allocScope()
+		valueCol := b.synthesizeColumn(
+			valueScope, scopeColName(schemaexpr.DomainValueName), baseType, nil /* expr */, nil, /* scalar */
+		)
+		baseValue := b.factory.ConstructCast(value, baseType)
+		var replace norm.ReplaceFunc
+		replace = func(e opt.Expr) opt.Expr {
+			if v, ok := e.(*memo.VariableExpr); ok && v.Col == valueCol.id {
+				return baseValue
+			}
+			return b.factory.Replace(e, replace)
+		}
+		for i := range domain.Constraints {
+			c := &domain.Constraints[i]
+			expr, err := parser.ParseExpr(c.Expr)
+			if err != nil {
+				panic(err)
+			}
+			texpr := valueScope.resolveAndRequireType(expr, types.Bool)
+			check := replace(b.buildScalar(texpr, valueScope, nil, nil, nil)).(opt.ScalarExpr)
+			// Like a table CHECK constraint, a domain CHECK constraint is
+			// satisfied if it evaluates to NULL.
+			whens = append(whens, b.factory.ConstructWhen(
+				b.factory.ConstructNot(check),
+				b.buildDomainViolation(typ, pgcode.CheckViolation,
+					fmt.Sprintf("value for domain %s violates check constraint %q", typ.Name(), c.Name)),
+			))
+		}
+	}
+	if len(whens) == 0 {
+		return value
+	}
+	return b.factory.ConstructCase(memo.TrueSingleton, whens, value)
+}
+
+// buildDomainViolation builds an expression of type typ that raises an error
+// with the given code and message when it is evaluated.
+func (b *Builder) buildDomainViolation(
+	typ *types.T, code pgcode.Code, msg string,
+) opt.ScalarExpr {
+	const forceErrorFnName = "crdb_internal.force_error"
+	props, overloads := builtinsregistry.GetBuiltinProperties(forceErrorFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", forceErrorFnName))
+	}
+	forceError := b.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.factory.ConstructConstVal(tree.NewDString(code.String()), types.String),
+			b.factory.ConstructConstVal(tree.NewDString(msg), types.String),
+		},
+		&memo.FunctionPrivate{
+			Name:       forceErrorFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	// The error is raised before the casts are evaluated.
+	return b.factory.ConstructCast(b.factory.ConstructCast(forceError, types.String), typ)
+}
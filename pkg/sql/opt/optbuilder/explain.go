@@ -45,7 +45,9 @@ func (b *Builder) buildExplain(explain *tree.Explain, inScope *scope) (outScope
 		telemetry.Inc(sqltelemetry.ExplainVecUseCounter)
 
 	case tree.ExplainDDL:
-		if explain.Flags[tree.ExplainFlagViz] {
+		if explain.Flags[tree.ExplainFlagRanges] {
+			telemetry.Inc(sqltelemetry.ExplainDDLRanges)
+		} else if explain.Flags[tree.ExplainFlagViz] {
 			telemetry.Inc(sqltelemetry.ExplainDDLViz)
 		} else if explain.Flags[tree.ExplainFlagVerbose] {
 			telemetry.Inc(sqltelemetry.ExplainDDLVerbose)
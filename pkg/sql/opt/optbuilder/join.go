@@ -59,6 +59,7 @@ func (b *Builder) buildJoin(
 	var flags memo.JoinFlags
 	switch join.Hint {
 	case "":
+		flags = b.joinFlagsWithStatementHints(joinType)
 	case tree.AstHash:
 		telemetry.Inc(sqltelemetry.HashJoinHintUseCounter)
 		flags = memo.AllowOnlyHashJoinStoreRight
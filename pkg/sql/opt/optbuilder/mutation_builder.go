@@ -118,6 +118,11 @@ type mutationBuilder struct {
 	// (see opt.Table.CheckCount).
 	checkColIDs opt.OptionalColList
 
+	// domainCheckedColIDs is the set of input column IDs whose values have
+	// been checked against the constraints of the domain types of their target
+	// columns. See addAssignmentCasts.
+	domainCheckedColIDs opt.ColSet
+
 	// partialIndexPutColIDs lists the input column IDs storing the boolean
 	// results of evaluating partial index predicate expressions of the target
 	// table. The predicate expressions are evaluated with their variables
@@ -307,6 +312,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintUpdateUseCounter)
 	}
+	indexFlags = mb.b.indexFlagsWithStatementHints(mb.tab, indexFlags)
 
 	if mb.b.evalCtx.SessionData().AvoidFullTableScansInMutations {
 		if indexFlags == nil {
@@ -424,6 +430,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintDeleteUseCounter)
 	}
+	indexFlags = mb.b.indexFlagsWithStatementHints(mb.tab, indexFlags)
 
 	if mb.b.evalCtx.SessionData().AvoidFullTableScansInMutations {
 		if indexFlags == nil {
@@ -1432,6 +1439,9 @@ func resultsNeeded(r tree.ReturningClause) bool {
 //
 // If there is no valid assignment cast from a column type in srcCols to its
 // corresponding target column type, then this function throws an error.
+//
+// Columns with a domain target type are also wrapped with checks of the
+// domain's constraints, once per column.
 func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 	var projectionScope *scope
 	for ord, colID := range srcCols {
@@ -1444,21 +1454,31 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		targetCol := mb.tab.Column(ord)
 		targetType := mb.tab.Column(ord).DatumType()
 
+		// Values assigned to a column of a domain type must satisfy the
+		// domain's constraints, even if they are already of the domain type.
+		checkDomain := targetType.IsDomain() && !mb.domainCheckedColIDs.Contains(colID)
+
 		// An assignment cast is not necessary if the source and target types
 		// are identical.
-		if srcType.Identical(targetType) {
+		needsCast := !srcType.Identical(targetType)
+		if !needsCast && !checkDomain {
 			continue
 		}
 
 		// Check if an assignment cast is available from the inScope column
 		// type to the out type.
-		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+		if needsCast && !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
 			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
 		}
 
 		// Create the cast expression.
-		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		var cast opt.ScalarExpr = mb.b.factory.ConstructVariable(colID)
+		if needsCast {
+			cast = mb.b.factory.ConstructAssignmentCast(cast, targetType)
+		}
+		if checkDomain {
+			cast = mb.b.buildDomainCheck(cast, targetType)
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1477,6 +1497,9 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 
 		// Replace old source column with the new one.
 		srcCols[ord] = scopeCol.id
+		if checkDomain {
+			mb.domainCheckedColIDs.Add(scopeCol.id)
+		}
 	}
 
 	if projectionScope != nil {
@@ -74,6 +74,10 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 		for i, uc := 0, mb.tab.UniqueCount(); i < uc; i++ {
 			constraint := mb.tab.Unique(i)
 			if constraint.Name() == string(onConflict.Constraint) {
+				if constraint.ExclusionOperators() != nil {
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"ON CONFLICT is not supported with exclusion constraint %q", onConflict.Constraint))
+				}
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
@@ -161,7 +165,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Exclusion constraints cannot be arbiters, since conflicts with them
+			// are not detected by matching equal values.
+			if u := mb.tab.Unique(uc); u.WithoutIndex() && u.ExclusionOperators() == nil {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -219,6 +225,10 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.ExclusionOperators() != nil {
+			// Exclusion constraints cannot be arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
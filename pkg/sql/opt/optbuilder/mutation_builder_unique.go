@@ -285,11 +285,13 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
-	if primaryOrds.Empty() {
-		// The primary key columns are a subset of the unique columns; unique check
-		// not needed.
-		return false
+	if h.unique.ExclusionOperators() == nil {
+		primaryOrds.DifferenceWith(uniqueOrds)
+		if primaryOrds.Empty() {
+			// The primary key columns are a subset of the unique columns; unique
+			// check not needed.
+			return false
+		}
 	}
 
 	h.uniqueOrdinals = uniqueOrds
@@ -306,7 +308,11 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 
 		// If one of the columns is a UUID (or UUID casted to STRING or BYTES) set
 		// to gen_random_uuid() and we don't require uniqueness checks for
-		// gen_random_uuid(), unique check not needed.
+		// gen_random_uuid(), unique check not needed. This does not apply to
+		// exclusion constraints, whose operators may match distinct values.
+		if h.unique.ExclusionOperators() != nil {
+			continue
+		}
 		switch mb.md.ColumnMeta(colID).Type.Family() {
 		case types.UuidFamily, types.StringFamily, types.BytesFamily:
 			if columnIsGenRandomUUID(mb.outScope.expr, colID) {
@@ -345,6 +351,12 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// This does not apply to exclusion constraints, which can be violated by
+	// rows with distinct values.
+	if h.unique.ExclusionOperators() != nil {
+		return true
+	}
 	var uniqueCols opt.ColSet
 	h.uniqueOrdinals.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
@@ -495,13 +507,31 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		numFilters += 2
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+	if ops := h.unique.ExclusionOperators(); ops != nil {
+		// For exclusion constraints, the columns are compared with the operators
+		// of the constraint instead:
+		//   (new_a op_a existing_a) AND (new_b op_b existing_b) AND ...
+		for i := range ops {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			newCol, existingCol := &uniqueCheckScope.cols[ord], &h.scanScope.cols[ord]
+			cmp := tree.NewTypedComparisonExpr(ops[i], newCol, existingCol)
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				h.mb.b.constructComparison(
+					cmp, f.ConstructVariable(newCol.id), f.ConstructVariable(existingCol.id),
+				),
+			))
+		}
+		// The fast path only supports equality filters.
+		buildFastPathCheck = false
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(uniqueCheckScope.cols[i].id),
+					f.ConstructVariable(h.scanScope.cols[i].id),
+				),
+			))
+		}
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -576,8 +606,16 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+	if h.unique.ExclusionOperators() != nil {
+		// The columns of exclusion constraints are not necessarily in ordinal
+		// order, so the key columns must follow the order of the constraint.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			keyCols = append(keyCols, uniqueCheckScope.cols[h.unique.ColumnOrdinal(h.mb.tab, i)].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -21,7 +21,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -242,7 +241,7 @@ func (b *Builder) buildRoutine(
 		args = make(memo.ScalarListExpr, 0, len(f.Exprs))
 		argTypes = make([]*types.T, 0, len(f.Exprs))
 		for i, pexpr := range f.Exprs {
-			if isProc && o.RoutineParams[i].Class == tree.RoutineParamOut {
+			if isProc && i < len(o.RoutineParams) && o.RoutineParams[i].Class == tree.RoutineParamOut {
 				// For procedures, OUT parameters need to be specified in the
 				// CALL statement, but they are not evaluated and shouldn't be
 				// passed down to the UDF Call (since the body can only
@@ -270,42 +269,69 @@ func (b *Builder) buildRoutine(
 	// CTEs that mutate and are not at the top-level.
 	bodyScope := b.allocScope()
 	var params opt.ColList
-	var polyArgTyp *types.T
+	var polyTypes tree.PolymorphicTypes
 	if o.Types.Length() > 0 {
-		// If necessary, add DEFAULT arguments.
-		args, argTypes = b.addDefaultArgs(f, args, argTypes, bodyScope, colRefs)
-
-		// Add all input parameters to the scope.
 		paramTypes, ok := o.Types.(tree.ParamTypes)
 		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+			panic(errors.AssertionFailedf("expected routine parameters to be ParamTypes, found %T", o.Types))
 		}
-		if len(paramTypes) != len(args) {
-			panic(errors.AssertionFailedf(
-				"different number of static parameters %d and actual arguments %d", len(paramTypes), len(args),
-			))
+		// The trailing arguments supplied for the VARIADIC parameter of a
+		// variadic routine are packed into a single array argument below, unless
+		// the array was passed directly using the VARIADIC keyword. Note that
+		// when no arguments are supplied for the VARIADIC parameter, its DEFAULT
+		// expression is used instead.
+		packVariadicArgs := o.Variadic && !f.Variadic && len(args) >= len(paramTypes)
+		if !packVariadicArgs {
+			// If necessary, add DEFAULT arguments.
+			args, argTypes = b.addDefaultArgs(f, args, argTypes, bodyScope, colRefs)
 		}
 
 		// Check the parameters for polymorphic types, and resolve to a concrete
-		// type if any exist.
-		if b.evalCtx.SessionData().OptimizerUsePolymorphicParameterFix {
+		// type if any exist. Each argument supplied for the VARIADIC parameter is
+		// matched against the element type of the parameter.
+		if b.evalCtx.SessionData().OptimizerUsePolymorphicParameterFix || packVariadicArgs {
+			polyParamTypes := paramTypes
+			if packVariadicArgs {
+				polyParamTypes = o.ExpandVariadicParams(len(args)).(tree.ParamTypes)
+			}
 			var numPolyParams int
-			_, numPolyParams, polyArgTyp = tree.ResolvePolymorphicArgTypes(
-				paramTypes, argTypes, nil /* anyElemTyp */, true, /* enforceConsistency */
+			_, numPolyParams, polyTypes = tree.ResolvePolymorphicArgTypes(
+				polyParamTypes, argTypes, tree.PolymorphicTypes{}, true, /* enforceConsistency */
 			)
 			if numPolyParams > 0 {
-				if polyArgTyp == nil {
-					// All supplied arguments were NULL, so a type could not be resolved
-					// for the polymorphic parameters.
-					panic(pgerror.New(pgcode.DatatypeMismatch,
-						"could not determine polymorphic type because input has type unknown",
-					))
-				}
 				// If the routine returns a polymorphic type, use the resolved
-				// polymorphic argument type to determine the concrete return type.
-				b.maybeResolvePolymorphicReturnType(f, polyArgTyp)
+				// polymorphic argument types to determine the concrete return type.
+				b.maybeResolvePolymorphicReturnType(f, polyTypes)
+			}
+		}
+
+		if packVariadicArgs {
+			variadicOrd := len(paramTypes) - 1
+			elemTyp := maybeReplacePolymorphicType(paramTypes[variadicOrd].Typ.ArrayContents(), polyTypes)
+			elems := make(memo.ScalarListExpr, 0, len(args)-variadicOrd)
+			for i := variadicOrd; i < len(args); i++ {
+				elem := args[i]
+				if !argTypes[i].Identical(elemTyp) {
+					if !cast.ValidCast(argTypes[i], elemTyp, cast.ContextAssignment) {
+						panic(errors.AssertionFailedf(
+							"VARIADIC argument expression has type %s, need type %s, assignment cast isn't possible",
+							argTypes[i].SQLStringForError(), elemTyp.SQLStringForError(),
+						))
+					}
+					elem = b.factory.ConstructCast(elem, elemTyp)
+				}
+				elems = append(elems, elem)
 			}
+			arrayTyp := types.MakeArray(elemTyp)
+			args = append(args[:variadicOrd], b.factory.ConstructArray(elems, arrayTyp))
+			argTypes = append(argTypes[:variadicOrd], arrayTyp)
+		}
+
+		// Add all input parameters to the scope.
+		if len(paramTypes) != len(args) {
+			panic(errors.AssertionFailedf(
+				"different number of static parameters %d and actual arguments %d", len(paramTypes), len(args),
+			))
 		}
 
 		// Add any needed casts from argument type to parameter type, and add a
@@ -313,7 +339,7 @@ func (b *Builder) buildRoutine(
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			argTyp := argTypes[i]
-			desiredTyp := maybeReplacePolymorphicType(paramTypes[i].Typ, polyArgTyp)
+			desiredTyp := maybeReplacePolymorphicType(paramTypes[i].Typ, polyTypes)
 			if desiredTyp.Identical(types.AnyTuple) {
 				// This is a RECORD-typed parameter. Use the actual argument type.
 				desiredTyp = argTyp
@@ -436,7 +462,7 @@ func (b *Builder) buildRoutine(
 			}
 			routineParams = append(routineParams, routineParam{
 				name:  param.Name,
-				typ:   maybeReplacePolymorphicType(typ, polyArgTyp),
+				typ:   maybeReplacePolymorphicType(typ, polyTypes),
 				class: param.Class,
 			})
 		}
@@ -751,12 +777,14 @@ func (b *Builder) addDefaultArgs(
 }
 
 // maybeResolvePolymorphicReturnType checks whether the return type of the
-// routine is polymorphic and if so, uses the resolved polymorphic argument type
+// routine is polymorphic and if so, uses the resolved polymorphic argument types
 // to determine the concrete return type.
-func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp *types.T) {
+func (b *Builder) maybeResolvePolymorphicReturnType(
+	f *tree.FuncExpr, polyTypes tree.PolymorphicTypes,
+) {
 	originalRTyp := f.ResolvedType()
 	if originalRTyp.IsPolymorphicType() {
-		f.SetTypeAnnotation(maybeReplacePolymorphicType(originalRTyp, polyArgTyp))
+		f.SetTypeAnnotation(maybeReplacePolymorphicType(originalRTyp, polyTypes))
 	} else if originalRTyp.Family() == types.TupleFamily && !f.ResolvedOverload().ReturnsRecordType {
 		var hasPolymorphicOutParam bool
 		for _, typ := range originalRTyp.TupleContents() {
@@ -768,7 +796,7 @@ func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp
 		if hasPolymorphicOutParam {
 			outParamTypes := make([]*types.T, len(originalRTyp.TupleContents()))
 			for i, outParamTyp := range originalRTyp.TupleContents() {
-				outParamTypes[i] = maybeReplacePolymorphicType(outParamTyp, polyArgTyp)
+				outParamTypes[i] = maybeReplacePolymorphicType(outParamTyp, polyTypes)
 			}
 			f.SetTypeAnnotation(types.MakeLabeledTuple(outParamTypes, originalRTyp.TupleLabels()))
 		}
@@ -776,23 +804,14 @@ func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp
 }
 
 // maybeReplacePolymorphicType checks whether the given type is polymorphic and
-// if so, replaces it with the given polymorphic argument type. It returns the
-// original type if it is not polymorphic.
-func maybeReplacePolymorphicType(originalTyp, polyArgTyp *types.T) *types.T {
-	if !originalTyp.IsPolymorphicType() || polyArgTyp == nil {
-		return originalTyp
-	}
-	switch originalTyp.Family() {
-	case types.ArrayFamily:
-		if polyArgTyp.Family() == types.ArrayFamily {
-			panic(pgerror.Newf(pgcode.UndefinedObject,
-				"could not find array type for data type %s", polyArgTyp.Name(),
-			))
-		}
-		return types.MakeArray(polyArgTyp)
-	default:
-		return polyArgTyp
+// if so, replaces it with the corresponding resolved polymorphic argument type.
+// It returns the original type if it is not polymorphic.
+func maybeReplacePolymorphicType(originalTyp *types.T, polyTypes tree.PolymorphicTypes) *types.T {
+	typ, err := polyTypes.ReplacePolymorphicType(originalTyp)
+	if err != nil {
+		panic(err)
 	}
+	return typ
 }
 
 func (b *Builder) withinNestedPLpgSQLCall(fn func()) {
@@ -277,7 +277,15 @@ func (b *Builder) buildScalar(
 	case *tree.CastExpr:
 		texpr := t.Expr.(tree.TypedExpr)
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
+		if typ := t.ResolvedType(); typ.Family() == types.ArrayFamily && typ.ArrayContents().IsDomain() {
+			// The elements of the array would not be checked against the domain's
+			// constraints.
+			panic(unimplemented.NewWithIssue(27796, "arrays of domains are not yet supported"))
+		}
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		if typ := t.ResolvedType(); typ.IsDomain() && texpr.ResolvedType().Oid() != typ.Oid() {
+			out = b.buildDomainCheck(out, typ)
+		}
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
@@ -808,6 +816,10 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
@@ -0,0 +1,87 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// indexFlagsWithStatementHints returns the index flags to use for a scan of
+// the given table, forcing the index named by a force_index (or plan_gist_indexes)
+// statement hint for the table, if any. Statement hints never override the
+// index hints of the statement itself, and hints that name an index that
+// doesn't exist are ignored. So are hints that name a partial, inverted or
+// vector index: whether those can be used depends on the filters of each
+// execution of the statement, and forcing one that can't be used fails the
+// statement.
+func (b *Builder) indexFlagsWithStatementHints(
+	tab cat.Table, indexFlags *tree.IndexFlags,
+) *tree.IndexFlags {
+	if b.StatementHints == nil || tab.IsVirtualTable() {
+		return indexFlags
+	}
+	if indexFlags != nil && (indexFlags.ForceIndex() || indexFlags.NoIndexJoin ||
+		indexFlags.ForceInvertedIndex || indexFlags.ForceZigzag ||
+		len(indexFlags.ZigzagIndexes) > 0 || len(indexFlags.ZigzagIndexIDs) > 0) {
+		return indexFlags
+	}
+	index, source, ok := b.StatementHints.ForceIndex(string(tab.Name()))
+	if !ok {
+		return indexFlags
+	}
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		if idx := tab.Index(i); idx.Name() == tree.Name(index) {
+			if _, isPartial := idx.Predicate(); isPartial || idx.IsInverted() || idx.IsVector() {
+				return indexFlags
+			}
+			var flags tree.IndexFlags
+			if indexFlags != nil {
+				flags = *indexFlags
+			}
+			flags.Index = tree.UnrestrictedName(index)
+			b.StatementHints.MarkApplied(source)
+			return &flags
+		}
+	}
+	return indexFlags
+}
+
+// joinFlagsWithStatementHints returns the join flags to use for a join without
+// a join hint of its own, given by a join_algorithm or join_order statement
+// hint, if any. LOOKUP and INVERTED hints are only applied to inner and left
+// joins, to which they are restricted. Unlike the join hints of the statement
+// itself, stored join hints are only preferences: if no plan satisfies them,
+// the statement is planned again without them (see Hints.DropJoinHint).
+func (b *Builder) joinFlagsWithStatementHints(joinType descpb.JoinType) memo.JoinFlags {
+	hint, source, ok := b.StatementHints.JoinHint()
+	if !ok {
+		return 0
+	}
+	var flags memo.JoinFlags
+	switch hint {
+	case tree.AstHash:
+		flags = memo.AllowOnlyHashJoinStoreRight
+	case tree.AstLookup, tree.AstInverted:
+		if joinType != descpb.InnerJoin && joinType != descpb.LeftOuterJoin {
+			return 0
+		}
+		flags = memo.AllowOnlyLookupJoinIntoRight
+		if hint == tree.AstInverted {
+			flags = memo.AllowOnlyInvertedJoinIntoRight
+		}
+	case tree.AstMerge:
+		flags = memo.AllowOnlyMergeJoin
+	case tree.AstStraight:
+		flags = memo.AllowAllJoinsIntoRight
+	default:
+		return 0
+	}
+	b.StatementHints.MarkApplied(source)
+	return flags
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -276,7 +277,11 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 				}
 			}
 		} else {
-			addCol(set.Expr, mb.targetColList[n])
+			expr := set.Expr
+			if set.Field != "" {
+				expr = mb.buildCompositeFieldUpdate(inScope, mb.targetColList[n], set.Field, expr)
+			}
+			addCol(expr, mb.targetColList[n])
 			n++
 		}
 	}
@@ -292,6 +297,55 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	mb.addSynthesizedColsForUpdate()
 }
 
+// buildCompositeFieldUpdate returns the new value of a composite-typed target
+// column when a single field of it is assigned, as in:
+//
+//	UPDATE t SET c.f = 1
+//
+// The new value is a tuple cast to the type of the column, with the given
+// expression for the field and the existing values for the other fields. If
+// the existing value is NULL, the other fields are NULL.
+func (mb *mutationBuilder) buildCompositeFieldUpdate(
+	inScope *scope, targetColID opt.ColumnID, field tree.Name, expr tree.Expr,
+) tree.Expr {
+	ord := mb.tabID.ColumnOrdinal(targetColID)
+	targetCol := mb.tab.Column(ord)
+	typ := targetCol.DatumType()
+	if typ.Family() != types.TupleFamily || !typ.UserDefined() {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"cannot assign to field %q of column %q because its type %s is not a composite type",
+			tree.ErrString(&field), targetCol.ColName(), typ.SQLStringForError()))
+	}
+	if _, ok := expr.(tree.DefaultVal); ok {
+		panic(unimplemented.NewWithIssue(27792, "DEFAULT for a field of a composite column"))
+	}
+	fieldIdx := -1
+	for i, label := range typ.TupleLabels() {
+		if label == string(field) {
+			fieldIdx = i
+			break
+		}
+	}
+	if fieldIdx == -1 {
+		panic(pgerror.Newf(pgcode.UndefinedColumn,
+			"cannot assign to field %q of column %q because there is no such column in data type %s",
+			tree.ErrString(&field), targetCol.ColName(), typ.SQLStringForError()))
+	}
+	existing := inScope.getColumn(mb.fetchColIDs[ord])
+	if existing == nil {
+		panic(errors.AssertionFailedf("column %q is not fetched", targetCol.ColName()))
+	}
+	exprs := make(tree.Exprs, len(typ.TupleContents()))
+	for i := range exprs {
+		if i == fieldIdx {
+			exprs[i] = expr
+		} else {
+			exprs[i] = &tree.ColumnAccessExpr{Expr: existing, ByIndex: true, ColIndex: i}
+		}
+	}
+	return &tree.CastExpr{Expr: &tree.Tuple{Exprs: exprs}, Type: typ, SyntaxMode: tree.CastShort}
+}
+
 // addSynthesizedColsForUpdate wraps an Update input expression with a Project
 // operator containing any computed columns that need to be updated. This
 // includes write-only mutation columns that are computed.
@@ -361,6 +361,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -747,6 +750,35 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	name := string(def.Name)
+	if name == "" {
+		var buf bytes.Buffer
+		buf.WriteString("exclude")
+		for i := range def.Elems {
+			buf.WriteRune('_')
+			buf.WriteString(string(def.Elems[i].Column))
+		}
+		name = buf.String()
+	}
+	u := UniqueConstraint{
+		name:               name,
+		tabID:              tt.TabID,
+		columnOrdinals:     make([]int, len(def.Elems)),
+		withoutIndex:       true,
+		validated:          true,
+		exclusionOperators: make([]treecmp.ComparisonOperator, len(def.Elems)),
+	}
+	for i := range def.Elems {
+		u.columnOrdinals[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		u.exclusionOperators[i] = def.Elems[i].Operator
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
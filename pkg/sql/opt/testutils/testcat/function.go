@@ -96,6 +96,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 	var outParamTypes []*types.T
 	var outParamNames []string
 	var defaultExprs []tree.Expr
+	var variadic bool
 	for i := range c.Params {
 		param := &c.Params[i]
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
@@ -108,6 +109,9 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 				Typ:  typ,
 			})
 		}
+		if param.Class == tree.RoutineParamVariadic {
+			variadic = true
+		}
 		if param.Class == tree.RoutineParamOut {
 			outParamOrdinals = append(outParamOrdinals, int32(i))
 			outParams = append(outParams, tree.ParamType{Typ: typ})
@@ -188,6 +192,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 		OutParamOrdinals:  outParamOrdinals,
 		OutParamTypes:     outParams,
 		DefaultExprs:      defaultExprs,
+		Variadic:          variadic,
 	}
 	overload.ReturnsRecordType = !c.IsProcedure && retType.Identical(types.AnyTuple)
 	if c.ReturnType != nil && c.ReturnType.SetOf {
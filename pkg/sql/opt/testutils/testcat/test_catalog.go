@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
@@ -1588,6 +1589,7 @@ type UniqueConstraint struct {
 	canUseTombstones      bool
 	tombstoneIndexOrdinal cat.IndexOrdinal
 	validated             bool
+	exclusionOperators    []treecmp.ComparisonOperator
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1650,6 +1652,11 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// ExclusionOperators is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperators() []treecmp.ComparisonOperator {
+	return u.exclusionOperators
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
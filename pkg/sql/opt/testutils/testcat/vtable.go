@@ -135,6 +135,7 @@ var systemTables = []string{
 	systemschema.StatementExecutionStatsTableSchema,
 	systemschema.TableMetadataTableSchema,
 	systemschema.PreparedTransactionsTableSchema,
+	systemschema.StatementHintsTableSchema,
 }
 
 func init() {
@@ -946,6 +946,16 @@ func newOptTable(
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
 		}
+		if u.IsExclusion() {
+			// The operators of an exclusion constraint are matched to the columns
+			// in the order in which they were declared.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			uc.exclusionOperators = make([]treecmp.ComparisonOperator, u.NumKeyColumns())
+			for j := range uc.exclusionOperators {
+				uc.exclusionOperators[j] = u.GetExclusionOperator(j)
+			}
+		}
 	}
 
 	// Build the indexes.
@@ -2035,6 +2045,10 @@ type optUniqueConstraint struct {
 	validity              descpb.ConstraintValidity
 
 	uniquenessGuaranteedByAnotherIndex bool
+
+	// exclusionOperators is non-nil for exclusion constraints, and contains
+	// the operator for each of the columns.
+	exclusionOperators []treecmp.ComparisonOperator
 }
 
 var _ cat.UniqueConstraint = &optUniqueConstraint{}
@@ -2100,6 +2114,11 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// ExclusionOperators is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperators() []treecmp.ComparisonOperator {
+	return u.exclusionOperators
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
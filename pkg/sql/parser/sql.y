@@ -312,6 +312,12 @@ func (u *sqlSymUnion) enumValueList() tree.EnumValueList {
 func (u *sqlSymUnion) compositeTypeList() []tree.CompositeTypeElem {
     return u.val.([]tree.CompositeTypeElem)
 }
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() []tree.DomainConstraint {
+    return u.val.([]tree.DomainConstraint)
+}
 func (u *sqlSymUnion) unresolvedName() *tree.UnresolvedName {
     return u.val.(*tree.UnresolvedName)
 }
@@ -645,6 +651,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -811,6 +823,12 @@ func (u *sqlSymUnion) typeReferences() []tree.ResolvableTypeReference {
 func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacement {
     return u.val.(*tree.AlterTypeAddValuePlacement)
 }
+func (u *sqlSymUnion) alterTypeCmd() tree.AlterTypeCmd {
+    return u.val.(tree.AlterTypeCmd)
+}
+func (u *sqlSymUnion) alterTypeCmds() []tree.AlterTypeCmd {
+    return u.val.([]tree.AlterTypeCmd)
+}
 func (u *sqlSymUnion) scheduleState() tree.ScheduleState {
   return u.val.(tree.ScheduleState)
 }
@@ -959,8 +977,8 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT AT_QUESTION
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY AVOID_FULL_SCAN
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
@@ -1120,6 +1138,7 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
 %type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
@@ -1284,6 +1303,7 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
@@ -1524,7 +1544,7 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_create_as_data
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 // Note that "no index" variants exist to disable custom ORDER BY <index> syntax
@@ -1532,6 +1552,8 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <tree.OrderBy> sort_clause sort_clause_no_index single_sort_clause opt_sort_clause opt_sort_clause_no_index
 %type <[]*tree.Order> sortby_list sortby_no_index_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExcludeElemList> exclude_elem_list
+%type <tree.ExcludeElem> exclude_elem
 %type <tree.IndexInvisibility> opt_index_visible alter_index_visible
 %type <tree.IndexType> opt_index_access_method
 %type <tree.NameList> name_list privilege_list
@@ -1604,7 +1626,7 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <tree.NameList> opt_storing
 %type <*tree.ColumnTableDef> column_table_def
 %type <tree.TableDef> table_elem
-%type <tree.Expr> where_clause opt_where_clause
+%type <tree.Expr> where_clause opt_where_clause opt_exclude_where
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
@@ -1639,15 +1661,20 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 %type <*tree.UpdateExpr> single_set_clause
 %type <tree.AsOfClause> as_of_clause opt_as_of_clause
 %type <tree.Expr> opt_changefeed_sink changefeed_sink
+%type <tree.Expr> opt_compact_from
 %type <str> opt_changefeed_family
 
 %type <str> explain_option_name
 %type <[]string> explain_option_list opt_enum_val_list enum_val_list
 %type <[]tree.CompositeTypeElem> composite_type_list opt_composite_type_list
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem domain_check_constraint
+%type <[]tree.DomainConstraint> domain_constraint_list opt_domain_constraint_list
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
 %type <*tree.AlterTypeAddValuePlacement> opt_add_val_placement
+%type <tree.AlterTypeCmd> alter_attribute_action
+%type <[]tree.AlterTypeCmd> alter_attribute_action_list
 %type <bool> opt_timezone
 %type <*types.T> numeric opt_numeric_modifiers
 %type <*types.T> opt_float
@@ -1833,11 +1860,11 @@ func (u *sqlSymUnion) indexType() tree.IndexType {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT DISTANCE COS_DISTANCE NEG_INNER_PRODUCT // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT AT_QUESTION DISTANCE COS_DISTANCE NEG_INNER_PRODUCT // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND ADJACENT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1932,6 +1959,7 @@ alter_ddl_stmt:
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 | alter_policy_stmt             // EXTEND WITH HELP: ALTER POLICY
+| alter_domain_stmt
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -3257,11 +3285,25 @@ alter_type_stmt:
   }
 | ALTER TYPE type_name RENAME ATTRIBUTE column_name TO column_name opt_drop_behavior
   {
-    return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRenameAttribute{
+        OldName: tree.Name($6),
+        NewName: tree.Name($8),
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
   }
 | ALTER TYPE type_name alter_attribute_action_list
   {
-    return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+    cmds := $4.alterTypeCmds()
+    if len(cmds) != 1 {
+      return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+    }
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: cmds[0],
+    }
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
@@ -3325,14 +3367,49 @@ role_spec_list:
 
 alter_attribute_action_list:
   alter_attribute_action
+  {
+    $$.val = []tree.AlterTypeCmd{$1.alterTypeCmd()}
+  }
 | alter_attribute_action_list ',' alter_attribute_action
+  {
+    $$.val = append($1.alterTypeCmds(), $3.alterTypeCmd())
+  }
 
 alter_attribute_action:
-  ADD ATTRIBUTE column_name type_name opt_collate opt_drop_behavior
+  ADD ATTRIBUTE column_name typename opt_collate opt_drop_behavior
+  {
+    if $5 != "" {
+      return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+    }
+    $$.val = &tree.AlterTypeAddAttribute{
+      Name: tree.Name($3),
+      Type: $4.typeReference(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
 | DROP ATTRIBUTE column_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterTypeDropAttribute{
+      Name: tree.Name($3),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
 | DROP ATTRIBUTE IF EXISTS column_name opt_drop_behavior
-| ALTER ATTRIBUTE column_name TYPE type_name opt_collate opt_drop_behavior
-| ALTER ATTRIBUTE column_name SET DATA TYPE type_name opt_collate opt_drop_behavior
+  {
+    $$.val = &tree.AlterTypeDropAttribute{
+      Name: tree.Name($5),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| ALTER ATTRIBUTE column_name TYPE typename opt_collate opt_drop_behavior
+  {
+    return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+  }
+| ALTER ATTRIBUTE column_name SET DATA TYPE typename opt_collate opt_drop_behavior
+  {
+    return unimplementedWithIssueDetail(sqllex, 48701, "ALTER TYPE ATTRIBUTE")
+  }
 
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
@@ -3384,6 +3461,13 @@ opt_clear_data:
 //        [ AS OF SYSTEM TIME <expr> ]
 //				[ WITH <option> [= <value>] [, ...] ]
 //
+// Compact the layers of a backup chain ending at or before <end> into one
+// layer. Without FROM, the full backup and its incrementals are compacted into
+// a new full backup.
+// BACKUP INTO {<subdir> | LATEST} IN <destination...>
+//        COMPACT [FROM <start>] TO <end>
+//				[ WITH <option> [= <value>] [, ...] ]
+//
 // Targets:
 //    Empty targets list: backup full cluster.
 //    TABLE <pattern> [, ...]
@@ -3432,6 +3516,26 @@ backup_stmt:
       Options: *$8.backupOptions(),
     }
   }
+| BACKUP opt_backup_targets INTO sconst_or_placeholder IN string_or_placeholder_opt_list COMPACT opt_compact_from TO sconst_or_placeholder opt_with_backup_options
+  {
+    $$.val = &tree.Backup{
+      Targets: $2.backupTargetListPtr(),
+      To: $6.stringOrPlaceholderOptList(),
+      Subdir: $4.expr(),
+      Compact: &tree.BackupCompactRange{From: $8.expr(), To: $10.expr()},
+      Options: *$11.backupOptions(),
+    }
+  }
+| BACKUP opt_backup_targets INTO LATEST IN string_or_placeholder_opt_list COMPACT opt_compact_from TO sconst_or_placeholder opt_with_backup_options
+  {
+    $$.val = &tree.Backup{
+      Targets: $2.backupTargetListPtr(),
+      To: $6.stringOrPlaceholderOptList(),
+      AppendToLatest: true,
+      Compact: &tree.BackupCompactRange{From: $8.expr(), To: $10.expr()},
+      Options: *$11.backupOptions(),
+    }
+  }
 | BACKUP opt_backup_targets TO error
   {
     setErr(sqllex, errors.New("The `BACKUP TO` syntax is no longer supported. Please use `BACKUP INTO` to create a backup collection."))
@@ -3450,6 +3554,16 @@ opt_backup_targets:
     $$.val = &t
   }
 
+opt_compact_from:
+  FROM sconst_or_placeholder
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 // Optional backup options.
 opt_with_backup_options:
   WITH backup_options_list
@@ -4041,6 +4155,50 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
+alter_domain_stmt:
+  ALTER DOMAIN type_name ADD domain_check_constraint
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{Constraint: $5.domainConstraint()},
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropNotNull{},
+    }
+  }
+
 alter_unsupported_stmt:
   ALTER DOMAIN error
   {
@@ -5272,7 +5430,7 @@ routine_param_class:
 | OUT { $$.val = tree.RoutineParamOut }
 | INOUT { $$.val = tree.RoutineParamInOut }
 | IN OUT { $$.val = tree.RoutineParamInOut }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
@@ -6303,6 +6461,7 @@ drop_ddl_stmt:
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 | drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
+| drop_domain_stmt
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -6437,6 +6596,26 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+      Domain: true,
+    }
+  }
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -8496,7 +8675,10 @@ show_histogram_stmt:
 
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text:
+// SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// SHOW BACKUP ROWS FOR TABLE <tablename> FROM <subdir> IN <collection...>
+//        [AS OF SYSTEM TIME <expr>] [WITH <option> [= <value>] [, ...]]
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
   SHOW BACKUPS IN string_or_placeholder_opt_list
@@ -8515,6 +8697,18 @@ show_backup_stmt:
 			Options: *$8.showBackupOptions(),
 		}
 	}
+| SHOW BACKUP ROWS FOR TABLE table_name FROM string_or_placeholder IN string_or_placeholder_opt_list opt_as_of_clause opt_with_show_backup_options
+	{
+		$$.val = &tree.ShowBackup{
+			From:         true,
+			Details:      tree.BackupRowDetails,
+			Table:        $6.unresolvedObjectName(),
+			Path:         $8.expr(),
+			InCollection: $10.stringOrPlaceholderOptList(),
+			AsOf:         $11.asOfClause(),
+			Options:      *$12.showBackupOptions(),
+		}
+	}
 | SHOW BACKUP string_or_placeholder IN string_or_placeholder_opt_list opt_with_show_backup_options
 	{
 		$$.val = &tree.ShowBackup{
@@ -10450,10 +10644,11 @@ alter_schema_stmt:
 // %Category: DDL
 // %Text:
 // CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<on_commit>]
-// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [<on commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [WITH [NO] DATA] [<on commit>]
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
+//    LIKE <tablename> [{INCLUDING | EXCLUDING} <like_option> ...]
 //    [UNIQUE | INVERTED | VECTOR] INDEX [<name>] ( <colname> [ASC | DESC] [, ...] )
 //                            [USING HASH] [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    FAMILY [<name>] ( <colnames...> )
@@ -10472,6 +10667,9 @@ alter_schema_stmt:
 //   COLLATE <collationname>
 //   AS ( <expr> ) { STORED | VIRTUAL }
 //
+// LIKE options:
+//    ALL | COMMENTS | CONSTRAINTS | DEFAULTS | GENERATED | IDENTITY | INDEXES | STATISTICS | STORAGE
+//
 // On commit clause:
 //    ON COMMIT {PRESERVE ROWS | DROP | DELETE ROWS}
 //
@@ -10609,6 +10807,7 @@ create_table_as_stmt:
       IfNotExists: false,
       Defs: $5.tblDefs(),
       AsSource: $8.slct(),
+      WithNoData: !$9.bool(),
       StorageParams: $6.storageParams(),
       OnCommit: $10.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -10622,6 +10821,7 @@ create_table_as_stmt:
       IfNotExists: true,
       Defs: $8.tblDefs(),
       AsSource: $11.slct(),
+      WithNoData: !$12.bool(),
       StorageParams: $9.storageParams(),
       OnCommit: $13.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -10629,9 +10829,9 @@ create_table_as_stmt:
   }
 
 opt_create_as_data:
-  /* EMPTY */  { /* no error */ }
-| WITH DATA    { /* SKIP DOC */ /* This is the default */ }
-| WITH NO DATA { return unimplemented(sqllex, "create table as with no data") }
+  /* EMPTY */  { $$.val = true }
+| WITH DATA    { /* SKIP DOC */ /* This is the default */ $$.val = true }
+| WITH NO DATA { $$.val = false }
 
 /*
  * Redundancy here is needed to avoid shift/reduce conflicts,
@@ -10723,14 +10923,14 @@ like_table_option_list:
   }
 
 like_table_option:
-  COMMENTS			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding comments") }
+  COMMENTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptComments} }
 | CONSTRAINTS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptConstraints} }
 | DEFAULTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptDefaults} }
-| IDENTITY	  	{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding identity") }
+| IDENTITY	  	{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIdentity} }
 | GENERATED			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptGenerated} }
 | INDEXES			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIndexes} }
-| STATISTICS		{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding statistics") }
-| STORAGE			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding storage") }
+| STATISTICS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStatistics} }
+| STORAGE			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStorage} }
 | ALL				{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptAll} }
 
 
@@ -11202,9 +11402,57 @@ constraint_elem:
       Actions: $10.referenceActions(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_exclude_access_method '(' exclude_elem_list ')' opt_deferrable opt_exclude_where
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Elems: $4.excludeElems(),
+      Predicate: $7.expr(),
+    }
+  }
+
+// Exclusion constraints are not backed by an index, so the access method is
+// only validated and otherwise ignored.
+opt_exclude_access_method:
+  USING name
+  {
+    switch $2 {
+    case "gist", "btree":
+    default:
+      sqllex.Error(fmt.Sprintf("access method %q is not supported for exclusion constraints", $2))
+      return 1
+    }
+  }
+| /* EMPTY */ {}
+
+exclude_elem_list:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elem_list ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  name WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{Column: tree.Name($1), Operator: op}
+  }
+
+opt_exclude_where:
+  WHERE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
   }
 
 
@@ -11987,8 +12235,70 @@ create_type_stmt:
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
   // Domain types.
+| CREATE DOMAIN type_name opt_as typename opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Domain,
+      DomainType: $5.typeReference(),
+      DomainConstraints: $6.domainConstraints(),
+    }
+  }
 | CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
 
+opt_domain_constraint_list:
+  domain_constraint_list
+  {
+    $$.val = $1.domainConstraints()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.DomainConstraint(nil)
+  }
+
+domain_constraint_list:
+  domain_constraint
+  {
+    $$.val = []tree.DomainConstraint{$1.domainConstraint()}
+  }
+| domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+
+domain_constraint_elem:
+  CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr(), Nullability: tree.SilentNull}
+  }
+| NOT NULL
+  {
+    $$.val = tree.DomainConstraint{Nullability: tree.NotNull}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{Nullability: tree.Null}
+  }
+
+domain_check_constraint:
+  CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Name: tree.Name($2), Check: $5.expr(), Nullability: tree.SilentNull}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr(), Nullability: tree.SilentNull}
+  }
+
 opt_enum_val_list:
   enum_val_list
   {
@@ -13415,7 +13725,10 @@ insert_column_list:
 // be needed together with support for composite types (#27792).
 insert_column_item:
   column_name
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+| column_name '.' name '=' a_expr
+  {
+    $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Field: tree.Name($3), Expr: $5.expr()}
+  }
 
 on_conflict:
   ON CONFLICT DO NOTHING
@@ -13531,7 +13844,10 @@ single_set_clause:
   {
     $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Expr: $3.expr()}
   }
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+| column_name '.' name '=' a_expr
+  {
+    $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Field: tree.Name($3), Expr: $5.expr()}
+  }
 
 multiple_set_clause:
   '(' insert_column_list ')' '=' in_expr
@@ -15715,6 +16031,14 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_QUESTION a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr DISTANCE a_expr
   {
     $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
@@ -16292,8 +16616,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
-| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
+| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_application_name '(' ALL expr_list opt_sort_clause_no_index ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -16939,6 +17269,8 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| AT_QUESTION { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
 | COS_DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.CosDistance) }
 | NEG_INNER_PRODUCT { $$.val = treebin.MakeBinaryOperator(treebin.NegInnerProduct) }
@@ -912,7 +912,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -1064,13 +1063,20 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
-			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
-			if err != nil {
-				return err
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				if err := showExclusionConstraint(&f.Buffer, table, uwoi); err != nil {
+					return err
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
+				if err != nil {
+					return err
+				}
+				f.WriteString(strings.Join(colNames, ", "))
+				f.WriteByte(')')
 			}
-			f.WriteString(strings.Join(colNames, ", "))
-			f.WriteByte(')')
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -2690,6 +2696,7 @@ func addPgProcUDFRow(
 	var foundAnyArgNames bool
 	var nArgs, nArgDefaults int
 	var argDefaultsBuilder strings.Builder
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
@@ -2728,6 +2735,8 @@ func addPgProcUDFRow(
 			argMode = proArgModeInOut
 		case tree.RoutineParamVariadic:
 			argMode = proArgModeVariadic
+			// provariadic stores the element type of the VARIADIC array.
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
 		default:
 			return errors.AssertionFailedf("unknown parameter class %d", class)
 		}
@@ -2763,7 +2772,7 @@ func addPgProcUDFRow(
 		lang,            // prolang
 		tree.DNull,      // procost
 		tree.DNull,      // prorows
-		oidZero,         // provariadic // TODO(88947): this might need an adjustment.
+		variadicType,    // provariadic
 		tree.DNull,      // prosupport
 		kind,            // prokind
 		tree.DBoolFalse, // prosecdef
@@ -3305,9 +3314,7 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3435,6 +3442,12 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
+	case types.RangeFamily:
+		typType = typTypeRange
+		// anyrange does not have an array type.
+		if typ.Oid() != oid.T_anyrange {
+			typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		}
 	case types.VoidFamily:
 		// void does not have an array type.
 	case types.TriggerFamily:
@@ -3445,6 +3458,15 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		typBaseType = tree.NewDOid(typ.DomainBaseType().Oid())
+		if d := typ.TypeMeta.DomainData; d != nil && d.NotNull {
+			typNotNull = tree.DBoolTrue
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3474,8 +3496,8 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
@@ -4815,6 +4837,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4826,9 +4849,12 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
 	types.PGVectorFamily:    typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
+	types.MacAddrFamily:     typCategoryUserDefined,
+	types.MoneyFamily:       typCategoryNumeric,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
 	types.TriggerFamily:     typCategoryPseudo,
@@ -4842,6 +4868,10 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.UserDefined() && typ.Family() == types.TupleFamily {
 		return typCategoryComposite
 	}
+	// Special case ANYRANGE, which is a pseudo-type like ANYELEMENT.
+	if typ.Oid() == oid.T_anyrange {
+		return typCategoryPseudo
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
@@ -205,6 +205,9 @@ func (c *conn) processCommands(
 		ctx, ac, authOpt, sqlServer.GetExecutorConfig(),
 	); retErr != nil {
 		// Auth failed or some other error.
+		sqlServer.GetExecutorConfig().SQLEventAggregator.RecordLogin(
+			ctx, c.sessionArgs.User.Normalized(), false, /* success */
+		)
 		return
 	}
 
@@ -230,6 +233,9 @@ func (c *conn) processCommands(
 	// Signal the connection was established to the authenticator.
 	ac.AuthOK(ctx)
 	ac.LogAuthOK(ctx)
+	sqlServer.GetExecutorConfig().SQLEventAggregator.RecordLogin(
+		ctx, c.sessionArgs.User.Normalized(), true, /* success */
+	)
 	ac.LogAuthInfof(ctx, redact.Sprintf(
 		"session created with SessionDefaults=%s and CustomOptions=%s",
 		c.sessionArgs.SessionDefaults,
@@ -75,55 +75,72 @@ var (
 	// Section: Class 21 - Cardinality Violation
 	CardinalityViolation = MakeCode("21000")
 	// Section: Class 22 - Data Exception
-	DataException                         = MakeCode("22000")
-	ArraySubscript                        = MakeCode("2202E")
-	CharacterNotInRepertoire              = MakeCode("22021")
-	DatetimeFieldOverflow                 = MakeCode("22008")
-	DivisionByZero                        = MakeCode("22012")
-	InvalidWindowFrameOffset              = MakeCode("22013")
-	ErrorInAssignment                     = MakeCode("22005")
-	EscapeCharacterConflict               = MakeCode("2200B")
-	IndicatorOverflow                     = MakeCode("22022")
-	IntervalFieldOverflow                 = MakeCode("22015")
-	InvalidArgumentForLogarithm           = MakeCode("2201E")
-	InvalidArgumentForNtileFunction       = MakeCode("22014")
-	InvalidArgumentForNthValueFunction    = MakeCode("22016")
-	InvalidArgumentForPowerFunction       = MakeCode("2201F")
-	InvalidArgumentForWidthBucketFunction = MakeCode("2201G")
-	InvalidCharacterValueForCast          = MakeCode("22018")
-	InvalidDatetimeFormat                 = MakeCode("22007")
-	InvalidEscapeCharacter                = MakeCode("22019")
-	InvalidEscapeOctet                    = MakeCode("2200D")
-	InvalidEscapeSequence                 = MakeCode("22025")
-	NonstandardUseOfEscapeCharacter       = MakeCode("22P06")
-	InvalidIndicatorParameterValue        = MakeCode("22010")
-	InvalidParameterValue                 = MakeCode("22023")
-	InvalidRegularExpression              = MakeCode("2201B")
-	InvalidRowCountInLimitClause          = MakeCode("2201W")
-	InvalidRowCountInResultOffsetClause   = MakeCode("2201X")
-	InvalidTimeZoneDisplacementValue      = MakeCode("22009")
-	InvalidUseOfEscapeCharacter           = MakeCode("2200C")
-	MostSpecificTypeMismatch              = MakeCode("2200G")
-	NullValueNotAllowed                   = MakeCode("22004")
-	NullValueNoIndicatorParameter         = MakeCode("22002")
-	NumericValueOutOfRange                = MakeCode("22003")
-	SequenceGeneratorLimitExceeded        = MakeCode("2200H")
-	StringDataLengthMismatch              = MakeCode("22026")
-	StringDataRightTruncation             = MakeCode("22001")
-	Substring                             = MakeCode("22011")
-	Trim                                  = MakeCode("22027")
-	UnterminatedCString                   = MakeCode("22024")
-	ZeroLengthCharacterString             = MakeCode("2200F")
-	FloatingPointException                = MakeCode("22P01")
-	InvalidTextRepresentation             = MakeCode("22P02")
-	InvalidBinaryRepresentation           = MakeCode("22P03")
-	BadCopyFileFormat                     = MakeCode("22P04")
-	UntranslatableCharacter               = MakeCode("22P05")
-	NotAnXMLDocument                      = MakeCode("2200L")
-	InvalidXMLDocument                    = MakeCode("2200M")
-	InvalidXMLContent                     = MakeCode("2200N")
-	InvalidXMLComment                     = MakeCode("2200S")
-	InvalidXMLProcessingInstruction       = MakeCode("2200T")
+	DataException                             = MakeCode("22000")
+	ArraySubscript                            = MakeCode("2202E")
+	CharacterNotInRepertoire                  = MakeCode("22021")
+	DatetimeFieldOverflow                     = MakeCode("22008")
+	DivisionByZero                            = MakeCode("22012")
+	InvalidWindowFrameOffset                  = MakeCode("22013")
+	ErrorInAssignment                         = MakeCode("22005")
+	EscapeCharacterConflict                   = MakeCode("2200B")
+	IndicatorOverflow                         = MakeCode("22022")
+	IntervalFieldOverflow                     = MakeCode("22015")
+	InvalidArgumentForLogarithm               = MakeCode("2201E")
+	InvalidArgumentForNtileFunction           = MakeCode("22014")
+	InvalidArgumentForNthValueFunction        = MakeCode("22016")
+	InvalidArgumentForPowerFunction           = MakeCode("2201F")
+	InvalidArgumentForWidthBucketFunction     = MakeCode("2201G")
+	InvalidCharacterValueForCast              = MakeCode("22018")
+	InvalidDatetimeFormat                     = MakeCode("22007")
+	InvalidEscapeCharacter                    = MakeCode("22019")
+	InvalidEscapeOctet                        = MakeCode("2200D")
+	InvalidEscapeSequence                     = MakeCode("22025")
+	NonstandardUseOfEscapeCharacter           = MakeCode("22P06")
+	InvalidIndicatorParameterValue            = MakeCode("22010")
+	InvalidParameterValue                     = MakeCode("22023")
+	InvalidRegularExpression                  = MakeCode("2201B")
+	InvalidRowCountInLimitClause              = MakeCode("2201W")
+	InvalidRowCountInResultOffsetClause       = MakeCode("2201X")
+	InvalidTimeZoneDisplacementValue          = MakeCode("22009")
+	InvalidUseOfEscapeCharacter               = MakeCode("2200C")
+	MostSpecificTypeMismatch                  = MakeCode("2200G")
+	NullValueNotAllowed                       = MakeCode("22004")
+	NullValueNoIndicatorParameter             = MakeCode("22002")
+	NumericValueOutOfRange                    = MakeCode("22003")
+	SequenceGeneratorLimitExceeded            = MakeCode("2200H")
+	StringDataLengthMismatch                  = MakeCode("22026")
+	StringDataRightTruncation                 = MakeCode("22001")
+	Substring                                 = MakeCode("22011")
+	Trim                                      = MakeCode("22027")
+	UnterminatedCString                       = MakeCode("22024")
+	ZeroLengthCharacterString                 = MakeCode("2200F")
+	FloatingPointException                    = MakeCode("22P01")
+	InvalidTextRepresentation                 = MakeCode("22P02")
+	InvalidBinaryRepresentation               = MakeCode("22P03")
+	BadCopyFileFormat                         = MakeCode("22P04")
+	UntranslatableCharacter                   = MakeCode("22P05")
+	NotAnXMLDocument                          = MakeCode("2200L")
+	InvalidXMLDocument                        = MakeCode("2200M")
+	InvalidXMLContent                         = MakeCode("2200N")
+	InvalidXMLComment                         = MakeCode("2200S")
+	InvalidXMLProcessingInstruction           = MakeCode("2200T")
+	DuplicateJSONObjectKeyValue               = MakeCode("22030")
+	InvalidArgumentForSQLJSONDatetimeFunction = MakeCode("22031")
+	InvalidJSONText                           = MakeCode("22032")
+	InvalidSQLJSONSubscript                   = MakeCode("22033")
+	MoreThanOneSQLJSONItem                    = MakeCode("22034")
+	NoSQLJSONItem                             = MakeCode("22035")
+	NonNumericSQLJSONItem                     = MakeCode("22036")
+	NonUniqueKeysInAJSONObject                = MakeCode("22037")
+	SingletonSQLJSONItemRequired              = MakeCode("22038")
+	SQLJSONArrayNotFound                      = MakeCode("22039")
+	SQLJSONMemberNotFound                     = MakeCode("2203A")
+	SQLJSONNumberNotFound                     = MakeCode("2203B")
+	SQLJSONObjectNotFound                     = MakeCode("2203C")
+	TooManyJSONArrayElements                  = MakeCode("2203D")
+	TooManyJSONObjectMembers                  = MakeCode("2203E")
+	SQLJSONScalarRequired                     = MakeCode("2203F")
+	SQLJSONItemCannotBeCastToTargetType       = MakeCode("2203G")
 	// Section: Class 23 - Integrity Constraint Violation
 	IntegrityConstraintViolation = MakeCode("23000")
 	RestrictViolation            = MakeCode("23001")
@@ -57,53 +57,70 @@ var PLpgSQLConditionNameToCode = map[string][]string{
 	// Section: Class 21 - Cardinality Violation
 	"cardinality_violation": {"21000"},
 	// Section: Class 22 - Data Exception
-	"data_exception":                             {"22000"},
-	"array_subscript_error":                      {"2202E"},
-	"character_not_in_repertoire":                {"22021"},
-	"datetime_field_overflow":                    {"22008"},
-	"division_by_zero":                           {"22012"},
-	"error_in_assignment":                        {"22005"},
-	"escape_character_conflict":                  {"2200B"},
-	"indicator_overflow":                         {"22022"},
-	"interval_field_overflow":                    {"22015"},
-	"invalid_argument_for_logarithm":             {"2201E"},
-	"invalid_argument_for_ntile_function":        {"22014"},
-	"invalid_argument_for_nth_value_function":    {"22016"},
-	"invalid_argument_for_power_function":        {"2201F"},
-	"invalid_argument_for_width_bucket_function": {"2201G"},
-	"invalid_character_value_for_cast":           {"22018"},
-	"invalid_datetime_format":                    {"22007"},
-	"invalid_escape_character":                   {"22019"},
-	"invalid_escape_octet":                       {"2200D"},
-	"invalid_escape_sequence":                    {"22025"},
-	"nonstandard_use_of_escape_character":        {"22P06"},
-	"invalid_indicator_parameter_value":          {"22010"},
-	"invalid_parameter_value":                    {"22023"},
-	"invalid_regular_expression":                 {"2201B"},
-	"invalid_row_count_in_limit_clause":          {"2201W"},
-	"invalid_row_count_in_result_offset_clause":  {"2201X"},
-	"invalid_tablesample_argument":               {"2202H"},
-	"invalid_tablesample_repeat":                 {"2202G"},
-	"invalid_time_zone_displacement_value":       {"22009"},
-	"invalid_use_of_escape_character":            {"2200C"},
-	"most_specific_type_mismatch":                {"2200G"},
-	"null_value_no_indicator_parameter":          {"22002"},
-	"numeric_value_out_of_range":                 {"22003"},
-	"string_data_length_mismatch":                {"22026"},
-	"substring_error":                            {"22011"},
-	"trim_error":                                 {"22027"},
-	"unterminated_c_string":                      {"22024"},
-	"zero_length_character_string":               {"2200F"},
-	"floating_point_exception":                   {"22P01"},
-	"invalid_text_representation":                {"22P02"},
-	"invalid_binary_representation":              {"22P03"},
-	"bad_copy_file_format":                       {"22P04"},
-	"untranslatable_character":                   {"22P05"},
-	"not_an_xml_document":                        {"2200L"},
-	"invalid_xml_document":                       {"2200M"},
-	"invalid_xml_content":                        {"2200N"},
-	"invalid_xml_comment":                        {"2200S"},
-	"invalid_xml_processing_instruction":         {"2200T"},
+	"data_exception":                                  {"22000"},
+	"array_subscript_error":                           {"2202E"},
+	"character_not_in_repertoire":                     {"22021"},
+	"datetime_field_overflow":                         {"22008"},
+	"division_by_zero":                                {"22012"},
+	"error_in_assignment":                             {"22005"},
+	"escape_character_conflict":                       {"2200B"},
+	"indicator_overflow":                              {"22022"},
+	"interval_field_overflow":                         {"22015"},
+	"invalid_argument_for_logarithm":                  {"2201E"},
+	"invalid_argument_for_ntile_function":             {"22014"},
+	"invalid_argument_for_nth_value_function":         {"22016"},
+	"invalid_argument_for_power_function":             {"2201F"},
+	"invalid_argument_for_width_bucket_function":      {"2201G"},
+	"invalid_character_value_for_cast":                {"22018"},
+	"invalid_datetime_format":                         {"22007"},
+	"invalid_escape_character":                        {"22019"},
+	"invalid_escape_octet":                            {"2200D"},
+	"invalid_escape_sequence":                         {"22025"},
+	"nonstandard_use_of_escape_character":             {"22P06"},
+	"invalid_indicator_parameter_value":               {"22010"},
+	"invalid_parameter_value":                         {"22023"},
+	"invalid_regular_expression":                      {"2201B"},
+	"invalid_row_count_in_limit_clause":               {"2201W"},
+	"invalid_row_count_in_result_offset_clause":       {"2201X"},
+	"invalid_tablesample_argument":                    {"2202H"},
+	"invalid_tablesample_repeat":                      {"2202G"},
+	"invalid_time_zone_displacement_value":            {"22009"},
+	"invalid_use_of_escape_character":                 {"2200C"},
+	"most_specific_type_mismatch":                     {"2200G"},
+	"null_value_no_indicator_parameter":               {"22002"},
+	"numeric_value_out_of_range":                      {"22003"},
+	"string_data_length_mismatch":                     {"22026"},
+	"substring_error":                                 {"22011"},
+	"trim_error":                                      {"22027"},
+	"unterminated_c_string":                           {"22024"},
+	"zero_length_character_string":                    {"2200F"},
+	"floating_point_exception":                        {"22P01"},
+	"invalid_text_representation":                     {"22P02"},
+	"invalid_binary_representation":                   {"22P03"},
+	"bad_copy_file_format":                            {"22P04"},
+	"untranslatable_character":                        {"22P05"},
+	"not_an_xml_document":                             {"2200L"},
+	"invalid_xml_document":                            {"2200M"},
+	"invalid_xml_content":                             {"2200N"},
+	"invalid_xml_comment":                             {"2200S"},
+	"invalid_xml_processing_instruction":              {"2200T"},
+	"duplicate_json_object_key_value":                 {"22030"},
+	"invalid_argument_for_sql_json_datetime_function": {"22031"},
+	"invalid_json_text":                               {"22032"},
+	"invalid_sql_json_subscript":                      {"22033"},
+	"more_than_one_sql_json_item":                     {"22034"},
+	"no_sql_json_item":                                {"22035"},
+	"non_numeric_sql_json_item":                       {"22036"},
+	"non_unique_keys_in_a_json_object":                {"22037"},
+	"singleton_sql_json_item_required":                {"22038"},
+	"sql_json_array_not_found":                        {"22039"},
+	"sql_json_member_not_found":                       {"2203A"},
+	"sql_json_number_not_found":                       {"2203B"},
+	"sql_json_object_not_found":                       {"2203C"},
+	"too_many_json_array_elements":                    {"2203D"},
+	"too_many_json_object_members":                    {"2203E"},
+	"sql_json_scalar_required":                        {"2203F"},
+	"sql_json_item_cannot_be_cast_to_target_type":     {"2203G"},
 	// Section: Class 23 - Integrity Constraint Violation
 	"integrity_constraint_violation": {"23000"},
 	"restrict_violation":             {"23001"},
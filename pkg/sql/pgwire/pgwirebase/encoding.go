@@ -332,6 +332,9 @@ func DecodeDatum(
 	b []byte,
 	da *tree.DatumAlloc,
 ) (tree.Datum, error) {
+	// Values of a domain are decoded as values of its base type. The domain's
+	// constraints are checked when the value is used.
+	typ = typ.DomainBaseType()
 	id := typ.Oid()
 	// Use a direct string pointing to b where we are sure we aren't retaining
 	// this string.
@@ -464,6 +467,28 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+		case oid.T_cidr:
+			d, err := tree.ParseDCIDR(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oid.T_macaddr, oidext.T_macaddr8:
+			parse := tree.ParseDMacAddr
+			if id == oidext.T_macaddr8 {
+				parse = tree.ParseDMacAddr8
+			}
+			d, err := parse(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oid.T_money:
+			d, err := tree.ParseDMoney(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
 		case oid.T_jsonb, oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -473,6 +498,12 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDJSON(tree.DJSON{JSON: v}), nil
+		case oidext.T_jsonpath:
+			d, err := tree.ParseDJsonpath(bs)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(bs)
 			if err != nil {
@@ -500,6 +531,12 @@ func DecodeDatum(
 				return nil, err
 			}
 			return da.NewDString(tree.DString(bs)), nil
+		case types.RangeFamily:
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		}
 	case FormatBinary:
 		switch id {
@@ -539,6 +576,29 @@ func DecodeDatum(
 			}
 			i := int64(binary.BigEndian.Uint64(b))
 			return da.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(i)}), nil
+		case oid.T_money:
+			if len(b) < 8 {
+				return nil, pgerror.Newf(pgcode.Syntax, "money requires 8 bytes for binary format")
+			}
+			i := int64(binary.BigEndian.Uint64(b))
+			return da.NewDMoney(tree.DMoney{Cents: i}), nil
+		case oid.T_macaddr, oidext.T_macaddr8:
+			// MACADDR values are 6 bytes long. MACADDR8 values are 8 bytes long,
+			// but 6 bytes are accepted as well and converted like in text format.
+			if len(b) != 6 && (id == oid.T_macaddr || len(b) != 8) {
+				return nil, NewInvalidBinaryRepresentationErrorf(
+					"invalid length %d for type %s in binary format", len(b), typ.Name(),
+				)
+			}
+			var addr uint64
+			for _, c := range b {
+				addr = addr<<8 | uint64(c)
+			}
+			d := da.NewDMacAddr(tree.DMacAddr{Addr: addr, Is8: len(b) == 8})
+			if id == oidext.T_macaddr8 {
+				return d.ToMacAddr8(), nil
+			}
+			return d, nil
 		case oid.T_float4:
 			if len(b) < 4 {
 				return nil, pgerror.Newf(pgcode.Syntax, "float4 requires 4 bytes for binary format")
@@ -731,6 +791,17 @@ func DecodeDatum(
 				return nil, err
 			}
 			return da.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+		case oid.T_cidr:
+			ipAddr, err := pgBinaryToIPAddr(b)
+			if err != nil {
+				return nil, err
+			}
+			if !ipAddr.IsNetwork() {
+				return nil, NewInvalidBinaryRepresentationErrorf(
+					"invalid external \"cidr\" value: bits set to right of mask",
+				)
+			}
+			return da.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), nil
 		case oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -757,6 +828,19 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return da.NewDJSON(tree.DJSON{JSON: v}), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(encoding.UnsafeConvertBytesToString(b))
 		case oid.T_varbit, oid.T_bit:
 			if len(b) < 4 {
 				return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
@@ -823,6 +907,9 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b, da)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b, da)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1156,3 +1243,66 @@ const (
 	// AF_NET + 1.
 	PGBinaryIPv6family byte = 3
 )
+
+const (
+	// PGRangeEmpty is set in the flags of the binary format of an empty
+	// range. The flags are defined by Postgres in
+	// src/include/utils/rangetypes.h.
+	PGRangeEmpty byte = 0x01
+	// PGRangeLowerInc is set if the lower bound of the range is inclusive.
+	PGRangeLowerInc byte = 0x02
+	// PGRangeUpperInc is set if the upper bound of the range is inclusive.
+	PGRangeUpperInc byte = 0x04
+	// PGRangeLowerInf is set if the range has no lower bound.
+	PGRangeLowerInf byte = 0x08
+	// PGRangeUpperInf is set if the range has no upper bound.
+	PGRangeUpperInf byte = 0x10
+)
+
+// pgRangeFlagLen is the size of the flags of the binary format of a range.
+const pgRangeFlagLen = 1
+
+// decodeBinaryRange decodes a range in the Postgres binary format: a flags
+// byte followed by each bound that is not unbounded, as a length-prefixed
+// value of the binary format of the range's subtype.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte, da *tree.DatumAlloc,
+) (tree.Datum, error) {
+	if len(b) < pgRangeFlagLen {
+		return nil, pgerror.New(pgcode.Syntax, "range requires a 1 byte header for binary format")
+	}
+	flags := b[0]
+	b = b[pgRangeFlagLen:]
+	if flags&PGRangeEmpty != 0 {
+		return tree.NewDEmptyRange(typ), nil
+	}
+	readBound := func() (tree.Datum, error) {
+		if len(b) < elementSize {
+			return nil, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound size for binary format")
+		}
+		n := int32(binary.BigEndian.Uint32(b))
+		b = b[elementSize:]
+		if n < 0 || int(n) > len(b) {
+			return nil, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound for binary format")
+		}
+		d, err := DecodeDatum(ctx, evalCtx, typ.RangeContents(), FormatBinary, b[:n], da)
+		b = b[n:]
+		return d, err
+	}
+	lower, upper := tree.Datum(tree.DNull), tree.Datum(tree.DNull)
+	var err error
+	if flags&PGRangeLowerInf == 0 {
+		if lower, err = readBound(); err != nil {
+			return nil, err
+		}
+	}
+	if flags&PGRangeUpperInf == 0 {
+		if upper, err = readBound(); err != nil {
+			return nil, err
+		}
+	}
+	if len(b) != 0 {
+		return nil, pgerror.New(pgcode.Syntax, "extra bytes after range for binary format")
+	}
+	return tree.NewDRange(typ, lower, upper, flags&PGRangeLowerInc != 0, flags&PGRangeUpperInc != 0)
+}
@@ -55,6 +55,8 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, report the base type of a domain to the client.
+	t = t.DomainBaseType()
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -160,7 +162,10 @@ func writeTextDatumNotNull(
 	sessionLoc *time.Location,
 	t *types.T,
 ) {
-
+	if t != nil {
+		// Values of a domain are written as values of its base type.
+		t = t.DomainBaseType()
+	}
 	oldDCC := b.textFormatter.SetDataConversionConfig(conv)
 	oldLoc := b.textFormatter.SetLocation(sessionLoc)
 	defer func() {
@@ -192,7 +197,18 @@ func writeTextDatumNotNull(
 		writeTextUUID(b, v.UUID)
 
 	case *tree.DIPAddr:
-		b.writeLengthPrefixedString(v.IPAddr.String())
+		if d.ResolvedType().Oid() == oid.T_cidr {
+			b.writeLengthPrefixedString(v.IPAddr.CIDRString())
+		} else {
+			b.writeLengthPrefixedString(v.IPAddr.String())
+		}
+
+	case *tree.DMacAddr:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DMoney:
+		b.writeLengthPrefixedString(tree.FormatMoney(v.Cents))
 
 	case *tree.DString:
 		writeTextString(b, string(*v), t)
@@ -252,6 +268,9 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Jsonpath.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -268,6 +287,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -624,6 +647,10 @@ func (b *writeBuffer) writeBinaryDatum(
 func writeBinaryDatumNotNull(
 	ctx context.Context, b *writeBuffer, d tree.Datum, sessionLoc *time.Location, t *types.T,
 ) {
+	if t != nil {
+		// Values of a domain are written as values of its base type.
+		t = t.DomainBaseType()
+	}
 	switch v := tree.UnwrapDOidWrapper(d).(type) {
 	case *tree.DBitArray:
 		words, lastBitsUsed := v.EncodingParts()
@@ -681,15 +708,20 @@ func writeBinaryDatumNotNull(
 		//  The int32 length of the following bytes.
 		//  The family byte.
 		//  The mask size byte.
-		//  A 0 byte for is_cidr. It's ignored on the postgres frontend.
+		//  The is_cidr byte, which is 1 for CIDR values and 0 otherwise. It's
+		//  ignored on the postgres frontend.
 		//  The length of our IP bytes.
 		//  The IP bytes.
 		const pgIPAddrBinaryHeaderSize = 4
+		var isCIDR byte
+		if d.ResolvedType().Oid() == oid.T_cidr {
+			isCIDR = 1
+		}
 		if v.Family == ipaddr.IPv4family {
 			b.putInt32(net.IPv4len + pgIPAddrBinaryHeaderSize)
 			b.writeByte(pgwirebase.PGBinaryIPv4family)
 			b.writeByte(v.Mask)
-			b.writeByte(0)
+			b.writeByte(isCIDR)
 			b.writeByte(byte(net.IPv4len))
 			err := v.Addr.WriteIPv4Bytes(b)
 			if err != nil {
@@ -699,7 +731,7 @@ func writeBinaryDatumNotNull(
 			b.putInt32(net.IPv6len + pgIPAddrBinaryHeaderSize)
 			b.writeByte(pgwirebase.PGBinaryIPv6family)
 			b.writeByte(v.Mask)
-			b.writeByte(0)
+			b.writeByte(isCIDR)
 			b.writeByte(byte(net.IPv6len))
 			err := v.Addr.WriteIPv6Bytes(b)
 			if err != nil {
@@ -762,6 +794,38 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+
+		var flags byte
+		if v.Empty {
+			flags |= pgwirebase.PGRangeEmpty
+		}
+		if v.LowerInc {
+			flags |= pgwirebase.PGRangeLowerInc
+		}
+		if v.UpperInc {
+			flags |= pgwirebase.PGRangeUpperInc
+		}
+		if !v.Empty && v.Lower == tree.DNull {
+			flags |= pgwirebase.PGRangeLowerInf
+		}
+		if !v.Empty && v.Upper == tree.DNull {
+			flags |= pgwirebase.PGRangeUpperInf
+		}
+		b.writeByte(flags)
+		for _, bound := range []tree.Datum{v.Lower, v.Upper} {
+			if bound != tree.DNull {
+				b.writeBinaryDatum(ctx, bound, sessionLoc, t.RangeContents())
+			}
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DVoid:
 		b.putInt32(0)
 
@@ -769,6 +833,21 @@ func writeBinaryDatumNotNull(
 		b.putInt32(8)
 		b.putInt64(int64(v.LSN))
 
+	case *tree.DMacAddr:
+		// MACADDR values are sent as 6 bytes and MACADDR8 values as 8 bytes.
+		n := 6
+		if v.Is8 {
+			n = 8
+		}
+		b.putInt32(int32(n))
+		for i := n - 1; i >= 0; i-- {
+			b.writeByte(byte(v.Addr >> (8 * i)))
+		}
+
+	case *tree.DMoney:
+		b.putInt32(8)
+		b.putInt64(v.Cents)
+
 	case *tree.DBox2D:
 		b.putInt32(32)
 		b.putInt64(int64(math.Float64bits(v.LoX)))
@@ -856,6 +935,13 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON, t)
 
+	case *tree.DJsonpath:
+		s := v.Jsonpath.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.Oid))
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -359,6 +360,10 @@ type optPlanningCtx struct {
 	// allowMemoReuse is false.
 	useCache bool
 
+	// hints are the statement hints stored for the fingerprint of the
+	// statement, if any.
+	hints *stmthints.Hints
+
 	flags planFlags
 }
 
@@ -407,6 +412,47 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 		opc.allowMemoReuse = false
 		opc.useCache = false
 	}
+	opc.loadStatementHints(ctx)
+}
+
+// loadStatementHints looks up the statement hints stored for the fingerprint
+// of the statement, resolving the indexes pinned by a plan_gist_indexes hint.
+// Memos built with statement hints are neither reused nor cached, since the
+// hints can change independently of the objects the statement depends on.
+func (opc *optPlanningCtx) loadStatementHints(ctx context.Context) {
+	p := opc.p
+	opc.hints = nil
+	if p.execCfg.StatementHints == nil || p.stmt.AST == nil {
+		return
+	}
+	fingerprint := p.stmt.StmtNoConstants
+	ast := p.stmt.AST
+	if e, ok := ast.(*tree.Explain); ok {
+		ast = e.Statement
+	}
+	if ast != p.stmt.AST || p.instrumentation.outputMode != unmodifiedOutput {
+		// For EXPLAIN and EXPLAIN ANALYZE, the hints of the explained statement
+		// apply.
+		fingerprint = formatStatementHideConstants(
+			ast, tree.FmtFlags(queryFormattingForFingerprintsMask.Get(&p.execCfg.Settings.SV)),
+		)
+	}
+	stored := p.execCfg.StatementHints.Lookup(fingerprint)
+	if len(stored) == 0 {
+		return
+	}
+	opc.hints = stmthints.New(stored)
+	if gist := opc.hints.PlanGist(); gist != "" {
+		indexes, err := explain.PlanGistIndexes(gist, opc.catalog)
+		if err != nil {
+			log.VEventf(ctx, 1, "ignoring plan gist hint %s: %v", gist, err)
+		}
+		for table, index := range indexes {
+			opc.hints.AddPlanGistIndex(string(table), string(index))
+		}
+	}
+	opc.allowMemoReuse = false
+	opc.useCache = false
 }
 
 func (opc *optPlanningCtx) log(ctx context.Context, msg redact.SafeString) {
@@ -473,6 +519,7 @@ func (opc *optPlanningCtx) buildReusableMemo(
 	f := opc.optimizer.Factory()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
 	bld.KeepPlaceholders = true
+	bld.StatementHints = opc.hints
 	if opc.flags.IsSet(planFlagSessionMigration) {
 		bld.SkipAOST = true
 	}
@@ -823,6 +870,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
+	bld.StatementHints = opc.hints
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
@@ -849,6 +897,21 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 		}
 	}
 
+	if opc.hints.JoinHintApplied() &&
+		opc.optimizer.Memo().RootExpr().(memo.RelExpr).Cost().Flags.HugeCostPenalty {
+		// No plan satisfies the stored join hint. Unlike the join hints of the
+		// statement itself, stored hints are only preferences, so plan the
+		// statement again without it.
+		opc.log(ctx, "planning without the unsatisfiable stored join hint")
+		opc.hints.DropJoinHint()
+		opc.optimizer.Init(ctx, p.EvalContext(), opc.catalog)
+		return opc.buildExecMemo(ctx)
+	}
+	if applied := opc.hints.Applied(); len(applied) > 0 {
+		p.instrumentation.appliedStatementHints = applied
+		p.execCfg.StatementHints.RecordApplied(applied)
+	}
+
 	// If this statement doesn't have placeholders and we have not constant-folded
 	// any VolatilityStable operators, add it to the cache.
 	// Note that non-prepared statements from pgwire clients cannot have
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -221,7 +223,17 @@ func RandDatumWithNullChance(
 		return tree.NewDUuid(tree.DUuid{UUID: gen.NewV4()})
 	case types.INetFamily:
 		ipAddr := ipaddr.RandIPAddr(rng)
+		if typ.Oid() == oid.T_cidr {
+			return tree.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr.Network()})
+		}
 		return tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr})
+	case types.MacAddrFamily:
+		if typ.Oid() == oidext.T_macaddr8 {
+			return tree.NewDMacAddr8(rng.Uint64())
+		}
+		return tree.NewDMacAddr(rng.Uint64() & (1<<48 - 1))
+	case types.MoneyFamily:
+		return tree.NewDMoney(int64(rng.Uint64()))
 	case types.JsonFamily:
 		j, err := json.Random(20, rng)
 		if err != nil {
@@ -329,6 +341,38 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(randJsonpath(rng))
+	case types.RangeFamily:
+		if typ.Oid() == oid.T_anyrange {
+			return RandDatumWithNullChance(rng, RandTypeFromSlice(rng, types.RangeTypes), nullChance,
+				favorCommonData, targetColumnIsUnique,
+			)
+		}
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(typ)
+		}
+		// Each bound is unbounded with a 10% chance.
+		bounds := [2]tree.Datum{tree.DNull, tree.DNull}
+		for i := range bounds {
+			if rng.Intn(10) != 0 {
+				bounds[i] = RandDatumWithNullChance(rng, typ.RangeContents(), 0, /* nullChance */
+					favorCommonData, false /* targetColumnIsUnique */)
+			}
+		}
+		if bounds[0] != tree.DNull && bounds[1] != tree.DNull {
+			lower := tree.RangeBound{Val: bounds[0], Inc: true, Lower: true}
+			upper := tree.RangeBound{Val: bounds[1], Inc: true, Lower: true}
+			if tree.CompareRangeBounds(lower, upper) > 0 {
+				bounds[0], bounds[1] = bounds[1], bounds[0]
+			}
+		}
+		d, err := tree.NewDRange(typ, bounds[0], bounds[1], rng.Intn(2) == 0, rng.Intn(2) == 0)
+		if err != nil {
+			// The bounds may be invalid, for example when they are NaN.
+			return tree.NewDEmptyRange(typ)
+		}
+		return d
 	case types.PGVectorFamily:
 		var maxDim = 1000
 		if util.RaceEnabled {
@@ -432,6 +476,21 @@ func adjustDatum(datum tree.Datum, typ *types.T) tree.Datum {
 		}
 		return datum
 
+	case types.INetFamily:
+		// CIDR values must not have any bits set to the right of the netmask.
+		if typ.Oid() == oid.T_cidr {
+			ipAddr := tree.MustBeDIPAddr(datum).IPAddr
+			return tree.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr.Network()})
+		}
+		return datum
+
+	case types.MacAddrFamily:
+		// All special MacAddrFamily datums are 6-byte MACADDR values.
+		if typ.Oid() == oidext.T_macaddr8 {
+			return tree.MustBeDMacAddr(datum).ToMacAddr8()
+		}
+		return datum
+
 	default:
 		return datum
 	}
@@ -509,6 +568,7 @@ func RandDatumSimple(rng *rand.Rand, typ *types.T) tree.Datum {
 				Addr: ipaddr.Addr(uint128.FromInts(0, uint64(rng.Intn(simpleRange)))),
 			},
 		})
+		datum = adjustDatum(datum, typ)
 	case types.JsonFamily:
 		datum = tree.NewDJSON(randJSONSimple(rng))
 	case types.OidFamily:
@@ -573,6 +633,32 @@ func randJSONSimpleDepth(rng *rand.Rand, depth int) json.JSON {
 	}
 }
 
+// randJsonpath returns a random jsonpath built from accessors, filters and
+// arithmetic on the keys produced by randJSONSimple.
+func randJsonpath(rng *rand.Rand) jsonpath.Jsonpath {
+	var sb strings.Builder
+	if rng.Intn(2) == 0 {
+		sb.WriteString("strict ")
+	}
+	sb.WriteString("$")
+	for i := rng.Intn(3); i > 0; i-- {
+		switch rng.Intn(4) {
+		case 0:
+			fmt.Fprintf(&sb, ".%s", randStringSimple(rng))
+		case 1:
+			sb.WriteString("[*]")
+		case 2:
+			fmt.Fprintf(&sb, "[%d]", rng.Intn(simpleRange))
+		default:
+			fmt.Fprintf(&sb, " ? (@.%s > %d)", randStringSimple(rng), rng.Intn(simpleRange))
+		}
+	}
+	if rng.Intn(4) == 0 {
+		fmt.Fprintf(&sb, " == %d", rng.Intn(simpleRange))
+	}
+	return jsonpath.MustParse(sb.String())
+}
+
 var (
 	// randInterestingDatums is a collection of interesting datums that can be
 	// used for random testing.
@@ -773,6 +859,18 @@ var (
 			tree.NewDPGLSN(math.MaxInt64 + 1),
 			tree.NewDPGLSN(math.MaxUint64),
 		},
+		types.MacAddrFamily: {
+			tree.NewDMacAddr(0),
+			tree.NewDMacAddr(1),
+			tree.NewDMacAddr(1<<48 - 1),
+		},
+		types.MoneyFamily: {
+			tree.NewDMoney(0),
+			tree.NewDMoney(-1),
+			tree.NewDMoney(1),
+			tree.NewDMoney(math.MinInt64),
+			tree.NewDMoney(math.MaxInt64),
+		},
 		types.RefCursorFamily: {
 			tree.NewDRefCursor(""),
 			tree.NewDRefCursor("X"),
@@ -950,6 +1048,12 @@ var (
 		types.PGLSNFamily: {
 			tree.NewDPGLSN(0x1000),
 		},
+		types.MacAddrFamily: {
+			tree.NewDMacAddr(0x08002b010203),
+		},
+		types.MoneyFamily: {
+			tree.NewDMoney(100),
+		},
 		types.RefCursorFamily: {
 			tree.NewDRefCursor("a"),
 			tree.NewDRefCursor("a\n"),
@@ -40,7 +40,7 @@ func init() {
 			// Temporarily don't include this.
 			// TODO(msirek): Remove this exclusion once
 			// https://github.com/cockroachdb/cockroach/issues/55791 is fixed.
-		case oid.T_unknown, oid.T_anyelement, oid.T_trigger:
+		case oid.T_unknown, oid.T_anyelement, oid.T_anyrange, oid.T_trigger:
 			// Don't include these.
 		case oid.T_float4:
 			// Don't include FLOAT4 due to known bugs that cause test failures.
@@ -93,6 +93,11 @@ func IsAllowedForArray(typ *types.T) bool {
 		return false
 	}
 
+	// Don't include the ANYRANGE pseudo-type.
+	if typ.Oid() == oid.T_anyrange {
+		return false
+	}
+
 	return true
 }
 
@@ -693,15 +693,17 @@ func EncodeInvertedIndexTableKeys(
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
 	case types.TSVectorFamily:
 		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily:
+		return encodeRangeInvertedIndexTableKeys(datum.(*tree.DRange), inKey)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError())
 }
 
 // EncodeContainingInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate a contains (@>) predicate with the given
-// datum, which should be a container (either JSON or Array). These spans
-// should be used to find the objects in the index that contain the given json
-// or array. In other words, if we have a predicate x @> y, this function
+// datum, which should be a container (either JSON, Array or a range). These
+// spans should be used to find the objects in the index that contain the given
+// json, array or range. In other words, if we have a predicate x @> y, this function
 // should use the value of y to find the spans to scan in an inverted index on
 // x.
 //
@@ -720,6 +722,8 @@ func EncodeContainingInvertedIndexSpans(
 		return json.EncodeContainingInvertedIndexSpans(nil /* inKey */, val.(*tree.DJSON).JSON)
 	case types.ArrayFamily:
 		return encodeContainingArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily:
+		return encodeContainingRangeInvertedIndexSpans(datum.(*tree.DRange), nil /* inKey */)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -729,9 +733,9 @@ func EncodeContainingInvertedIndexSpans(
 
 // EncodeContainedInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate a contained by (<@) predicate with the given
-// datum, which should be a container (either an Array or JSON). These spans
-// should be used to find the objects in the index that could be contained by
-// the given json or array. In other words, if we have a predicate x <@ y, this
+// datum, which should be a container (either an Array, JSON or a range). These
+// spans should be used to find the objects in the index that could be
+// contained by the given json, array or range. In other words, if we have a predicate x <@ y, this
 // function should use the value of y to find the spans to scan in an inverted
 // index on x.
 //
@@ -751,6 +755,8 @@ func EncodeContainedInvertedIndexSpans(
 		return encodeContainedArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
 	case types.JsonFamily:
 		return json.EncodeContainedInvertedIndexSpans(nil /* inKey */, val.(*tree.DJSON).JSON)
+	case types.RangeFamily:
+		return encodeContainedRangeInvertedIndexSpans(datum.(*tree.DRange), nil /* inKey */)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -815,15 +821,15 @@ func EncodeExistsInvertedIndexSpans(
 
 // EncodeOverlapsInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate an overlaps (&&) predicate with the given
-// datum, which should be an Array. These spans should be used to find the
-// objects in the index that could overlap with the given array. In other
-// words, if we have a predicate x && y, this function should use the value of
-// y to find the spans to scan in an inverted index on x.
+// datum, which should be an Array or a range. These spans should be used to
+// find the objects in the index that could overlap with the given array or
+// range. In other words, if we have a predicate x && y, this function should
+// use the value of y to find the spans to scan in an inverted index on x.
 //
 // The spans are returned in an inverted.SpanExpression, which represents the
 // set operations that must be applied on the spans read during execution. The
-// span expression returned will be tight. See comments in the
-// SpanExpression definition for details.
+// span expression returned will be tight for arrays, but not for ranges. See
+// comments in the SpanExpression definition for details.
 func EncodeOverlapsInvertedIndexSpans(
 	ctx context.Context, evalCtx *eval.Context, val tree.Datum,
 ) (invertedExpr inverted.Expression, err error) {
@@ -834,6 +840,8 @@ func EncodeOverlapsInvertedIndexSpans(
 	switch val.ResolvedType().Family() {
 	case types.ArrayFamily:
 		return encodeOverlapsArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily:
+		return encodeOverlapsRangeInvertedIndexSpans(datum.(*tree.DRange), nil /* inKey */)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
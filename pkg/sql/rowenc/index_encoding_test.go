@@ -1228,6 +1228,75 @@ func TestEncodeTrigramInvertedIndexSpansError(t *testing.T) {
 	}
 }
 
+func TestEncodeRangeInvertedIndexSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	rng, _ := randutil.NewTestRand()
+
+	// randRange generates a random int8range, with bounds clustered around
+	// zero so that the ranges frequently overlap.
+	randRange := func() *tree.DRange {
+		randBound := func() tree.Datum {
+			switch rng.Intn(10) {
+			case 0:
+				return tree.DNull
+			case 1:
+				return tree.NewDInt(tree.DInt(rng.Int63() - rng.Int63()))
+			default:
+				return tree.NewDInt(tree.DInt(rng.Intn(2000) - 1000))
+			}
+		}
+		if rng.Intn(20) == 0 {
+			return tree.NewDEmptyRange(types.Int8Range)
+		}
+		lower, upper := randBound(), randBound()
+		if lower != tree.DNull && upper != tree.DNull && *lower.(*tree.DInt) > *upper.(*tree.DInt) {
+			lower, upper = upper, lower
+		}
+		r, err := tree.NewDRange(types.Int8Range, lower, upper, rng.Intn(2) == 0, rng.Intn(2) == 0)
+		require.NoError(t, err)
+		return r
+	}
+
+	for i := 0; i < 1000; i++ {
+		indexed, value := randRange(), randRange()
+		keys, err := EncodeInvertedIndexTableKeys(indexed, nil, descpb.LatestIndexDescriptorVersion)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(keys), 4)
+
+		for _, tc := range []struct {
+			op       string
+			encode   func(context.Context, *eval.Context, tree.Datum) (inverted.Expression, error)
+			expected bool
+		}{
+			{"&&", EncodeOverlapsInvertedIndexSpans, indexed.Overlaps(value)},
+			{"@>", EncodeContainingInvertedIndexSpans, indexed.Contains(value)},
+			{"<@", EncodeContainedInvertedIndexSpans, value.Contains(indexed)},
+		} {
+			invertedExpr, err := tc.encode(ctx, &evalCtx, value)
+			require.NoError(t, err)
+			spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+			if !ok {
+				// Only containment of the empty range cannot use the index.
+				require.True(t, tc.op == "@>" && value.Empty)
+				continue
+			}
+			found, err := spanExpr.ContainsKeys(keys)
+			require.NoError(t, err)
+			// The spans may have false positives, unless they are tight, but never
+			// false negatives.
+			if tc.expected && !found {
+				t.Errorf("expected spans of %s %s %s to include %s", indexed, tc.op, value, indexed)
+			}
+			if spanExpr.Tight && found != tc.expected {
+				t.Errorf("expected tight spans of %s %s %s to exclude %s", indexed, tc.op, value, indexed)
+			}
+		}
+	}
+}
+
 func TestDecodeKeyVals(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -44,6 +45,10 @@ func Decode(
 	switch valType.Family() {
 	case types.ArrayFamily:
 		return decodeArrayKey(a, valType, key, dir)
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
+	case types.TupleFamily:
+		return decodeTupleKey(a, valType, key, dir)
 	case types.BitFamily:
 		var r bitarray.BitArray
 		if dir == encoding.Ascending {
@@ -78,6 +83,22 @@ func Decode(
 			rkey, i, err = encoding.DecodeUvarintDescending(key)
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(i)}), rkey, err
+	case types.MacAddrFamily:
+		var i uint64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeUvarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeUvarintDescending(key)
+		}
+		return a.NewDMacAddr(tree.DMacAddr{Addr: i, Is8: valType.Oid() == oidext.T_macaddr8}), rkey, err
+	case types.MoneyFamily:
+		var i int64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeVarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeVarintDescending(key)
+		}
+		return a.NewDMoney(tree.DMoney{Cents: i}), rkey, err
 	case types.RefCursorFamily:
 		var r string
 		if dir == encoding.Ascending {
@@ -259,6 +280,9 @@ func Decode(
 		}
 		var ipAddr ipaddr.IPAddr
 		_, err := ipAddr.FromBuffer(r)
+		if valType.Oid() == oid.T_cidr {
+			return a.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), rkey, err
+		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), rkey, err
 	case types.OidFamily:
 		// TODO: This possibly should use DecodeUint32 (with corresponding changes
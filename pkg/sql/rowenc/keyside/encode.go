@@ -77,6 +77,16 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 			return encoding.EncodeUvarintAscending(b, uint64(t.LSN)), nil
 		}
 		return encoding.EncodeUvarintDescending(b, uint64(t.LSN)), nil
+	case *tree.DMacAddr:
+		if dir == encoding.Ascending {
+			return encoding.EncodeUvarintAscending(b, t.Addr), nil
+		}
+		return encoding.EncodeUvarintDescending(b, t.Addr), nil
+	case *tree.DMoney:
+		if dir == encoding.Ascending {
+			return encoding.EncodeVarintAscending(b, t.Cents), nil
+		}
+		return encoding.EncodeVarintDescending(b, t.Cents), nil
 	case *tree.DBox2D:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBox2DAscending(b, t.CartesianBoundingBox.BoundingBox)
@@ -140,20 +150,7 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		}
 		return encoding.EncodeBytesDescending(b, data), nil
 	case *tree.DTuple:
-		// TODO(49975): due to the fact that we're not adding any "tuple
-		// marker", this encoding is faulty since it can lead to incorrect
-		// decoding: e.g. tuple (NULL, NULL) is encoded as [0, 0], but when
-		// decoding it, encoding.PeekLength will return 1 leaving the second
-		// zero in the buffer which could later result in corruption of the
-		// datum for the next column.
-		for _, datum := range t.D {
-			var err error
-			b, err = Encode(b, datum, dir)
-			if err != nil {
-				return nil, err
-			}
-		}
-		return b, nil
+		return encodeTupleKey(b, t, dir)
 	case *tree.DArray:
 		return encodeArrayKey(b, t, dir)
 	case *tree.DCollatedString:
@@ -181,6 +178,8 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return append(b, []byte(*t)...), nil
 	case *tree.DJSON:
 		return encodeJSONKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
 	}
 	if buildutil.CrdbTestBuild {
 		return nil, errors.AssertionFailedf("unable to encode table key: %T", val)
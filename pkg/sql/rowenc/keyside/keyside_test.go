@@ -249,3 +249,47 @@ func hasKeyEncoding(typ *types.T) bool {
 	}
 	return !colinfo.MustBeValueEncoded(typ)
 }
+
+// TestEncodeDecodeTuple tests that tuples, including those with NULL
+// elements, roundtrip through the key encoding and sort in the order defined
+// by DTuple.Compare.
+func TestEncodeDecodeTuple(t *testing.T) {
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	typ := types.MakeLabeledTuple([]*types.T{types.Int, types.String}, []string{"a", "b"})
+	mk := func(a, b tree.Datum) tree.Datum { return tree.NewDTuple(typ, a, b) }
+	// The tuples are listed in ascending order.
+	tuples := []tree.Datum{
+		tree.DNull,
+		mk(tree.DNull, tree.DNull),
+		mk(tree.DNull, tree.NewDString("")),
+		mk(tree.NewDInt(1), tree.DNull),
+		mk(tree.NewDInt(1), tree.NewDString("")),
+		mk(tree.NewDInt(1), tree.NewDString("a")),
+		mk(tree.NewDInt(1), tree.NewDString("a\x00")),
+		mk(tree.NewDInt(2), tree.DNull),
+	}
+	for _, dir := range []encoding.Direction{encoding.Ascending, encoding.Descending} {
+		var prev []byte
+		for i, d := range tuples {
+			b, err := keyside.Encode(nil, d, dir)
+			require.NoError(t, err)
+			// Append another value to check that the tuple is self-delimiting.
+			rest := encoding.EncodeNullAscending(nil)
+			decoded, remaining, err := keyside.Decode(&tree.DatumAlloc{}, typ, append(b, rest...), dir)
+			require.NoError(t, err)
+			require.Equal(t, rest, remaining)
+			cmp, err := decoded.Compare(ctx, evalCtx, d)
+			require.NoError(t, err)
+			require.Zero(t, cmp, "%s decoded as %s", d, decoded)
+			if i > 0 {
+				c := bytes.Compare(prev, b)
+				if dir == encoding.Descending {
+					c = -c
+				}
+				require.Equal(t, -1, c, "%s and %s are not ordered", tuples[i-1], d)
+			}
+			prev = b
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The markers used within the key encoding of a range. They are chosen so
+// that the encoded ranges sort in the order defined by DRange.Compare: the
+// empty range first, then by lower bound, then by upper bound.
+const (
+	rangeEmptyMarker    = 0
+	rangeNonEmptyMarker = 1
+
+	// rangeLowerInfMarker sorts an unbounded lower bound before any value.
+	rangeLowerInfMarker   = 0
+	rangeLowerValueMarker = 1
+	// An inclusive lower bound sorts before an exclusive one with the same
+	// value.
+	rangeLowerInclusive = 0
+	rangeLowerExclusive = 1
+
+	rangeUpperValueMarker = 0
+	// rangeUpperInfMarker sorts an unbounded upper bound after any value.
+	rangeUpperInfMarker = 1
+	// An exclusive upper bound sorts before an inclusive one with the same
+	// value.
+	rangeUpperExclusive = 0
+	rangeUpperInclusive = 1
+)
+
+// encodeRangeKey encodes a range. The components of the range are encoded in
+// ascending order, and the result is wrapped as bytes so that the range can
+// be skipped as a single value and encoded in descending order.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	if r.Empty {
+		inner = encoding.EncodeVarintAscending(inner, rangeEmptyMarker)
+	} else {
+		inner = encoding.EncodeVarintAscending(inner, rangeNonEmptyMarker)
+		var err error
+		if r.Lower == tree.DNull {
+			inner = encoding.EncodeVarintAscending(inner, rangeLowerInfMarker)
+		} else {
+			inner = encoding.EncodeVarintAscending(inner, rangeLowerValueMarker)
+			if inner, err = Encode(inner, r.Lower, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			if r.LowerInc {
+				inner = encoding.EncodeVarintAscending(inner, rangeLowerInclusive)
+			} else {
+				inner = encoding.EncodeVarintAscending(inner, rangeLowerExclusive)
+			}
+		}
+		if r.Upper == tree.DNull {
+			inner = encoding.EncodeVarintAscending(inner, rangeUpperInfMarker)
+		} else {
+			inner = encoding.EncodeVarintAscending(inner, rangeUpperValueMarker)
+			if inner, err = Encode(inner, r.Upper, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			if r.UpperInc {
+				inner = encoding.EncodeVarintAscending(inner, rangeUpperInclusive)
+			} else {
+				inner = encoding.EncodeVarintAscending(inner, rangeUpperExclusive)
+			}
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var rkey, inner []byte
+	var err error
+	if dir == encoding.Ascending {
+		rkey, inner, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		rkey, inner, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var marker int64
+	if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeEmptyMarker {
+		return tree.NewDEmptyRange(t), rkey, nil
+	}
+	contents := t.RangeContents()
+	lower, upper := tree.Datum(tree.DNull), tree.Datum(tree.DNull)
+	var lowerInc, upperInc bool
+	if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeLowerValueMarker {
+		if lower, inner, err = Decode(a, contents, inner, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+			return nil, nil, err
+		}
+		lowerInc = marker == rangeLowerInclusive
+	}
+	if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeUpperValueMarker {
+		if upper, inner, err = Decode(a, contents, inner, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+			return nil, nil, err
+		}
+		upperInc = marker == rangeUpperInclusive
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(inner))
+	}
+	r, err := tree.NewDRange(t, lower, upper, lowerInc, upperInc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, rkey, nil
+}
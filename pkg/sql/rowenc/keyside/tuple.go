@@ -0,0 +1,64 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeTupleKey encodes a tuple. The elements of the tuple are encoded in
+// ascending order, and the result is wrapped as bytes so that the tuple can be
+// skipped as a single value and encoded in descending order. Since the
+// encoding of every element is self-delimiting and NULL elements sort first,
+// the encoded tuples sort in the order defined by DTuple.Compare.
+func encodeTupleKey(b []byte, t *tree.DTuple, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	for _, datum := range t.D {
+		var err error
+		if inner, err = Encode(inner, datum, encoding.Ascending); err != nil {
+			return nil, err
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// decodeTupleKey decodes a tuple key generated by encodeTupleKey.
+func decodeTupleKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var rkey, inner []byte
+	var err error
+	if dir == encoding.Ascending {
+		rkey, inner, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		rkey, inner, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	contents := t.TupleContents()
+	result := *(tree.NewDTuple(t))
+	result.D = a.NewDatums(len(contents))
+	for i := range contents {
+		if len(inner) == 0 {
+			return nil, nil, errors.AssertionFailedf(
+				"invalid tuple encoding (expected %d elements, found %d)", len(contents), i)
+		}
+		if result.D[i], inner, err = Decode(a, contents[i], inner, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid tuple encoding (%d trailing bytes)", len(inner))
+	}
+	return a.NewDTuple(result), rkey, nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rowenc
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Inverted indexes on range columns use a one-dimensional analog of the S2
+// cell coverings used by geospatial indexes (see geoindex). The bounds of a
+// range are mapped to positions in [0, 2^63) by a monotonic function of the
+// bound values, and the interval of positions is covered by a small number of
+// dyadic "cells". A cell at level L in [0, 63] contains the positions sharing
+// a prefix of L bits, and is identified like an S2 cell: the prefix followed
+// by a single set bit and then zeros. So the descendants of a cell are exactly
+// the ids in [id - lsb + 1, id + lsb - 1], where lsb is the lowest set bit of
+// the id.
+//
+// Two ranges can only overlap if a cell covering one of them is equal to, an
+// ancestor of, or a descendant of a cell covering the other. Since the
+// position mapping is lossy for some types, the resulting spans are never
+// tight.
+
+// rangeIndexMaxCells is the maximum number of cells used to cover a range.
+const rangeIndexMaxCells = 4
+
+// rangeIndexPositionBits is the number of bits of a position.
+const rangeIndexPositionBits = 63
+
+// rangeIndexMaxPosition is the position of an unbounded upper bound.
+const rangeIndexMaxPosition = 1<<rangeIndexPositionBits - 1
+
+// rangeIndexEmptyKey is the key of the empty range. It is not a valid cell
+// id, since every cell id has a set bit.
+const rangeIndexEmptyKey = 0
+
+// rangeIndexCell is a cell at the given level, with the given prefix of
+// position bits.
+type rangeIndexCell struct {
+	prefix uint64
+	level  int
+}
+
+// id returns the cell id of the cell.
+func (c rangeIndexCell) id() uint64 {
+	return c.prefix<<(64-c.level) | c.lsb()
+}
+
+// lsb returns the lowest set bit of the cell id.
+func (c rangeIndexCell) lsb() uint64 {
+	return 1 << (rangeIndexPositionBits - c.level)
+}
+
+// minPosition returns the smallest position in the cell.
+func (c rangeIndexCell) minPosition() uint64 {
+	return c.prefix << (rangeIndexPositionBits - c.level)
+}
+
+// maxPosition returns the largest position in the cell.
+func (c rangeIndexCell) maxPosition() uint64 {
+	return c.minPosition() | (1<<(rangeIndexPositionBits-c.level) - 1)
+}
+
+// rangeIndexPosition maps a finite bound value of a range to a position. The
+// mapping is monotonic, but not necessarily injective.
+func rangeIndexPosition(d tree.Datum) (uint64, error) {
+	var pos uint64
+	switch t := d.(type) {
+	case *tree.DInt:
+		pos = uint64(*t) ^ (1 << 63)
+	case *tree.DDate:
+		pos = uint64(t.UnixEpochDays()) ^ (1 << 63)
+	case *tree.DTimestamp:
+		pos = uint64(t.UnixMicro()) ^ (1 << 63)
+	case *tree.DTimestampTZ:
+		pos = uint64(t.UnixMicro()) ^ (1 << 63)
+	case *tree.DDecimal:
+		f, err := t.Float64()
+		if err != nil {
+			// Decimals which overflow a float are converted to infinities.
+			f = math.Inf(t.Sign())
+		}
+		pos = math.Float64bits(f)
+		if pos&(1<<63) != 0 {
+			pos = ^pos
+		} else {
+			pos |= 1 << 63
+		}
+	default:
+		return 0, errors.AssertionFailedf("unexpected range bound %T", d)
+	}
+	return pos >> (64 - rangeIndexPositionBits), nil
+}
+
+// rangeIndexCovering returns the cells covering the positions of the given
+// non-empty range.
+func rangeIndexCovering(r *tree.DRange) ([]rangeIndexCell, error) {
+	lo, hi := uint64(0), uint64(rangeIndexMaxPosition)
+	var err error
+	if r.Lower != tree.DNull {
+		if lo, err = rangeIndexPosition(r.Lower); err != nil {
+			return nil, err
+		}
+	}
+	if r.Upper != tree.DNull {
+		if hi, err = rangeIndexPosition(r.Upper); err != nil {
+			return nil, err
+		}
+	}
+	// Start with the smallest cell containing both positions, and refine the
+	// cells one level at a time while the covering is small enough.
+	level := rangeIndexPositionBits
+	if lo != hi {
+		level = bits.LeadingZeros64(lo^hi) - (64 - rangeIndexPositionBits)
+	}
+	cells := []rangeIndexCell{{prefix: lo >> (rangeIndexPositionBits - level), level: level}}
+	for {
+		var next []rangeIndexCell
+		refined := false
+		for _, c := range cells {
+			if c.level == rangeIndexPositionBits || (c.minPosition() >= lo && c.maxPosition() <= hi) {
+				next = append(next, c)
+				continue
+			}
+			refined = true
+			for _, child := range []rangeIndexCell{
+				{prefix: c.prefix << 1, level: c.level + 1},
+				{prefix: c.prefix<<1 | 1, level: c.level + 1},
+			} {
+				if child.maxPosition() >= lo && child.minPosition() <= hi {
+					next = append(next, child)
+				}
+			}
+		}
+		if !refined || len(next) > rangeIndexMaxCells {
+			return cells, nil
+		}
+		cells = next
+	}
+}
+
+// encodeRangeInvertedIndexKey encodes the inverted index key with the given
+// cell id.
+func encodeRangeInvertedIndexKey(inKey []byte, id uint64) []byte {
+	outKey := make([]byte, len(inKey), len(inKey)+encoding.MaxVarintLen)
+	copy(outKey, inKey)
+	return encoding.EncodeUvarintAscending(outKey, id)
+}
+
+// encodeRangeInvertedIndexTableKeys returns the inverted index keys for the
+// given range, one per cell of its covering. The input inKey is prefixed to
+// all returned keys.
+func encodeRangeInvertedIndexTableKeys(val *tree.DRange, inKey []byte) ([][]byte, error) {
+	if val.Empty {
+		return [][]byte{encodeRangeInvertedIndexKey(inKey, rangeIndexEmptyKey)}, nil
+	}
+	cells, err := rangeIndexCovering(val)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, len(cells))
+	for i, c := range cells {
+		keys[i] = encodeRangeInvertedIndexKey(inKey, c.id())
+	}
+	return keys, nil
+}
+
+// encodeOverlapsRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index to evaluate an overlaps (&&) predicate with
+// the given range. The input inKey is prefixed to all returned keys.
+func encodeOverlapsRangeInvertedIndexSpans(
+	val *tree.DRange, inKey []byte,
+) (invertedExpr inverted.Expression, err error) {
+	if val.Empty {
+		// Nothing overlaps the empty range.
+		return &inverted.SpanExpression{Tight: true, Unique: true}, nil
+	}
+	cells, err := rangeIndexCovering(val)
+	if err != nil {
+		return nil, err
+	}
+	// For each cell of the covering, scan the cell and its descendants, and
+	// look up each of its ancestors.
+	var ancestors []uint64
+	seen := make(map[uint64]struct{})
+	for _, c := range cells {
+		id := c.id()
+		span := inverted.Span{
+			Start: encodeRangeInvertedIndexKey(inKey, id-c.lsb()+1),
+			End:   keysbase.PrefixEnd(encodeRangeInvertedIndexKey(inKey, id+c.lsb()-1)),
+		}
+		invertedExpr = orRangeSpanExpr(invertedExpr, inverted.ExprForSpan(span, false /* tight */))
+		for level := c.level - 1; level >= 0; level-- {
+			ancestor := rangeIndexCell{prefix: c.prefix >> (c.level - level), level: level}.id()
+			if _, ok := seen[ancestor]; !ok {
+				seen[ancestor] = struct{}{}
+				ancestors = append(ancestors, ancestor)
+			}
+		}
+	}
+	for _, id := range ancestors {
+		spanExpr := inverted.ExprForSpan(
+			inverted.MakeSingleValSpan(encodeRangeInvertedIndexKey(inKey, id)), false, /* tight */
+		)
+		invertedExpr = orRangeSpanExpr(invertedExpr, spanExpr)
+	}
+	return invertedExpr, nil
+}
+
+// encodeContainingRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index to evaluate a contains (@>) predicate with
+// the given range. The input inKey is prefixed to all returned keys.
+func encodeContainingRangeInvertedIndexSpans(
+	val *tree.DRange, inKey []byte,
+) (invertedExpr inverted.Expression, err error) {
+	if val.Empty {
+		// All ranges contain the empty range, so the index cannot be used to
+		// constrain the result.
+		return inverted.NonInvertedColExpression{}, nil
+	}
+	// A range containing a non-empty range overlaps it.
+	return encodeOverlapsRangeInvertedIndexSpans(val, inKey)
+}
+
+// encodeContainedRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index to evaluate a contained by (<@) predicate with
+// the given range. The input inKey is prefixed to all returned keys.
+func encodeContainedRangeInvertedIndexSpans(
+	val *tree.DRange, inKey []byte,
+) (invertedExpr inverted.Expression, err error) {
+	// The empty range is contained by every range.
+	emptySpanExpr := inverted.ExprForSpan(
+		inverted.MakeSingleValSpan(encodeRangeInvertedIndexKey(inKey, rangeIndexEmptyKey)), true, /* tight */
+	)
+	emptySpanExpr.Unique = true
+	if val.Empty {
+		return emptySpanExpr, nil
+	}
+	// A non-empty range contained by a range overlaps it.
+	invertedExpr, err = encodeOverlapsRangeInvertedIndexSpans(val, inKey)
+	if err != nil {
+		return nil, err
+	}
+	invertedExpr = inverted.Or(invertedExpr, emptySpanExpr)
+	invertedExpr.SetNotTight()
+	return invertedExpr, nil
+}
+
+func orRangeSpanExpr(left inverted.Expression, right *inverted.SpanExpression) inverted.Expression {
+	if left == nil {
+		return right
+	}
+	return inverted.Or(left, right)
+}
@@ -209,7 +209,7 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 	case types.DecimalFamily:
 		return encoding.Decimal, nil
 	case types.BytesFamily, types.StringFamily, types.CollatedStringFamily,
-		types.EnumFamily, types.RefCursorFamily:
+		types.EnumFamily, types.RefCursorFamily, types.RangeFamily, types.JsonpathFamily:
 		return encoding.Bytes, nil
 	case types.TimestampFamily, types.TimestampTZFamily:
 		return encoding.Time, nil
@@ -226,7 +226,7 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.True, nil
 	case types.BitFamily:
 		return encoding.BitArray, nil
-	case types.PGLSNFamily:
+	case types.PGLSNFamily, types.MacAddrFamily, types.MoneyFamily:
 		return encoding.Int, nil
 	case types.UuidFamily:
 		return encoding.UUID, nil
@@ -282,6 +282,10 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedIntValue(b, t.UnixEpochDaysWithOrig()), nil
 	case *tree.DPGLSN:
 		return encoding.EncodeUntaggedIntValue(b, int64(t.LSN)), nil
+	case *tree.DMacAddr:
+		return encoding.EncodeUntaggedIntValue(b, int64(t.Addr)), nil
+	case *tree.DMoney:
+		return encoding.EncodeUntaggedIntValue(b, t.Cents), nil
 	case *tree.DBox2D:
 		return encoding.EncodeUntaggedBox2DValue(b, t.CartesianBoundingBox.BoundingBox)
 	case *tree.DGeography:
@@ -319,6 +323,8 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 	case *tree.DTuple:
 		res, _, err := encodeUntaggedTuple(t, b, nil)
 		return res, err
+	case *tree.DJsonpath:
+		return encoding.EncodeUntaggedBytesValue(b, []byte(t.Jsonpath.String())), nil
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
@@ -334,6 +340,12 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 			return nil, err
 		}
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DRange:
+		encoded, err := encodeRangeData(nil, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	default:
 		return nil, errors.Errorf("don't know how to encode %s (%T)", d, d)
 	}
@@ -66,6 +66,10 @@ func EncodeWithScratch(
 		return encoding.EncodeIntValue(appendTo, uint32(colID), t.UnixEpochDaysWithOrig()), scratch, nil
 	case *tree.DPGLSN:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.LSN)), scratch, nil
+	case *tree.DMacAddr:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.Addr)), scratch, nil
+	case *tree.DMoney:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), t.Cents), scratch, nil
 	case *tree.DBox2D:
 		res, err = encoding.EncodeBox2DValue(appendTo, uint32(colID), t.CartesianBoundingBox.BoundingBox)
 		return res, scratch, err
@@ -95,6 +99,8 @@ func EncodeWithScratch(
 			return nil, nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), scratch), scratch, nil
+	case *tree.DJsonpath:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Jsonpath.String())), scratch, nil
 	case *tree.DTSQuery:
 		scratch, err = tsearch.EncodeTSQuery(scratch[:0], t.TSQuery)
 		if err != nil {
@@ -113,6 +119,12 @@ func EncodeWithScratch(
 			return nil, nil, err
 		}
 		return encoding.EncodePGVectorValue(appendTo, uint32(colID), scratch), scratch, nil
+	case *tree.DRange:
+		scratch, err = encodeRangeData(scratch[:0], t)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), scratch), scratch, nil
 	case *tree.DArray:
 		scratch, err = encodeArray(t, scratch[:0])
 		if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -89,6 +90,16 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetInt(int64(v.LSN))
 			return r, nil
 		}
+	case types.MacAddrFamily:
+		if v, ok := val.(*tree.DMacAddr); ok {
+			r.SetInt(int64(v.Addr))
+			return r, nil
+		}
+	case types.MoneyFamily:
+		if v, ok := val.(*tree.DMoney); ok {
+			r.SetInt(v.Cents)
+			return r, nil
+		}
 	case types.RefCursorFamily:
 		if v, ok := tree.AsDString(val); ok {
 			r.SetString(string(v))
@@ -135,7 +146,7 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			return r, nil
 		}
 	case types.INetFamily:
-		if v, ok := val.(*tree.DIPAddr); ok {
+		if v, ok := tree.AsDIPAddr(val); ok {
 			data := v.ToBuffer(nil)
 			r.SetBytes(data)
 			return r, nil
@@ -149,6 +160,11 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetString(v.Jsonpath.String())
+			return r, nil
+		}
 	case types.TSQueryFamily:
 		if v, ok := val.(*tree.DTSQuery); ok {
 			data := tsearch.EncodeTSQueryPGBinary(nil, v.TSQuery)
@@ -173,6 +189,15 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			data, err := encodeRangeData(nil, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.ArrayFamily:
 		if v, ok := val.(*tree.DArray); ok {
 			if err := checkElementType(v.ParamTyp, colType.ArrayContents()); err != nil {
@@ -259,6 +284,20 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(v)}), nil
+	case types.MacAddrFamily:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDMacAddr(tree.DMacAddr{
+			Addr: uint64(v), Is8: typ.Oid() == oidext.T_macaddr8,
+		}), nil
+	case types.MoneyFamily:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDMoney(tree.DMoney{Cents: v}), nil
 	case types.RefCursorFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -375,6 +414,9 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 		if err != nil {
 			return nil, err
 		}
+		if typ.Oid() == oid.T_cidr {
+			return a.NewDCIDR(tree.DIPAddr{IPAddr: ipAddr}), nil
+		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
 	case types.OidFamily:
 		v, err := value.GetInt()
@@ -407,6 +449,12 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJsonpath(string(v))
 	case types.TSQueryFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -437,6 +485,12 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDPGVector(vec), nil
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeRangeData(a, typ, v)
 	case types.EnumFamily:
 		v, err := value.GetBytes()
 		if err != nil {
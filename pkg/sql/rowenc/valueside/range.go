@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags which precede the bounds in the encoding of a range.
+const (
+	rangeEmpty    = 1 << 0
+	rangeLowerInc = 1 << 1
+	rangeUpperInc = 1 << 2
+	rangeLowerInf = 1 << 3
+	rangeUpperInf = 1 << 4
+)
+
+// encodeRangeData produces the data encoded in a bytes value for a range: the
+// flags of the range, followed by the value encoding of each bound that is
+// not unbounded.
+func encodeRangeData(appendTo []byte, r *tree.DRange) (_ []byte, err error) {
+	var flags uint64
+	if r.Empty {
+		return encoding.EncodeNonsortingUvarint(appendTo, rangeEmpty), nil
+	}
+	if r.LowerInc {
+		flags |= rangeLowerInc
+	}
+	if r.UpperInc {
+		flags |= rangeUpperInc
+	}
+	if r.Lower == tree.DNull {
+		flags |= rangeLowerInf
+	}
+	if r.Upper == tree.DNull {
+		flags |= rangeUpperInf
+	}
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, flags)
+	for _, b := range []tree.Datum{r.Lower, r.Upper} {
+		if b == tree.DNull {
+			continue
+		}
+		if appendTo, err = Encode(appendTo, NoColumnID, b); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeRangeData decodes a range from the data produced by encodeRangeData.
+func decodeRangeData(a *tree.DatumAlloc, t *types.T, data []byte) (tree.Datum, error) {
+	data, _, flags, err := encoding.DecodeNonsortingUvarint(data)
+	if err != nil {
+		return nil, err
+	}
+	if flags&rangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	lower, upper := tree.Datum(tree.DNull), tree.Datum(tree.DNull)
+	if flags&rangeLowerInf == 0 {
+		if lower, data, err = Decode(a, t.RangeContents(), data); err != nil {
+			return nil, err
+		}
+	}
+	if flags&rangeUpperInf == 0 {
+		if upper, data, err = Decode(a, t.RangeContents(), data); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) != 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(data))
+	}
+	return tree.NewDRange(t, lower, upper, flags&rangeLowerInc != 0, flags&rangeUpperInc != 0)
+}
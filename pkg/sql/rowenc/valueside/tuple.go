@@ -35,21 +35,39 @@ func encodeUntaggedTuple(
 
 // decodeTuple decodes a tuple from its value encoding. It is the
 // counterpart of encodeTuple().
+//
+// The number of encoded elements may differ from the number of elements of
+// tupTyp if attributes were added to its composite type. If the tuple was
+// encoded before attributes were added, the missing trailing elements are
+// decoded as NULL. If it was encoded with a newer version of the type than
+// tupTyp, the extra trailing elements are skipped.
 func decodeTuple(a *tree.DatumAlloc, tupTyp *types.T, b []byte) (tree.Datum, []byte, error) {
-	b, _, _, err := encoding.DecodeNonsortingUvarint(b)
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
 	if err != nil {
 		return nil, nil, err
 	}
+	contents := tupTyp.TupleContents()
 
 	result := *(tree.NewDTuple(tupTyp))
-	result.D = a.NewDatums(len(tupTyp.TupleContents()))
+	result.D = a.NewDatums(len(contents))
 	var datum tree.Datum
-	for i := range tupTyp.TupleContents() {
-		datum, b, err = Decode(a, tupTyp.TupleContents()[i], b)
+	for i := range contents {
+		if uint64(i) >= n {
+			result.D[i] = tree.DNull
+			continue
+		}
+		datum, b, err = Decode(a, contents[i], b)
 		if err != nil {
 			return nil, b, err
 		}
 		result.D[i] = datum
 	}
+	for i := uint64(len(contents)); i < n; i++ {
+		_, l, err := encoding.PeekValueLength(b)
+		if err != nil {
+			return nil, b, err
+		}
+		b = b[l:]
+	}
 	return a.NewDTuple(result), b, nil
 }
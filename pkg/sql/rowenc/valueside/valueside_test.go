@@ -338,3 +338,47 @@ func genDatumWithType(columnType interface{}) gopter.Gen {
 		return gopter.NewGenResult(datum, gopter.NoShrinker)
 	}
 }
+
+// This test ensures that a tuple value can be decoded with a tuple type that
+// has more or fewer elements than the encoded tuple, as happens when
+// attributes are added to a composite type.
+func TestDecodeTupleValueWithAddedElements(t *testing.T) {
+	oldType := types.MakeLabeledTuple([]*types.T{types.Int}, []string{"a"})
+	newType := types.MakeLabeledTuple([]*types.T{types.Int, types.String}, []string{"a", "b"})
+	oldDatum := tree.NewDTuple(oldType, tree.NewDInt(tree.DInt(1)))
+	newDatum := tree.NewDTuple(newType, tree.NewDInt(tree.DInt(1)), tree.NewDString("foo"))
+	// Append another value to check that the whole tuple is consumed.
+	next := tree.NewDInt(tree.DInt(2))
+	encode := func(d tree.Datum) []byte {
+		buf, err := valueside.Encode(nil, valueside.NoColumnID, d)
+		require.NoError(t, err)
+		buf, err = valueside.Encode(buf, valueside.NoColumnID, next)
+		require.NoError(t, err)
+		return buf
+	}
+	for _, tc := range []struct {
+		encoded  tree.Datum
+		typ      *types.T
+		expected tree.Datum
+	}{
+		{
+			encoded:  oldDatum,
+			typ:      newType,
+			expected: tree.NewDTuple(newType, tree.NewDInt(tree.DInt(1)), tree.DNull),
+		},
+		{
+			encoded:  newDatum,
+			typ:      oldType,
+			expected: oldDatum,
+		},
+	} {
+		da := tree.DatumAlloc{}
+		decoded, rest, err := valueside.Decode(&da, tc.typ, encode(tc.encoded))
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, decoded)
+		decodedNext, rest, err := valueside.Decode(&da, types.Int, rest)
+		require.NoError(t, err)
+		require.Equal(t, next, decodedNext)
+		require.Empty(t, rest)
+	}
+}
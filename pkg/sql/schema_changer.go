@@ -2014,10 +2014,12 @@ func maybeUpdateZoneConfigsForPKChange(
 
 	// It is safe to copy the zone config off a primary index of a REGIONAL BY ROW
 	// table. This is because the prefix of the PK we used to partition by will
-	// stay the same, so a direct copy will always work. If forceSwap is true, we
-	// are performing an operation that does not need to worry about data (like
-	// for TRUNCATE).
-	if table.IsLocalityRegionalByRow() || forceSwap {
+	// stay the same, so a direct copy will always work. The same holds for any
+	// PARTITION ALL BY table, since the subzones of partitions which were
+	// removed are deleted before the swap. If forceSwap is true, we are
+	// performing an operation that does not need to worry about data (like for
+	// TRUNCATE).
+	if table.IsLocalityRegionalByRow() || table.IsPartitionAllBy() || forceSwap {
 		oldIdxToNewIdx[swapInfo.OldPrimaryIndexId] = swapInfo.NewPrimaryIndexId
 	}
 
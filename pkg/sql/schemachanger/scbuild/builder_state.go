@@ -857,6 +857,42 @@ func (b *builderState) PartialIndexPredicateExpression(
 	return parsedExpr
 }
 
+var _ scbuildstmt.TypeHelpers = (*builderState)(nil)
+
+// mustReadDomain returns the descriptor of the domain with the given ID.
+func (b *builderState) mustReadDomain(typeID catid.DescID) catalog.DomainTypeDescriptor {
+	b.ensureDescriptor(typeID)
+	desc := b.descCache[typeID].desc
+	typ, ok := desc.(catalog.TypeDescriptor)
+	if !ok || typ.AsDomainTypeDescriptor() == nil {
+		panic(errors.AssertionFailedf("Expected domain type descriptor for ID %d, instead got %s",
+			desc.GetID(), desc.DescriptorType()))
+	}
+	return typ.AsDomainTypeDescriptor()
+}
+
+// DomainBaseType implements the scbuildstmt.TypeHelpers interface.
+func (b *builderState) DomainBaseType(typeID catid.DescID) *types.T {
+	return b.mustReadDomain(typeID).DomainBaseType()
+}
+
+// NextDomainConstraintID implements the scbuildstmt.TypeHelpers interface.
+func (b *builderState) NextDomainConstraintID(typeID catid.DescID) (ret catid.ConstraintID) {
+	ret = b.mustReadDomain(typeID).TypeDesc().Domain.NextConstraintID
+	if ret == 0 {
+		ret = 1
+	}
+	// Consult all present domain constraints in case their ID is larger.
+	scpb.ForEachDomainConstraint(b.QueryByID(typeID), func(
+		_ scpb.Status, _ scpb.TargetStatus, c *scpb.DomainConstraint,
+	) {
+		if c.ConstraintID >= ret {
+			ret = c.ConstraintID + 1
+		}
+	})
+	return ret
+}
+
 var _ scbuildstmt.ElementReferences = (*builderState)(nil)
 
 // ForwardReferences implements the scbuildstmt.ElementReferences interface.
@@ -1045,7 +1081,7 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_ENUM:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
-	case descpb.TypeDescriptor_COMPOSITE:
+	case descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_DOMAIN:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
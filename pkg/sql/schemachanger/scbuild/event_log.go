@@ -383,7 +383,7 @@ func (pb payloadBuilder) build(b buildCtx) logpb.EventPayload {
 				TypeName: fullyQualifiedName(b, e),
 			}
 		}
-	case *scpb.CompositeType:
+	case *scpb.CompositeType, *scpb.DomainType:
 		if pb.TargetStatus == scpb.Status_PUBLIC {
 			return nil
 		} else {
@@ -494,6 +494,10 @@ func (pb payloadBuilder) build(b buildCtx) logpb.EventPayload {
 				CommonZoneConfigDetails: zcDetails,
 			}
 		}
+	case *scpb.DomainConstraint, *scpb.DomainNotNull:
+		return &eventpb.AlterType{
+			TypeName: fullyQualifiedName(b, e),
+		}
 	case *scpb.Trigger:
 		if pb.TargetStatus == scpb.Status_PUBLIC {
 			return &eventpb.CreateTrigger{
@@ -0,0 +1,128 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+)
+
+// AlterDomain implements ALTER DOMAIN ADD CONSTRAINT and ALTER DOMAIN SET NOT
+// NULL. The remaining commands are handled by the legacy schema changer.
+func AlterDomain(b BuildCtx, n *tree.AlterDomain) {
+	elts := b.ResolveUserDefinedTypeType(n.Domain, ResolveParams{
+		RequireOwnership: true,
+	})
+	_, _, domain := scpb.FindDomainType(elts)
+	if domain == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", n.Domain.Object()))
+	}
+	tn := tree.MakeTypeNameWithPrefix(b.NamePrefix(domain), n.Domain.Object())
+	b.SetUnresolvedNameAnnotation(n.Domain, &tn)
+	b.IncrementSchemaChangeAlterCounter("domain", n.Cmd.TelemetryName())
+	switch t := n.Cmd.(type) {
+	case *tree.AlterDomainAddConstraint:
+		alterDomainAddConstraint(b, n, domain, &t.Constraint)
+	case *tree.AlterDomainSetNotNull:
+		alterDomainSetNotNull(b, domain)
+	default:
+		panic(scerrors.NotImplementedError(n))
+	}
+}
+
+func alterDomainAddConstraint(
+	b BuildCtx, n *tree.AlterDomain, domain *scpb.DomainType, c *tree.DomainConstraint,
+) {
+	if c.Check == nil {
+		panic(scerrors.NotImplementedError(n))
+	}
+	elts := b.QueryByID(domain.TypeID)
+	_, _, ns := scpb.FindNamespace(elts)
+	name := string(c.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s_check", ns.Name)
+		for i := 1; domainConstraintNameExists(elts, name); i++ {
+			name = fmt.Sprintf("%s_check%d", ns.Name, i)
+		}
+	} else if domainConstraintNameExists(elts, name) {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, ns.Name))
+	}
+	checkExpr, err := schemaexpr.ValidateDomainCheckExpr(
+		b, c.Check, b.DomainBaseType(domain.TypeID), b.SemaCtx(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	typedCheckExpr, err := parser.ParseExpr(checkExpr)
+	if err != nil {
+		panic(err)
+	}
+	expr := b.WrapExpression(domain.TypeID, typedCheckExpr)
+	// Domain constraints do not track references to other descriptors, so
+	// leave expressions which have any to the legacy schema changer.
+	if len(expr.UsesTypeIDs) > 0 || len(expr.UsesSequenceIDs) > 0 || len(expr.UsesFunctionIDs) > 0 {
+		panic(scerrors.NotImplementedErrorf(n, "domain constraint referencing other objects"))
+	}
+	dc := &scpb.DomainConstraint{
+		TypeID:       domain.TypeID,
+		ConstraintID: b.NextDomainConstraintID(domain.TypeID),
+		Name:         name,
+		Expression:   *expr,
+	}
+	b.Add(dc)
+	b.LogEventForExistingTarget(dc)
+}
+
+func alterDomainSetNotNull(b BuildCtx, domain *scpb.DomainType) {
+	_, target, notNull := scpb.FindDomainNotNull(b.QueryByID(domain.TypeID))
+	if notNull != nil && target == scpb.ToPublic {
+		return
+	}
+	dnn := &scpb.DomainNotNull{TypeID: domain.TypeID}
+	b.Add(dnn)
+	b.LogEventForExistingTarget(dnn)
+}
+
+// domainConstraintNameExists returns true if the domain has a constraint with
+// the given name which is not being dropped.
+func domainConstraintNameExists(elts ElementResultSet, name string) (found bool) {
+	scpb.ForEachDomainConstraint(elts, func(
+		_ scpb.Status, target scpb.TargetStatus, c *scpb.DomainConstraint,
+	) {
+		if c.Name == name && target != scpb.ToAbsent {
+			found = true
+		}
+	})
+	return found
+}
+
+// alterDomainChecks determines if the ALTER DOMAIN command is supported by the
+// declarative schema changer.
+func alterDomainChecks(
+	n *tree.AlterDomain,
+	_ sessiondatapb.NewSchemaChangerMode,
+	activeVersion clusterversion.ClusterVersion,
+) bool {
+	if !activeVersion.IsActive(clusterversion.V25_1) {
+		return false
+	}
+	switch n.Cmd.(type) {
+	case *tree.AlterDomainAddConstraint, *tree.AlterDomainSetNotNull:
+		return true
+	default:
+		return false
+	}
+}
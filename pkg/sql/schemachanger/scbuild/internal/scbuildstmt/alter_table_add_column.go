@@ -181,6 +181,11 @@ func alterTableAddColumn(
 	}
 	if desc.IsComputed() {
 		expr := b.WrapExpression(tbl.TableID, b.ComputedColumnExpression(tbl, d))
+		if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(
+			string(expr.Expr), tree.StoredComputedColumnExpr,
+		); err != nil {
+			panic(err)
+		}
 		if spec.colType.ElementCreationMetadata.In_24_3OrLater {
 			spec.compute = &scpb.ColumnComputeExpression{
 				TableID:    tbl.TableID,
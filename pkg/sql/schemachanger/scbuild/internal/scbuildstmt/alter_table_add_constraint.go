@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -62,6 +63,8 @@ func alterTableAddConstraint(
 		alterTableAddCheck(b, tn, tbl, t)
 	case *tree.ForeignKeyConstraintTableDef:
 		alterTableAddForeignKey(b, tn, tbl, stmt, t)
+	case *tree.ExcludeConstraintTableDef:
+		alterTableAddExclusion(b, tn, tbl, t)
 	}
 }
 
@@ -564,6 +567,115 @@ func alterTableAddUniqueWithoutIndex(
 	})
 }
 
+// alterTableAddExclusion contains logic for building
+// `ALTER TABLE ... ADD EXCLUDE ... [NOT VALID]`.
+// It assumes `t` is such a command.
+func alterTableAddExclusion(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAddConstraint,
+) {
+	d := t.ConstraintDef.(*tree.ExcludeConstraintTableDef)
+	// Nodes running v24.3 ignore the operators of exclusion constraints.
+	if !b.EvalCtx().Settings.Version.ActiveVersion(b).IsActive(clusterversion.V25_1) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"EXCLUDE constraints unsupported in mixed-version cluster"))
+	}
+
+	// 1. Check that the columns have no duplicates, and that each operator can
+	// be used with the type of its column.
+	var colSet catalog.TableColSet
+	var colIDs []catid.ColumnID
+	var colNames []string
+	var ops []string
+	for _, elem := range d.Elems {
+		colID := getColumnIDFromColumnName(b, tbl.TableID, elem.Column, true /*required*/)
+		if colSet.Contains(colID) {
+			panic(pgerror.Newf(pgcode.DuplicateColumn,
+				"column %q appears twice in exclusion constraint", elem.Column))
+		}
+		colType := mustRetrieveColumnTypeElem(b, tbl.TableID, colID).Type
+		if err := schemaexpr.ValidateExclusionOperator(elem.Operator, colType); err != nil {
+			panic(err)
+		}
+		colSet.Add(colID)
+		colIDs = append(colIDs, colID)
+		colNames = append(colNames, string(elem.Column))
+		ops = append(ops, elem.Operator.Symbol.String())
+	}
+
+	// 2. If a name is provided, check that this name is not used; Otherwise,
+	// generate a unique name for it.
+	if skip, err := validateConstraintNameIsNotUsed(b, tn, tbl, t); err != nil {
+		panic(err)
+	} else if skip {
+		return
+	}
+	if d.Name == "" {
+		ns := mustRetrieveNamespaceElem(b, tbl.TableID)
+		d.Name = tree.Name(tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", ns.Name, strings.Join(colNames, "_")),
+			func(name string) bool {
+				return constraintNameInUse(b, tbl.TableID, name)
+			},
+		))
+	}
+
+	// 3. If there is a predicate, validate it.
+	if d.Predicate != nil {
+		predicate, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, d.Predicate, types.Bool,
+			tree.UniqueWithoutIndexPredicateExpr, b.SemaCtx(), volatility.Immutable, tn, b.ClusterSettings().Version.ActiveVersion(b),
+			func() colinfo.ResultColumns {
+				return getNonDropResultColumns(b, tbl.TableID)
+			},
+			func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+				return columnLookupFn(b, tbl.TableID, columnName)
+			},
+		)
+		if err != nil {
+			panic(err)
+		}
+		typedPredicate, err := parser.ParseExpr(predicate)
+		if err != nil {
+			panic(err)
+		}
+		d.Predicate = typedPredicate
+	}
+
+	// 4. Add a UniqueWithoutIndex element carrying the exclusion operators, and
+	// a ConstraintName element to builder state.
+	constraintID := b.NextTableConstraintID(tbl.TableID)
+	if t.ValidationBehavior == tree.ValidationDefault {
+		uwi := &scpb.UniqueWithoutIndexConstraint{
+			TableID:              tbl.TableID,
+			ConstraintID:         constraintID,
+			ColumnIDs:            colIDs,
+			IndexIDForValidation: getIndexIDForValidationForConstraint(b, tbl.TableID),
+			ExclusionOperators:   ops,
+		}
+		if d.Predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		}
+		b.Add(uwi)
+		b.LogEventForExistingTarget(uwi)
+	} else {
+		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
+			TableID:            tbl.TableID,
+			ConstraintID:       constraintID,
+			ColumnIDs:          colIDs,
+			ExclusionOperators: ops,
+		}
+		if d.Predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		}
+		b.Add(uwi)
+		b.LogEventForExistingTarget(uwi)
+	}
+	b.Add(&scpb.ConstraintWithoutIndexName{
+		TableID:      tbl.TableID,
+		ConstraintID: constraintID,
+		Name:         string(d.Name),
+	})
+}
+
 // getFullyResolvedColNames returns fully resolved column names for `colNames`.
 // For each column name in `colNames`, its fully resolved name will be "db.sc.tbl.col".
 // The order of column names in the return is in syc with that in the input `colNames`.
@@ -595,7 +707,8 @@ func areColsUniqueInTable(b BuildCtx, tableID catid.DescID, columnIDs []catid.Co
 		case *scpb.SecondaryIndex:
 			ret = isIndexUniqueAndCanServeFK(b, &te.Index, columnIDs)
 		case *scpb.UniqueWithoutIndexConstraint:
-			if te.Predicate == nil && descpb.ColumnIDs(te.ColumnIDs).PermutationOf(columnIDs) {
+			if te.Predicate == nil && len(te.ExclusionOperators) == 0 &&
+				descpb.ColumnIDs(te.ColumnIDs).PermutationOf(columnIDs) {
 				ret = true
 			}
 		}
@@ -626,6 +739,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		ifNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		ifNotExists = d.IfNotExists
 	default:
 		return false, errors.AssertionFailedf(
 			"unsupported constraint: %T", t.ConstraintDef)
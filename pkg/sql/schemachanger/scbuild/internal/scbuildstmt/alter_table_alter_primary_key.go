@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -68,6 +69,7 @@ func alterPrimaryKey(
 	}
 
 	panicIfRegionChangeUnderwayOnRBRTable(b, "ALTER PRIMARY KEY", tbl.TableID)
+	panicIfPartialIndexUsesFunctions(b, tbl.TableID)
 	// TODO (xiang): This section contains all fall-back cases and need to
 	// be removed to fully support `ALTER PRIMARY KEY`.
 	fallBackIfShardedIndexExists(b, t, tbl.TableID)
@@ -102,7 +104,8 @@ func alterPrimaryKey(
 	b.LogEventForExistingTarget(inflatedChain.finalSpec.primary)
 
 	// Recreate all secondary indexes.
-	recreateAllSecondaryIndexes(b, t, tbl, inflatedChain.finalSpec.primary, inflatedChain.inter2Spec.primary)
+	recreateAllSecondaryIndexes(b, tbl, inflatedChain.finalSpec.primary, inflatedChain.inter2Spec.primary,
+		nil /* repartitionings */)
 
 	// Drop the rowid column, if applicable.
 	rowidToDrop := getPrimaryIndexDefaultRowIDColumn(b, tbl.TableID, inflatedChain.oldSpec.primary.IndexID)
@@ -212,66 +215,78 @@ func alterPKInPrimaryIndexAndItsTemp(
 		return ret
 	}
 
-	// alterPKInIndex is a helper function that changes columns in index `indexID`
-	// toward `inColumns`.
-	alterPKInIndex := func(indexID catid.IndexID, inColumns []indexColumnSpec) {
-		// Collect all existing index columns.
-		indexKeyCols := getIndexColumns(b.QueryByID(tableID), indexID, scpb.IndexColumn_KEY)
-		indexStoredCols := getIndexColumns(b.QueryByID(tableID), indexID, scpb.IndexColumn_STORED)
-		existingIndexColsByColumnID := make(map[catid.ColumnID]*scpb.IndexColumn)
-		// uncoveredExistingIndexCols are existing columns in the index that are not
-		// mentioned/covered in `inColumns`.
-		uncoveredExistingIndexCols := make(map[catid.ColumnID]bool)
-		for _, existingIndexCol := range append(indexKeyCols, indexStoredCols...) {
-			existingIndexColsByColumnID[existingIndexCol.ColumnID] = existingIndexCol
-			uncoveredExistingIndexCols[existingIndexCol.ColumnID] = true
-		}
+	alterIndexColumns(b, tableID, index.IndexID,
+		generateIndexColumnForNewPK(index.IndexID), isIndexFinal)
+	alterIndexColumns(b, tableID, index.TemporaryIndexID,
+		generateIndexColumnForNewPK(index.TemporaryIndexID), isIndexFinal)
+}
 
-		// Modify existing index columns toward `inColumns`.
-		// Note that `inColumns` might contain index column that does not exist yet,
-		// in which case we add them to the builder state.
-		m := make(map[scpb.IndexColumn_Kind]uint32)
-		for _, inColumn := range inColumns {
-			ordinalInKind := m[inColumn.kind]
-			m[inColumn.kind] = ordinalInKind + 1
-
-			if existingIndexCol, ok := existingIndexColsByColumnID[inColumn.columnID]; ok {
-				existingIndexCol.Kind = inColumn.kind
-				existingIndexCol.OrdinalInKind = ordinalInKind
-				existingIndexCol.Direction = inColumn.direction
-				delete(uncoveredExistingIndexCols, existingIndexCol.ColumnID)
+// alterIndexColumns changes the KEY and STORED columns of the new primary
+// index (or its temporary index) `indexID` toward `inColumns`. `isIndexFinal`
+// is set if the index is the final primary index, in which case the added
+// index columns target PUBLIC. Otherwise, they are TRANSIENT.
+func alterIndexColumns(
+	b BuildCtx,
+	tableID catid.DescID,
+	indexID catid.IndexID,
+	inColumns []indexColumnSpec,
+	isIndexFinal bool,
+) {
+	// Collect all existing index columns.
+	indexKeyCols := getIndexColumns(b.QueryByID(tableID), indexID, scpb.IndexColumn_KEY)
+	indexStoredCols := getIndexColumns(b.QueryByID(tableID), indexID, scpb.IndexColumn_STORED)
+	existingIndexColsByColumnID := make(map[catid.ColumnID]*scpb.IndexColumn)
+	// uncoveredExistingIndexCols are existing columns in the index that are not
+	// mentioned/covered in `inColumns`.
+	uncoveredExistingIndexCols := make(map[catid.ColumnID]bool)
+	for _, existingIndexCol := range append(indexKeyCols, indexStoredCols...) {
+		existingIndexColsByColumnID[existingIndexCol.ColumnID] = existingIndexCol
+		uncoveredExistingIndexCols[existingIndexCol.ColumnID] = true
+	}
+
+	// Modify existing index columns toward `inColumns`.
+	// Note that `inColumns` might contain index column that does not exist yet,
+	// in which case we add them to the builder state.
+	m := make(map[scpb.IndexColumn_Kind]uint32)
+	for _, inColumn := range inColumns {
+		ordinalInKind := m[inColumn.kind]
+		m[inColumn.kind] = ordinalInKind + 1
+
+		if existingIndexCol, ok := existingIndexColsByColumnID[inColumn.columnID]; ok {
+			existingIndexCol.Kind = inColumn.kind
+			existingIndexCol.OrdinalInKind = ordinalInKind
+			existingIndexCol.Direction = inColumn.direction
+			existingIndexCol.Implicit = inColumn.implicit
+			delete(uncoveredExistingIndexCols, existingIndexCol.ColumnID)
+		} else {
+			inIndexCol := &scpb.IndexColumn{
+				TableID:       tableID,
+				IndexID:       indexID,
+				ColumnID:      inColumn.columnID,
+				OrdinalInKind: ordinalInKind,
+				Kind:          inColumn.kind,
+				Direction:     inColumn.direction,
+				Implicit:      inColumn.implicit,
+			}
+			if isIndexFinal {
+				b.Add(inIndexCol)
 			} else {
-				inIndexCol := &scpb.IndexColumn{
-					TableID:       tableID,
-					IndexID:       indexID,
-					ColumnID:      inColumn.columnID,
-					OrdinalInKind: ordinalInKind,
-					Kind:          inColumn.kind,
-					Direction:     inColumn.direction,
-				}
-				if isIndexFinal {
-					b.Add(inIndexCol)
-				} else {
-					b.AddTransient(inIndexCol)
-				}
+				b.AddTransient(inIndexCol)
 			}
 		}
+	}
 
-		// Finally, if there is any existing column that is not mentioned in `inColumns`,
-		// then we need to drop them.
-		// For now, the only case this will happen is the shard column of the old PK.
-		for uncoveredExistingIndexColID := range uncoveredExistingIndexCols {
-			// sanity check: this index column must be the old shard column.
-			if !mustRetrieveColumnTypeElem(b, tableID, uncoveredExistingIndexColID).IsVirtual {
-				panic(errors.AssertionFailedf("programming error: find a physical column %v"+
-					" that existed in the index but is no longer after the primary key change", uncoveredExistingIndexColID))
-			}
-			b.Drop(existingIndexColsByColumnID[uncoveredExistingIndexColID])
+	// Finally, if there is any existing column that is not mentioned in `inColumns`,
+	// then we need to drop them.
+	// For now, the only case this will happen is the shard column of the old PK.
+	for uncoveredExistingIndexColID := range uncoveredExistingIndexCols {
+		// sanity check: this index column must be the old shard column.
+		if !mustRetrieveColumnTypeElem(b, tableID, uncoveredExistingIndexColID).IsVirtual {
+			panic(errors.AssertionFailedf("programming error: find a physical column %v"+
+				" that existed in the index but is no longer after the primary key change", uncoveredExistingIndexColID))
 		}
+		b.Drop(existingIndexColsByColumnID[uncoveredExistingIndexColID])
 	}
-
-	alterPKInIndex(index.IndexID, generateIndexColumnForNewPK(index.IndexID))
-	alterPKInIndex(index.TemporaryIndexID, generateIndexColumnForNewPK(index.TemporaryIndexID))
 }
 
 // checkForEarlyExit asserts several precondition for a
@@ -408,6 +423,20 @@ func fallBackIfPartitionedIndexExists(b BuildCtx, t alterPrimaryKeySpec, tableID
 	})
 }
 
+// panicIfPartialIndexUsesFunctions panics if the predicate of a secondary
+// index uses a user-defined function, since the secondary indexes are rebuilt
+// by a backfill which cannot evaluate it.
+func panicIfPartialIndexUsesFunctions(b BuildCtx, tableID catid.DescID) {
+	tableElts := b.QueryByID(tableID).Filter(notFilter(absentTargetFilter))
+	scpb.ForEachSecondaryIndexPartial(tableElts, func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.SecondaryIndexPartial) {
+		if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(
+			string(e.Expr), tree.IndexPredicateExpr,
+		); err != nil {
+			panic(err)
+		}
+	})
+}
+
 // fallBackIfShardedIndexExists panics with an unimplemented
 // error if there exists sharded indexes on the table.
 func fallBackIfShardedIndexExists(b BuildCtx, t alterPrimaryKeySpec, tableID catid.DescID) {
@@ -620,12 +649,14 @@ func checkIfConstraintNameAlreadyExists(b BuildCtx, tbl *scpb.Table, t alterPrim
 
 // recreateAllSecondaryIndexes recreates all secondary indexes. While the key
 // columns remain the same in the face of a primary key change, the key suffix
-// columns or the stored columns may not.
+// columns or the stored columns may not. The indexes which have an entry in
+// `repartitionings` are recreated with its implicitly partitioned columns and
+// partitioning instead of their own.
 func recreateAllSecondaryIndexes(
 	b BuildCtx,
-	t alterPrimaryKeySpec,
 	tbl *scpb.Table,
 	newPrimaryIndex, sourcePrimaryIndex *scpb.PrimaryIndex,
+	repartitionings map[catid.IndexID]indexRepartitioning,
 ) {
 	publicTableElts := b.QueryByID(tbl.TableID).Filter(publicTargetFilter)
 	// Generate all possible key suffix columns.
@@ -675,16 +706,26 @@ func recreateAllSecondaryIndexes(
 			}
 		}
 
+		repartitioning, isRepartitioned := repartitionings[idx.IndexID]
 		var idxColIDs catalog.TableColSet
 		inColumns := make([]indexColumnSpec, 0, len(out.columns))
 		// Determine which columns end up in the new secondary index.
 		{
 			var largestKeyOrdinal uint32
 			var invertedColumnID catid.ColumnID
-			// First, add all key columns.
+			// First, add all key columns, starting with the new implicitly
+			// partitioned columns if the index is repartitioned.
 			// Also determine the ID of the inverted column, if applicable.
+			for _, colID := range repartitioning.implicitColumnIDs {
+				idxColIDs.Add(colID)
+				inColumns = append(inColumns, indexColumnSpec{
+					columnID: colID,
+					kind:     scpb.IndexColumn_KEY,
+					implicit: true,
+				})
+			}
 			for _, ic := range out.columns {
-				if ic.Kind == scpb.IndexColumn_KEY {
+				if ic.Kind == scpb.IndexColumn_KEY && !(isRepartitioned && ic.Implicit) {
 					idxColIDs.Add(ic.ColumnID)
 					inColumns = append(inColumns, indexColumnSpec{
 						columnID:  ic.ColumnID,
@@ -737,6 +778,13 @@ func recreateAllSecondaryIndexes(
 		}
 		in, temp := makeSwapIndexSpec(b, out, sourcePrimaryIndex.IndexID, inColumns, false /* inUseTempIDs */)
 		in.secondary.RecreateSourceIndexID = out.indexID()
+		if isRepartitioned {
+			if in.partitioning == nil || temp.partitioning == nil {
+				panic(errors.AssertionFailedf("index %q is not partitioned", out.name.Name))
+			}
+			in.partitioning.PartitioningDescriptor = repartitioning.partitioning
+			temp.partitioning.PartitioningDescriptor = *protoutil.Clone(&repartitioning.partitioning).(*catpb.PartitioningDescriptor)
+		}
 		out.apply(b.Drop)
 		in.apply(b.Add)
 		temp.apply(b.AddTransient)
@@ -0,0 +1,353 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// alterTablePartitionByTableChecks determines if the declarative schema
+// changer supports the PARTITION BY clause. Only PARTITION ALL BY is
+// supported, since it is the only one which may rewrite the indexes.
+func alterTablePartitionByTableChecks(
+	t *tree.AlterTablePartitionByTable,
+	_ sessiondatapb.NewSchemaChangerMode,
+	activeVersion clusterversion.ClusterVersion,
+) bool {
+	return t.All && activeVersion.IsActive(clusterversion.V25_1)
+}
+
+// indexRepartitioning is the partitioning of an index under a PARTITION ALL BY
+// clause, along with the columns the index is implicitly partitioned by.
+type indexRepartitioning struct {
+	implicitColumnIDs []catid.ColumnID
+	partitioning      catpb.PartitioningDescriptor
+}
+
+// alterTablePartitionByTable implements ALTER TABLE ... PARTITION ALL BY when
+// it changes the implicitly partitioned columns of the table. The primary
+// index keeps its explicit columns and is rewritten, along with all the
+// secondary indexes, in the same way as for ALTER PRIMARY KEY. The zone configs
+// of the partitions which keep their name are carried over to the new indexes.
+//
+// Changes to the partitioning which keep the implicitly partitioned columns
+// only update metadata, and are left to the legacy schema changer.
+func alterTablePartitionByTable(
+	b BuildCtx,
+	tn *tree.TableName,
+	tbl *scpb.Table,
+	stmt tree.Statement,
+	t *tree.AlterTablePartitionByTable,
+) {
+	if !t.All || t.PartitionBy == nil {
+		panic(scerrors.NotImplementedErrorf(t,
+			"only ALTER TABLE ... PARTITION ALL BY LIST or RANGE is supported"))
+	}
+	if alterTable, ok := stmt.(*tree.AlterTable); ok && len(alterTable.Cmds) > 1 {
+		panic(scerrors.NotImplementedErrorf(t,
+			"PARTITION ALL BY along with other ALTER TABLE commands is not supported"))
+	}
+	if isMultiRegionTable(b, tbl.TableID) {
+		panic(scerrors.NotImplementedErrorf(t,
+			"PARTITION ALL BY on a multi-region table is not supported"))
+	}
+	if !b.SessionData().ImplicitColumnPartitioningEnabled {
+		panic(errors.WithHint(
+			pgerror.New(
+				pgcode.ExperimentalFeature,
+				"PARTITION ALL BY LIST/RANGE is currently experimental",
+			),
+			"to enable, use SET experimental_enable_implicit_column_partitioning = true",
+		))
+	}
+	fallBackIfNotRepartitionable(b, t, tbl.TableID)
+	panicIfPartialIndexUsesFunctions(b, tbl.TableID)
+
+	oldPrimary := mustRetrieveCurrentPrimaryIndexElement(b, tbl.TableID)
+	primaryRepartitioning := repartitionIndex(b, tbl.TableID, &oldPrimary.Index, t.PartitionBy)
+	var oldImplicitColumnIDs []catid.ColumnID
+	for _, ic := range getIndexColumns(b.QueryByID(tbl.TableID), oldPrimary.IndexID, scpb.IndexColumn_KEY) {
+		if ic.Implicit {
+			oldImplicitColumnIDs = append(oldImplicitColumnIDs, ic.ColumnID)
+		}
+	}
+	if slices.Equal(oldImplicitColumnIDs, primaryRepartitioning.implicitColumnIDs) {
+		panic(scerrors.NotImplementedErrorf(t,
+			"PARTITION ALL BY which keeps the implicitly partitioned columns is not supported"))
+	}
+	secondaryRepartitionings := make(map[catid.IndexID]indexRepartitioning)
+	scpb.ForEachSecondaryIndex(b.QueryByID(tbl.TableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex,
+	) {
+		secondaryRepartitionings[idx.IndexID] = repartitionIndex(b, tbl.TableID, &idx.Index, t.PartitionBy)
+	})
+
+	// Rewrite the primary index with the new implicitly partitioned columns,
+	// then recreate all the secondary indexes on top of it.
+	inflatedChain := getInflatedPrimaryIndexChain(b, tbl.TableID)
+	repartitionPrimaryIndexAndItsTemp(b, tbl.TableID, inflatedChain.inter2Spec.primary,
+		primaryRepartitioning, false /* isIndexFinal */)
+	repartitionPrimaryIndexAndItsTemp(b, tbl.TableID, inflatedChain.finalSpec.primary,
+		primaryRepartitioning, true /* isIndexFinal */)
+	b.LogEventForExistingTarget(inflatedChain.finalSpec.primary)
+	recreateAllSecondaryIndexes(b, tbl, inflatedChain.finalSpec.primary, inflatedChain.inter2Spec.primary,
+		secondaryRepartitionings)
+
+	// The subzones of the new indexes are keyed by their IDs, so the new
+	// primary index needs its actual ID before they are carried over.
+	maybeDropRedundantPrimaryIndexes(b, tbl.TableID)
+	maybeRewriteTempIDsInPrimaryIndexes(b, tbl.TableID)
+	chain := getPrimaryIndexChain(b, tbl.TableID)
+	carryOverSubzones(b, tbl.TableID, chain.oldSpec.primary.IndexID, chain.finalSpec.primary.IndexID)
+	scpb.ForEachSecondaryIndex(b.QueryByID(tbl.TableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex,
+	) {
+		if _, ok := secondaryRepartitionings[idx.RecreateSourceIndexID]; ok {
+			carryOverSubzones(b, tbl.TableID, idx.RecreateSourceIndexID, idx.IndexID)
+		}
+	})
+}
+
+// fallBackIfNotRepartitionable panics with an unimplemented error if the
+// indexes of the table can't be rewritten by PARTITION ALL BY, which is the
+// case if the table isn't already partitioned with PARTITION ALL BY, if any
+// index is sharded, or if any index is being added or dropped.
+func fallBackIfNotRepartitionable(
+	b BuildCtx, t *tree.AlterTablePartitionByTable, tableID catid.DescID,
+) {
+	tableElts := b.QueryByID(tableID)
+	if tableElts.FilterTablePartitioning().IsEmpty() {
+		panic(scerrors.NotImplementedErrorf(t,
+			"PARTITION ALL BY on a table which is not partitioned with PARTITION ALL BY is not supported"))
+	}
+	checkIndex := func(idx *scpb.Index) {
+		if idx.Sharding != nil {
+			panic(scerrors.NotImplementedErrorf(t,
+				"PARTITION ALL BY on a table with sharded indexes is not supported"))
+		}
+	}
+	tableElts.FilterPrimaryIndex().ForEach(func(
+		current scpb.Status, target scpb.TargetStatus, e *scpb.PrimaryIndex,
+	) {
+		if current != target.Status() {
+			panic(scerrors.NotImplementedErrorf(t,
+				"PARTITION ALL BY while the primary key is being changed is not supported"))
+		}
+		checkIndex(&e.Index)
+	})
+	tableElts.FilterSecondaryIndex().ForEach(func(
+		current scpb.Status, target scpb.TargetStatus, e *scpb.SecondaryIndex,
+	) {
+		if current != target.Status() {
+			panic(scerrors.NotImplementedErrorf(t,
+				"PARTITION ALL BY while an index is being added or dropped is not supported"))
+		}
+		checkIndex(&e.Index)
+	})
+	if !tableElts.FilterTemporaryIndex().IsEmpty() {
+		panic(scerrors.NotImplementedErrorf(t,
+			"PARTITION ALL BY while an index is being added or dropped is not supported"))
+	}
+}
+
+// repartitionIndex computes the partitioning of the index under the PARTITION
+// ALL BY clause, along with its new implicitly partitioned columns, from the
+// explicit key columns of the index.
+func repartitionIndex(
+	b BuildCtx, tableID catid.DescID, idx *scpb.Index, partBy *tree.PartitionBy,
+) (ret indexRepartitioning) {
+	var explicitColumns []*scpb.IndexColumn
+	var explicitColumnIDs catalog.TableColSet
+	for _, ic := range getIndexColumns(b.QueryByID(tableID), idx.IndexID, scpb.IndexColumn_KEY) {
+		if !ic.Implicit {
+			explicitColumns = append(explicitColumns, ic)
+			explicitColumnIDs.Add(ic.ColumnID)
+		}
+	}
+	// The partitioning is computed as if the index wasn't implicitly
+	// partitioned yet, which is the case of an index ID without an
+	// IndexPartitioning element.
+	unpartitioned := *idx
+	unpartitioned.IndexID = 0
+	indexName := mustRetrieveIndexNameElem(b, tableID, idx.IndexID).Name
+	ret.partitioning = b.IndexPartitioningDescriptor(indexName, &unpartitioned, explicitColumns, partBy)
+	for _, field := range partBy.Fields[:ret.partitioning.NumImplicitColumns] {
+		colID := getColumnIDFromColumnName(b, tableID, field, true /* required */)
+		if explicitColumnIDs.Contains(colID) {
+			panic(scerrors.NotImplementedErrorf(partBy,
+				"PARTITION ALL BY on column %q which is also an explicit column of index %q is not supported",
+				field, indexName))
+		}
+		ret.implicitColumnIDs = append(ret.implicitColumnIDs, colID)
+	}
+	return ret
+}
+
+// repartitionPrimaryIndexAndItsTemp changes the key columns of the new primary
+// index `index` and of its temporary index to the new implicitly partitioned
+// columns followed by the explicit key columns, and sets their partitioning.
+// The implicitly partitioned columns which are no longer part of the key are
+// stored instead.
+func repartitionPrimaryIndexAndItsTemp(
+	b BuildCtx,
+	tableID catid.DescID,
+	index *scpb.PrimaryIndex,
+	r indexRepartitioning,
+	isIndexFinal bool,
+) {
+	generateIndexColumns := func(indexID catid.IndexID) (ret []indexColumnSpec) {
+		var keyColIDs catalog.TableColSet
+		for _, colID := range r.implicitColumnIDs {
+			ret = append(ret, indexColumnSpec{
+				columnID: colID,
+				kind:     scpb.IndexColumn_KEY,
+				implicit: true,
+			})
+			keyColIDs.Add(colID)
+		}
+		for _, ic := range getIndexColumns(b.QueryByID(tableID), indexID, scpb.IndexColumn_KEY) {
+			if ic.Implicit {
+				continue
+			}
+			ret = append(ret, indexColumnSpec{
+				columnID:  ic.ColumnID,
+				kind:      scpb.IndexColumn_KEY,
+				direction: ic.Direction,
+			})
+			keyColIDs.Add(ic.ColumnID)
+		}
+		// All other columns in this index will be STORED columns, excluding
+		// virtual columns and system columns.
+		for _, colID := range getSortedColumnIDsInIndex(b, tableID, indexID) {
+			if keyColIDs.Contains(colID) ||
+				mustRetrieveColumnTypeElem(b, tableID, colID).IsVirtual ||
+				colinfo.IsColIDSystemColumn(colID) {
+				continue
+			}
+			ret = append(ret, indexColumnSpec{
+				columnID: colID,
+				kind:     scpb.IndexColumn_STORED,
+			})
+		}
+		return ret
+	}
+
+	for _, indexID := range []catid.IndexID{index.IndexID, index.TemporaryIndexID} {
+		alterIndexColumns(b, tableID, indexID, generateIndexColumns(indexID), isIndexFinal)
+		partitioning := b.QueryByID(tableID).FilterIndexPartitioning().
+			Filter(func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.IndexPartitioning) bool {
+				return e.IndexID == indexID
+			}).MustGetZeroOrOneElement()
+		if partitioning == nil {
+			panic(errors.AssertionFailedf("index %d of table %d is not partitioned", indexID, tableID))
+		}
+		partitioning.PartitioningDescriptor = *protoutil.Clone(&r.partitioning).(*catpb.PartitioningDescriptor)
+	}
+}
+
+// carryOverSubzones adds the zone configs of the index `oldIndexID` and of its
+// partitions to the index `newIndexID` which replaces it, and drops those of
+// the old index. The zone configs of the partitions which the new index
+// doesn't have are not carried over.
+func carryOverSubzones(b BuildCtx, tableID catid.DescID, oldIndexID, newIndexID catid.IndexID) {
+	tableElts := b.QueryByID(tableID)
+	var indexZoneConfig *scpb.IndexZoneConfig
+	tableElts.FilterIndexZoneConfig().NotToAbsent().ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.IndexZoneConfig,
+	) {
+		if e.IndexID == oldIndexID && (indexZoneConfig == nil || e.SeqNum > indexZoneConfig.SeqNum) {
+			indexZoneConfig = e
+		}
+	})
+	newPartitioning := mustRetrievePartitioningFromIndexPartitioning(b, tableID, newIndexID)
+	partitionZoneConfigs := make(map[string]*scpb.PartitionZoneConfig)
+	var partitionNames []string
+	tableElts.FilterPartitionZoneConfig().NotToAbsent().ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.PartitionZoneConfig,
+	) {
+		if e.IndexID != oldIndexID || newPartitioning.FindPartitionByName(e.PartitionName) == nil {
+			return
+		}
+		if prev, ok := partitionZoneConfigs[e.PartitionName]; !ok {
+			partitionNames = append(partitionNames, e.PartitionName)
+		} else if prev.SeqNum > e.SeqNum {
+			return
+		}
+		partitionZoneConfigs[e.PartitionName] = e
+	})
+
+	// The zone configs of the old index are removed along with its data.
+	tableElts.FilterIndexZoneConfig().NotToAbsent().ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.IndexZoneConfig,
+	) {
+		if e.IndexID == oldIndexID {
+			b.Drop(e)
+		}
+	})
+	tableElts.FilterPartitionZoneConfig().NotToAbsent().ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.PartitionZoneConfig,
+	) {
+		if e.IndexID == oldIndexID {
+			b.Drop(e)
+		}
+	})
+
+	var subzones []zonepb.Subzone
+	if indexZoneConfig != nil {
+		subzones = append(subzones, *protoutil.Clone(&indexZoneConfig.Subzone).(*zonepb.Subzone))
+	}
+	for _, name := range partitionNames {
+		subzones = append(subzones, *protoutil.Clone(&partitionZoneConfigs[name].Subzone).(*zonepb.Subzone))
+	}
+	if len(subzones) == 0 {
+		return
+	}
+	for i := range subzones {
+		subzones[i].IndexID = uint32(newIndexID)
+	}
+	subzoneSpans, err := generateIndexSubzoneSpans(b, tableID, newIndexID, subzones)
+	if err != nil {
+		panic(err)
+	}
+	idxToSpans := getSubzoneSpansWithIdx(len(subzones), subzoneSpans)
+	for i, subzone := range subzones {
+		if len(subzone.PartitionName) == 0 {
+			b.Add(&scpb.IndexZoneConfig{
+				TableID:      tableID,
+				IndexID:      newIndexID,
+				Subzone:      subzone,
+				SubzoneSpans: idxToSpans[int32(i)],
+				SeqNum:       1,
+				OldIdxRef:    -1,
+			})
+		} else {
+			b.Add(&scpb.PartitionZoneConfig{
+				TableID:       tableID,
+				IndexID:       newIndexID,
+				PartitionName: subzone.PartitionName,
+				Subzone:       subzone,
+				SubzoneSpans:  idxToSpans[int32(i)],
+				SeqNum:        1,
+				OldIdxRef:     -1,
+			})
+		}
+	}
+}
@@ -359,7 +359,12 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
-
+		case types.RangeFamily:
+			switch columnNode.OpClass {
+			case "range_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
 		scpb.ForEachIndexColumn(relationElts, func(current scpb.Status, target scpb.TargetStatus, e *scpb.IndexColumn) {
@@ -913,6 +918,11 @@ func maybeAddIndexPredicate(b BuildCtx, n *tree.CreateIndex, idxSpec *indexSpec)
 	}
 	expr := b.PartialIndexPredicateExpression(idxSpec.secondary.TableID, n.Predicate)
 	idxSpec.secondary.EmbeddedExpr = b.WrapExpression(idxSpec.secondary.TableID, expr)
+	if err := schemaexpr.ValidateNoUDFsInBackfilledExpr(
+		string(idxSpec.secondary.Expr), tree.IndexPredicateExpr,
+	); err != nil {
+		panic(err)
+	}
 	b.IncrementSchemaChangeIndexCounter("partial")
 	if n.Inverted {
 		b.IncrementSchemaChangeIndexCounter("partial_inverted")
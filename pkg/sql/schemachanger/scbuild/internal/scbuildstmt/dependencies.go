@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
 )
 
@@ -83,6 +84,7 @@ type BuilderState interface {
 	NameResolver
 	PrivilegeChecker
 	TableHelpers
+	TypeHelpers
 	FunctionHelpers
 	SchemaHelpers
 
@@ -316,6 +318,17 @@ type TableHelpers interface {
 	IsTableEmpty(tbl *scpb.Table) bool
 }
 
+// TypeHelpers has methods useful for creating new type elements.
+type TypeHelpers interface {
+
+	// DomainBaseType returns the type over which the domain is defined.
+	DomainBaseType(typeID catid.DescID) *types.T
+
+	// NextDomainConstraintID returns the ID that should be used for any new
+	// constraint added to this domain.
+	NextDomainConstraintID(typeID catid.DescID) catid.ConstraintID
+}
+
 type FunctionHelpers interface {
 	BuildReferenceProvider(stmt tree.Statement) ReferenceProvider
 	WrapFunctionBody(fnID descpb.ID, bodyStr string, lang catpb.Function_Language,
@@ -20,6 +20,9 @@ import (
 // DropType implements DROP TYPE.
 func DropType(b BuildCtx, n *tree.DropType) {
 	if n.DropBehavior == tree.DropCascade {
+		if n.Domain {
+			panic(scerrors.NotImplementedErrorf(n, "DROP DOMAIN CASCADE is not yet supported"))
+		}
 		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
 	}
 	var toCheckBackrefs []catid.DescID
@@ -31,7 +34,14 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		})
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
-		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
+		_, _, domain := scpb.FindDomainType(elts)
+		if n.Domain && domain == nil && !elts.IsEmpty() {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name.Object()))
+		}
+		if domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
+		} else if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
 			typ = enum
@@ -22,6 +22,7 @@ func TestSupportedStatements(t *testing.T) {
 	var multiTagStmts = map[reflect.Type][]tree.Statement{
 		reflect.TypeOf((*tree.DropRoutine)(nil)):   {&tree.DropRoutine{}, &tree.DropRoutine{Procedure: true}},
 		reflect.TypeOf((*tree.CreateRoutine)(nil)): {&tree.CreateRoutine{}, &tree.CreateRoutine{IsProcedure: true}},
+		reflect.TypeOf((*tree.DropType)(nil)):      {&tree.DropType{}, &tree.DropType{Domain: true}},
 	}
 
 	sv := &settings.Values{}
@@ -805,6 +805,66 @@ func generateSubzoneSpans(
 		return nil, err
 	}
 
+	return subzoneSpansFromCoverings(
+		b, tableID, partitionCoverings, indexCovering, subzoneIndexByIndexID, subzoneIndexByPartition,
+	), nil
+}
+
+// generateIndexSubzoneSpans is like generateSubzoneSpans, but only generates
+// the spans of the given index, all of whose subzones are in `subzones`. Unlike
+// generateSubzoneSpans, it can be used for an index whose partitions share
+// their names with the partitions of another index of the table, like an index
+// which replaces an existing one.
+func generateIndexSubzoneSpans(
+	b BuildCtx, tableID catid.DescID, indexID catid.IndexID, subzones []zonepb.Subzone,
+) ([]zonepb.SubzoneSpan, error) {
+	subzoneIndexByIndexID := make(map[descpb.IndexID]int32)
+	subzoneIndexByPartition := make(map[string]int32)
+	for i, subzone := range subzones {
+		if descpb.IndexID(subzone.IndexID) != indexID {
+			return nil, errors.AssertionFailedf(
+				"subzone of index %d is not a subzone of index %d", subzone.IndexID, indexID)
+		}
+		if len(subzone.PartitionName) > 0 {
+			subzoneIndexByPartition[subzone.PartitionName] = int32(i)
+		} else {
+			subzoneIndexByIndexID[indexID] = int32(i)
+		}
+	}
+
+	var indexCovering covering.Covering
+	if _, indexSubzoneExists := subzoneIndexByIndexID[indexID]; indexSubzoneExists {
+		prefix := roachpb.Key(rowenc.MakeIndexKeyPrefix(b.Codec(), tableID, indexID))
+		indexCovering = covering.Covering{{
+			Start: prefix, End: prefix.PrefixEnd(),
+			Payload: zonepb.Subzone{IndexID: uint32(indexID)},
+		}}
+	}
+	var emptyPrefix []tree.Datum
+	index := mustRetrieveIndexColumnElements(b, tableID, indexID)
+	partitioning := mustRetrievePartitioningFromIndexPartitioning(b, tableID, indexID)
+	partitionCoverings, err := indexCoveringsForPartitioning(
+		b, &tree.DatumAlloc{}, tableID, indexID, index, partitioning, subzoneIndexByPartition, emptyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return subzoneSpansFromCoverings(
+		b, tableID, partitionCoverings, indexCovering, subzoneIndexByIndexID, subzoneIndexByPartition,
+	), nil
+}
+
+// subzoneSpansFromCoverings applies precedence to the partition and index
+// coverings and converts the result into subzone spans, in the format required
+// by `system.zones`. The subzone index of each span is looked up in
+// subzoneIndexByIndexID or subzoneIndexByPartition.
+func subzoneSpansFromCoverings(
+	b BuildCtx,
+	tableID catid.DescID,
+	partitionCoverings []covering.Covering,
+	indexCovering covering.Covering,
+	subzoneIndexByIndexID map[descpb.IndexID]int32,
+	subzoneIndexByPartition map[string]int32,
+) []zonepb.SubzoneSpan {
 	// OverlapCoveringMerge returns the payloads for any coverings that overlap
 	// in the same order they were input. So, we require that they be ordered
 	// with highest precedence first, so the first payload of each range is the
@@ -839,7 +899,7 @@ func generateSubzoneSpans(
 		}
 		subzoneSpans = append(subzoneSpans, subzoneSpan)
 	}
-	return subzoneSpans, nil
+	return subzoneSpans
 }
 
 // indexCoveringsForPartitioning returns span coverings representing the
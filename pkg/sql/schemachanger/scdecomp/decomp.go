@@ -213,6 +213,29 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      domain.GetID(),
+			ArrayTypeID: domain.GetArrayTypeID(),
+		})
+		for _, c := range domain.DomainConstraints() {
+			expr, err := w.newExpression(c.Expr)
+			if err != nil {
+				panic(errors.NewAssertionErrorWithWrappedErrf(err, "constraint %q in domain %q (%d)",
+					c.Name, typ.GetName(), typ.GetID()))
+			}
+			w.ev(domainConstraintStatus(typ, c.Validity), &scpb.DomainConstraint{
+				TypeID:       typ.GetID(),
+				ConstraintID: c.ConstraintID,
+				Name:         c.Name,
+				Expression:   *expr,
+			})
+		}
+		if domain.DomainNotNull() {
+			w.ev(domainConstraintStatus(typ, typ.TypeDesc().Domain.NotNullValidity), &scpb.DomainNotNull{
+				TypeID: typ.GetID(),
+			})
+		}
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -233,6 +256,21 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 	}
 }
 
+// domainConstraintStatus returns the current status of a domain's CHECK or
+// NOT NULL constraint given its validity.
+func domainConstraintStatus(
+	typ catalog.TypeDescriptor, validity descpb.ConstraintValidity,
+) scpb.Status {
+	switch validity {
+	case descpb.ConstraintValidity_Validating:
+		return scpb.Status_WRITE_ONLY
+	case descpb.ConstraintValidity_Dropping:
+		return scpb.Status_VALIDATED
+	default:
+		return descriptorStatus(typ)
+	}
+}
+
 func GetSequenceOptions(
 	sequenceID descpb.ID, opts *descpb.TableDescriptor_SequenceOpts,
 ) []*scpb.SequenceOption {
@@ -739,20 +777,29 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 				c.GetName(), tbl.GetName(), tbl.GetID()))
 		}
 	}
+	// The operators of an exclusion constraint correspond to its columns in
+	// their declared order.
+	columnIDs := c.CollectKeyColumnIDs().Ordered()
+	if c.IsExclusion() {
+		columnIDs = append([]descpb.ColumnID(nil), c.UniqueWithoutIndexDesc().ColumnIDs...)
+	}
+	exclusionOps := c.UniqueWithoutIndexDesc().ExclusionOperators
 	if c.IsConstraintUnvalidated() {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          columnIDs,
+			Predicate:          expr,
+			ExclusionOperators: exclusionOps,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraint{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          columnIDs,
+			Predicate:          expr,
+			ExclusionOperators: exclusionOps,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	}
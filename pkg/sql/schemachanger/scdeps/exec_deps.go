@@ -283,11 +283,23 @@ func (d *txnDeps) UpdateSubzoneConfig(
 	if idxRefToDelete == -1 {
 		idxRefToDelete = zc.GetSubzoneIndex(subzone.IndexID, subzone.PartitionName)
 	}
+	isNewSubzone := zc.GetSubzoneIndex(subzone.IndexID, subzone.PartitionName) == -1
 
 	// Update the subzone in the zone config.
 	zc.SetSubzone(subzone)
 	// Update the subzone spans.
 	subzoneSpansToWrite := subzoneSpans
+	// A new subzone is appended to the zone config, so its position is only
+	// known here when several subzones are added by the same schema change.
+	// All the given spans belong to this subzone, so point them at it.
+	if isNewSubzone {
+		subzoneIdx := zc.GetSubzoneIndex(subzone.IndexID, subzone.PartitionName)
+		subzoneSpansToWrite = make([]zonepb.SubzoneSpan, len(subzoneSpans))
+		for i, s := range subzoneSpans {
+			s.SubzoneIndex = subzoneIdx
+			subzoneSpansToWrite[i] = s
+		}
+	}
 	// If there are subzone spans that currently exist, merge those with the new
 	// spans we are updating. Otherwise, the zone config's set of subzone spans
 	// will be our input subzoneSpans.
@@ -60,6 +60,16 @@ type ValidateConstraintFn func(
 	execOverride sessiondata.InternalExecutorOverride,
 ) error
 
+// ValidateDomainConstraintFn callback function for validating the values
+// of a domain type.
+type ValidateDomainConstraintFn func(
+	ctx context.Context,
+	domain catalog.TypeDescriptor,
+	checkExpr string,
+	runHistoricalTxn descs.HistoricalInternalExecTxnRunner,
+	execOverride sessiondata.InternalExecutorOverride,
+) error
+
 // NewFakeSessionDataFn callback function used to create session data
 // for the internal executor.
 type NewFakeSessionDataFn func(ctx context.Context, settings *cluster.Settings, opName redact.SafeString) *sessiondata.SessionData
@@ -72,6 +82,7 @@ type validator struct {
 	validateForwardIndexes     ValidateForwardIndexesFn
 	validateInvertedIndexes    ValidateInvertedIndexesFn
 	validateConstraint         ValidateConstraintFn
+	validateDomainConstraint   ValidateDomainConstraintFn
 	newFakeSessionData         NewFakeSessionDataFn
 	protectedTimestampProvider scexec.ProtectedTimestampManager
 }
@@ -120,6 +131,17 @@ func (vd validator) ValidateConstraint(
 		vd.makeHistoricalInternalExecTxnRunner(), override)
 }
 
+// ValidateDomainConstraint checks that the values stored in table columns of
+// the domain type satisfy the constraint.
+func (vd validator) ValidateDomainConstraint(
+	ctx context.Context,
+	domain catalog.TypeDescriptor,
+	checkExpr string,
+	override sessiondata.InternalExecutorOverride,
+) error {
+	return vd.validateDomainConstraint(ctx, domain, checkExpr, vd.makeHistoricalInternalExecTxnRunner(), override)
+}
+
 // makeHistoricalInternalExecTxnRunner creates a new transaction runner which
 // always runs at the same time and that time is the current time as of when
 // this constructor was called.
@@ -148,6 +170,7 @@ func NewValidator(
 	validateForwardIndexes ValidateForwardIndexesFn,
 	validateInvertedIndexes ValidateInvertedIndexesFn,
 	validateCheckConstraint ValidateConstraintFn,
+	validateDomainConstraint ValidateDomainConstraintFn,
 	newFakeSessionData NewFakeSessionDataFn,
 ) scexec.Validator {
 	return validator{
@@ -158,6 +181,7 @@ func NewValidator(
 		validateForwardIndexes:     validateForwardIndexes,
 		validateInvertedIndexes:    validateInvertedIndexes,
 		validateConstraint:         validateCheckConstraint,
+		validateDomainConstraint:   validateDomainConstraint,
 		newFakeSessionData:         newFakeSessionData,
 		protectedTimestampProvider: protectedTimestampProvider,
 	}
@@ -235,6 +235,16 @@ type Validator interface {
 		indexIDForValidation descpb.IndexID,
 		override sessiondata.InternalExecutorOverride,
 	) error
+
+	// ValidateDomainConstraint checks that the values stored in table columns
+	// of the domain type satisfy checkExpr. If checkExpr is empty, the values
+	// are checked to not be NULL instead.
+	ValidateDomainConstraint(
+		ctx context.Context,
+		domain catalog.TypeDescriptor,
+		checkExpr string,
+		override sessiondata.InternalExecutorOverride,
+	) error
 }
 
 // IndexSpanSplitter can try to split an index span in the current transaction
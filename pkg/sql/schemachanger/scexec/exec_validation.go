@@ -100,6 +100,66 @@ func executeValidateColumnNotNull(
 	return nil
 }
 
+func executeValidateDomainConstraint(
+	ctx context.Context, deps Dependencies, op *scop.ValidateDomainConstraint,
+) error {
+	domain, err := mustReadDomain(ctx, deps, op.TypeID)
+	if err != nil {
+		return err
+	}
+	var checkExpr string
+	for _, c := range domain.AsDomainTypeDescriptor().DomainConstraints() {
+		if c.ConstraintID == op.ConstraintID {
+			checkExpr = c.Expr
+			break
+		}
+	}
+	if checkExpr == "" {
+		return errors.AssertionFailedf("failed to find constraint %d in domain %q (%d)",
+			op.ConstraintID, domain.GetName(), domain.GetID())
+	}
+
+	// Execute the validation operation as a node user.
+	execOverride := sessiondata.NodeUserSessionDataOverride
+	err = deps.Validator().ValidateDomainConstraint(ctx, domain, checkExpr, execOverride)
+	if err != nil {
+		return scerrors.SchemaChangerUserError(err)
+	}
+	return nil
+}
+
+func executeValidateDomainNotNull(
+	ctx context.Context, deps Dependencies, op *scop.ValidateDomainNotNull,
+) error {
+	domain, err := mustReadDomain(ctx, deps, op.TypeID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the validation operation as a node user.
+	execOverride := sessiondata.NodeUserSessionDataOverride
+	err = deps.Validator().ValidateDomainConstraint(ctx, domain, "" /* checkExpr */, execOverride)
+	if err != nil {
+		return scerrors.SchemaChangerUserError(err)
+	}
+	return nil
+}
+
+func mustReadDomain(
+	ctx context.Context, deps Dependencies, id descpb.ID,
+) (catalog.TypeDescriptor, error) {
+	descs, err := deps.Catalog().MustReadImmutableDescriptors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	desc := descs[0]
+	typ, ok := desc.(catalog.TypeDescriptor)
+	if !ok || typ.AsDomainTypeDescriptor() == nil {
+		return nil, catalog.WrapTypeDescRefErr(desc.GetID(), catalog.NewDescriptorTypeError(desc))
+	}
+	return typ, nil
+}
+
 func executeValidationOps(ctx context.Context, deps Dependencies, ops []scop.Op) (err error) {
 	for _, op := range ops {
 		if err = executeValidationOp(ctx, deps, op); err != nil {
@@ -132,6 +192,20 @@ func executeValidationOp(ctx context.Context, deps Dependencies, op scop.Op) (er
 			}
 			return err
 		}
+	case *scop.ValidateDomainConstraint:
+		if err = executeValidateDomainConstraint(ctx, deps, op); err != nil {
+			if !scerrors.HasSchemaChangerUserError(err) {
+				return errors.Wrapf(err, "%T: %v", op, op)
+			}
+			return err
+		}
+	case *scop.ValidateDomainNotNull:
+		if err = executeValidateDomainNotNull(ctx, deps, op); err != nil {
+			if !scerrors.HasSchemaChangerUserError(err) {
+				return errors.Wrapf(err, "%T: %v", op, op)
+			}
+			return err
+		}
 
 	default:
 		panic("unimplemented")
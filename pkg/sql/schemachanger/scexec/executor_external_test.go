@@ -500,6 +500,15 @@ func (noopValidator) ValidateConstraint(
 	return nil
 }
 
+func (noopValidator) ValidateDomainConstraint(
+	ctx context.Context,
+	domain catalog.TypeDescriptor,
+	checkExpr string,
+	override sessiondata.InternalExecutorOverride,
+) error {
+	return nil
+}
+
 type noopStatsReferesher struct{}
 
 var _ scexec.StatsRefresher = noopStatsReferesher{}
@@ -514,12 +514,13 @@ func (i *immediateVisitor) AddUniqueWithoutIndexConstraint(
 	}
 
 	uwi := &descpb.UniqueWithoutIndexConstraint{
-		TableID:      op.TableID,
-		ColumnIDs:    op.ColumnIDs,
-		Name:         tabledesc.ConstraintNamePlaceholder(op.ConstraintID),
-		Validity:     op.Validity,
-		ConstraintID: op.ConstraintID,
-		Predicate:    string(op.PartialExpr),
+		TableID:            op.TableID,
+		ColumnIDs:          op.ColumnIDs,
+		Name:               tabledesc.ConstraintNamePlaceholder(op.ConstraintID),
+		Validity:           op.Validity,
+		ConstraintID:       op.ConstraintID,
+		Predicate:          string(op.PartialExpr),
+		ExclusionOperators: op.ExclusionOperators,
 	}
 	if op.Validity == descpb.ConstraintValidity_Unvalidated {
 		// Unvalidated constraint doesn't need to transition through an intermediate
@@ -0,0 +1,148 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) AddDomainConstraint(
+	ctx context.Context, op scop.AddDomainConstraint,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	domain := typ.Domain
+	if op.ConstraintID >= domain.NextConstraintID {
+		domain.NextConstraintID = op.ConstraintID + 1
+	}
+	domain.Constraints = append(domain.Constraints, descpb.TypeDescriptor_Domain_Constraint{
+		ConstraintID: op.ConstraintID,
+		Name:         op.Name,
+		Expr:         string(op.CheckExpr),
+		Validity:     op.Validity,
+	})
+	return nil
+}
+
+func (i *immediateVisitor) MakeValidatedDomainConstraintPublic(
+	ctx context.Context, op scop.MakeValidatedDomainConstraintPublic,
+) error {
+	return i.setDomainConstraintValidity(ctx, op.TypeID, op.ConstraintID, descpb.ConstraintValidity_Validated)
+}
+
+func (i *immediateVisitor) MakePublicDomainConstraintValidated(
+	ctx context.Context, op scop.MakePublicDomainConstraintValidated,
+) error {
+	return i.setDomainConstraintValidity(ctx, op.TypeID, op.ConstraintID, descpb.ConstraintValidity_Dropping)
+}
+
+func (i *immediateVisitor) RemoveDomainConstraint(
+	ctx context.Context, op scop.RemoveDomainConstraint,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	constraints := typ.Domain.Constraints
+	for idx := range constraints {
+		if constraints[idx].ConstraintID == op.ConstraintID {
+			typ.Domain.Constraints = append(constraints[:idx:idx], constraints[idx+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) MakeAbsentDomainNotNullWriteOnly(
+	ctx context.Context, op scop.MakeAbsentDomainNotNullWriteOnly,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	typ.Domain.NotNull = true
+	typ.Domain.NotNullValidity = descpb.ConstraintValidity_Validating
+	return nil
+}
+
+func (i *immediateVisitor) MakeValidatedDomainNotNullPublic(
+	ctx context.Context, op scop.MakeValidatedDomainNotNullPublic,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	if !typ.Domain.NotNull {
+		return errors.AssertionFailedf("domain %q (%d) does not have a NOT NULL constraint",
+			typ.GetName(), typ.GetID())
+	}
+	typ.Domain.NotNullValidity = descpb.ConstraintValidity_Validated
+	return nil
+}
+
+func (i *immediateVisitor) MakePublicDomainNotNullValidated(
+	ctx context.Context, op scop.MakePublicDomainNotNullValidated,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	typ.Domain.NotNullValidity = descpb.ConstraintValidity_Dropping
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainNotNull(
+	ctx context.Context, op scop.RemoveDomainNotNull,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	typ.Domain.NotNull = false
+	typ.Domain.NotNullValidity = descpb.ConstraintValidity_Validated
+	return nil
+}
+
+// checkOutDomain checks out the mutable type descriptor of a domain.
+func (i *immediateVisitor) checkOutDomain(
+	ctx context.Context, id descpb.ID,
+) (*typedesc.Mutable, error) {
+	typ, err := i.checkOutType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Domain == nil {
+		return nil, errors.AssertionFailedf("type %q (%d) is not a domain", typ.GetName(), typ.GetID())
+	}
+	return typ, nil
+}
+
+func (i *immediateVisitor) setDomainConstraintValidity(
+	ctx context.Context,
+	typeID descpb.ID,
+	constraintID descpb.ConstraintID,
+	validity descpb.ConstraintValidity,
+) error {
+	typ, err := i.checkOutDomain(ctx, typeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	for idx := range typ.Domain.Constraints {
+		if c := &typ.Domain.Constraints[idx]; c.ConstraintID == constraintID {
+			c.Validity = validity
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("failed to find constraint %d in domain %q (%d)",
+		constraintID, typ.GetName(), typ.GetID())
+}
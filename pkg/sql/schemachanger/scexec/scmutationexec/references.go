@@ -389,6 +389,34 @@ func (i *immediateVisitor) RemoveTableColumnBackReferencesInFunctions(
 	return nil
 }
 
+func (i *immediateVisitor) AddTableIndexBackReferencesInFunctions(
+	ctx context.Context, op scop.AddTableIndexBackReferencesInFunctions,
+) error {
+	for _, id := range op.FunctionIDs {
+		fnDesc, err := i.checkOutFunction(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fnDesc.AddIndexReference(op.BackReferencedTableID, op.BackReferencedIndexID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTableIndexBackReferencesInFunctions(
+	ctx context.Context, op scop.RemoveTableIndexBackReferencesInFunctions,
+) error {
+	for _, id := range op.FunctionIDs {
+		fnDesc, err := i.checkOutFunction(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveIndexReference(op.BackReferencedTableID, op.BackReferencedIndexID)
+	}
+	return nil
+}
+
 func (i *immediateVisitor) AddTriggerBackReferencesInRoutines(
 	ctx context.Context, op scop.AddTriggerBackReferencesInRoutines,
 ) error {
@@ -692,6 +720,9 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 			if tree.IsInParamClass(class) {
 				ol.ArgTypes = append(ol.ArgTypes, p.Type)
 			}
+			if class == tree.RoutineParamVariadic {
+				ol.Variadic = true
+			}
 			if class == tree.RoutineParamOut {
 				ol.OutParamOrdinals = append(ol.OutParamOrdinals, int32(pIdx))
 				ol.OutParamTypes = append(ol.OutParamTypes, p.Type)
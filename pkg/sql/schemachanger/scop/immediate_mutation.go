@@ -436,6 +436,9 @@ type AddUniqueWithoutIndexConstraint struct {
 	ColumnIDs    []descpb.ColumnID
 	PartialExpr  catpb.Expression
 	Validity     descpb.ConstraintValidity
+	// ExclusionOperators, if non-empty, makes the constraint an exclusion
+	// constraint.
+	ExclusionOperators []string
 }
 
 // MakeValidatedUniqueWithoutIndexConstraintPublic moves a new, validated unique_without_index
@@ -674,6 +677,24 @@ type RemoveTableColumnBackReferencesInFunctions struct {
 	FunctionIDs            []descpb.ID
 }
 
+// AddTableIndexBackReferencesInFunctions adds back-references to an index
+// from functions referenced by its partial predicate.
+type AddTableIndexBackReferencesInFunctions struct {
+	immediateMutationOp
+	BackReferencedTableID descpb.ID
+	BackReferencedIndexID descpb.IndexID
+	FunctionIDs           []descpb.ID
+}
+
+// RemoveTableIndexBackReferencesInFunctions removes back-references to an
+// index from functions referenced by its partial predicate.
+type RemoveTableIndexBackReferencesInFunctions struct {
+	immediateMutationOp
+	BackReferencedTableID descpb.ID
+	BackReferencedIndexID descpb.IndexID
+	FunctionIDs           []descpb.ID
+}
+
 // AddTriggerBackReferencesInRoutines adds back references to a trigger from
 // referenced functions.
 type AddTriggerBackReferencesInRoutines struct {
@@ -1059,3 +1080,63 @@ type AddPartitionZoneConfig struct {
 	SubzoneSpans         []zonepb.SubzoneSpan
 	SubzoneIndexToDelete int32
 }
+
+// AddDomainConstraint adds a CHECK constraint to a domain.
+type AddDomainConstraint struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+	Name         string
+	CheckExpr    catpb.Expression
+	Validity     descpb.ConstraintValidity
+}
+
+// MakeValidatedDomainConstraintPublic marks a validated domain CHECK
+// constraint as holding for all existing values of the domain.
+type MakeValidatedDomainConstraintPublic struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// MakePublicDomainConstraintValidated moves a public domain CHECK constraint
+// to VALIDATED.
+type MakePublicDomainConstraintValidated struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// RemoveDomainConstraint removes a CHECK constraint from a domain.
+type RemoveDomainConstraint struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// MakeAbsentDomainNotNullWriteOnly adds a NOT NULL constraint to a domain
+// which is enforced for new values but not yet validated.
+type MakeAbsentDomainNotNullWriteOnly struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// MakeValidatedDomainNotNullPublic marks the validated NOT NULL constraint of
+// a domain as holding for all existing values of the domain.
+type MakeValidatedDomainNotNullPublic struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// MakePublicDomainNotNullValidated moves the public NOT NULL constraint of a
+// domain to VALIDATED.
+type MakePublicDomainNotNullValidated struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// RemoveDomainNotNull removes the NOT NULL constraint from a domain.
+type RemoveDomainNotNull struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
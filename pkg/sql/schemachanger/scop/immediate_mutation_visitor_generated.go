@@ -101,6 +101,8 @@ type ImmediateMutationVisitor interface {
 	RemoveTableConstraintBackReferencesFromFunctions(context.Context, RemoveTableConstraintBackReferencesFromFunctions) error
 	AddTableColumnBackReferencesInFunctions(context.Context, AddTableColumnBackReferencesInFunctions) error
 	RemoveTableColumnBackReferencesInFunctions(context.Context, RemoveTableColumnBackReferencesInFunctions) error
+	AddTableIndexBackReferencesInFunctions(context.Context, AddTableIndexBackReferencesInFunctions) error
+	RemoveTableIndexBackReferencesInFunctions(context.Context, RemoveTableIndexBackReferencesInFunctions) error
 	AddTriggerBackReferencesInRoutines(context.Context, AddTriggerBackReferencesInRoutines) error
 	RemoveTriggerBackReferencesInRoutines(context.Context, RemoveTriggerBackReferencesInRoutines) error
 	SetColumnName(context.Context, SetColumnName) error
@@ -154,6 +156,14 @@ type ImmediateMutationVisitor interface {
 	AddTableZoneConfig(context.Context, AddTableZoneConfig) error
 	AddIndexZoneConfig(context.Context, AddIndexZoneConfig) error
 	AddPartitionZoneConfig(context.Context, AddPartitionZoneConfig) error
+	AddDomainConstraint(context.Context, AddDomainConstraint) error
+	MakeValidatedDomainConstraintPublic(context.Context, MakeValidatedDomainConstraintPublic) error
+	MakePublicDomainConstraintValidated(context.Context, MakePublicDomainConstraintValidated) error
+	RemoveDomainConstraint(context.Context, RemoveDomainConstraint) error
+	MakeAbsentDomainNotNullWriteOnly(context.Context, MakeAbsentDomainNotNullWriteOnly) error
+	MakeValidatedDomainNotNullPublic(context.Context, MakeValidatedDomainNotNullPublic) error
+	MakePublicDomainNotNullValidated(context.Context, MakePublicDomainNotNullValidated) error
+	RemoveDomainNotNull(context.Context, RemoveDomainNotNull) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -576,6 +586,16 @@ func (op RemoveTableColumnBackReferencesInFunctions) Visit(ctx context.Context,
 	return v.RemoveTableColumnBackReferencesInFunctions(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTableIndexBackReferencesInFunctions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTableIndexBackReferencesInFunctions(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTableIndexBackReferencesInFunctions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTableIndexBackReferencesInFunctions(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op AddTriggerBackReferencesInRoutines) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.AddTriggerBackReferencesInRoutines(ctx, op)
@@ -840,3 +860,43 @@ func (op AddIndexZoneConfig) Visit(ctx context.Context, v ImmediateMutationVisit
 func (op AddPartitionZoneConfig) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.AddPartitionZoneConfig(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddDomainConstraint) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddDomainConstraint(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakeValidatedDomainConstraintPublic) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakeValidatedDomainConstraintPublic(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakePublicDomainConstraintValidated) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakePublicDomainConstraintValidated(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainConstraint) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainConstraint(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakeAbsentDomainNotNullWriteOnly) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakeAbsentDomainNotNullWriteOnly(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakeValidatedDomainNotNullPublic) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakeValidatedDomainNotNullPublic(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakePublicDomainNotNullValidated) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakePublicDomainNotNullValidated(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainNotNull) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainNotNull(ctx, op)
+}
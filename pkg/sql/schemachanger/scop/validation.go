@@ -39,5 +39,20 @@ type ValidateColumnNotNull struct {
 	IndexIDForValidation descpb.IndexID
 }
 
+// ValidateDomainConstraint validates a CHECK constraint of a domain against
+// the values stored in table columns of the domain type.
+type ValidateDomainConstraint struct {
+	validationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// ValidateDomainNotNull validates the NOT NULL constraint of a domain against
+// the values stored in table columns of the domain type.
+type ValidateDomainNotNull struct {
+	validationOp
+	TypeID descpb.ID
+}
+
 // Make sure baseOp is used for linter.
 var _ = validationOp{baseOp: baseOp{}}
@@ -20,6 +20,8 @@ type ValidationVisitor interface {
 	ValidateIndex(context.Context, ValidateIndex) error
 	ValidateConstraint(context.Context, ValidateConstraint) error
 	ValidateColumnNotNull(context.Context, ValidateColumnNotNull) error
+	ValidateDomainConstraint(context.Context, ValidateDomainConstraint) error
+	ValidateDomainNotNull(context.Context, ValidateDomainNotNull) error
 }
 
 // Visit is part of the ValidationOp interface.
@@ -36,3 +38,13 @@ func (op ValidateConstraint) Visit(ctx context.Context, v ValidationVisitor) err
 func (op ValidateColumnNotNull) Visit(ctx context.Context, v ValidationVisitor) error {
 	return v.ValidateColumnNotNull(ctx, op)
 }
+
+// Visit is part of the ValidationOp interface.
+func (op ValidateDomainConstraint) Visit(ctx context.Context, v ValidationVisitor) error {
+	return v.ValidateDomainConstraint(ctx, op)
+}
+
+// Visit is part of the ValidationOp interface.
+func (op ValidateDomainNotNull) Visit(ctx context.Context, v ValidationVisitor) error {
+	return v.ValidateDomainNotNull(ctx, op)
+}
@@ -861,6 +861,117 @@ func (c *ElementCollection[E]) FilterDatabaseZoneConfig() *ElementCollection[*Da
 	return (*ElementCollection[*DatabaseZoneConfig])(ret)
 }
 
+func (e DomainConstraint) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainConstraint) Element() Element {
+	return e.DomainConstraint
+}
+
+// ForEachDomainConstraint iterates over elements of type DomainConstraint.
+// Deprecated
+func ForEachDomainConstraint(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainConstraint),
+) {
+  c.FilterDomainConstraint().ForEach(fn)
+}
+
+// FindDomainConstraint finds the first element of type DomainConstraint.
+// Deprecated
+func FindDomainConstraint(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainConstraint) {
+	if tc := c.FilterDomainConstraint(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainConstraint)
+	}
+	return current, target, element
+}
+
+// DomainConstraintElements filters elements of type DomainConstraint.
+func (c *ElementCollection[E]) FilterDomainConstraint() *ElementCollection[*DomainConstraint] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainConstraint)
+		return ok
+	})
+	return (*ElementCollection[*DomainConstraint])(ret)
+}
+
+func (e DomainNotNull) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainNotNull) Element() Element {
+	return e.DomainNotNull
+}
+
+// ForEachDomainNotNull iterates over elements of type DomainNotNull.
+// Deprecated
+func ForEachDomainNotNull(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainNotNull),
+) {
+  c.FilterDomainNotNull().ForEach(fn)
+}
+
+// FindDomainNotNull finds the first element of type DomainNotNull.
+// Deprecated
+func FindDomainNotNull(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainNotNull) {
+	if tc := c.FilterDomainNotNull(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainNotNull)
+	}
+	return current, target, element
+}
+
+// DomainNotNullElements filters elements of type DomainNotNull.
+func (c *ElementCollection[E]) FilterDomainNotNull() *ElementCollection[*DomainNotNull] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainNotNull)
+		return ok
+	})
+	return (*ElementCollection[*DomainNotNull])(ret)
+}
+
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -3058,6 +3169,12 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
 		case *DatabaseZoneConfig:
 			e.ElementOneOf = &ElementProto_DatabaseZoneConfig{ DatabaseZoneConfig: t}
+		case *DomainConstraint:
+			e.ElementOneOf = &ElementProto_DomainConstraint{ DomainConstraint: t}
+		case *DomainNotNull:
+			e.ElementOneOf = &ElementProto_DomainNotNull{ DomainNotNull: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -3203,6 +3320,9 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
 	((*ElementProto_DatabaseZoneConfig)(nil)),
+	((*ElementProto_DomainConstraint)(nil)),
+	((*ElementProto_DomainNotNull)(nil)),
+	((*ElementProto_DomainType)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -3291,6 +3411,9 @@ func GetElementTypes() []interface{} {
 	((*DatabaseRegionConfig)(nil)),
 	((*DatabaseRoleSetting)(nil)),
 	((*DatabaseZoneConfig)(nil)),
+	((*DomainConstraint)(nil)),
+	((*DomainNotNull)(nil)),
+	((*DomainType)(nil)),
 	((*EnumType)(nil)),
 	((*EnumTypeValue)(nil)),
 	((*ForeignKeyConstraint)(nil)),
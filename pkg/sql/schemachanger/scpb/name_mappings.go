@@ -124,6 +124,10 @@ func (ts TargetState) ConstraintName(
 			if e.TableID == relationID && e.ConstraintID == constraintID {
 				return e.Name
 			}
+		case *DomainConstraint:
+			if e.TypeID == relationID && e.ConstraintID == constraintID {
+				return e.Name
+			}
 		}
 		return ""
 	}); len(name) > 0 {
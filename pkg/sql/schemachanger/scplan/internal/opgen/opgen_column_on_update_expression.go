@@ -40,6 +40,16 @@ func init() {
 						BackReferencedColumnID: this.ColumnID,
 					}
 				}),
+				emit(func(this *scpb.ColumnOnUpdateExpression) *scop.AddTableColumnBackReferencesInFunctions {
+					if len(this.UsesFunctionIDs) == 0 {
+						return nil
+					}
+					return &scop.AddTableColumnBackReferencesInFunctions{
+						FunctionIDs:            this.UsesFunctionIDs,
+						BackReferencedTableID:  this.TableID,
+						BackReferencedColumnID: this.ColumnID,
+					}
+				}),
 			),
 		),
 		toAbsent(
@@ -70,6 +80,16 @@ func init() {
 						BackReferencedColumnID: this.ColumnID,
 					}
 				}),
+				emit(func(this *scpb.ColumnOnUpdateExpression) *scop.RemoveTableColumnBackReferencesInFunctions {
+					if len(this.UsesFunctionIDs) == 0 {
+						return nil
+					}
+					return &scop.RemoveTableColumnBackReferencesInFunctions{
+						FunctionIDs:            this.UsesFunctionIDs,
+						BackReferencedTableID:  this.TableID,
+						BackReferencedColumnID: this.ColumnID,
+					}
+				}),
 			),
 		),
 	)
@@ -0,0 +1,68 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainConstraint)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_WRITE_ONLY,
+				emit(func(this *scpb.DomainConstraint) *scop.AddDomainConstraint {
+					return &scop.AddDomainConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+						Name:         this.Name,
+						CheckExpr:    this.Expr,
+						Validity:     descpb.ConstraintValidity_Validating,
+					}
+				}),
+			),
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainConstraint) *scop.ValidateDomainConstraint {
+					return &scop.ValidateDomainConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainConstraint) *scop.MakeValidatedDomainConstraintPublic {
+					return &scop.MakeValidatedDomainConstraintPublic{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainConstraint) *scop.MakePublicDomainConstraintValidated {
+					return &scop.MakePublicDomainConstraintValidated{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+			equiv(scpb.Status_WRITE_ONLY),
+			to(scpb.Status_ABSENT,
+				revertible(false),
+				emit(func(this *scpb.DomainConstraint) *scop.RemoveDomainConstraint {
+					return &scop.RemoveDomainConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+		),
+	)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainNotNull)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_WRITE_ONLY,
+				emit(func(this *scpb.DomainNotNull) *scop.MakeAbsentDomainNotNullWriteOnly {
+					return &scop.MakeAbsentDomainNotNullWriteOnly{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainNotNull) *scop.ValidateDomainNotNull {
+					return &scop.ValidateDomainNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainNotNull) *scop.MakeValidatedDomainNotNullPublic {
+					return &scop.MakeValidatedDomainNotNullPublic{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainNotNull) *scop.MakePublicDomainNotNullValidated {
+					return &scop.MakePublicDomainNotNullValidated{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+			equiv(scpb.Status_WRITE_ONLY),
+			to(scpb.Status_ABSENT,
+				revertible(false),
+				emit(func(this *scpb.DomainNotNull) *scop.RemoveDomainNotNull {
+					return &scop.RemoveDomainNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+		),
+	)
+}
@@ -23,11 +23,12 @@ func init() {
 						partialExpr = this.Predicate.Expr
 					}
 					return &scop.AddUniqueWithoutIndexConstraint{
-						TableID:      this.TableID,
-						ConstraintID: this.ConstraintID,
-						ColumnIDs:    this.ColumnIDs,
-						PartialExpr:  partialExpr,
-						Validity:     descpb.ConstraintValidity_Validating,
+						TableID:            this.TableID,
+						ConstraintID:       this.ConstraintID,
+						ColumnIDs:          this.ColumnIDs,
+						PartialExpr:        partialExpr,
+						ExclusionOperators: this.ExclusionOperators,
+						Validity:           descpb.ConstraintValidity_Validating,
 					}
 				}),
 				emit(func(this *scpb.UniqueWithoutIndexConstraint) *scop.UpdateTableBackReferencesInTypes {
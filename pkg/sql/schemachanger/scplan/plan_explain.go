@@ -533,6 +533,17 @@ func (p Plan) explainValidations(root treeprinter.Node, ops []scop.Op) error {
 				p.IndexName(op.TableID, op.IndexIDForValidation),
 				p.Name(op.TableID),
 			)))
+		case *scop.ValidateDomainConstraint:
+			root.Child(accountFor(fmt.Sprintf(
+				"validate CHECK constraint %s of domain %s",
+				p.ConstraintName(op.TypeID, op.ConstraintID),
+				p.Name(op.TypeID),
+			)))
+		case *scop.ValidateDomainNotNull:
+			root.Child(accountFor(fmt.Sprintf(
+				"validate NOT NULL constraint of domain %s",
+				p.Name(op.TypeID),
+			)))
 		}
 	}
 	return p.Params.MemAcc.Grow(p.Params.Ctx, int64(estimatedMemAlloc))
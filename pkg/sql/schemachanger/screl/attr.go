@@ -151,6 +151,17 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.CompositeType)(nil)),
 		rel.EntityAttr(DescID, "TypeID"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
+	rel.EntityMapping(t((*scpb.DomainConstraint)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(ConstraintID, "ConstraintID"),
+		rel.EntityAttr(Name, "Name"),
+	),
+	rel.EntityMapping(t((*scpb.DomainNotNull)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
 	rel.EntityMapping(t((*scpb.CompositeTypeAttrName)(nil)),
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(Name, "Name"),
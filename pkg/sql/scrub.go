@@ -442,6 +442,10 @@ func createConstraintCheckOperations(
 		} else if uwi := constraint.AsUniqueWithIndex(); uwi != nil {
 			op = newSQLUniqueWithIndexConstraintCheckOperation(tableName, tableDesc, uwi, asOf)
 		} else if uwoi := constraint.AsUniqueWithoutIndex(); uwoi != nil {
+			if uwoi.IsExclusion() {
+				// Exclusion constraints are not supported by SCRUB.
+				continue
+			}
 			op = newSQLUniqueWithoutIndexConstraintCheckOperation(tableName, tableDesc, uwoi, asOf)
 		} else {
 			return nil, errors.AssertionFailedf("unknown constraint type %T", constraint)
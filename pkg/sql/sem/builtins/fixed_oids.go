@@ -2607,6 +2607,166 @@ var builtinOidsArray = []string{
 	2644: `crdb_internal.range_stats_with_errors(key: bytes) -> jsonb`,
 	2645: `crdb_internal.lease_holder_with_errors(key: bytes) -> jsonb`,
 	2646: `crdb_internal.pretty_key(raw_key: bytes) -> string`,
+	2647: `int4rangesend(int4range: int4range) -> bytes`,
+	2648: `int4rangerecv(input: anyelement) -> int4range`,
+	2649: `int4rangeout(int4range: int4range) -> bytes`,
+	2650: `int4rangein(input: anyelement) -> int4range`,
+	2651: `int8rangesend(int8range: int8range) -> bytes`,
+	2652: `int8rangerecv(input: anyelement) -> int8range`,
+	2653: `int8rangeout(int8range: int8range) -> bytes`,
+	2654: `int8rangein(input: anyelement) -> int8range`,
+	2655: `numrangesend(numrange: numrange) -> bytes`,
+	2656: `numrangerecv(input: anyelement) -> numrange`,
+	2657: `numrangeout(numrange: numrange) -> bytes`,
+	2658: `numrangein(input: anyelement) -> numrange`,
+	2659: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2660: `tsrangerecv(input: anyelement) -> tsrange`,
+	2661: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2662: `tsrangein(input: anyelement) -> tsrange`,
+	2663: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2664: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2665: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2666: `tstzrangein(input: anyelement) -> tstzrange`,
+	2667: `daterangesend(daterange: daterange) -> bytes`,
+	2668: `daterangerecv(input: anyelement) -> daterange`,
+	2669: `daterangeout(daterange: daterange) -> bytes`,
+	2670: `daterangein(input: anyelement) -> daterange`,
+	2671: `anyrange_send(anyrange: anyrange) -> bytes`,
+	2672: `anyrange_recv(input: anyelement) -> anyrange`,
+	2673: `anyrange_out(anyrange: anyrange) -> bytes`,
+	2674: `anyrange_in(input: anyelement) -> anyrange`,
+	2675: `int4range(lower: int4, upper: int4) -> int4range`,
+	2676: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2677: `int8range(lower: int, upper: int) -> int8range`,
+	2678: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2679: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2680: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2681: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2682: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2683: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2684: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2685: `daterange(lower: date, upper: date) -> daterange`,
+	2686: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2687: `lower(range: anyrange) -> anyelement`,
+	2688: `upper(range: anyrange) -> anyelement`,
+	2689: `isempty(range: anyrange) -> bool`,
+	2690: `lower_inc(range: anyrange) -> bool`,
+	2691: `upper_inc(range: anyrange) -> bool`,
+	2692: `lower_inf(range: anyrange) -> bool`,
+	2693: `upper_inf(range: anyrange) -> bool`,
+	2694: `cidrsend(cidr: cidr) -> bytes`,
+	2695: `cidrrecv(input: anyelement) -> cidr`,
+	2696: `cidrout(cidr: cidr) -> bytes`,
+	2697: `cidrin(input: anyelement) -> cidr`,
+	2698: `cidr(string: string) -> cidr`,
+	2699: `cidr(inet: inet) -> cidr`,
+	2700: `macaddrsend(macaddr: macaddr) -> bytes`,
+	2701: `macaddrrecv(input: anyelement) -> macaddr`,
+	2702: `macaddrout(macaddr: macaddr) -> bytes`,
+	2703: `macaddrin(input: anyelement) -> macaddr`,
+	2704: `macaddr(string: string) -> macaddr`,
+	2705: `macaddr(macaddr: macaddr) -> macaddr`,
+	2706: `varchar(macaddr: macaddr) -> varchar`,
+	2707: `text(macaddr: macaddr) -> string`,
+	2708: `bpchar(macaddr: macaddr) -> bpchar`,
+	2709: `name(macaddr: macaddr) -> name`,
+	2710: `char(macaddr: macaddr) -> "char"`,
+	2711: `max(arg1: macaddr) -> anyelement`,
+	2712: `percentile_disc_impl(arg1: float, arg2: macaddr) -> macaddr`,
+	2713: `percentile_disc_impl(arg1: float[], arg2: macaddr) -> macaddr[]`,
+	2714: `min(arg1: macaddr) -> anyelement`,
+	2715: `array_cat_agg(arg1: macaddr[]) -> macaddr[]`,
+	2716: `array_agg(arg1: macaddr) -> macaddr[]`,
+	2717: `array_prepend(elem: macaddr, array: macaddr[]) -> macaddr[]`,
+	2718: `array_remove(array: macaddr[], elem: macaddr) -> anyelement`,
+	2719: `array_positions(array: macaddr[], elem: macaddr) -> int[]`,
+	2720: `array_cat(left: macaddr[], right: macaddr[]) -> macaddr[]`,
+	2721: `array_position(array: macaddr[], elem: macaddr) -> int`,
+	2722: `array_replace(array: macaddr[], toreplace: macaddr, replacewith: macaddr) -> anyelement`,
+	2723: `array_append(array: macaddr[], elem: macaddr) -> macaddr[]`,
+	2724: `first_value(val: macaddr) -> macaddr`,
+	2725: `nth_value(val: macaddr, n: int) -> macaddr`,
+	2726: `lag(val: macaddr) -> macaddr`,
+	2727: `lag(val: macaddr, n: int) -> macaddr`,
+	2728: `lag(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	2729: `lead(val: macaddr) -> macaddr`,
+	2730: `lead(val: macaddr, n: int) -> macaddr`,
+	2731: `lead(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	2732: `last_value(val: macaddr) -> macaddr`,
+	2733: `array_position(array: macaddr[], elem: macaddr, start: int) -> int`,
+	2734: `array_agg(arg1: macaddr[]) -> macaddr[][]`,
+	2735: `macaddr8send(macaddr8: macaddr8) -> bytes`,
+	2736: `macaddr8recv(input: anyelement) -> macaddr8`,
+	2737: `macaddr8out(macaddr8: macaddr8) -> bytes`,
+	2738: `macaddr8in(input: anyelement) -> macaddr8`,
+	2739: `macaddr8(string: string) -> macaddr8`,
+	2740: `macaddr8(macaddr: macaddr) -> macaddr8`,
+	2741: `moneysend(money: money) -> bytes`,
+	2742: `moneyrecv(input: anyelement) -> money`,
+	2743: `moneyout(money: money) -> bytes`,
+	2744: `moneyin(input: anyelement) -> money`,
+	2745: `money(string: string) -> money`,
+	2746: `money(int: int) -> money`,
+	2747: `money(decimal: decimal) -> money`,
+	2748: `money(money: money) -> money`,
+	2749: `numeric(money: money) -> decimal`,
+	2750: `varchar(money: money) -> varchar`,
+	2751: `text(money: money) -> string`,
+	2752: `bpchar(money: money) -> bpchar`,
+	2753: `name(money: money) -> name`,
+	2754: `char(money: money) -> "char"`,
+	2755: `max(arg1: money) -> anyelement`,
+	2756: `percentile_disc_impl(arg1: float, arg2: money) -> money`,
+	2757: `percentile_disc_impl(arg1: float[], arg2: money) -> money[]`,
+	2758: `min(arg1: money) -> anyelement`,
+	2759: `array_cat_agg(arg1: money[]) -> money[]`,
+	2760: `array_agg(arg1: money) -> money[]`,
+	2761: `array_prepend(elem: money, array: money[]) -> money[]`,
+	2762: `array_remove(array: money[], elem: money) -> anyelement`,
+	2763: `array_positions(array: money[], elem: money) -> int[]`,
+	2764: `array_cat(left: money[], right: money[]) -> money[]`,
+	2765: `array_position(array: money[], elem: money) -> int`,
+	2766: `array_replace(array: money[], toreplace: money, replacewith: money) -> anyelement`,
+	2767: `array_append(array: money[], elem: money) -> money[]`,
+	2768: `first_value(val: money) -> money`,
+	2769: `nth_value(val: money, n: int) -> money`,
+	2770: `lag(val: money) -> money`,
+	2771: `lag(val: money, n: int) -> money`,
+	2772: `lag(val: money, n: int, default: money) -> money`,
+	2773: `lead(val: money) -> money`,
+	2774: `lead(val: money, n: int) -> money`,
+	2775: `lead(val: money, n: int, default: money) -> money`,
+	2776: `last_value(val: money) -> money`,
+	2777: `array_position(array: money[], elem: money, start: int) -> int`,
+	2778: `array_agg(arg1: money[]) -> money[][]`,
+	2779: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2780: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2781: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2782: `jsonpathin(input: anyelement) -> jsonpath`,
+	2783: `jsonpath(string: string) -> jsonpath`,
+	2784: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2785: `varchar(jsonpath: jsonpath) -> varchar`,
+	2786: `text(jsonpath: jsonpath) -> string`,
+	2787: `bpchar(jsonpath: jsonpath) -> bpchar`,
+	2788: `name(jsonpath: jsonpath) -> name`,
+	2789: `char(jsonpath: jsonpath) -> "char"`,
+	2790: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2791: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2792: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2793: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2794: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2795: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2796: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2797: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2798: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2799: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2800: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2801: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2802: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2803: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2804: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2805: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2806: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
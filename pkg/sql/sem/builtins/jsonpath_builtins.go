@@ -0,0 +1,244 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		// Most builtins in this file are of the Normal class, but
+		// jsonb_path_query is of the Generator class.
+		const enforceClass = false
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(types.Bool, func(q jsonPathQuery) (tree.Datum, error) {
+			res, isNull, err := q.path.Exists(q.target, q.vars, q.silent)
+			return jsonPathPredicateResult(res, isNull, err)
+		}, "Returns whether the JSON path returns any item for the target document.")...,
+	),
+	"jsonb_path_exists_opr": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOperatorOverload(func(q jsonPathQuery) (tree.Datum, error) {
+			res, isNull, err := q.path.Exists(q.target, q.vars, q.silent)
+			return jsonPathPredicateResult(res, isNull, err)
+		}, "Implementation of the @? operator."),
+	),
+	"jsonb_path_match": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(types.Bool, func(q jsonPathQuery) (tree.Datum, error) {
+			res, isNull, err := q.path.Match(q.target, q.vars, q.silent)
+			return jsonPathPredicateResult(res, isNull, err)
+		}, "Returns the result of a JSON path predicate check for the target document. "+
+			"Only the first item of the result is taken into account. If the result is "+
+			"not a boolean, NULL is returned.")...,
+	),
+	"jsonb_path_match_opr": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOperatorOverload(func(q jsonPathQuery) (tree.Datum, error) {
+			res, isNull, err := q.path.Match(q.target, q.vars, q.silent)
+			return jsonPathPredicateResult(res, isNull, err)
+		}, "Implementation of the @@ operator."),
+	),
+	"jsonb_path_query": makeBuiltin(genProps(),
+		makeJSONPathQueryGeneratorOverload(jsonPathParams(false /* withVars */, false /* withSilent */)),
+		makeJSONPathQueryGeneratorOverload(jsonPathParams(true /* withVars */, false /* withSilent */)),
+		makeJSONPathQueryGeneratorOverload(jsonPathParams(true /* withVars */, true /* withSilent */)),
+	),
+	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(types.Jsonb, func(q jsonPathQuery) (tree.Datum, error) {
+			res, err := q.path.Query(q.target, q.vars, q.silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		}, "Returns all the items returned by the JSON path for the target document, "+
+			"as a JSON array.")...,
+	),
+	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(types.Jsonb, func(q jsonPathQuery) (tree.Datum, error) {
+			res, err := q.path.Query(q.target, q.vars, q.silent)
+			if err != nil {
+				return nil, err
+			}
+			if len(res) == 0 {
+				return tree.DNull, nil
+			}
+			return tree.NewDJSON(res[0]), nil
+		}, "Returns the first item returned by the JSON path for the target document, "+
+			"or NULL if there are no results.")...,
+	),
+}
+
+// jsonPathQuery holds the arguments of a jsonb_path_* builtin.
+type jsonPathQuery struct {
+	target json.JSON
+	path   *tree.DJsonpath
+	// vars is nil if the builtin was called without variables.
+	vars   json.JSON
+	silent bool
+}
+
+// makeJSONPathQuery returns the arguments of a jsonb_path_* builtin. The
+// vars and silent arguments are optional.
+func makeJSONPathQuery(args tree.Datums) jsonPathQuery {
+	q := jsonPathQuery{
+		target: tree.MustBeDJSON(args[0]).JSON,
+		path:   tree.MustBeDJsonpath(args[1]),
+	}
+	if len(args) > 2 {
+		q.vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		q.silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return q
+}
+
+// jsonPathParams returns the parameters of a jsonb_path_* builtin.
+func jsonPathParams(withVars, withSilent bool) tree.ParamTypes {
+	params := tree.ParamTypes{
+		{Name: "target", Typ: types.Jsonb},
+		{Name: "path", Typ: types.Jsonpath},
+	}
+	if withVars {
+		params = append(params, tree.ParamType{Name: "vars", Typ: types.Jsonb})
+	}
+	if withSilent {
+		params = append(params, tree.ParamType{Name: "silent", Typ: types.Bool})
+	}
+	return params
+}
+
+const jsonPathVarsInfo = "\n\nThe vars argument is an object whose fields are the values of " +
+	"the variables referenced by the path. If silent is true, data-dependent errors " +
+	"such as missing keys or items of the wrong type are suppressed."
+
+// makeJSONPathOverloads returns the overloads of a jsonb_path_* builtin, which
+// take optional vars and silent arguments.
+func makeJSONPathOverloads(
+	ret *types.T, fn func(q jsonPathQuery) (tree.Datum, error), info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, 3)
+	for _, params := range []tree.ParamTypes{
+		jsonPathParams(false /* withVars */, false /* withSilent */),
+		jsonPathParams(true /* withVars */, false /* withSilent */),
+		jsonPathParams(true /* withVars */, true /* withSilent */),
+	} {
+		overloads = append(overloads, tree.Overload{
+			Types:      params,
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(makeJSONPathQuery(args))
+			},
+			Info:       info + jsonPathVarsInfo,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeJSONPathOperatorOverload returns the overload of the builtin that
+// implements a jsonpath operator, which suppresses data-dependent errors.
+func makeJSONPathOperatorOverload(
+	fn func(q jsonPathQuery) (tree.Datum, error), info string,
+) tree.Overload {
+	return tree.Overload{
+		Types:      jsonPathParams(false /* withVars */, false /* withSilent */),
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			q := makeJSONPathQuery(args)
+			q.silent = true
+			return fn(q)
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+func jsonPathPredicateResult(res, isNull bool, err error) (tree.Datum, error) {
+	if err != nil {
+		return nil, err
+	}
+	if isNull {
+		return tree.DNull, nil
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func makeJSONPathQueryGeneratorOverload(params tree.ParamTypes) tree.Overload {
+	return makeGeneratorOverload(
+		params,
+		types.Jsonb,
+		makeJSONPathQueryGenerator,
+		"Returns all the items returned by the JSON path for the target document."+jsonPathVarsInfo,
+		volatility.Immutable,
+	)
+}
+
+// jsonPathQueryGenerator is the generator of jsonb_path_query, which returns
+// one row for each item returned by the path.
+type jsonPathQueryGenerator struct {
+	q     jsonPathQuery
+	items []json.JSON
+	buf   [1]tree.Datum
+}
+
+var _ eval.ValueGenerator = &jsonPathQueryGenerator{}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &jsonPathQueryGenerator{q: makeJSONPathQuery(args)}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	items, err := g.q.path.Query(g.q.target, g.q.vars, g.q.silent)
+	if err != nil {
+		return err
+	}
+	g.items = items
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	if len(g.items) == 0 {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.items[0])
+	g.items = g.items[1:]
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
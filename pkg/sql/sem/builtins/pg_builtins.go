@@ -83,6 +83,7 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.Timestamp.Oid():   {},
 	types.TimestampTZ.Oid(): {},
 	types.AnyTuple.Oid():    {},
+	types.AnyRange.Oid():    {},
 }
 
 // PGIOBuiltinPrefix returns the string prefix to a type's IO functions. This
@@ -150,6 +151,11 @@ func init() {
 		if !ok {
 			return
 		}
+		if toType.Family() == types.RangeFamily {
+			// The range types have constructor builtins with the same names, see
+			// range_builtins.go.
+			return
+		}
 		distSQLBlockList := toType.Family() == types.OidFamily
 		if _, ok := castBuiltins[toOID]; !ok {
 			castBuiltins[toOID] = &builtinDefinition{
@@ -256,9 +262,16 @@ func shouldMakeFromCastBuiltin(in *types.T) bool {
 		return false
 	case in.Family() == types.FloatFamily && in.Oid() != oid.T_float8:
 		return false
+	case in.Family() == types.INetFamily && in.Oid() != oid.T_inet:
+		return false
+	case in.Family() == types.MacAddrFamily && in.Oid() != oid.T_macaddr:
+		return false
 	case in.Family() == types.TriggerFamily:
 		// TRIGGER is not a valid cast target.
 		return false
+	case in.Family() == types.RangeFamily:
+		// The range types all share a family, so there is no preferred type.
+		return false
 	}
 	return true
 }
@@ -0,0 +1,171 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for _, t := range types.RangeTypes {
+		rangeBuiltins[t.Name()] = makeRangeConstructor(t)
+	}
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Empty },
+		"Returns whether the range is empty.",
+	),
+	"lower_inc": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.LowerInc },
+		"Returns whether the lower bound of the range is inclusive.",
+	),
+	"upper_inc": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return r.UpperInc },
+		"Returns whether the upper bound of the range is inclusive.",
+	),
+	"lower_inf": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return !r.Empty && r.Lower == tree.DNull },
+		"Returns whether the range has no lower bound.",
+	),
+	"upper_inf": makeRangePredicateBuiltin(
+		func(r *tree.DRange) bool { return !r.Empty && r.Upper == tree.DNull },
+		"Returns whether the range has no upper bound.",
+	),
+}
+
+// makeRangeBoundOverload returns the overload of the lower or upper builtin
+// which returns the corresponding bound of a range.
+func makeRangeBoundOverload(upper bool) tree.Overload {
+	side := "lower"
+	if upper {
+		side = "upper"
+	}
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+		ReturnType: rangeContentsReturnType,
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			// The bounds of an empty or unbounded range are NULL.
+			r := tree.MustBeDRange(args[0])
+			if upper {
+				return r.Upper, nil
+			}
+			return r.Lower, nil
+		},
+		Info: fmt.Sprintf("Returns the %[1]s bound of the range, or NULL if the range is empty "+
+			"or has no %[1]s bound.", side),
+		Volatility: volatility.Immutable,
+		// Prefer the string overload for NULL and placeholder arguments, as was
+		// the case before ranges existed.
+		OverloadPreference: tree.OverloadPreferenceUnpreferred,
+	}
+}
+
+// rangeContentsReturnType returns the type of the bounds of the range
+// argument.
+func rangeContentsReturnType(args []tree.TypedExpr) *types.T {
+	if len(args) == 0 {
+		return tree.UnknownReturnType
+	}
+	t := args[0].ResolvedType()
+	if t.Family() != types.RangeFamily {
+		return types.Unknown
+	}
+	return t.RangeContents()
+}
+
+func makeRangePredicateBuiltin(pred func(r *tree.DRange) bool, info string) builtinDefinition {
+	return makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(pred(tree.MustBeDRange(args[0])))), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeRangeConstructor returns the constructor of the given range type, which
+// is named after the type. A NULL bound makes the range unbounded on that
+// side. The bounds are inclusive on the lower side and exclusive on the upper
+// side unless specified otherwise.
+func makeRangeConstructor(t *types.T) builtinDefinition {
+	contents := t.RangeContents()
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		var lowerInc, upperInc bool
+		switch bounds {
+		case "[]":
+			lowerInc, upperInc = true, true
+		case "[)":
+			lowerInc = true
+		case "(]":
+			upperInc = true
+		case "()":
+		default:
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		return tree.NewDRange(t, args[0], args[1], lowerInc, upperInc)
+	}
+	return makeBuiltin(defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: contents},
+				{Name: "upper", Typ: contents},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			CalledOnNullInput: true,
+			Info: fmt.Sprintf("Returns the %s with the given bounds, including the lower bound "+
+				"and excluding the upper bound. A NULL bound makes the range unbounded.", t.Name()),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: contents},
+				{Name: "upper", Typ: contents},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed,
+						"range constructor flags argument must not be null")
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			CalledOnNullInput: true,
+			Info: fmt.Sprintf("Returns the %s with the given bounds. The bounds argument is one of "+
+				"`[]`, `[)`, `(]` or `()` and specifies whether each bound is inclusive. "+
+				"A NULL bound makes the range unbounded.", t.Name()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
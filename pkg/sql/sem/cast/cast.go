@@ -215,6 +215,32 @@ func OIDInCastMap(src, tgt oid.Oid) bool {
 // LookupCast returns a cast that describes the cast from src to tgt if it
 // exists. If it does not exist, ok=false is returned.
 func LookupCast(src, tgt *types.T) (Cast, bool) {
+	// Domains are cast through their base types. Casts to a domain also check
+	// the domain's constraints, which can be altered, so they are at most
+	// stable and are not allowed in implicit contexts.
+	if src.IsDomain() || tgt.IsDomain() {
+		if src.Oid() == tgt.Oid() {
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		if src.IsDomain() {
+			return LookupCast(src.DomainBaseType(), tgt)
+		}
+		c, ok := LookupCast(src, tgt.DomainBaseType())
+		if !ok {
+			return Cast{}, false
+		}
+		if c.MaxContext > ContextAssignment {
+			c.MaxContext = ContextAssignment
+		}
+		if c.Volatility < volatility.Stable {
+			c.Volatility = volatility.Stable
+		}
+		return c, true
+	}
+
 	srcFamily := src.Family()
 	tgtFamily := tgt.Family()
 
@@ -107,6 +107,8 @@ const (
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
 	TableMetadata                          SystemTableName = "table_metadata"
 	PreparedTransactionsTableName          SystemTableName = "prepared_transactions"
+	StatementHintsTableName                SystemTableName = "statement_hints"
+	SQLEventAggregatesTableName            SystemTableName = "sql_event_aggregates"
 )
 
 // Oid for virtual database and table.
@@ -223,6 +225,9 @@ const (
 	CrdbInternalFullyQualifiedNamesViewID
 	CrdbInternalStoreLivenessSupportFrom
 	CrdbInternalStoreLivenessSupportFor
+	CrdbInternalStatementHintsTableID
+	CrdbInternalNodeActiveSessionHistoryTableID
+	CrdbInternalClusterActiveSessionHistoryTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
@@ -461,6 +466,8 @@ const (
 	ConstraintTypeCheck ConstraintType = "CHECK"
 	// ConstraintTypeUniqueWithoutIndex identifies a UNIQUE_WITHOUT_INDEX constraint.
 	ConstraintTypeUniqueWithoutIndex ConstraintType = "UNIQUE WITHOUT INDEX"
+	// ConstraintTypeExclusion identifies an EXCLUDE constraint.
+	ConstraintTypeExclusion ConstraintType = "EXCLUDE"
 )
 
 // SafeValue implements the redact.SafeValue interface.
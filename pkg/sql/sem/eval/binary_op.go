@@ -301,6 +301,18 @@ func (e *evaluator) EvalContainedByJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).Contains(tree.MustBeDRange(a)))), nil
+}
+
+func (e *evaluator) EvalContainedByElemRangeOp(
+	ctx context.Context, _ *tree.ContainedByElemRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).ContainsElem(a))), nil
+}
+
 func (e *evaluator) EvalContainsArrayOp(
 	ctx context.Context, _ *tree.ContainsArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -319,6 +331,18 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).Contains(tree.MustBeDRange(b)))), nil
+}
+
+func (e *evaluator) EvalContainsRangeElemOp(
+	ctx context.Context, _ *tree.ContainsRangeElemOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).ContainsElem(b))), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1207,6 +1231,18 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Overlaps(tree.MustBeDRange(right)))), nil
+}
+
+func (e *evaluator) EvalAdjacentRangeOp(
+	ctx context.Context, _ *tree.AdjacentRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Adjacent(tree.MustBeDRange(right)))), nil
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1225,6 +1261,40 @@ func (e *evaluator) EvalTSMatchesVectorQueryOp(
 	return tree.MakeDBool(tree.DBool(ret)), err
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The operator suppresses data-dependent errors, as jsonb_path_exists does
+	// when silent is true.
+	res, isNull, err := tree.MustBeDJsonpath(right).Exists(
+		tree.MustBeDJSON(left).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if isNull {
+		return tree.DNull, nil
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The operator suppresses data-dependent errors, as jsonb_path_match does
+	// when silent is true.
+	res, isNull, err := tree.MustBeDJsonpath(right).Match(
+		tree.MustBeDJSON(left).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if isNull {
+		return tree.DNull, nil
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
 func (e *evaluator) EvalDistanceVectorOp(
 	ctx context.Context, _ *tree.DistanceVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1707,3 +1777,150 @@ func (e *evaluator) EvalMultPGVectorOp(
 	}
 	return tree.NewDPGVector(ret), nil
 }
+
+func (e *evaluator) EvalPlusRangeOp(
+	ctx context.Context, _ *tree.PlusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Union(tree.MustBeDRange(right))
+}
+
+func (e *evaluator) EvalMinusRangeOp(
+	ctx context.Context, _ *tree.MinusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Difference(tree.MustBeDRange(right))
+}
+
+func (e *evaluator) EvalMultRangeOp(
+	ctx context.Context, _ *tree.MultRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Intersect(tree.MustBeDRange(right))
+}
+
+// macAddrOperands returns the addresses of the two MACADDR or MACADDR8
+// operands of a bitwise operation. If only one of them is a MACADDR8, the
+// other is converted to a MACADDR8 as well.
+func macAddrOperands(left, right tree.Datum) (l, r *tree.DMacAddr) {
+	l, r = tree.MustBeDMacAddr(left), tree.MustBeDMacAddr(right)
+	if l.Is8 != r.Is8 {
+		l, r = l.ToMacAddr8(), r.ToMacAddr8()
+	}
+	return l, r
+}
+
+func (e *evaluator) EvalBitAndMacAddrOp(
+	ctx context.Context, _ *tree.BitAndMacAddrOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := macAddrOperands(left, right)
+	return &tree.DMacAddr{Addr: l.Addr & r.Addr, Is8: l.Is8}, nil
+}
+
+func (e *evaluator) EvalBitOrMacAddrOp(
+	ctx context.Context, _ *tree.BitOrMacAddrOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	l, r := macAddrOperands(left, right)
+	return &tree.DMacAddr{Addr: l.Addr | r.Addr, Is8: l.Is8}, nil
+}
+
+func (e *evaluator) EvalPlusMoneyOp(
+	ctx context.Context, _ *tree.PlusMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, ok := arith.AddWithOverflow(tree.MustBeDMoney(left).Cents, tree.MustBeDMoney(right).Cents)
+	if !ok {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(r), nil
+}
+
+func (e *evaluator) EvalMinusMoneyOp(
+	ctx context.Context, _ *tree.MinusMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, ok := arith.SubWithOverflow(tree.MustBeDMoney(left).Cents, tree.MustBeDMoney(right).Cents)
+	if !ok {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(r), nil
+}
+
+func (e *evaluator) EvalMultMoneyIntOp(
+	ctx context.Context, _ *tree.MultMoneyIntOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return multMoneyInt(tree.MustBeDMoney(left).Cents, int64(tree.MustBeDInt(right)))
+}
+
+func (e *evaluator) EvalMultIntMoneyOp(
+	ctx context.Context, _ *tree.MultIntMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return multMoneyInt(tree.MustBeDMoney(right).Cents, int64(tree.MustBeDInt(left)))
+}
+
+func (e *evaluator) EvalMultMoneyFloatOp(
+	ctx context.Context, _ *tree.MultMoneyFloatOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	c := float64(tree.MustBeDMoney(left).Cents)
+	return moneyFromFloat(c * float64(*right.(*tree.DFloat)))
+}
+
+func (e *evaluator) EvalMultFloatMoneyOp(
+	ctx context.Context, _ *tree.MultFloatMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	c := float64(tree.MustBeDMoney(right).Cents)
+	return moneyFromFloat(c * float64(*left.(*tree.DFloat)))
+}
+
+func (e *evaluator) EvalDivMoneyIntOp(
+	ctx context.Context, _ *tree.DivMoneyIntOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	c, i := tree.MustBeDMoney(left).Cents, int64(tree.MustBeDInt(right))
+	if i == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	if c == math.MinInt64 && i == -1 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	// Like postgres, the result is truncated towards zero.
+	return tree.NewDMoney(c / i), nil
+}
+
+func (e *evaluator) EvalDivMoneyFloatOp(
+	ctx context.Context, _ *tree.DivMoneyFloatOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	f := float64(*right.(*tree.DFloat))
+	if f == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	return moneyFromFloat(float64(tree.MustBeDMoney(left).Cents) / f)
+}
+
+func (e *evaluator) EvalDivMoneyOp(
+	ctx context.Context, _ *tree.DivMoneyOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r := tree.MustBeDMoney(right).Cents
+	if r == 0 {
+		return nil, tree.ErrDivByZero
+	}
+	return tree.NewDFloat(tree.DFloat(float64(tree.MustBeDMoney(left).Cents) / float64(r))), nil
+}
+
+// multMoneyInt returns the MONEY value with c cents multiplied by i.
+func multMoneyInt(c, i int64) (tree.Datum, error) {
+	r := c * i
+	if c == 0 || i == 0 || c == 1 || i == 1 {
+		// ignore
+	} else if c == math.MinInt64 || i == math.MinInt64 {
+		// This test is required to detect math.MinInt64 * -1.
+		return nil, tree.ErrMoneyOutOfRange
+	} else if r/i != c {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(r), nil
+}
+
+// moneyFromFloat returns the MONEY value with the given number of cents,
+// rounded to the nearest integer.
+func moneyFromFloat(cents float64) (tree.Datum, error) {
+	r := math.RoundToEven(cents)
+	if math.IsNaN(r) || r < math.MinInt64 || r >= math.MaxInt64 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(int64(r)), nil
+}
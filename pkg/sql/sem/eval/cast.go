@@ -16,12 +16,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/arith"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -96,6 +98,11 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// Values of a domain are represented by datums of its base type. The
+	// domain's constraints are checked by the optimizer, not here.
+	if t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -382,6 +389,8 @@ func performCastWithoutPrecisionTruncation(
 		case *tree.DInterval:
 			v.AsBigInt(&dd.Coeff)
 			dd.Exponent = -9
+		case *tree.DMoney:
+			dd.SetFinite(v.Cents, -2)
 		case *tree.DJSON:
 			dec, ok := v.AsDecimal()
 			if !ok {
@@ -436,7 +445,8 @@ func performCastWithoutPrecisionTruncation(
 			}
 		case *tree.DBool, *tree.DDecimal:
 			s = d.String()
-		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D, *tree.DPGLSN:
+		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D, *tree.DPGLSN,
+			*tree.DMacAddr, *tree.DMoney:
 			s = tree.AsStringWithFlags(d, tree.FmtBareStrings)
 		case *tree.DTimestampTZ:
 			// Convert to context timezone for correct display.
@@ -462,6 +472,13 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
+		case *tree.DRange:
+			s = tree.AsStringWithFlags(
+				d,
+				tree.FmtPgwireText,
+				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
+				tree.FmtLocation(evalCtx.GetLocation()),
+			)
 		case *tree.DInterval:
 			// When converting an interval to string, we need a string representation
 			// of the duration (e.g. "5s") and not of the interval itself (e.g.
@@ -494,6 +511,8 @@ func performCastWithoutPrecisionTruncation(
 			s = tree.AsStringWithFlags(t, tree.FmtPgwireText)
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Jsonpath.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -564,6 +583,19 @@ func performCastWithoutPrecisionTruncation(
 		}
 
 	case types.INetFamily:
+		if t.Oid() == oid.T_cidr {
+			switch t := d.(type) {
+			case *tree.DString:
+				return tree.ParseDCIDR(string(*t))
+			case *tree.DCollatedString:
+				return tree.ParseDCIDR(t.Contents)
+			case *tree.DIPAddr:
+				// Casting an INET to a CIDR zeroes out the bits to the right of
+				// the netmask.
+				return tree.NewDCIDR(tree.DIPAddr{IPAddr: t.IPAddr.Network()}), nil
+			}
+			break
+		}
 		switch t := d.(type) {
 		case *tree.DString:
 			return tree.ParseDIPAddrFromINetString(string(*t))
@@ -573,6 +605,44 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.MacAddrFamily:
+		is8 := t.Oid() == oidext.T_macaddr8
+		switch d := d.(type) {
+		case *tree.DString:
+			if is8 {
+				return tree.ParseDMacAddr8(string(*d))
+			}
+			return tree.ParseDMacAddr(string(*d))
+		case *tree.DCollatedString:
+			if is8 {
+				return tree.ParseDMacAddr8(d.Contents)
+			}
+			return tree.ParseDMacAddr(d.Contents)
+		case *tree.DMacAddr:
+			if is8 {
+				return d.ToMacAddr8(), nil
+			}
+			return d.ToMacAddr()
+		}
+
+	case types.MoneyFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMoney(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDMoney(d.Contents)
+		case *tree.DInt:
+			c, ok := arith.MulHalfPositiveWithOverflow(int64(*d), 100)
+			if !ok {
+				return nil, tree.ErrMoneyOutOfRange
+			}
+			return tree.NewDMoney(c), nil
+		case *tree.DDecimal:
+			return decimalToMoney(&d.Decimal)
+		case *tree.DMoney:
+			return d, nil
+		}
+
 	case types.Box2DFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -907,6 +977,13 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		case *tree.DJsonpath:
+			return d, nil
+		}
 	case types.TSQueryFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -929,6 +1006,17 @@ func performCastWithoutPrecisionTruncation(
 		case *tree.DTSVector:
 			return d, nil
 		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
 	case types.ArrayFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -1077,6 +1165,25 @@ func roundDecimalToInt(d *apd.Decimal) (int64, error) {
 	return i, nil
 }
 
+// decimalToMoney converts a decimal amount to a MONEY value, rounding it to
+// the nearest cent.
+func decimalToMoney(d *apd.Decimal) (tree.Datum, error) {
+	if d.Form != apd.Finite {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	var cents apd.Decimal
+	cents.Set(d)
+	cents.Exponent += 2
+	if _, err := tree.DecimalCtx.RoundToIntegralValue(&cents, &cents); err != nil {
+		return nil, err
+	}
+	c, err := cents.Int64()
+	if err != nil {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(c), nil
+}
+
 func failedCastFromJSON(j *tree.DJSON, t *types.T) error {
 	return pgerror.Newf(
 		pgcode.InvalidParameterValue,
@@ -46,6 +46,16 @@ func (e *evaluator) EvalComplementIntOp(
 	return tree.NewDInt(^tree.MustBeDInt(d)), nil
 }
 
+func (e *evaluator) EvalComplementMacAddrOp(
+	ctx context.Context, _ *tree.ComplementMacAddrOp, d tree.Datum,
+) (tree.Datum, error) {
+	m := tree.MustBeDMacAddr(d)
+	if m.Is8 {
+		return tree.NewDMacAddr8(^m.Addr), nil
+	}
+	return tree.NewDMacAddr(^m.Addr & (1<<48 - 1)), nil
+}
+
 func (e *evaluator) EvalComplementVarBitOp(
 	ctx context.Context, _ *tree.ComplementVarBitOp, d tree.Datum,
 ) (tree.Datum, error) {
@@ -102,3 +112,13 @@ func (e *evaluator) EvalUnaryMinusIntervalOp(
 	i.Months = -i.Months
 	return &tree.DInterval{Duration: i}, nil
 }
+
+func (e *evaluator) EvalUnaryMinusMoneyOp(
+	ctx context.Context, _ *tree.UnaryMinusMoneyOp, d tree.Datum,
+) (tree.Datum, error) {
+	c := tree.MustBeDMoney(d).Cents
+	if c == math.MinInt64 {
+		return nil, tree.ErrMoneyOutOfRange
+	}
+	return tree.NewDMoney(-c), nil
+}
@@ -26,12 +26,15 @@ type AlterTypeCmd interface {
 	TelemetryName() string
 }
 
-func (*AlterTypeAddValue) alterTypeCmd()    {}
-func (*AlterTypeRenameValue) alterTypeCmd() {}
-func (*AlterTypeRename) alterTypeCmd()      {}
-func (*AlterTypeSetSchema) alterTypeCmd()   {}
-func (*AlterTypeOwner) alterTypeCmd()       {}
-func (*AlterTypeDropValue) alterTypeCmd()   {}
+func (*AlterTypeAddValue) alterTypeCmd()        {}
+func (*AlterTypeRenameValue) alterTypeCmd()     {}
+func (*AlterTypeRename) alterTypeCmd()          {}
+func (*AlterTypeSetSchema) alterTypeCmd()       {}
+func (*AlterTypeOwner) alterTypeCmd()           {}
+func (*AlterTypeDropValue) alterTypeCmd()       {}
+func (*AlterTypeAddAttribute) alterTypeCmd()    {}
+func (*AlterTypeDropAttribute) alterTypeCmd()   {}
+func (*AlterTypeRenameAttribute) alterTypeCmd() {}
 
 var _ AlterTypeCmd = &AlterTypeAddValue{}
 var _ AlterTypeCmd = &AlterTypeRenameValue{}
@@ -39,6 +42,9 @@ var _ AlterTypeCmd = &AlterTypeRename{}
 var _ AlterTypeCmd = &AlterTypeSetSchema{}
 var _ AlterTypeCmd = &AlterTypeOwner{}
 var _ AlterTypeCmd = &AlterTypeDropValue{}
+var _ AlterTypeCmd = &AlterTypeAddAttribute{}
+var _ AlterTypeCmd = &AlterTypeDropAttribute{}
+var _ AlterTypeCmd = &AlterTypeRenameAttribute{}
 
 // AlterTypeAddValue represents an ALTER TYPE ADD VALUE command.
 type AlterTypeAddValue struct {
@@ -111,6 +117,79 @@ func (node *AlterTypeDropValue) TelemetryName() string {
 	return "drop_value"
 }
 
+// AlterTypeAddAttribute represents an ALTER TYPE ADD ATTRIBUTE command.
+type AlterTypeAddAttribute struct {
+	Name         Name
+	Type         ResolvableTypeReference
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTypeAddAttribute) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD ATTRIBUTE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.FormatTypeReference(node.Type)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterTypeAddAttribute) TelemetryName() string {
+	return "add_attribute"
+}
+
+// AlterTypeDropAttribute represents an ALTER TYPE DROP ATTRIBUTE command.
+type AlterTypeDropAttribute struct {
+	Name         Name
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTypeDropAttribute) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP ATTRIBUTE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterTypeDropAttribute) TelemetryName() string {
+	return "drop_attribute"
+}
+
+// AlterTypeRenameAttribute represents an ALTER TYPE RENAME ATTRIBUTE command.
+type AlterTypeRenameAttribute struct {
+	OldName      Name
+	NewName      Name
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTypeRenameAttribute) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME ATTRIBUTE ")
+	ctx.FormatNode(&node.OldName)
+	ctx.WriteString(" TO ")
+	ctx.FormatNode(&node.NewName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// TelemetryName implements the AlterTypeCmd interface.
+func (node *AlterTypeRenameAttribute) TelemetryName() string {
+	return "rename_attribute"
+}
+
 // AlterTypeRename represents an ALTER TYPE RENAME command.
 type AlterTypeRename struct {
 	NewName Name
@@ -67,10 +67,37 @@ type Backup struct {
 	// by the user, then this will be set during BACKUP planning once the destination
 	// has been resolved.
 	Subdir Expr
+
+	// Compact is set when the SQL query is of the form `BACKUP INTO ... COMPACT
+	// ...`, which compacts existing layers of a backup chain instead of backing
+	// up new data.
+	Compact *BackupCompactRange
 }
 
 var _ Statement = &Backup{}
 
+// BackupCompactRange is the range of backup layers compacted by a `BACKUP
+// INTO ... COMPACT [FROM <start>] TO <end>` statement.
+type BackupCompactRange struct {
+	// From is the start time of the first compacted layer. If nil, the chain is
+	// compacted from its full backup.
+	From Expr
+	// To is the end time of the last compacted layer.
+	To Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *BackupCompactRange) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMPACT ")
+	if node.From != nil {
+		ctx.WriteString("FROM ")
+		ctx.FormatNode(node.From)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("TO ")
+	ctx.FormatNode(node.To)
+}
+
 // Format implements the NodeFormatter interface.
 func (node *Backup) Format(ctx *FmtCtx) {
 	ctx.WriteString("BACKUP ")
@@ -90,6 +117,10 @@ func (node *Backup) Format(ctx *FmtCtx) {
 		ctx.WriteString(" ")
 		ctx.FormatNode(&node.AsOf)
 	}
+	if node.Compact != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(node.Compact)
+	}
 
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH OPTIONS (")
@@ -536,6 +536,9 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
+		types.MacAddr,
+		types.Money,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.PGVector,
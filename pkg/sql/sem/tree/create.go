@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -372,6 +373,36 @@ type CompositeTypeElem struct {
 	Type  ResolvableTypeReference
 }
 
+// DomainConstraint is a single constraint in a domain definition.
+type DomainConstraint struct {
+	Name Name
+	// Check is the expression of a CHECK constraint. It is nil for NULL and
+	// NOT NULL constraints.
+	Check Expr
+	// Nullability is NotNull or Null for NOT NULL and NULL constraints, and
+	// SilentNull for CHECK constraints.
+	Nullability Nullability
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch node.Nullability {
+	case NotNull:
+		ctx.WriteString("NOT NULL")
+	case Null:
+		ctx.WriteString("NULL")
+	default:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Check)
+		ctx.WriteByte(')')
+	}
+}
+
 // CreateType represents a CREATE TYPE statement.
 type CreateType struct {
 	TypeName *UnresolvedObjectName
@@ -381,6 +412,10 @@ type CreateType struct {
 	// CompositeTypeList is set when this repesnets a CREATE TYPE ... AS ( )
 	// statement.
 	CompositeTypeList []CompositeTypeElem
+	// DomainType is set when this represents a CREATE DOMAIN statement.
+	DomainType ResolvableTypeReference
+	// DomainConstraints are the constraints of a CREATE DOMAIN statement.
+	DomainConstraints []DomainConstraint
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
 }
@@ -389,6 +424,17 @@ var _ Statement = &CreateType{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateType) Format(ctx *FmtCtx) {
+	if node.Variety == Domain {
+		ctx.WriteString("CREATE DOMAIN ")
+		ctx.FormatNode(node.TypeName)
+		ctx.WriteString(" AS ")
+		ctx.FormatTypeReference(node.DomainType)
+		for i := range node.DomainConstraints {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(&node.DomainConstraints[i])
+		}
+		return
+	}
 	ctx.WriteString("CREATE TYPE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -433,6 +479,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -1113,6 +1160,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -1267,6 +1315,76 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExcludeConstraintTableDef represents an exclusion constraint within a
+// CREATE TABLE statement. The constraint is violated by any two rows for
+// which the operators of all of its elements return true.
+type ExcludeConstraintTableDef struct {
+	Name        Name
+	Elems       ExcludeElemList
+	Predicate   Expr
+	IfNotExists bool
+}
+
+// ExcludeElem is a column of an exclusion constraint, along with the operator
+// used to compare the values of the column in two rows.
+type ExcludeElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	// Exclusion constraints are not backed by an index, so the access method
+	// is always formatted as gist, which is what PostgreSQL requires for most
+	// operators.
+	ctx.WriteString("EXCLUDE USING gist (")
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE (")
+		ctx.FormatNode(node.Predicate)
+		ctx.WriteByte(')')
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1510,7 +1628,10 @@ type CreateTable struct {
 	// these columns.
 	Defs     TableDefs
 	AsSource *Select
-	Locality *Locality
+	// WithNoData is set for CREATE TABLE ... AS ... WITH NO DATA, in which case
+	// the table is created without being populated by the AsSource query.
+	WithNoData bool
+	Locality   *Locality
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1567,6 +1688,9 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		}
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(node.AsSource)
+		if node.WithNoData {
+			ctx.WriteString(" WITH NO DATA")
+		}
 	} else {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
@@ -1868,6 +1992,10 @@ const (
 	LikeTableOptDefaults
 	LikeTableOptGenerated
 	LikeTableOptIndexes
+	LikeTableOptComments
+	LikeTableOptIdentity
+	LikeTableOptStatistics
+	LikeTableOptStorage
 
 	// Make sure this field stays last!
 	likeTableOptInvalid
@@ -1891,6 +2019,14 @@ func (o LikeTableOpt) String() string {
 		return "GENERATED"
 	case LikeTableOptIndexes:
 		return "INDEXES"
+	case LikeTableOptComments:
+		return "COMMENTS"
+	case LikeTableOptIdentity:
+		return "IDENTITY"
+	case LikeTableOptStatistics:
+		return "STATISTICS"
+	case LikeTableOptStorage:
+		return "STORAGE"
 	case LikeTableOptAll:
 		return "ALL"
 	default:
@@ -421,10 +421,10 @@ const (
 )
 
 // IsInParamClass returns true if the given parameter class specifies an input
-// parameter (i.e. either unspecified, IN or, INOUT).
+// parameter (i.e. either unspecified, IN, INOUT, or VARIADIC).
 func IsInParamClass(class RoutineParamClass) bool {
 	switch class {
-	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut:
+	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut, RoutineParamVariadic:
 		return true
 	default:
 		return false
@@ -442,8 +442,8 @@ func IsOutParamClass(class RoutineParamClass) bool {
 	}
 }
 
-// IsInParam returns true if the parameter is an input parameter (i.e. either IN
-// or INOUT).
+// IsInParam returns true if the parameter is an input parameter (i.e. either
+// IN, INOUT, or VARIADIC).
 func (node *RoutineParam) IsInParam() bool {
 	return IsInParamClass(node.Class)
 }
@@ -645,6 +645,7 @@ const (
 	ColumnDefaultExprInNewView      SchemaExprContext = "DEFAULT (in CREATE VIEW)"
 	ColumnDefaultExprInSetDefault   SchemaExprContext = "DEFAULT (in SET DEFAULT)"
 	CheckConstraintExpr             SchemaExprContext = "CHECK"
+	DomainCheckConstraintExpr       SchemaExprContext = "DOMAIN CHECK"
 	UniqueWithoutIndexPredicateExpr SchemaExprContext = "UNIQUE WITHOUT INDEX PREDICATE"
 	IndexPredicateExpr              SchemaExprContext = "INDEX PREDICATE"
 	ExpressionIndexElementExpr      SchemaExprContext = "EXPRESSION INDEX ELEMENT"
@@ -7,6 +7,7 @@ package tree
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -36,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -399,6 +401,23 @@ func ParseDIPAddrFromINetString(s string) (*DIPAddr, error) {
 	return &d, nil
 }
 
+// ParseDCIDR parses and returns the CIDR Datum value represented by the
+// provided string, or an error if parsing is unsuccessful or if the value has
+// bits set to the right of its netmask.
+func ParseDCIDR(s string) (Datum, error) {
+	d, err := ParseDIPAddrFromINetString(s)
+	if err != nil {
+		return nil, err
+	}
+	if !d.IsNetwork() {
+		return nil, errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidTextRepresentation, "invalid cidr value: %q", s),
+			"Value has bits set to right of mask.",
+		)
+	}
+	return NewDCIDR(*d), nil
+}
+
 // GetBool gets DBool or an error (also treats NULL as false, not an error).
 func GetBool(d Datum) (DBool, error) {
 	if v, ok := d.(*DBool); ok {
@@ -1974,12 +1993,17 @@ func (*DIPAddr) AmbiguousFormat() bool {
 
 // Format implements the NodeFormatter interface.
 func (d *DIPAddr) Format(ctx *FmtCtx) {
+	formatIPAddr(ctx, d.IPAddr.String())
+}
+
+// formatIPAddr formats the string representation of an INET or CIDR value.
+func formatIPAddr(ctx *FmtCtx, s string) {
 	f := ctx.flags
 	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
 	if !bareStrings {
 		ctx.WriteByte('\'')
 	}
-	ctx.WriteString(d.IPAddr.String())
+	ctx.WriteString(s)
 	if !bareStrings {
 		ctx.WriteByte('\'')
 	}
@@ -3651,6 +3675,785 @@ func (d *DPGLSN) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DMacAddr is the Datum for the MACADDR and MACADDR8 types. The bytes of the
+// address are stored in Addr in network order, so that the last byte of the
+// address is the least significant byte of Addr.
+type DMacAddr struct {
+	Addr uint64
+	// Is8 is set for MACADDR8 values, which have 8 bytes instead of 6.
+	Is8 bool
+}
+
+const (
+	// maxMacAddr is the largest 6-byte MAC address.
+	maxMacAddr = 1<<48 - 1
+	// macAddr8Marker is the pair of bytes that is inserted in the middle of a
+	// 6-byte MAC address when converting it to an 8-byte MAC address.
+	macAddr8Marker = 0xfffe
+)
+
+// NewDMacAddr returns a new MACADDR Datum.
+func NewDMacAddr(addr uint64) *DMacAddr {
+	return &DMacAddr{Addr: addr}
+}
+
+// NewDMacAddr8 returns a new MACADDR8 Datum.
+func NewDMacAddr8(addr uint64) *DMacAddr {
+	return &DMacAddr{Addr: addr, Is8: true}
+}
+
+// AsDMacAddr attempts to retrieve a *DMacAddr from an Expr, returning a
+// *DMacAddr and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMacAddr wrapped by a *DOidWrapper is possible.
+func AsDMacAddr(e Expr) (*DMacAddr, bool) {
+	switch t := e.(type) {
+	case *DMacAddr:
+		return t, true
+	case *DOidWrapper:
+		return AsDMacAddr(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMacAddr attempts to retrieve a *DMacAddr from an Expr, panicking
+// if the assertion fails.
+func MustBeDMacAddr(e Expr) *DMacAddr {
+	i, ok := AsDMacAddr(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMacAddr, found %T", e))
+	}
+	return i
+}
+
+// ToMacAddr8 returns the address as a MACADDR8 value. A 6-byte address is
+// converted by inserting FF and FE as the 4th and 5th bytes.
+func (d *DMacAddr) ToMacAddr8() *DMacAddr {
+	if d.Is8 {
+		return d
+	}
+	return NewDMacAddr8(d.addr8())
+}
+
+// ToMacAddr returns the address as a MACADDR value. An 8-byte address can
+// only be converted if its 4th and 5th bytes are FF and FE.
+func (d *DMacAddr) ToMacAddr() (*DMacAddr, error) {
+	if !d.Is8 {
+		return d, nil
+	}
+	if (d.Addr>>24)&0xffff != macAddr8Marker {
+		return nil, errors.WithHint(
+			pgerror.New(pgcode.NumericValueOutOfRange,
+				"macaddr8 data out of range to convert to macaddr"),
+			"Only addresses that have FF and FE as values in the 4th and 5th bytes "+
+				"from the left, for example xx:xx:xx:ff:fe:xx:xx:xx, are eligible to "+
+				"be converted from macaddr8 to macaddr.",
+		)
+	}
+	return NewDMacAddr((d.Addr>>40)<<24 | d.Addr&0xffffff), nil
+}
+
+// addr8 returns the 8-byte representation of the address.
+func (d *DMacAddr) addr8() uint64 {
+	if d.Is8 {
+		return d.Addr
+	}
+	return (d.Addr>>24)<<40 | macAddr8Marker<<24 | d.Addr&0xffffff
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMacAddr) ResolvedType() *types.T {
+	if d.Is8 {
+		return types.MacAddr8
+	}
+	return types.MacAddr
+}
+
+// Compare implements the Datum interface.
+func (d *DMacAddr) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMacAddr)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	l, r := d.Addr, v.Addr
+	if d.Is8 != v.Is8 {
+		// A MACADDR compares to a MACADDR8 as its MACADDR8 conversion.
+		l, r = d.addr8(), v.addr8()
+	}
+	return cmp.Compare(l, r), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMacAddr) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx, cmpCtx) {
+		return nil, false
+	}
+	return &DMacAddr{Addr: d.Addr - 1, Is8: d.Is8}, true
+}
+
+// Next implements the Datum interface.
+func (d *DMacAddr) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx, cmpCtx) {
+		return nil, false
+	}
+	return &DMacAddr{Addr: d.Addr + 1, Is8: d.Is8}, true
+}
+
+// IsMax implements the Datum interface.
+func (d *DMacAddr) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	if d.Is8 {
+		return d.Addr == math.MaxUint64
+	}
+	return d.Addr == maxMacAddr
+}
+
+// IsMin implements the Datum interface.
+func (d *DMacAddr) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Addr == 0
+}
+
+// Max implements the Datum interface.
+func (d *DMacAddr) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.Is8 {
+		return NewDMacAddr8(math.MaxUint64), true
+	}
+	return NewDMacAddr(maxMacAddr), true
+}
+
+// Min implements the Datum interface.
+func (d *DMacAddr) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return &DMacAddr{Is8: d.Is8}, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMacAddr) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMacAddr) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	n := 6
+	if d.Is8 {
+		n = 8
+	}
+	const hexDigits = "0123456789abcdef"
+	for i := n - 1; i >= 0; i-- {
+		b := byte(d.Addr >> (8 * i))
+		ctx.WriteByte(hexDigits[b>>4])
+		ctx.WriteByte(hexDigits[b&0xf])
+		if i > 0 {
+			ctx.WriteByte(':')
+		}
+	}
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMacAddr) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DMoney is the Datum for the MONEY type. The amount is stored as a number of
+// cents.
+type DMoney struct {
+	Cents int64
+}
+
+// NewDMoney returns a new MONEY Datum.
+func NewDMoney(cents int64) *DMoney {
+	return &DMoney{Cents: cents}
+}
+
+// AsDMoney attempts to retrieve a *DMoney from an Expr, returning a *DMoney
+// and a flag signifying whether the assertion was successful. The function
+// should be used instead of direct type assertions wherever a *DMoney wrapped
+// by a *DOidWrapper is possible.
+func AsDMoney(e Expr) (*DMoney, bool) {
+	switch t := e.(type) {
+	case *DMoney:
+		return t, true
+	case *DOidWrapper:
+		return AsDMoney(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMoney attempts to retrieve a *DMoney from an Expr, panicking if the
+// assertion fails.
+func MustBeDMoney(e Expr) *DMoney {
+	i, ok := AsDMoney(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMoney, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DMoney) ResolvedType() *types.T {
+	return types.Money
+}
+
+// Compare implements the Datum interface.
+func (d *DMoney) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMoney)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return cmp.Compare(d.Cents, v.Cents), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMoney) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMoney(d.Cents - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMoney) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx, cmpCtx) {
+		return nil, false
+	}
+	return NewDMoney(d.Cents + 1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DMoney) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Cents == math.MaxInt64
+}
+
+// IsMin implements the Datum interface.
+func (d *DMoney) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Cents == math.MinInt64
+}
+
+// Max implements the Datum interface.
+func (d *DMoney) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMoney(math.MaxInt64), true
+}
+
+// Min implements the Datum interface.
+func (d *DMoney) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDMoney(math.MinInt64), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMoney) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMoney) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(FormatMoney(d.Cents))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMoney) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DRange is the Datum representation of the range types. Its bounds are
+// datums of the type returned by the RangeContents method of its type.
+//
+// A range of a discrete type, such as INT4RANGE or DATERANGE, is always in its
+// canonical form, with an inclusive lower bound and an exclusive upper bound.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. A bound is DNull if the
+	// range is unbounded on that side.
+	Lower, Upper Datum
+	// LowerInc and UpperInc are set if the corresponding bound is inclusive.
+	// They are never set for unbounded sides.
+	LowerInc, UpperInc bool
+	// Empty is set if the range contains no values, in which case the bounds
+	// are DNull.
+	Empty bool
+}
+
+// NewDEmptyRange returns the empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Lower: DNull, Upper: DNull, Empty: true}
+}
+
+// NewDRange returns the range of the given range type with the given bounds.
+// A DNull bound makes the range unbounded on that side. The range is
+// canonicalized, and is empty if the bounds are equal and not both inclusive.
+func NewDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) (*DRange, error) {
+	for _, b := range []Datum{lower, upper} {
+		if dd, ok := b.(*DDecimal); ok && dd.Form == apd.NaN {
+			return nil, pgerror.New(pgcode.DataException, "range bound cannot be NaN")
+		}
+	}
+	if lower == DNull {
+		lowerInc = false
+	}
+	if upper == DNull {
+		upperInc = false
+	}
+	d := &DRange{typ: typ, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}
+	if lower != DNull && upper != DNull {
+		if c := compareRangeValues(lower, upper); c > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		} else if c == 0 && !(lowerInc && upperInc) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if err := d.canonicalize(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// canonicalize converts a range of a discrete type to the form with an
+// inclusive lower bound and an exclusive upper bound.
+func (d *DRange) canonicalize() error {
+	if d.Empty || !rangeIsDiscrete(d.typ) {
+		return nil
+	}
+	if d.Lower != DNull && !d.LowerInc {
+		next, err := nextRangeValue(d.typ, d.Lower)
+		if err != nil {
+			return err
+		}
+		d.Lower, d.LowerInc = next, true
+	}
+	if d.Upper != DNull && d.UpperInc {
+		next, err := nextRangeValue(d.typ, d.Upper)
+		if err != nil {
+			return err
+		}
+		d.Upper, d.UpperInc = next, false
+	}
+	if d.Lower != DNull && d.Upper != DNull && compareRangeValues(d.Lower, d.Upper) == 0 {
+		*d = *NewDEmptyRange(d.typ)
+	}
+	return nil
+}
+
+// rangeIsDiscrete returns whether the bounds of the range type have a discrete
+// domain, in which case the ranges of the type are canonicalized.
+func rangeIsDiscrete(typ *types.T) bool {
+	switch typ.Oid() {
+	case oid.T_int4range, oid.T_int8range, oid.T_daterange:
+		return true
+	}
+	return false
+}
+
+// nextRangeValue returns the value following v in the domain of the bounds of
+// a discrete range type.
+func nextRangeValue(typ *types.T, v Datum) (Datum, error) {
+	switch t := v.(type) {
+	case *DInt:
+		maxVal := int64(math.MaxInt64)
+		if typ.Oid() == oid.T_int4range {
+			maxVal = math.MaxInt32
+		}
+		if int64(*t) >= maxVal {
+			return nil, pgerror.Newf(pgcode.NumericValueOutOfRange, "%s out of range", typ.RangeContents().Name())
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(next), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected range bound %T", v)
+}
+
+// compareRangeValues compares two non-NULL bound values of a range.
+func compareRangeValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		return cmp.Compare(*t, *b.(*DInt))
+	case *DDecimal:
+		return t.Cmp(&b.(*DDecimal).Decimal)
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return t.Time.Compare(b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return t.Time.Compare(b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range bound %T", a))
+}
+
+// RangeBound is a bound of a range, as used to compare ranges.
+type RangeBound struct {
+	// Val is the value of the bound, or DNull if the bound is unbounded.
+	Val   Datum
+	Inc   bool
+	Lower bool
+}
+
+// LowerBound returns the lower bound of the range, which must not be empty.
+func (d *DRange) LowerBound() RangeBound {
+	return RangeBound{Val: d.Lower, Inc: d.LowerInc, Lower: true}
+}
+
+// UpperBound returns the upper bound of the range, which must not be empty.
+func (d *DRange) UpperBound() RangeBound {
+	return RangeBound{Val: d.Upper, Inc: d.UpperInc}
+}
+
+// CompareRangeBounds compares two bounds, either of which may be a lower or an
+// upper bound. An exclusive lower bound is just after its value and an
+// exclusive upper bound is just before its value.
+func CompareRangeBounds(a, b RangeBound) int {
+	switch {
+	case a.Val == DNull && b.Val == DNull:
+		if a.Lower == b.Lower {
+			return 0
+		}
+	case a.Val != DNull && b.Val != DNull:
+		if c := compareRangeValues(a.Val, b.Val); c != 0 {
+			return c
+		}
+		switch {
+		case a.Inc && b.Inc:
+			return 0
+		case !a.Inc && !b.Inc:
+			if a.Lower == b.Lower {
+				return 0
+			}
+			if a.Lower {
+				return 1
+			}
+			return -1
+		case a.Inc:
+			if b.Lower {
+				return -1
+			}
+			return 1
+		default:
+			if a.Lower {
+				return 1
+			}
+			return -1
+		}
+	}
+	// Exactly one of the bounds is unbounded, or both are with different
+	// sides.
+	if a.Val == DNull {
+		if a.Lower {
+			return -1
+		}
+		return 1
+	}
+	if b.Lower {
+		return 1
+	}
+	return -1
+}
+
+// rangeBoundsAdjacent returns whether the upper bound and the lower bound,
+// which have no values between them, meet without overlapping.
+func rangeBoundsAdjacent(upper, lower RangeBound) bool {
+	if upper.Val == DNull || lower.Val == DNull {
+		return false
+	}
+	if compareRangeValues(upper.Val, lower.Val) == 0 {
+		return upper.Inc != lower.Inc
+	}
+	return false
+}
+
+// Overlaps returns whether the two ranges have a value in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return CompareRangeBounds(d.LowerBound(), other.UpperBound()) <= 0 &&
+		CompareRangeBounds(other.LowerBound(), d.UpperBound()) <= 0
+}
+
+// Contains returns whether every value of the other range is in the range.
+func (d *DRange) Contains(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return CompareRangeBounds(d.LowerBound(), other.LowerBound()) <= 0 &&
+		CompareRangeBounds(other.UpperBound(), d.UpperBound()) <= 0
+}
+
+// ContainsElem returns whether the value is in the range.
+func (d *DRange) ContainsElem(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	return CompareRangeBounds(d.LowerBound(), RangeBound{Val: v, Inc: true, Lower: true}) <= 0 &&
+		CompareRangeBounds(RangeBound{Val: v, Inc: true}, d.UpperBound()) <= 0
+}
+
+// Adjacent returns whether the two ranges meet without overlapping.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.UpperBound(), other.LowerBound()) ||
+		rangeBoundsAdjacent(other.UpperBound(), d.LowerBound())
+}
+
+// Union returns the union of the two ranges, which must overlap or be
+// adjacent.
+func (d *DRange) Union(other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	if !d.Overlaps(other) && !d.Adjacent(other) {
+		return nil, pgerror.New(pgcode.DataException,
+			"result of range union would not be contiguous")
+	}
+	lower, upper := d.LowerBound(), d.UpperBound()
+	if CompareRangeBounds(other.LowerBound(), lower) < 0 {
+		lower = other.LowerBound()
+	}
+	if CompareRangeBounds(other.UpperBound(), upper) > 0 {
+		upper = other.UpperBound()
+	}
+	return NewDRange(d.typ, lower.Val, upper.Val, lower.Inc, upper.Inc)
+}
+
+// Intersect returns the intersection of the two ranges.
+func (d *DRange) Intersect(other *DRange) (*DRange, error) {
+	if !d.Overlaps(other) {
+		return NewDEmptyRange(d.typ), nil
+	}
+	lower, upper := d.LowerBound(), d.UpperBound()
+	if CompareRangeBounds(other.LowerBound(), lower) > 0 {
+		lower = other.LowerBound()
+	}
+	if CompareRangeBounds(other.UpperBound(), upper) < 0 {
+		upper = other.UpperBound()
+	}
+	return NewDRange(d.typ, lower.Val, upper.Val, lower.Inc, upper.Inc)
+}
+
+// Difference returns the values of the range which are not in the other
+// range, which must not split the range in two.
+func (d *DRange) Difference(other *DRange) (*DRange, error) {
+	if !d.Overlaps(other) {
+		return d, nil
+	}
+	l1, u1 := d.LowerBound(), d.UpperBound()
+	l2, u2 := other.LowerBound(), other.UpperBound()
+	cmpL1L2 := CompareRangeBounds(l1, l2)
+	cmpU1U2 := CompareRangeBounds(u1, u2)
+	switch {
+	case cmpL1L2 < 0 && cmpU1U2 > 0:
+		return nil, pgerror.New(pgcode.DataException,
+			"result of range difference would not be contiguous")
+	case cmpL1L2 >= 0 && cmpU1U2 <= 0:
+		return NewDEmptyRange(d.typ), nil
+	case cmpL1L2 < 0:
+		// The other range covers the upper part of the range.
+		return NewDRange(d.typ, l1.Val, l2.Val, l1.Inc, !l2.Inc)
+	default:
+		// The other range covers the lower part of the range.
+		return NewDRange(d.typ, u2.Val, u1.Val, !u2.Inc, u1.Inc)
+	}
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange
+// and a flag signifying whether the assertion was successful. The function
+// should be used instead of direct type assertions wherever a *DRange wrapped
+// by a *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface. The empty range sorts first, and the
+// other ranges are ordered by their lower bounds, then by their upper bounds.
+func (d *DRange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DRange)
+	if !ok || !d.typ.Equivalent(v.typ) {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	switch {
+	case d.Empty && v.Empty:
+		return 0, nil
+	case d.Empty:
+		return -1, nil
+	case v.Empty:
+		return 1, nil
+	}
+	if c := CompareRangeBounds(d.LowerBound(), v.LowerBound()); c != 0 {
+		return c, nil
+	}
+	return CompareRangeBounds(d.UpperBound(), v.UpperBound()), nil
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range []Datum{d.Lower, d.Upper} {
+		if cdatum, ok := b.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	var buf bytes.Buffer
+	if d.Empty {
+		buf.WriteString("empty")
+	} else {
+		if d.LowerInc {
+			buf.WriteByte('[')
+		} else {
+			buf.WriteByte('(')
+		}
+		d.formatBound(ctx, &buf, d.Lower)
+		buf.WriteByte(',')
+		d.formatBound(ctx, &buf, d.Upper)
+		if d.UpperInc {
+			buf.WriteByte(']')
+		} else {
+			buf.WriteByte(')')
+		}
+	}
+	str := buf.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// formatBound writes the text representation of a bound of the range, which
+// is empty for an unbounded side, and quoted if it contains any of the
+// characters which delimit the bounds.
+func (d *DRange) formatBound(ctx *FmtCtx, buf *bytes.Buffer, b Datum) {
+	if b == DNull {
+		return
+	}
+	s := AsStringWithFlags(b, FmtBareStrings,
+		FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+	quote := s == "" || strings.ContainsAny(s, "\"\\()[], \t\n\r\v\f")
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			// Bounds double " and \.
+			buf.WriteRune(r)
+		}
+		buf.WriteRune(r)
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != DNull {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != DNull {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
 // DPGVector is the Datum representation of the PGVector type.
 type DPGVector struct {
 	vector.T
@@ -4031,7 +4834,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector:
+		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector, *DRange, *DMacAddr, *DMoney, *DJsonpath:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4265,6 +5068,122 @@ func ParseDTSQuery(s string) (Datum, error) {
 	return NewDTSQuery(v), nil
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	jsonpath.Jsonpath
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Jsonpath.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return strings.Compare(d.String(), v.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return uintptr(len(d.Jsonpath.String()))
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(j jsonpath.Jsonpath) *DJsonpath {
+	return &DJsonpath{Jsonpath: j}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (*DJsonpath, error) {
+	j, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(j), nil
+}
+
 // DTSVector is the tsvector Datum.
 type DTSVector struct {
 	tsearch.TSVector
@@ -4792,6 +5711,16 @@ func (d *DTuple) ContainsNull() bool {
 	return false
 }
 
+// IsComposite implements the CompositeDatum interface.
+func (d *DTuple) IsComposite() bool {
+	for _, elem := range d.D {
+		if cdatum, ok := elem.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
 type dNull struct{}
 
 // ResolvedType implements the TypedExpr interface.
@@ -5705,6 +6634,7 @@ func (d *DOid) Name() string {
 // Types that currently benefit from DOidWrapper are:
 // - DName => DOidWrapper(*DString, oid.T_name)
 // - DRefCursor => DOidWrapper(*DString, oid.T_refcursor)
+// - DCIDR => DOidWrapper(*DIPAddr, oid.T_cidr)
 type DOidWrapper struct {
 	Wrapped Datum
 	Oid     oid.Oid
@@ -5718,11 +6648,12 @@ func wrapWithOid(d Datum, oid oid.Oid) Datum {
 	case *DInt:
 	case *DString:
 	case *DArray:
+	case *DIPAddr:
 	case dNull, *DOidWrapper:
 		panic(errors.AssertionFailedf("cannot wrap %T with an Oid", v))
 	default:
-		// Currently only *DInt, *DString, *DArray are hooked up to work with
-		// *DOidWrapper. To support another base Datum type, replace all type
+		// Currently only *DInt, *DString, *DArray, *DIPAddr are hooked up to work
+		// with *DOidWrapper. To support another base Datum type, replace all type
 		// assertions to that type with calls to functions like AsDInt and
 		// MustBeDInt.
 		panic(errors.AssertionFailedf("unsupported Datum type passed to wrapWithOid: %T", d))
@@ -5801,10 +6732,16 @@ func (d *DOidWrapper) AmbiguousFormat() bool {
 
 // Format implements the NodeFormatter interface.
 func (d *DOidWrapper) Format(ctx *FmtCtx) {
-	if d.Oid == oid.T_refcursor {
+	switch d.Oid {
+	case oid.T_refcursor:
 		wrapped := MustBeDString(d.Wrapped)
 		wrapped.Format(ctx)
 		return
+	case oid.T_cidr:
+		// CIDR values are always formatted with their netmask.
+		wrapped := MustBeDIPAddr(d.Wrapped)
+		formatIPAddr(ctx, wrapped.IPAddr.CIDRString())
+		return
 	}
 	ctx.FormatNode(d.Wrapped)
 }
@@ -5885,6 +6822,13 @@ func NewDRefCursor(d string) Datum {
 	return NewDRefCursorFromDString(NewDString(d))
 }
 
+// NewDCIDR is a helper routine to create a *DCIDR (implemented as a
+// *DOidWrapper) initialized from a DIPAddr. The caller must ensure that the
+// address has no bits set to the right of its netmask.
+func NewDCIDR(d DIPAddr) Datum {
+	return wrapWithOid(NewDIPAddr(d), oid.T_cidr)
+}
+
 // NewDIntVectorFromDArray is a helper routine to create a new *DArray,
 // initialized from an existing *DArray, with the special oid for IntVector.
 func NewDIntVectorFromDArray(d *DArray) Datum {
@@ -6081,8 +7025,11 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.DateFamily:           {unsafe.Sizeof(DDate{}), fixedSize},
 	types.GeographyFamily:      {unsafe.Sizeof(DGeography{}), variableSize},
 	types.GeometryFamily:       {unsafe.Sizeof(DGeometry{}), variableSize},
+	types.MacAddrFamily:        {unsafe.Sizeof(DMacAddr{}), fixedSize},
+	types.MoneyFamily:          {unsafe.Sizeof(DMoney{}), fixedSize},
 	types.PGLSNFamily:          {unsafe.Sizeof(DPGLSN{}), fixedSize},
 	types.PGVectorFamily:       {unsafe.Sizeof(DPGVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
 	types.RefCursorFamily:      {unsafe.Sizeof(DString("")), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
@@ -6092,6 +7039,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/lib/pq/oid"
 )
 
 // DatumAlloc provides batch allocation of datum pointers, amortizing the cost
@@ -50,6 +51,8 @@ type DatumAlloc struct {
 	doidAlloc         []DOid
 	dvoidAlloc        []DVoid
 	dpglsnAlloc       []DPGLSN
+	dmacaddrAlloc     []DMacAddr
+	dmoneyAlloc       []DMoney
 	// TODO(yuzefovich): add support for TSQuery and TSVector types.
 	// stringAlloc is used by all datum types that are strings (DBytes, DString, DEncodedKey).
 	stringAlloc []string
@@ -191,6 +194,48 @@ func (a *DatumAlloc) NewDPGLSN(v DPGLSN) *DPGLSN {
 	return r
 }
 
+// NewDMacAddr allocates a DMacAddr.
+func (a *DatumAlloc) NewDMacAddr(v DMacAddr) *DMacAddr {
+	if a == nil {
+		r := new(DMacAddr)
+		*r = v
+		return r
+	}
+	buf := &a.dmacaddrAlloc
+	if len(*buf) == 0 {
+		allocSize := defaultDatumAllocSize
+		if a.DefaultAllocSize != 0 {
+			allocSize = a.DefaultAllocSize
+		}
+		*buf = make([]DMacAddr, allocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
+// NewDMoney allocates a DMoney.
+func (a *DatumAlloc) NewDMoney(v DMoney) *DMoney {
+	if a == nil {
+		r := new(DMoney)
+		*r = v
+		return r
+	}
+	buf := &a.dmoneyAlloc
+	if len(*buf) == 0 {
+		allocSize := defaultDatumAllocSize
+		if a.DefaultAllocSize != 0 {
+			allocSize = a.DefaultAllocSize
+		}
+		*buf = make([]DMoney, allocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
 // NewDFloat allocates a DFloat.
 func (a *DatumAlloc) NewDFloat(v DFloat) *DFloat {
 	if a == nil {
@@ -663,6 +708,11 @@ func (a *DatumAlloc) NewDIPAddr(v DIPAddr) *DIPAddr {
 	return r
 }
 
+// NewDCIDR allocates a DIPAddr wrapped as a CIDR.
+func (a *DatumAlloc) NewDCIDR(v DIPAddr) Datum {
+	return wrapWithOid(a.NewDIPAddr(v), oid.T_cidr)
+}
+
 // NewDJSON allocates a DJSON.
 func (a *DatumAlloc) NewDJSON(v DJSON) *DJSON {
 	if a == nil {
@@ -39,6 +39,8 @@ var (
 	ErrDecOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "decimal out of range")
 	// ErrCharOutOfRange is reported when int cast to ASCII byte overflows.
 	ErrCharOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "\"char\" out of range")
+	// ErrMoneyOutOfRange is reported when money arithmetic overflows.
+	ErrMoneyOutOfRange = pgerror.New(pgcode.NumericValueOutOfRange, "money out of range")
 
 	// ErrDivByZero is reported on a division by zero.
 	ErrDivByZero = pgerror.New(pgcode.DivisionByZero, "division by zero")
@@ -178,6 +180,12 @@ var UnaryOps = unaryOpFixups(map[UnaryOperatorSymbol]*UnaryOpOverloads{
 			EvalOp:     &UnaryMinusIntervalOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			Typ:        types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &UnaryMinusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	UnaryComplement: {overloads: []*UnaryOp{
@@ -199,6 +207,12 @@ var UnaryOps = unaryOpFixups(map[UnaryOperatorSymbol]*UnaryOpOverloads{
 			EvalOp:     &ComplementINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			Typ:        types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &ComplementMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	UnarySqrt: {overloads: []*UnaryOp{
@@ -472,10 +486,39 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// initRangeOperators initializes the union, intersection and difference
+// operators of the range types.
+func initRangeOperators() {
+	for _, t := range types.RangeTypes {
+		addBinOp(treebin.Plus, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &PlusRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		addBinOp(treebin.Minus, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &MinusRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		addBinOp(treebin.Mult, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &MultRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+	}
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -570,6 +613,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitAndINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MacAddr,
+			RightType:  types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &BitAndMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitor: {overloads: []*BinOp{
@@ -594,6 +644,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitOrINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MacAddr,
+			RightType:  types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &BitOrMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitxor: {overloads: []*BinOp{
@@ -796,6 +853,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &PlusPGLSNDecimalOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &PlusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 		{
 			LeftType:   types.PGVector,
 			RightType:  types.PGVector,
@@ -989,6 +1053,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MinusPGLSNOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MinusMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 		{
 			LeftType:   types.PGVector,
 			RightType:  types.PGVector,
@@ -1079,6 +1150,34 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MultIntervalDecimalOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Int,
+			ReturnType: types.Money,
+			EvalOp:     &MultMoneyIntOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Int,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MultIntMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Float,
+			ReturnType: types.Money,
+			EvalOp:     &MultMoneyFloatOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Float,
+			RightType:  types.Money,
+			ReturnType: types.Money,
+			EvalOp:     &MultFloatMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 		{
 			LeftType:   types.PGVector,
 			RightType:  types.PGVector,
@@ -1138,6 +1237,27 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &DivIntervalFloatOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Int,
+			ReturnType: types.Money,
+			EvalOp:     &DivMoneyIntOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Float,
+			ReturnType: types.Money,
+			EvalOp:     &DivMoneyFloatOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.Money,
+			RightType:  types.Money,
+			ReturnType: types.Float,
+			EvalOp:     &DivMoneyOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.FloorDiv: {overloads: []*BinOp{
@@ -1489,16 +1609,65 @@ func cmpOpFixups(
 		))
 	}
 
-	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum, types.AnyCollatedString) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
 		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
+	// Range comparisons, containment and overlap. Each range type gets its own
+	// overloads (rather than using AnyRange) so that the element type of the
+	// containment operators is resolved from the range type.
+	for _, t := range types.RangeTypes {
+		appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.In, makeEvalTupleIn(t, volatility.Immutable))
+		appendCmpOp(treecmp.Contains, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &ContainsRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.Contains, &CmpOp{
+			LeftType:   t,
+			RightType:  t.RangeContents(),
+			EvalOp:     &ContainsRangeElemOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.ContainedBy, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &ContainedByRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.ContainedBy, &CmpOp{
+			LeftType:   t.RangeContents(),
+			RightType:  t,
+			EvalOp:     &ContainedByElemRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.Overlaps, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &OverlapsRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.Adjacent, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &AdjacentRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+	}
+
+	// Array equality comparisons.
+	elemTypes := append(append([]*types.T(nil), types.Scalar...), types.AnyEnum, types.AnyCollatedString)
+	for _, t := range append(elemTypes, types.RangeTypes...) {
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1620,6 +1789,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Int, types.Int, volatility.Leakproof),
 		makeEqFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeEqFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeEqFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeEqFn(types.Money, types.Money, volatility.Leakproof),
 		makeEqFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeEqFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeEqFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1685,6 +1856,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.INet, types.INet, volatility.Leakproof),
 		makeLtFn(types.Int, types.Int, volatility.Leakproof),
 		makeLtFn(types.Interval, types.Interval, volatility.Leakproof),
+		makeLtFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeLtFn(types.Money, types.Money, volatility.Leakproof),
 		makeLtFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLtFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeLtFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1749,6 +1922,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.INet, types.INet, volatility.Leakproof),
 		makeLeFn(types.Int, types.Int, volatility.Leakproof),
 		makeLeFn(types.Interval, types.Interval, volatility.Leakproof),
+		makeLeFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeLeFn(types.Money, types.Money, volatility.Leakproof),
 		makeLeFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLeFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeLeFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1834,6 +2009,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Int, types.Int, volatility.Leakproof),
 		makeIsFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeIsFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeIsFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeIsFn(types.Money, types.Money, volatility.Leakproof),
 		makeIsFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeIsFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeIsFn(types.PGVector, types.PGVector, volatility.Leakproof),
@@ -1907,6 +2084,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.Int, volatility.Leakproof),
 		makeEvalTupleIn(types.Interval, volatility.Leakproof),
 		makeEvalTupleIn(types.Jsonb, volatility.Leakproof),
+		makeEvalTupleIn(types.MacAddr, volatility.Leakproof),
+		makeEvalTupleIn(types.Money, volatility.Leakproof),
 		makeEvalTupleIn(types.Oid, volatility.Leakproof),
 		makeEvalTupleIn(types.PGLSN, volatility.Leakproof),
 		makeEvalTupleIn(types.PGVector, volatility.Leakproof),
@@ -2002,6 +2181,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
 	treecmp.Contains: {overloads: []*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -2063,6 +2251,12 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
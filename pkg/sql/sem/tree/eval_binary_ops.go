@@ -70,6 +70,12 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
+// AdjacentRangeOp is a BinaryEvalOp.
+type AdjacentRangeOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -113,6 +119,8 @@ type (
 	BitAndVarBitOp struct{}
 	// BitAndINetOp is a BinaryEvalOp.
 	BitAndINetOp struct{}
+	// BitAndMacAddrOp is a BinaryEvalOp.
+	BitAndMacAddrOp struct{}
 )
 
 type (
@@ -122,6 +130,8 @@ type (
 	BitOrVarBitOp struct{}
 	// BitOrINetOp is a BinaryEvalOp.
 	BitOrINetOp struct{}
+	// BitOrMacAddrOp is a BinaryEvalOp.
+	BitOrMacAddrOp struct{}
 )
 
 type (
@@ -184,8 +194,12 @@ type (
 	PlusDecimalPGLSNOp struct{}
 	// PlusPGLSNDecimalOp is a BinaryEvalOp.
 	PlusPGLSNDecimalOp struct{}
+	// PlusMoneyOp is a BinaryEvalOp.
+	PlusMoneyOp struct{}
 	// PlusPGVectorOp is a BinaryEvalOp.
 	PlusPGVectorOp struct{}
+	// PlusRangeOp is a BinaryEvalOp.
+	PlusRangeOp struct{}
 )
 
 type (
@@ -237,12 +251,16 @@ type (
 	MinusINetOp struct{}
 	// MinusINetIntOp is a BinaryEvalOp.
 	MinusINetIntOp struct{}
+	// MinusMoneyOp is a BinaryEvalOp.
+	MinusMoneyOp struct{}
 	// MinusPGLSNDecimalOp is a BinaryEvalOp.
 	MinusPGLSNDecimalOp struct{}
 	// MinusPGLSNOp is a BinaryEvalOp.
 	MinusPGLSNOp struct{}
 	// MinusPGVectorOp is a BinaryEvalOp.
 	MinusPGVectorOp struct{}
+	// MinusRangeOp is a BinaryEvalOp.
+	MinusRangeOp struct{}
 )
 type (
 	// MultDecimalIntOp is a BinaryEvalOp.
@@ -267,8 +285,18 @@ type (
 	MultIntervalFloatOp struct{}
 	// MultIntervalIntOp is a BinaryEvalOp.
 	MultIntervalIntOp struct{}
+	// MultMoneyFloatOp is a BinaryEvalOp.
+	MultMoneyFloatOp struct{}
+	// MultFloatMoneyOp is a BinaryEvalOp.
+	MultFloatMoneyOp struct{}
+	// MultMoneyIntOp is a BinaryEvalOp.
+	MultMoneyIntOp struct{}
+	// MultIntMoneyOp is a BinaryEvalOp.
+	MultIntMoneyOp struct{}
 	// MultPGVectorOp is a BinaryEvalOp.
 	MultPGVectorOp struct{}
+	// MultRangeOp is a BinaryEvalOp.
+	MultRangeOp struct{}
 )
 
 type (
@@ -286,6 +314,12 @@ type (
 	DivIntervalFloatOp struct{}
 	// DivIntervalIntOp is a BinaryEvalOp.
 	DivIntervalIntOp struct{}
+	// DivMoneyFloatOp is a BinaryEvalOp.
+	DivMoneyFloatOp struct{}
+	// DivMoneyIntOp is a BinaryEvalOp.
+	DivMoneyIntOp struct{}
+	// DivMoneyOp is a BinaryEvalOp.
+	DivMoneyOp struct{}
 )
 
 type (
@@ -380,6 +414,12 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
@@ -392,8 +432,20 @@ type ContainsArrayOp struct{}
 // ContainsJsonbOp is a BinaryEvalOp.
 type ContainsJsonbOp struct{}
 
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainsRangeElemOp is a BinaryEvalOp.
+type ContainsRangeElemOp struct{}
+
 // ContainedByArrayOp is a BinaryEvalOp.
 type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// ContainedByElemRangeOp is a BinaryEvalOp.
+type ContainedByElemRangeOp struct{}
@@ -24,6 +24,8 @@ type (
 	UnaryMinusIntOp struct{}
 	// UnaryMinusDecimalOp is a UnaryEvalOp.
 	UnaryMinusDecimalOp struct{}
+	// UnaryMinusMoneyOp is a UnaryEvalOp.
+	UnaryMinusMoneyOp struct{}
 )
 type (
 	// ComplementIntOp is a UnaryEvalOp.
@@ -32,6 +34,8 @@ type (
 	ComplementVarBitOp struct{}
 	// ComplementINetOp is a UnaryEvalOp.
 	ComplementINetOp struct{}
+	// ComplementMacAddrOp is a UnaryEvalOp.
+	ComplementMacAddrOp struct{}
 )
 type (
 	// SqrtFloatOp is a UnaryEvalOp.
@@ -117,6 +117,8 @@ const (
 	ExplainFlagShape
 	ExplainFlagViz
 	ExplainFlagRedact
+	ExplainFlagProfile
+	ExplainFlagRanges
 	numExplainFlags = iota
 )
 
@@ -130,6 +132,8 @@ var explainFlagStrings = [...]string{
 	ExplainFlagShape:   "SHAPE",
 	ExplainFlagViz:     "VIZ",
 	ExplainFlagRedact:  "REDACT",
+	ExplainFlagProfile: "PROFILE",
+	ExplainFlagRanges:  "RANGES",
 }
 
 var explainFlagStringMap = func() map[string]ExplainFlag {
@@ -294,6 +298,14 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		}
 	}
 
+	if opts.Flags[ExplainFlagProfile] && (!analyze || opts.Mode != ExplainDebug) {
+		return nil, pgerror.Newf(pgcode.Syntax, "the PROFILE flag can only be used with EXPLAIN ANALYZE (DEBUG)")
+	}
+
+	if opts.Flags[ExplainFlagRanges] && (analyze || opts.Mode != ExplainDDL) {
+		return nil, pgerror.Newf(pgcode.Syntax, "the RANGES flag can only be used with DDL")
+	}
+
 	if analyze {
 		if opts.Mode != ExplainDistSQL && opts.Mode != ExplainDebug && opts.Mode != ExplainPlan {
 			return nil, pgerror.Newf(pgcode.Syntax, "EXPLAIN ANALYZE cannot be used with %s", opts.Mode)
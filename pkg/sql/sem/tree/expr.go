@@ -1272,6 +1272,11 @@ type FuncExpr struct {
 	// InCall is true when the FuncExpr is part of a CALL statement.
 	InCall bool
 
+	// Variadic is true when the last argument is marked VARIADIC, in which case
+	// it is passed as the array for the VARIADIC parameter of a variadic
+	// routine rather than as a single element of that array.
+	Variadic bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1387,7 +1392,19 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if node.Variadic && len(node.Exprs) > 0 {
+		for i := range node.Exprs {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			if i == len(node.Exprs)-1 {
+				ctx.WriteString("VARIADIC ")
+			}
+			ctx.FormatNode(node.Exprs[i])
+		}
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1738,6 +1755,9 @@ func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DBox2D) String() string           { return AsString(node) }
 func (node *DPGLSN) String() string           { return AsString(node) }
+func (node *DMacAddr) String() string         { return AsString(node) }
+func (node *DMoney) String() string           { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
 func (node *DGeography) String() string       { return AsString(node) }
 func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
@@ -323,7 +323,6 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 		}
 		if tryDefaultExprs && len(ol.defaultExprs()) > 0 {
 			// Check whether any of the input arguments might have been omitted.
-			// TODO(88947): this logic might need to change to support VARIADIC.
 			if inputTypes, ok := ol.Types.(ParamTypes); ok {
 				numOmittedExprs := len(inputTypes) - len(paramTypes)
 				if numOmittedExprs > 0 && numOmittedExprs <= len(inputTypes) {
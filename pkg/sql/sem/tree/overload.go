@@ -299,6 +299,10 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// Variadic is true if the last input parameter of the routine is a
+	// VARIADIC parameter, which accepts any number of trailing arguments of
+	// the element type of the parameter's array type.
+	Variadic bool
 
 	// SecurityMode is true when privilege checks during function execution
 	// should be performed against the function owner rather than the invoking
@@ -323,6 +327,29 @@ func (b Overload) defaultExprs() Exprs {
 	return b.DefaultExprs
 }
 
+// ExpandVariadicParams returns the input parameter types that numInputArgs
+// input arguments of a call to the overload are matched against. If the
+// overload is variadic and at least one argument is supplied for its VARIADIC
+// parameter, the VARIADIC array parameter is replaced by one parameter of its
+// element type for each trailing argument. Otherwise, the overload's parameter
+// types are returned unchanged.
+func (b *Overload) ExpandVariadicParams(numInputArgs int) TypeList {
+	params, ok := b.Types.(ParamTypes)
+	if !b.Variadic || !ok || len(params) == 0 || numInputArgs < len(params) {
+		return b.Types
+	}
+	variadicParam := params[len(params)-1]
+	expanded := make(ParamTypes, numInputArgs)
+	copy(expanded, params[:len(params)-1])
+	for i := len(params) - 1; i < numInputArgs; i++ {
+		expanded[i] = ParamType{
+			Name: variadicParam.Name,
+			Typ:  variadicParam.Typ.ArrayContents(),
+		}
+	}
+	return expanded
+}
+
 // FixedReturnType returns a fixed type that the function returns, returning Any
 // if the return type is based on the function's arguments.
 func (b Overload) FixedReturnType() *types.T {
@@ -983,7 +1010,6 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		}
 		// Some "suffix" parameters have DEFAULT expressions, so values for them
 		// can be omitted from the input expressions.
-		// TODO(88947): this logic might need to change to support VARIADIC.
 		paramsLen := params.Length()
 		return paramsLen-len(defaultExprs) <= numInputExprs && numInputExprs <= paramsLen
 	}
@@ -1071,13 +1097,15 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 
 	// Remove any overloads with polymorphic parameters for which the supplied
 	// argument types are invalid.
-	s.overloadIdxs = filterOverloads(s.overloadIdxs, s.overloads, func(o overloadImpl) bool {
+	s.overloadIdxs = filterParams(s.overloadIdxs, s.overloads, s.params, func(o overloadImpl, p TypeList) bool {
 		ol, ok := o.(*Overload)
 		if !ok || ol.Type == BuiltinRoutine {
 			// Don't filter builtin routines.
 			return true
 		}
-		params := ol.Types.(ParamTypes)
+		// Note that the parameters of variadic routines might have been
+		// expanded to match the number of supplied arguments.
+		params := p.(ParamTypes)
 		var outParams ParamTypes
 		if ol.Type == ProcedureRoutine && foundOutParams {
 			outParams = ol.OutParamTypes.(ParamTypes)
@@ -1112,9 +1140,9 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		// Check the concrete types of the arguments supplied for IN parameters.
 		// Only pass the parameters up to len(argTypes), since polymorphic type
 		// checking for default expressions happens later.
-		var anyElemTyp *types.T
-		if ok, _, anyElemTyp = ResolvePolymorphicArgTypes(
-			params[:len(argTypes)], argTypes, nil /* anyElemTyp */, false, /* enforceConsistency */
+		var polyTypes PolymorphicTypes
+		if ok, _, polyTypes = ResolvePolymorphicArgTypes(
+			params[:len(argTypes)], argTypes, PolymorphicTypes{}, false, /* enforceConsistency */
 		); !ok {
 			return false
 		}
@@ -1122,11 +1150,11 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 			return true
 		}
 		// Check the concrete types of the arguments supplied for OUT parameters.
-		// Use the concrete type previously resolved from ANYELEMENT IN parameters.
-		// Note that DEFAULT expressions cannot be used for OUT parameters, so there
-		// is no need to truncate the outParams slice.
+		// Use the concrete types previously resolved from polymorphic IN
+		// parameters. Note that DEFAULT expressions cannot be used for OUT
+		// parameters, so there is no need to truncate the outParams slice.
 		ok, _, _ = ResolvePolymorphicArgTypes(
-			outParams, outArgTypes, anyElemTyp, false, /* enforceConsistency */
+			outParams, outArgTypes, polyTypes, false, /* enforceConsistency */
 		)
 		return ok
 	})
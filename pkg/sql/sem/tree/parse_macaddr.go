@@ -0,0 +1,94 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// ParseDMacAddr parses a MACADDR value from its string representation, which
+// consists of 6 pairs of hexadecimal digits optionally separated by one of
+// ':', '-' or '.'. For example, all of the following represent the same
+// address:
+//
+//	08:00:2b:01:02:03
+//	08-00-2b-01-02-03
+//	08002b:010203
+//	0800.2b01.0203
+//	08002b010203
+func ParseDMacAddr(s string) (*DMacAddr, error) {
+	addr, n, ok := parseMacAddr(s)
+	if !ok || n != 6 {
+		return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type macaddr: \"%s\"", s,
+		)
+	}
+	return NewDMacAddr(addr), nil
+}
+
+// ParseDMacAddr8 parses a MACADDR8 value from its string representation, which
+// uses the same format as MACADDR values but with either 6 or 8 pairs of
+// hexadecimal digits. A 6-byte address is converted to an 8-byte address by
+// inserting FF and FE as the 4th and 5th bytes.
+func ParseDMacAddr8(s string) (*DMacAddr, error) {
+	addr, n, ok := parseMacAddr(s)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type macaddr8: \"%s\"", s,
+		)
+	}
+	if n == 6 {
+		return NewDMacAddr(addr).ToMacAddr8(), nil
+	}
+	return NewDMacAddr8(addr), nil
+}
+
+// parseMacAddr parses 6 or 8 pairs of hexadecimal digits. The pairs can be
+// separated by one of ':', '-' or '.', as long as the same separator is used
+// throughout the string. It returns the parsed address and its number of
+// bytes.
+func parseMacAddr(s string) (addr uint64, n int, ok bool) {
+	s = strings.TrimSpace(s)
+	var sep byte
+	for i := 0; i < len(s); {
+		if n > 0 && (s[i] == ':' || s[i] == '-' || s[i] == '.') {
+			if sep == 0 {
+				sep = s[i]
+			} else if s[i] != sep {
+				return 0, 0, false
+			}
+			i++
+		}
+		if i+1 >= len(s) || n == 8 {
+			return 0, 0, false
+		}
+		hi, ok1 := hexDigitValue(s[i])
+		lo, ok2 := hexDigitValue(s[i+1])
+		if !ok1 || !ok2 {
+			return 0, 0, false
+		}
+		addr = addr<<8 | uint64(hi<<4|lo)
+		n++
+		i += 2
+	}
+	return addr, n, n == 6 || n == 8
+}
+
+// hexDigitValue returns the value of the hexadecimal digit c.
+func hexDigitValue(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMacAddr(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str       string
+		expected  string
+		expected8 string
+	}{
+		{`08:00:2b:01:02:03`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`08-00-2b-01-02-03`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`08002b:010203`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`08002b-010203`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`0800.2b01.0203`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`0800-2b01-0203`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`08002B010203`, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{` 08:00:2b:01:02:03 `, `08:00:2b:01:02:03`, `08:00:2b:ff:fe:01:02:03`},
+		{`08:00:2b:01:02:03:04:05`, ``, `08:00:2b:01:02:03:04:05`},
+		{`08002b0102030405`, ``, `08:00:2b:01:02:03:04:05`},
+		{`ff:ff:ff:ff:ff:ff:ff:ff`, ``, `ff:ff:ff:ff:ff:ff:ff:ff`},
+		{`08:00-2b:01:02:03`, ``, ``},
+		{`08::00:2b:01:02:03`, ``, ``},
+		{`:08:00:2b:01:02:03`, ``, ``},
+		{`08:00:2b:01:02:03:`, ``, ``},
+		{`08:00:2b:01:02`, ``, ``},
+		{`08:00:2b:01:02:03:04`, ``, ``},
+		{`08:00:2b:01:02:03:04:05:06`, ``, ``},
+		{`0800.2b01.020`, ``, ``},
+		{`08:00:2g:01:02:03`, ``, ``},
+		{``, ``, ``},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			d, err := tree.ParseDMacAddr(td.str)
+			if td.expected == `` {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, td.expected, tree.AsStringWithFlags(d, tree.FmtBareStrings))
+			}
+			d8, err := tree.ParseDMacAddr8(td.str)
+			if td.expected8 == `` {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, td.expected8, tree.AsStringWithFlags(d8, tree.FmtBareStrings))
+			}
+			if td.expected != `` {
+				// Converting the MACADDR8 value back must give the MACADDR value.
+				back, err := d8.ToMacAddr()
+				require.NoError(t, err)
+				require.Equal(t, td.expected, tree.AsStringWithFlags(back, tree.FmtBareStrings))
+			} else if td.expected8 != `` {
+				_, err := d8.ToMacAddr()
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// moneyFractionalDigits is the number of fractional digits of MONEY values.
+const moneyFractionalDigits = 2
+
+// ParseDMoney parses a MONEY value from its string representation. The format
+// follows the rules postgres uses for the C locale: the number may be preceded
+// by a '$' currency symbol, uses ',' as an optional group separator and '.'
+// as the decimal point, and is negative if it has a leading or trailing '-' or
+// is enclosed in parentheses. Digits beyond the second fractional digit are
+// rounded. For example:
+//
+//	$1,234.56
+//	-12.345
+//	($1,000)
+func ParseDMoney(s string) (*DMoney, error) {
+	cents, err := parseMoney(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMoney(cents), nil
+}
+
+// parseMoney returns the number of cents represented by the string s.
+func parseMoney(s string) (int64, error) {
+	str := s
+	invalidErr := func() error {
+		return pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type money: \"%s\"", str,
+		)
+	}
+	outOfRangeErr := func() error {
+		return pgerror.Newf(pgcode.NumericValueOutOfRange,
+			"value \"%s\" is out of range for type money", str,
+		)
+	}
+	skipSpaces := func() {
+		s = strings.TrimLeft(s, " \t\n\r\v\f")
+	}
+	skipSymbol := func(sym string) bool {
+		if strings.HasPrefix(s, sym) {
+			s = s[len(sym):]
+			return true
+		}
+		return false
+	}
+
+	skipSpaces()
+	skipSymbol("$")
+	skipSpaces()
+	neg := false
+	if skipSymbol("-") || skipSymbol("(") {
+		neg = true
+	} else {
+		skipSymbol("+")
+	}
+	skipSpaces()
+	skipSymbol("$")
+	skipSpaces()
+
+	// The value is accumulated as a negative number, so that the most negative
+	// value can be represented.
+	var value int64
+	var seenDigit, seenDot bool
+	var dec int
+	for ; len(s) > 0; s = s[1:] {
+		c := s[0]
+		if isASCIIDigit(c) && (!seenDot || dec < moneyFractionalDigits) {
+			digit := int64(c - '0')
+			if value < (math.MinInt64+digit)/10 {
+				return 0, outOfRangeErr()
+			}
+			value = value*10 - digit
+			if seenDot {
+				dec++
+			}
+			seenDigit = true
+		} else if c == '.' && !seenDot {
+			seenDot = true
+		} else if c != ',' {
+			break
+		}
+	}
+	if !seenDigit {
+		return 0, invalidErr()
+	}
+
+	// Round off if there is another digit.
+	if len(s) > 0 && isASCIIDigit(s[0]) && s[0] >= '5' {
+		if value == math.MinInt64 {
+			return 0, outOfRangeErr()
+		}
+		value--
+	}
+	// Adjust for less than the required number of fractional digits.
+	for ; dec < moneyFractionalDigits; dec++ {
+		if value < math.MinInt64/10 {
+			return 0, outOfRangeErr()
+		}
+		value *= 10
+	}
+
+	// Only trailing digits followed by whitespace, a right parenthesis, a
+	// trailing sign or a trailing currency symbol are allowed.
+	s = strings.TrimLeft(s, "0123456789")
+	for len(s) > 0 {
+		switch s[0] {
+		case ' ', '\t', '\n', '\r', '\v', '\f', ')', '+', '$':
+		case '-':
+			neg = true
+		default:
+			return 0, invalidErr()
+		}
+		s = s[1:]
+	}
+
+	if !neg {
+		if value == math.MinInt64 {
+			return 0, outOfRangeErr()
+		}
+		value = -value
+	}
+	return value, nil
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// FormatMoney returns the string representation of a MONEY value with the
+// given number of cents, such as $1,234.56 or -$0.05.
+func FormatMoney(cents int64) string {
+	u := uint64(cents)
+	if cents < 0 {
+		u = -u
+	}
+	whole := strconv.FormatUint(u/100, 10)
+	frac := u % 100
+
+	var b strings.Builder
+	b.Grow(len(whole) + len(whole)/3 + 5)
+	if cents < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteByte('$')
+	for i := 0; i < len(whole); i++ {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte(whole[i])
+	}
+	b.WriteByte('.')
+	b.WriteByte(byte('0' + frac/10))
+	b.WriteByte(byte('0' + frac%10))
+	return b.String()
+}
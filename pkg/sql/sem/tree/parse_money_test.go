@@ -0,0 +1,82 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		expected string
+		err      string
+	}{
+		{`0`, `$0.00`, ``},
+		{`12.3`, `$12.30`, ``},
+		{`$1,234.56`, `$1,234.56`, ``},
+		{`1234.567`, `$1,234.57`, ``},
+		{`1234.564`, `$1,234.56`, ``},
+		{` $ 5 `, `$5.00`, ``},
+		{`-12.345`, `-$12.35`, ``},
+		{`($1,000)`, `-$1,000.00`, ``},
+		{`1-`, `-$1.00`, ``},
+		{`+7`, `$7.00`, ``},
+		{`92233720368547758.07`, `$92,233,720,368,547,758.07`, ``},
+		{`-92233720368547758.08`, `-$92,233,720,368,547,758.08`, ``},
+		{`92233720368547758.08`, ``, `value "92233720368547758.08" is out of range for type money`},
+		{`-92233720368547758.09`, ``, `value "-92233720368547758.09" is out of range for type money`},
+		{`1000000000000000000000`, ``, `value "1000000000000000000000" is out of range for type money`},
+		{``, ``, `invalid input syntax for type money: ""`},
+		{`$`, ``, `invalid input syntax for type money: "$"`},
+		{`abc`, ``, `invalid input syntax for type money: "abc"`},
+		{`1e5`, ``, `invalid input syntax for type money: "1e5"`},
+		{`1.2.3`, ``, `invalid input syntax for type money: "1.2.3"`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			d, err := tree.ParseDMoney(td.str)
+			if td.err != `` {
+				require.EqualError(t, err, td.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(d, tree.FmtBareStrings))
+		})
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		cents    int64
+		expected string
+	}{
+		{0, `$0.00`},
+		{5, `$0.05`},
+		{-5, `-$0.05`},
+		{100, `$1.00`},
+		{99999, `$999.99`},
+		{100000, `$1,000.00`},
+		{-123456789, `-$1,234,567.89`},
+		{math.MaxInt64, `$92,233,720,368,547,758.07`},
+		{math.MinInt64, `-$92,233,720,368,547,758.08`},
+	}
+	for _, td := range testData {
+		require.Equal(t, td.expected, tree.FormatMoney(td.cents))
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal")
+
+// ParseDRangeFromString parses the string-form of constructing ranges, such as
+// `'[1,10)'::int8range` or `'empty'::daterange`. The input type t is the type
+// of the range to parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	ret, dependsOnContext, err := doParseDRangeFromString(ctx, s, t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return ret, dependsOnContext, nil
+}
+
+// doParseDRangeFromString does most of the work of ParseDRangeFromString,
+// except the error it returns isn't prettified as a parsing error.
+func doParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	contents := t.RangeContents()
+	if contents == nil {
+		return nil, false, errors.AssertionFailedf("not a range type %s", t.SQLStringForError())
+	}
+	if contents.Family() == types.AnyFamily {
+		return nil, false, pgerror.Newf(pgcode.FeatureNotSupported, "cannot parse anyrange literal")
+	}
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		return NewDEmptyRange(t), false, nil
+	}
+	if s == "" {
+		return nil, false, errors.WithDetail(malformedRangeError, `Missing left parenthesis or bracket.`)
+	}
+	var lowerInc, upperInc bool
+	switch s[0] {
+	case '[':
+		lowerInc = true
+	case '(':
+	default:
+		return nil, false, errors.WithDetail(malformedRangeError, `Missing left parenthesis or bracket.`)
+	}
+	s = s[1:]
+
+	lowerStr, lowerInf, s, err := parseRangeBound(s, ',')
+	if err != nil {
+		return nil, false, err
+	}
+	if s == "" {
+		return nil, false, errors.WithDetail(malformedRangeError, `Missing comma after lower bound.`)
+	}
+	s = s[1:]
+	upperStr, upperInf, s, err := parseRangeBound(s, ')')
+	if err != nil {
+		return nil, false, err
+	}
+	if s == "" {
+		return nil, false, errors.WithDetail(malformedRangeError, `Missing right parenthesis or bracket.`)
+	}
+	switch s[0] {
+	case ']':
+		upperInc = true
+	case ')':
+	default:
+		return nil, false, errors.WithDetail(malformedRangeError, `Too many commas.`)
+	}
+	if strings.TrimSpace(s[1:]) != "" {
+		return nil, false, errors.WithDetail(malformedRangeError, `Junk after right parenthesis or bracket.`)
+	}
+
+	lower, upper := Datum(DNull), Datum(DNull)
+	if !lowerInf {
+		var ctxDep bool
+		lower, ctxDep, err = ParseAndRequireString(contents, lowerStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || ctxDep
+	}
+	if !upperInf {
+		var ctxDep bool
+		upper, ctxDep, err = ParseAndRequireString(contents, upperStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || ctxDep
+	}
+	ret, err := NewDRange(t, lower, upper, lowerInc, upperInc)
+	if err != nil {
+		return nil, false, err
+	}
+	return ret, dependsOnContext, nil
+}
+
+// parseRangeBound parses a bound of a range literal up to the given delimiter,
+// or the closing bracket in the case of the upper bound, returning the bound
+// and the remainder of the string starting at the delimiter. An empty
+// unquoted bound is unbounded. Within a bound, double quotes may be used to
+// include the delimiting characters, and a backslash escapes the following
+// character.
+func parseRangeBound(s string, delim byte) (bound string, inf bool, rest string, _ error) {
+	isDelim := func(ch byte) bool {
+		if delim == ',' {
+			return ch == ','
+		}
+		return ch == ')' || ch == ']'
+	}
+	var result strings.Builder
+	inQuote, quoted := false, false
+	i := 0
+	for ; i < len(s); i++ {
+		ch := s[i]
+		if !inQuote && (isDelim(ch) || ch == '(' || ch == '[' || (delim == ')' && ch == ',')) {
+			break
+		}
+		switch {
+		case ch == '\\':
+			i++
+			if i >= len(s) {
+				return "", false, "", errors.WithDetail(malformedRangeError, `Unexpected end of input.`)
+			}
+			result.WriteByte(s[i])
+		case ch == '"':
+			quoted = true
+			if inQuote && i+1 < len(s) && s[i+1] == '"' {
+				// Two double quotes within quotes are one double quote.
+				result.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+		default:
+			result.WriteByte(ch)
+		}
+	}
+	if inQuote {
+		return "", false, "", errors.WithDetail(malformedRangeError, `Unexpected end of input.`)
+	}
+	rest = s[i:]
+	if rest != "" && (rest[0] == '(' || rest[0] == '[') {
+		return "", false, "", malformedRangeError
+	}
+	bound = result.String()
+	if !quoted && bound == "" {
+		return "", true, rest, nil
+	}
+	return bound, false, rest, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`[1,10)`, types.Int8Range, `[1,10)`},
+		{` [ 1 , 10 ) `, types.Int8Range, `[1,10)`},
+		{`(1,10]`, types.Int8Range, `[2,11)`},
+		{`[1,1)`, types.Int4Range, `empty`},
+		{`(1,2)`, types.Int4Range, `empty`},
+		{`[1,1]`, types.Int4Range, `[1,2)`},
+		{`EMPTY`, types.Int8Range, `empty`},
+		{`(,5)`, types.Int8Range, `(,5)`},
+		{`[,5]`, types.Int8Range, `(,6)`},
+		{`(5,]`, types.Int8Range, `[6,)`},
+		{`(,)`, types.Int8Range, `(,)`},
+		{`[1.5,2.5]`, types.NumRange, `[1.5,2.5]`},
+		{`(1.5,1.5]`, types.NumRange, `empty`},
+		{`["1.5","2.5")`, types.NumRange, `[1.5,2.5)`},
+		{`[2024-01-01,2024-01-31]`, types.DateRange, `[2024-01-01,2024-02-01)`},
+		{
+			`[2024-01-01 00:00:00,2024-01-02 00:00:00)`,
+			types.TSRange,
+			`["2024-01-01 00:00:00","2024-01-02 00:00:00")`,
+		},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			actual, _, err := tree.ParseDRangeFromString(evalContext, td.str, td.typ)
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(actual, tree.FmtBareStrings))
+
+			// The formatted range must round-trip.
+			roundTripped, _, err := tree.ParseDRangeFromString(evalContext, td.expected, td.typ)
+			require.NoError(t, err)
+			cmp, err := actual.Compare(context.Background(), evalContext, roundTripped)
+			require.NoError(t, err)
+			require.Equal(t, 0, cmp)
+		})
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str           string
+		typ           *types.T
+		expectedError string
+	}{
+		{``, types.Int8Range, `malformed range literal`},
+		{`1,10`, types.Int8Range, `malformed range literal`},
+		{`[1,10`, types.Int8Range, `malformed range literal`},
+		{`[1,2,3)`, types.Int8Range, `malformed range literal`},
+		{`[1,10) x`, types.Int8Range, `malformed range literal`},
+		{`[10,1)`, types.Int8Range, `range lower bound must be less than or equal to range upper bound`},
+		{`[a,b)`, types.Int8Range, `could not parse "a" as type int`},
+		{`[NaN,1)`, types.NumRange, `range bound cannot be NaN`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			_, _, err := tree.ParseDRangeFromString(evalContext, td.str, td.typ)
+			require.ErrorContains(t, err, td.expectedError)
+		})
+	}
+}
+
+func TestRangeOperators(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	parse := func(s string) *tree.DRange {
+		r, _, err := tree.ParseDRangeFromString(evalContext, s, types.Int8Range)
+		require.NoError(t, err)
+		return r
+	}
+	format := func(r *tree.DRange) string {
+		return tree.AsStringWithFlags(r, tree.FmtBareStrings)
+	}
+
+	testData := []struct {
+		a, b                   string
+		overlaps, contains     bool
+		adjacent               bool
+		union, intersect, diff string
+	}{
+		{a: `[1,5)`, b: `[3,8)`, overlaps: true, union: `[1,8)`, intersect: `[3,5)`, diff: `[1,3)`},
+		{a: `[1,5)`, b: `[5,8)`, adjacent: true, union: `[1,8)`, intersect: `empty`, diff: `[1,5)`},
+		{a: `[1,10)`, b: `[3,5)`, overlaps: true, contains: true, union: `[1,10)`, intersect: `[3,5)`},
+		{a: `(,)`, b: `[3,5)`, overlaps: true, contains: true, union: `(,)`, intersect: `[3,5)`},
+		{a: `[1,5)`, b: `empty`, contains: true, union: `[1,5)`, intersect: `empty`, diff: `[1,5)`},
+		{a: `[3,5)`, b: `[1,10)`, overlaps: true, union: `[1,10)`, intersect: `[3,5)`, diff: `empty`},
+		{a: `[1,3)`, b: `[5,8)`, intersect: `empty`, diff: `[1,3)`},
+	}
+	for _, td := range testData {
+		t.Run(td.a+" "+td.b, func(t *testing.T) {
+			a, b := parse(td.a), parse(td.b)
+			require.Equal(t, td.overlaps, a.Overlaps(b))
+			require.Equal(t, td.overlaps, b.Overlaps(a))
+			require.Equal(t, td.contains, a.Contains(b))
+			require.Equal(t, td.adjacent, a.Adjacent(b))
+			require.Equal(t, td.adjacent, b.Adjacent(a))
+			if td.union != "" {
+				u, err := a.Union(b)
+				require.NoError(t, err)
+				require.Equal(t, td.union, format(u))
+			} else {
+				_, err := a.Union(b)
+				require.ErrorContains(t, err, "result of range union would not be contiguous")
+			}
+			i, err := a.Intersect(b)
+			require.NoError(t, err)
+			require.Equal(t, td.intersect, format(i))
+			if td.diff != "" {
+				d, err := a.Difference(b)
+				require.NoError(t, err)
+				require.Equal(t, td.diff, format(d))
+			} else {
+				_, err := a.Difference(b)
+				require.ErrorContains(t, err, "result of range difference would not be contiguous")
+			}
+		})
+	}
+}
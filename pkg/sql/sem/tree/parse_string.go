@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -53,7 +54,11 @@ func ParseAndRequireString(
 	case types.FloatFamily:
 		d, err = ParseDFloat(strings.TrimSpace(s))
 	case types.INetFamily:
-		d, err = ParseDIPAddrFromINetString(s)
+		if t.Oid() == oid.T_cidr {
+			d, err = ParseDCIDR(s)
+		} else {
+			d, err = ParseDIPAddrFromINetString(s)
+		}
 	case types.IntFamily:
 		d, err = ParseDInt(strings.TrimSpace(s))
 	case types.IntervalFamily:
@@ -62,10 +67,20 @@ func ParseAndRequireString(
 			return nil, false, typErr
 		}
 		d, err = ParseDIntervalWithTypeMetadata(intervalStyle(ctx), s, itm)
+	case types.MacAddrFamily:
+		if t.Oid() == oidext.T_macaddr8 {
+			d, err = ParseDMacAddr8(s)
+		} else {
+			d, err = ParseDMacAddr(s)
+		}
+	case types.MoneyFamily:
+		d, err = ParseDMoney(s)
 	case types.PGLSNFamily:
 		d, err = ParseDPGLSN(s)
 	case types.PGVectorFamily:
 		d, err = ParseDPGVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
 	case types.RefCursorFamily:
 		d = NewDRefCursor(s)
 	case types.Box2DFamily:
@@ -76,6 +91,8 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == UnknownOidName {
 			d = NewDOidWithType(UnknownOidValue, t)
@@ -730,6 +730,17 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 
 	if len(node.Exprs) > 0 {
 		args := node.Exprs.doc(p)
+		if node.Variadic {
+			d := make([]pretty.Doc, len(node.Exprs))
+			for i, e := range node.Exprs {
+				if p.Simplify {
+					e = StripParens(e)
+				}
+				d[i] = p.Doc(e)
+			}
+			d[len(d)-1] = pretty.ConcatSpace(pretty.Keyword("VARIADIC"), d[len(d)-1])
+			args = p.commaSeparated(d...)
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1243,6 +1254,9 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 	if node.Tuple {
 		d = p.bracket("(", d, ")")
 	}
+	if node.Field != "" {
+		d = pretty.Concat(d, pretty.Concat(pretty.Text("."), p.Doc(&node.Field)))
+	}
 	e := node.Expr
 	if p.Simplify {
 		e = StripParens(e)
@@ -1290,6 +1304,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	clauses := make([]pretty.Doc, 0, 4)
 	if node.As() {
 		clauses = append(clauses, p.Doc(node.AsSource))
+		if node.WithNoData {
+			clauses = append(clauses, pretty.Keyword("WITH NO DATA"))
+		}
 	}
 	if node.PartitionByTable != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByTable))
@@ -2153,7 +2170,7 @@ func (node *ReferenceActions) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *Backup) doc(p *PrettyCfg) pretty.Doc {
-	items := make([]pretty.TableRow, 0, 7)
+	items := make([]pretty.TableRow, 0, 9)
 
 	items = append(items, p.row("BACKUP", pretty.Nil))
 	if node.Targets != nil {
@@ -2171,6 +2188,14 @@ func (node *Backup) doc(p *PrettyCfg) pretty.Doc {
 	if node.AsOf.Expr != nil {
 		items = append(items, node.AsOf.docRow(p))
 	}
+	if node.Compact != nil {
+		if node.Compact.From != nil {
+			items = append(items, p.row("COMPACT FROM", p.Doc(node.Compact.From)))
+			items = append(items, p.row("TO", p.Doc(node.Compact.To)))
+		} else {
+			items = append(items, p.row("COMPACT TO", p.Doc(node.Compact.To)))
+		}
+	}
 	if !node.Options.IsDefault() {
 		items = append(items, p.row("WITH", p.Doc(&node.Options)))
 	}
@@ -88,6 +88,8 @@ const (
 	BackupValidateDetails
 	// BackupConnectionTest identifies a SHOW BACKUP CONNECTION statement
 	BackupConnectionTest
+	// BackupRowDetails identifies a SHOW BACKUP ROWS statement.
+	BackupRowDetails
 )
 
 // TODO (msbutler): 22.2 after removing old style show backup syntax, rename
@@ -102,6 +104,11 @@ type ShowBackup struct {
 	From         bool
 	Details      ShowBackupDetails
 	Options      ShowBackupOptions
+
+	// Table and AsOf are only set for SHOW BACKUP ROWS, and name the table
+	// whose rows are read and the time they are read as of.
+	Table *UnresolvedObjectName
+	AsOf  AsOfClause
 }
 
 // Format implements the NodeFormatter interface.
@@ -122,6 +129,10 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("SCHEMAS ")
 	case BackupConnectionTest:
 		ctx.WriteString("CONNECTION ")
+	case BackupRowDetails:
+		ctx.WriteString("ROWS FOR TABLE ")
+		ctx.FormatNode(node.Table)
+		ctx.WriteString(" ")
 	}
 
 	if node.From {
@@ -135,6 +146,10 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 	} else {
 		ctx.FormatURI(node.Path)
 	}
+	if node.AsOf.Expr != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.AsOf)
+	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH OPTIONS (")
 		ctx.FormatNode(&node.Options)
@@ -9,12 +9,14 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // presetTypesForTesting is a mapping of qualified names to types that can be mocked out
@@ -69,6 +71,10 @@ func SampleDatum(t *types.T) Datum {
 		u, _ := ParseDUuidFromString("3189ad07-52f2-4d60-83e8-4a8347fef718")
 		return u
 	case types.INetFamily:
+		if t.Oid() == oid.T_cidr {
+			c, _ := ParseDCIDR("127.0.0.0/24")
+			return c
+		}
 		i, _ := ParseDIPAddrFromINetString("127.0.0.1")
 		return i
 	case types.JsonFamily:
@@ -76,6 +82,14 @@ func SampleDatum(t *types.T) Datum {
 		return j
 	case types.OidFamily:
 		return NewDOidWithType(1009, t)
+	case types.MacAddrFamily:
+		m, _ := ParseDMacAddr("08:00:2b:01:02:03")
+		if t.Oid() == oidext.T_macaddr8 {
+			return m.ToMacAddr8()
+		}
+		return m
+	case types.MoneyFamily:
+		return NewDMoney(123456)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
 	case types.RefCursorFamily:
@@ -65,6 +65,8 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	Adjacent
+	JSONPathExists
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -117,6 +119,8 @@ var comparisonOpName = [...]string{
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
 	TSMatches:         "@@",
+	Adjacent:          "-|-",
+	JSONPathExists:    "@?",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -148,3 +152,14 @@ func ComparisonOpName(op ComparisonOperatorSymbol) string {
 	}
 	return comparisonOpName[op]
 }
+
+// ComparisonOperatorSymbolFromName returns the symbol with the given name, as
+// returned by ComparisonOpName, and whether there is such a symbol.
+func ComparisonOperatorSymbolFromName(name string) (ComparisonOperatorSymbol, bool) {
+	for i, n := range comparisonOpName {
+		if n == name {
+			return ComparisonOperatorSymbol(i), true
+		}
+	}
+	return 0, false
+}
@@ -1229,10 +1229,30 @@ func (expr *FuncExpr) TypeCheck(
 		return sb.String()
 	}
 
+	if expr.Variadic {
+		// Only variadic routines can be called with a VARIADIC argument.
+		variadicDef := &ResolvedFunctionDefinition{Name: def.Name}
+		for _, o := range def.Overloads {
+			if o.Variadic {
+				variadicDef.Overloads = append(variadicDef.Overloads, o)
+			}
+		}
+		if len(variadicDef.Overloads) == 0 {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.UndefinedFunction,
+					"%s does not have a VARIADIC parameter", &expr.Func),
+				"VARIADIC can only be used for the last argument of a call to a "+
+					"user-defined routine with a VARIADIC parameter.",
+			)
+		}
+		def = variadicDef
+	}
+
 	s := getOverloadTypeChecker(
 		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
 	)
 	defer s.release()
+	s.expandVariadicParams(expr)
 
 	if err = expr.typeCheckWithFuncAncestor(semaCtx, func() error {
 		if err := s.typeCheckOverloadedExprs(ctx, semaCtx, desired, false /* inBinOp */); err != nil {
@@ -1261,6 +1281,7 @@ func (expr *FuncExpr) TypeCheck(
 				}()
 				s2 := getOverloadTypeChecker((*qualifiedOverloads)(&functionOverloads), expr.Exprs...)
 				defer s2.release()
+				s2.expandVariadicParams(expr)
 				err2 := s2.typeCheckOverloadedExprs(ctx, semaCtx, desired, false /* inBinOp */)
 				if err2 == nil && len(s2.overloadIdxs) > 0 {
 					// This time we found a match, so return the proper error.
@@ -2143,12 +2164,30 @@ func (d *DPGLSN) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Typed
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMacAddr) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMoney) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DPGVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -2167,6 +2206,12 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -3476,6 +3521,33 @@ func (v stripFuncsVisitor) VisitPre(expr Expr) (recurse bool, newExpr Expr) {
 
 func (stripFuncsVisitor) VisitPost(expr Expr) Expr { return expr }
 
+// inputParams returns the parameters that the arguments of the given function
+// expression are matched against for the given overload. The VARIADIC
+// parameter of a variadic routine is matched against each of the trailing
+// arguments, unless the last argument is explicitly marked VARIADIC.
+func inputParams(ov overloadImpl, expr *FuncExpr) TypeList {
+	ol, ok := ov.(*Overload)
+	if !ok || !ol.Variadic || expr.Variadic {
+		return ov.params()
+	}
+	numInputArgs := len(expr.Exprs)
+	if expr.InCall && ol.Type == ProcedureRoutine {
+		// The arguments of a CALL statement include the OUT parameters of the
+		// procedure.
+		numInputArgs -= len(ol.OutParamOrdinals)
+	}
+	return ol.ExpandVariadicParams(numInputArgs)
+}
+
+// expandVariadicParams replaces the parameters of variadic routine overloads
+// with the parameters that the arguments of the given function expression are
+// matched against. See inputParams for details.
+func (s *overloadTypeChecker) expandVariadicParams(expr *FuncExpr) {
+	for i := range s.overloads {
+		s.params[i] = inputParams(s.overloads[i], expr)
+	}
+}
+
 // getMostSignificantOverload returns the overload from the most significant
 // schema. If there are more than one overload available from the most
 // significant schema, ambiguity error will be thrown. If search path is not
@@ -3522,7 +3594,7 @@ func getMostSignificantOverload(
 		foundMatch := false
 		for k, idx := range oImpls {
 			candidate := overloads[idx]
-			srcParams := candidate.params()
+			srcParams := inputParams(candidate, expr)
 			matches := srcParams.MatchIdentical(allArgTypes)
 			if !matches {
 				routineType, outParamOrdinals, _ := candidate.outParamInfo()
@@ -3552,8 +3624,6 @@ func getMostSignificantOverload(
 				} else {
 					inputTypes = allArgTypes
 				}
-				// TODO(88947): this logic might need to change to support
-				// VARIADIC.
 				ovInputTypes, ok := srcParams.(ParamTypes)
 				if !ok {
 					return QualifiedOverload{}, errors.AssertionFailedf("overload params is %T and not ParamTypes", srcParams)
@@ -3647,42 +3717,99 @@ func getMostSignificantOverload(
 	return ret, nil
 }
 
+// PolymorphicTypes contains the concrete types resolved for the polymorphic
+// parameters of a routine invocation.
+type PolymorphicTypes struct {
+	// AnyElement is the concrete type of ANYELEMENT and ANYENUM parameters, and
+	// the element type of ANYARRAY parameters.
+	AnyElement *types.T
+	// AnyCompatible is the common type of ANYCOMPATIBLE parameters, and the
+	// element type of ANYCOMPATIBLEARRAY parameters.
+	AnyCompatible *types.T
+}
+
+// ReplacePolymorphicType returns the concrete type for the given polymorphic
+// type. It returns the given type if it is not polymorphic, or if no concrete
+// type was resolved for it.
+func (p PolymorphicTypes) ReplacePolymorphicType(typ *types.T) (*types.T, error) {
+	if !typ.IsPolymorphicType() {
+		return typ, nil
+	}
+	concreteTyp := p.AnyElement
+	if typ.IsAnyCompatibleType() {
+		concreteTyp = p.AnyCompatible
+	}
+	if concreteTyp == nil {
+		return typ, nil
+	}
+	if typ.Family() == types.ArrayFamily {
+		if concreteTyp.Family() == types.ArrayFamily {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find array type for data type %s", concreteTyp.Name(),
+			)
+		}
+		return types.MakeArray(concreteTyp), nil
+	}
+	return concreteTyp, nil
+}
+
 // ResolvePolymorphicArgTypes iterates through the list of routine parameters
 // and supplied arguments (including default expressions), and attempts to
 // determine the concrete types for any polymorphic-typed parameters. It returns
-// true if the supplied argument types are valid, as well as the determined
-// element type (nil if there were no polymorphic parameters).
+// true if the supplied argument types are valid, as well as the resolved
+// concrete types.
 //
-// CRDB currently supports two polymorphic types:
+// CRDB currently supports the following polymorphic types:
 // * ANYELEMENT allows any argument type.
 // * ANYARRAY allows only array types.
+// * ANYENUM allows only enum types.
+// * ANYCOMPATIBLE allows any argument type.
+// * ANYCOMPATIBLEARRAY allows only array types.
 //
 // The rules for argument validity are as follows:
-//  1. The arguments supplied for ANYELEMENT parameters must all have the same
-//     type.
+//  1. The arguments supplied for ANYELEMENT and ANYENUM parameters must all
+//     have the same type, and that type must be an enum if there is at least
+//     one ANYENUM parameter.
 //  2. The supplied types for ANYARRAY parameters must match each other, and the
-//     array *element* type must match all ANYELEMENT parameters.
-//  3. NULL arguments are exempt from the above two rules. However, there must
-//     be at least one non-NULL argument in order to resolve a concrete type.
+//     array *element* type must match all ANYELEMENT and ANYENUM parameters.
+//  3. The arguments supplied for ANYCOMPATIBLE parameters and the element types
+//     of the arguments supplied for ANYCOMPATIBLEARRAY parameters must all be
+//     implicitly castable to a common type, which is resolved independently of
+//     the other polymorphic types.
+//  4. NULL arguments are exempt from the above rules. However, there must be at
+//     least one non-NULL argument in each family of polymorphic parameters in
+//     order to resolve a concrete type for it.
 //
-// anyElemTyp, if non-nil, allows the caller to pass in the expected concrete
-// type for ANYELEMENT parameters.
+// resolved allows the caller to pass in the expected concrete types, if they
+// are already known.
 //
 // enforceConsistency, if true, indicates that ResolvePolymorphicArgTypes should
 // throw a suitable error in the case of invalid arguments, rather than
 // returning with ok=false. Note: we do this instead of always returning the
 // error because error construction can be expensive.
 func ResolvePolymorphicArgTypes(
-	paramTypes ParamTypes, argTypes []*types.T, anyElemTyp *types.T, enforceConsistency bool,
-) (ok bool, numPolyParams int, _ *types.T) {
+	paramTypes ParamTypes, argTypes []*types.T, resolved PolymorphicTypes, enforceConsistency bool,
+) (ok bool, numPolyParams int, _ PolymorphicTypes) {
+	anyElemTyp := resolved.AnyElement
 	var anyArrayTyp *types.T
+	var anyCompatTyps []*types.T
+	if resolved.AnyCompatible != nil {
+		anyCompatTyps = append(anyCompatTyps, resolved.AnyCompatible)
+	}
+	var sawAnyElem, sawAnyEnum, sawAnyCompat bool
 	for i := range paramTypes {
 		paramTyp := paramTypes[i].Typ
 		if !paramTyp.IsPolymorphicType() {
 			continue
 		}
-		argTyp := argTypes[i]
 		numPolyParams++
+		isAnyCompat := paramTyp.IsAnyCompatibleType()
+		if isAnyCompat {
+			sawAnyCompat = true
+		} else {
+			sawAnyElem = true
+		}
+		argTyp := argTypes[i]
 		if argTyp.Family() == types.UnknownFamily {
 			continue
 		}
@@ -3694,29 +3821,50 @@ func ResolvePolymorphicArgTypes(
 				panic(errors.WithDetailf(err, "%s versus %s", actualTyp, expectedTyp))
 			}
 		}
+		if isAnyCompat {
+			if paramTyp.Family() == types.ArrayFamily {
+				if argTyp.Family() != types.ArrayFamily {
+					if enforceConsistency {
+						panic(pgerror.Newf(pgcode.DatatypeMismatch,
+							"argument declared anycompatiblearray is not an array but type %s", argTyp,
+						))
+					}
+					return false, 0, PolymorphicTypes{}
+				}
+				argTyp = argTyp.ArrayContents()
+			}
+			anyCompatTyps = append(anyCompatTyps, argTyp)
+			continue
+		}
 		switch paramTyp.Family() {
 		case types.AnyFamily:
 			if anyElemTyp == nil {
 				anyElemTyp = argTyp
 			} else if !anyElemTyp.Identical(argTyp) {
 				maybeMakeNotAlikeErr("anyelement", anyElemTyp, argTyp)
-				return false, 0, nil
+				return false, 0, PolymorphicTypes{}
 			}
 		case types.ArrayFamily:
 			if anyArrayTyp == nil {
 				anyArrayTyp = argTyp
 			} else if !anyArrayTyp.Identical(argTyp) {
 				maybeMakeNotAlikeErr("anyarray", anyArrayTyp, argTyp)
-				return false, 0, nil
+				return false, 0, PolymorphicTypes{}
 			}
 		case types.EnumFamily:
-			panic(unimplemented.NewWithIssue(123048, "ANYENUM is not yet supported"))
+			sawAnyEnum = true
+			if anyElemTyp == nil {
+				anyElemTyp = argTyp
+			} else if !anyElemTyp.Identical(argTyp) {
+				maybeMakeNotAlikeErr("anyenum", anyElemTyp, argTyp)
+				return false, 0, PolymorphicTypes{}
+			}
 		default:
 			panic(errors.AssertionFailedf("unexpected type: %s", paramTyp.SQLStringForError()))
 		}
 	}
 	if numPolyParams == 0 {
-		return true, 0, nil
+		return true, 0, PolymorphicTypes{}
 	}
 	if anyArrayTyp != nil {
 		if anyArrayTyp.Family() != types.ArrayFamily {
@@ -3725,7 +3873,7 @@ func ResolvePolymorphicArgTypes(
 					"argument declared anyarray is not an array but type %s", anyArrayTyp,
 				))
 			}
-			return false, 0, nil
+			return false, 0, PolymorphicTypes{}
 		}
 		if anyElemTyp == nil {
 			// Derive the type from the array element type.
@@ -3737,10 +3885,63 @@ func ResolvePolymorphicArgTypes(
 				)
 				panic(errors.WithDetailf(err, "%s versus %s", anyArrayTyp, anyElemTyp))
 			}
-			return false, 0, nil
+			return false, 0, PolymorphicTypes{}
+		}
+	}
+	if sawAnyEnum && anyElemTyp != nil && anyElemTyp.Family() != types.EnumFamily {
+		if enforceConsistency {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"type matched to anyenum is not an enum type: %s", anyElemTyp,
+			))
+		}
+		return false, 0, PolymorphicTypes{}
+	}
+	var anyCompatTyp *types.T
+	if len(anyCompatTyps) > 0 {
+		var ok bool
+		if anyCompatTyp, ok = resolveAnyCompatibleType(anyCompatTyps); !ok {
+			if enforceConsistency {
+				err := pgerror.New(pgcode.DatatypeMismatch,
+					"arguments declared \"anycompatible\" cannot be cast to a common type",
+				)
+				panic(errors.WithDetailf(err, "%s versus %s", anyCompatTyps[0], anyCompatTyp))
+			}
+			return false, 0, PolymorphicTypes{}
+		}
+	}
+	if enforceConsistency && ((sawAnyElem && anyElemTyp == nil) || (sawAnyCompat && anyCompatTyp == nil)) {
+		// All supplied arguments for a family of polymorphic parameters were
+		// NULL, so a type could not be resolved for them.
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"could not determine polymorphic type because input has type unknown",
+		))
+	}
+	return true, numPolyParams, PolymorphicTypes{AnyElement: anyElemTyp, AnyCompatible: anyCompatTyp}
+}
+
+// resolveAnyCompatibleType determines the common type for the given types of
+// the arguments supplied for ANYCOMPATIBLE parameters, following the rules for
+// UNION, CASE, and related constructs:
+// https://www.postgresql.org/docs/15/typeconv-union-case.html
+//
+// If the types cannot be cast to a common type, it returns false along with
+// the first type that could not be cast to the candidate type.
+func resolveAnyCompatibleType(typs []*types.T) (*types.T, bool) {
+	candidateTyp := typs[0]
+	for _, typ := range typs[1:] {
+		// If the candidate type can be implicitly converted to the other type,
+		// but not vice-versa, select the other type as the new candidate type.
+		if cast.ValidCast(candidateTyp, typ, cast.ContextImplicit) &&
+			!cast.ValidCast(typ, candidateTyp, cast.ContextImplicit) {
+			candidateTyp = typ
+		}
+	}
+	for _, typ := range typs {
+		if !typ.Equivalent(candidateTyp) && !cast.ValidCast(typ, candidateTyp, cast.ContextImplicit) {
+			return typ, false
 		}
 	}
-	return true, numPolyParams, anyElemTyp
+	return candidateTyp, true
 }
 
 // UnsupportedTypeChecker is used to check that a type is supported by the
@@ -72,6 +72,10 @@ func (node *UpdateExprs) Format(ctx *FmtCtx) {
 type UpdateExpr struct {
 	Tuple bool
 	Names NameList
+	// Field, if set, is the name of the field of the composite-typed column
+	// in Names that is assigned by the expression. It is only set if Tuple is
+	// false.
+	Field Name
 	Expr  Expr
 }
 
@@ -84,6 +88,10 @@ func (node *UpdateExpr) Format(ctx *FmtCtx) {
 	ctx.WriteString(open)
 	ctx.FormatNode(&node.Names)
 	ctx.WriteString(close)
+	if node.Field != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&node.Field)
+	}
 	ctx.WriteString(" = ")
 	ctx.FormatNode(node.Expr)
 }
@@ -757,9 +757,18 @@ func (expr *DBox2D) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DPGLSN) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DMacAddr) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DMoney) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DPGVector) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DRange) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DGeography) Walk(_ Visitor) Expr { return expr }
 
@@ -769,6 +778,9 @@ func (expr *DGeometry) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DJsonpath) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DTSQuery) Walk(_ Visitor) Expr { return expr }
 
@@ -1309,6 +1321,23 @@ func (stmt *Backup) walkStmt(v Visitor) Statement {
 			ret.To[i] = e
 		}
 	}
+	if stmt.Compact != nil {
+		compact := *stmt.Compact
+		var changed bool
+		if compact.From != nil {
+			var fromChanged bool
+			compact.From, fromChanged = WalkExpr(v, compact.From)
+			changed = changed || fromChanged
+		}
+		var toChanged bool
+		compact.To, toChanged = WalkExpr(v, compact.To)
+		if changed || toChanged {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Compact = &compact
+		}
+	}
 
 	if stmt.Options.EncryptionPassphrase != nil {
 		pw, changed := WalkExpr(v, stmt.Options.EncryptionPassphrase)
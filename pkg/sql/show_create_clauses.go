@@ -594,6 +594,28 @@ func showForeignKeyConstraint(
 	return nil
 }
 
+// showExclusionConstraint writes the EXCLUDE clause of the given exclusion
+// constraint, without its name or predicate, to the buffer.
+func showExclusionConstraint(
+	buf *bytes.Buffer, desc catalog.TableDescriptor, c catalog.UniqueWithoutIndexConstraint,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(desc, c.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return err
+	}
+	buf.WriteString("EXCLUDE USING gist (")
+	for i := range colNames {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		formatQuoteNames(buf, colNames[i])
+		buf.WriteString(" WITH ")
+		buf.WriteString(c.GetExclusionOperator(i).String())
+	}
+	buf.WriteString(")")
+	return nil
+}
+
 // ShowCreateSequence returns a valid SQL representation of the
 // CREATE SEQUENCE statement used to create the given sequence.
 func ShowCreateSequence(
@@ -853,13 +875,19 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		if c.IsExclusion() {
+			if err := showExclusionConstraint(&f.Buffer, desc, c); err != nil {
+				return err
+			}
+		} else {
+			f.WriteString("UNIQUE WITHOUT INDEX (")
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -0,0 +1,273 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package sqlevents aggregates the SQL events of a node, such as statement
+// executions and logins, into cheap usage analytics: the top statements by
+// CPU and contention time and the activity of each user, per minute. The
+// aggregations are emitted to the TELEMETRY log channel and written to
+// system.sql_event_aggregates.
+//
+// The aggregation is built on the obs/eventagg library, whose flushes are
+// logged via log.Structured, and the obs/logstream library, which routes the
+// logged aggregations to the Aggregator for them to be written to the system
+// table.
+package sqlevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/obs/eventagg"
+	"github.com/cockroachdb/cockroach/pkg/obs/logstream"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// Enabled controls whether the SQL events are aggregated.
+var Enabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.event_aggregation.enabled",
+	"if set, statement executions and logins are aggregated per minute into the "+
+		"top statements by CPU and contention time and the activity of each user, "+
+		"which are logged to the TELEMETRY channel and written to system.sql_event_aggregates",
+	false,
+	settings.WithPublic,
+)
+
+var topStatementsCount = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.event_aggregation.top_statements.count",
+	"the number of statements with the highest CPU time, and of statements with "+
+		"the highest contention time, reported by each node per minute",
+	10,
+	settings.PositiveInt,
+	settings.WithPublic,
+)
+
+var retention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.event_aggregation.retention",
+	"the age after which the rows of system.sql_event_aggregates are deleted",
+	7*24*time.Hour,
+	settings.DurationWithMinimum(time.Hour),
+	settings.WithPublic,
+)
+
+const (
+	// window is the duration of the aggregation windows.
+	window = time.Minute
+	// flushCheckInterval is the interval at which the aggregations are checked
+	// for a completed window, in case no new events are added.
+	flushCheckInterval = 5 * time.Second
+	// cleanupInterval is the interval at which the rows of
+	// system.sql_event_aggregates beyond the retention are deleted.
+	cleanupInterval = time.Hour
+)
+
+// Statement is the execution of a statement.
+type Statement struct {
+	FingerprintID appstatspb.StmtFingerprintID
+	// Fingerprint is the statement with its constants hidden.
+	Fingerprint string
+	// User is the user which executed the statement.
+	User string
+	// Failed is set if the statement returned an error.
+	Failed bool
+	// CPUTime and ContentionTime are zero unless execution statistics were
+	// collected for the statement.
+	CPUTime        time.Duration
+	ContentionTime time.Duration
+}
+
+// StatementStats is the aggregation of the executions of a statement
+// fingerprint during a window.
+type StatementStats struct {
+	Fingerprint     string `json:"fingerprint"`
+	ExecCount       int64  `json:"exec_count"`
+	FailureCount    int64  `json:"failure_count"`
+	CPUNanos        int64  `json:"cpu_nanos"`
+	ContentionNanos int64  `json:"contention_nanos"`
+}
+
+// UserActivity is the aggregation of the statements executed and the logins
+// of a user during a window.
+type UserActivity struct {
+	ExecCount        int64 `json:"exec_count"`
+	FailureCount     int64 `json:"failure_count"`
+	CPUNanos         int64 `json:"cpu_nanos"`
+	LoginCount       int64 `json:"login_count"`
+	FailedLoginCount int64 `json:"failed_login_count"`
+}
+
+func (a *UserActivity) add(other *UserActivity) {
+	a.ExecCount += other.ExecCount
+	a.FailureCount += other.FailureCount
+	a.CPUNanos += other.CPUNanos
+	a.LoginCount += other.LoginCount
+	a.FailedLoginCount += other.FailedLoginCount
+}
+
+// userEvent is the contribution of a statement execution or a login to the
+// activity of a user.
+type userEvent struct {
+	user     string
+	activity UserActivity
+}
+
+func mergeStatement(s *Statement, stats *StatementStats) {
+	stats.Fingerprint = s.Fingerprint
+	stats.ExecCount++
+	if s.Failed {
+		stats.FailureCount++
+	}
+	stats.CPUNanos += s.CPUTime.Nanoseconds()
+	stats.ContentionNanos += s.ContentionTime.Nanoseconds()
+}
+
+// Aggregator aggregates the SQL events of a node.
+type Aggregator struct {
+	st       *cluster.Settings
+	db       isql.DB
+	nodeID   *base.SQLIDContainer
+	tenantID roachpb.TenantID
+
+	statements *eventagg.MapReduceAggregator[*Statement, appstatspb.StmtFingerprintID, *StatementStats]
+	users      *eventagg.MapReduceAggregator[*userEvent, string, *UserActivity]
+}
+
+// NewAggregator returns a new Aggregator. Start needs to be called for the
+// aggregations to be written to system.sql_event_aggregates.
+func NewAggregator(
+	st *cluster.Settings,
+	stopper *stop.Stopper,
+	db isql.DB,
+	nodeID *base.SQLIDContainer,
+	tenantID roachpb.TenantID,
+	timeSource timeutil.TimeSource,
+) *Aggregator {
+	a := &Aggregator{
+		st:       st,
+		db:       db,
+		nodeID:   nodeID,
+		tenantID: tenantID,
+	}
+	a.statements = eventagg.NewMapReduceAggregator[*Statement, appstatspb.StmtFingerprintID, *StatementStats](
+		stopper,
+		func() *StatementStats { return &StatementStats{} },
+		func(s *Statement) appstatspb.StmtFingerprintID { return s.FingerprintID },
+		mergeStatement,
+		eventagg.NewWindowedFlush(window, timeSource.Now),
+		eventagg.NewTopKConsumer[appstatspb.StmtFingerprintID, *StatementStats](
+			func() int { return int(topStatementsCount.Get(&st.SV)) },
+			[]func(*StatementStats) int64{
+				func(s *StatementStats) int64 { return s.CPUNanos },
+				func(s *StatementStats) int64 { return s.ContentionNanos },
+			},
+			eventagg.NewLogWriteConsumer[appstatspb.StmtFingerprintID, *StatementStats](log.SQL_TOP_STATEMENTS),
+		),
+	)
+	a.statements.SetEnabledFn(a.Enabled)
+	a.users = eventagg.NewMapReduceAggregator[*userEvent, string, *UserActivity](
+		stopper,
+		func() *UserActivity { return &UserActivity{} },
+		func(e *userEvent) string { return e.user },
+		func(e *userEvent, activity *UserActivity) { activity.add(&e.activity) },
+		eventagg.NewWindowedFlush(window, timeSource.Now),
+		eventagg.NewLogWriteConsumer[string, *UserActivity](log.SQL_USER_ACTIVITY),
+	)
+	a.users.SetEnabledFn(a.Enabled)
+	return a
+}
+
+// Enabled returns whether the SQL events are aggregated.
+func (a *Aggregator) Enabled() bool {
+	return a != nil && Enabled.Get(&a.st.SV)
+}
+
+// withTenant returns a context annotated with the tenant of the Aggregator,
+// which logstream uses to route the flushed aggregations to the processors of
+// the same tenant.
+func (a *Aggregator) withTenant(ctx context.Context) context.Context {
+	return roachpb.ContextWithClientTenant(ctx, a.tenantID)
+}
+
+// RecordStatement records the execution of a statement.
+func (a *Aggregator) RecordStatement(ctx context.Context, s *Statement) {
+	if !a.Enabled() {
+		return
+	}
+	ctx = a.withTenant(ctx)
+	a.statements.Add(ctx, s)
+	a.users.Add(ctx, &userEvent{
+		user: s.User,
+		activity: UserActivity{
+			ExecCount:    1,
+			FailureCount: boolToInt(s.Failed),
+			CPUNanos:     s.CPUTime.Nanoseconds(),
+		},
+	})
+}
+
+// RecordLogin records a login attempt of the given user.
+func (a *Aggregator) RecordLogin(ctx context.Context, user string, success bool) {
+	if !a.Enabled() {
+		return
+	}
+	a.users.Add(a.withTenant(ctx), &userEvent{
+		user: user,
+		activity: UserActivity{
+			LoginCount:       1,
+			FailedLoginCount: boolToInt(!success),
+		},
+	})
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Start registers the Aggregator as the processor of the logged aggregations
+// and starts the tasks which flush the completed windows and delete the rows
+// of system.sql_event_aggregates beyond the retention.
+func (a *Aggregator) Start(ctx context.Context, stopper *stop.Stopper) error {
+	ctx = a.withTenant(ctx)
+	logstream.RegisterProcessor(ctx, stopper, log.SQL_TOP_STATEMENTS, a)
+	logstream.RegisterProcessor(ctx, stopper, log.SQL_USER_ACTIVITY, a)
+	return stopper.RunAsyncTask(ctx, "sql-event-aggregation", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		ticker := time.NewTicker(flushCheckInterval)
+		defer ticker.Stop()
+		lastCleanup := timeutil.Now()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			a.statements.MaybeFlush(ctx)
+			a.users.MaybeFlush(ctx)
+			if !a.Enabled() {
+				continue
+			}
+			if now := timeutil.Now(); now.Sub(lastCleanup) >= cleanupInterval {
+				lastCleanup = now
+				if err := a.deleteExpired(ctx, now); err != nil && ctx.Err() == nil {
+					log.Warningf(ctx, "unable to delete expired SQL event aggregates: %v", err)
+				}
+			}
+		}
+	})
+}
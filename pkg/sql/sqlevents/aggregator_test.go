@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sqlevents_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlevents"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+func TestAggregator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	runner := sqlutils.MakeSQLRunner(db)
+	execCfg := s.ApplicationLayer().ExecutorConfig().(sql.ExecutorConfig)
+	sqlevents.Enabled.Override(ctx, &execCfg.Settings.SV, true)
+
+	windowStart := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	timeSource := timeutil.NewManualTime(windowStart)
+	agg := sqlevents.NewAggregator(
+		execCfg.Settings, s.Stopper(), execCfg.InternalDB, execCfg.NodeInfo.NodeID, execCfg.Codec.TenantID, timeSource,
+	)
+	if err := agg.Start(ctx, s.Stopper()); err != nil {
+		t.Fatal(err)
+	}
+
+	agg.RecordStatement(ctx, &sqlevents.Statement{
+		FingerprintID: 1, Fingerprint: "SELECT * FROM t WHERE a = _", User: "alice", CPUTime: time.Second,
+	})
+	agg.RecordStatement(ctx, &sqlevents.Statement{
+		FingerprintID: 2, Fingerprint: "UPDATE t SET b = _", User: "bob", Failed: true, ContentionTime: time.Second,
+	})
+	// This statement has neither CPU nor contention time, so it's not one of
+	// the top statements, but it is part of the activity of bob.
+	agg.RecordStatement(ctx, &sqlevents.Statement{
+		FingerprintID: 3, Fingerprint: "SELECT _", User: "bob",
+	})
+	agg.RecordLogin(ctx, "alice", true /* success */)
+	agg.RecordLogin(ctx, "bob", false /* success */)
+	// Complete the window, which is then flushed in the background.
+	timeSource.Advance(time.Minute)
+
+	expected := [][]string{
+		{"sql_top_statements", "0000000000000001", "1", "0", "1000000000", "0", "0"},
+		{"sql_top_statements", "0000000000000002", "1", "1", "0", "1000000000", "0"},
+		{"sql_user_activity", "alice", "1", "0", "1000000000", "1", "0"},
+		{"sql_user_activity", "bob", "2", "1", "0", "1", "1"},
+	}
+	testutils.SucceedsSoon(t, func() error {
+		rows := runner.QueryStr(t, `
+SELECT event_type, key, value->>'exec_count', value->>'failure_count', value->>'cpu_nanos',
+       COALESCE(value->>'contention_nanos', value->>'login_count'), COALESCE(value->>'failed_login_count', '0')
+  FROM system.sql_event_aggregates
+ WHERE aggregated_ts = $1
+ ORDER BY event_type, key`, windowStart)
+		if !reflect.DeepEqual(expected, rows) {
+			return errors.Newf("expected:\n%s\ngot:\n%s", sqlutils.MatrixToStr(expected), sqlutils.MatrixToStr(rows))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sqlevents
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/obs/eventagg"
+	"github.com/cockroachdb/cockroach/pkg/obs/logstream"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// deleteBatchSize is the maximum number of rows deleted from
+// system.sql_event_aggregates by a single statement.
+const deleteBatchSize = 10000
+
+var _ logstream.Processor = (*Aggregator)(nil)
+
+// Process implements the logstream.Processor interface. It writes the logged
+// aggregations to system.sql_event_aggregates.
+func (a *Aggregator) Process(ctx context.Context, event any) error {
+	switch e := event.(type) {
+	case eventagg.KeyValueLog[appstatspb.StmtFingerprintID, *StatementStats]:
+		key := hex.EncodeToString(encoding.EncodeUint64Ascending(nil, uint64(e.Key)))
+		return a.write(ctx, e.AggInfo, log.SQL_TOP_STATEMENTS.EventType, key, e.Value)
+	case eventagg.KeyValueLog[string, *UserActivity]:
+		return a.write(ctx, e.AggInfo, log.SQL_USER_ACTIVITY.EventType, e.Key, e.Value)
+	default:
+		return errors.AssertionFailedf("unexpected event type %T", event)
+	}
+}
+
+func (a *Aggregator) write(
+	ctx context.Context, aggInfo eventagg.AggInfo, eventType log.EventType, key string, value any,
+) error {
+	if !a.st.Version.IsActive(ctx, clusterversion.V25_1_SQLEventAggregatesTable) {
+		return nil
+	}
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.Executor().ExecEx(ctx, "write-sql-event-aggregate", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.sql_event_aggregates (aggregated_ts, node_id, event_type, key, value)
+			VALUES ($1, $2, $3, $4, $5::JSONB)`,
+		timeutil.Unix(0, aggInfo.StartTime),
+		int64(a.nodeID.SQLInstanceID()),
+		string(eventType),
+		key,
+		string(valueJSON),
+	)
+	return err
+}
+
+// deleteExpired deletes the rows of system.sql_event_aggregates older than the
+// retention, for all nodes.
+func (a *Aggregator) deleteExpired(ctx context.Context, now time.Time) error {
+	if !a.st.Version.IsActive(ctx, clusterversion.V25_1_SQLEventAggregatesTable) {
+		return nil
+	}
+	cutoff := now.Add(-retention.Get(&a.st.SV))
+	for {
+		n, err := a.db.Executor().ExecEx(ctx, "delete-expired-sql-event-aggregates", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.sql_event_aggregates WHERE aggregated_ts < $1 LIMIT $2`,
+			cutoff, deleteBatchSize,
+		)
+		if err != nil || n < deleteBatchSize {
+			return err
+		}
+	}
+}
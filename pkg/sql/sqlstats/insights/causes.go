@@ -15,6 +15,10 @@ type causes struct {
 // return the result. Buf allows the slice to be pooled.
 func (c *causes) examine(buf []Cause, stmt *Statement) (result []Cause) {
 	result = buf
+	if stmt.PreviousPlanGist != "" {
+		result = append(result, Cause_PlanRegression)
+	}
+
 	if len(stmt.IndexRecommendations) > 0 {
 		result = append(result, Cause_SuboptimalPlan)
 	}
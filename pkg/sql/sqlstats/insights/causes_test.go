@@ -33,6 +33,11 @@ func TestCauses(t *testing.T) {
 			statement: &Statement{},
 			causes:    nil,
 		},
+		{
+			name:      "plan regression",
+			statement: &Statement{PlanGist: "AgHQAQIABQAAAAY=", PreviousPlanGist: "AgHQAQIAAgAAAAY="},
+			causes:    []Cause{Cause_PlanRegression},
+		},
 		{
 			name:      "suboptimal plan",
 			statement: &Statement{IndexRecommendations: []string{"THIS IS AN INDEX RECOMMENDATION"}},
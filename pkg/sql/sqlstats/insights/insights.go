@@ -80,6 +80,71 @@ var HighRetryCountThreshold = settings.RegisterIntSetting(
 	settings.NonNegativeInt,
 	settings.WithPublic)
 
+// PlanRegressionDetectionEnabled turns on per-fingerprint tracking of the
+// plans chosen for each statement, marking executions as slow when a change
+// of plan makes the statement markedly slower than it used to be.
+var PlanRegressionDetectionEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.enabled",
+	"enable per-fingerprint plan tracking and plan regression detection",
+	true,
+	settings.WithPublic)
+
+// PlanRegressionLatencyRatio is the factor by which the mean latency of a
+// statement's current plan must exceed that of its previous plan for the
+// change of plan to be considered a regression.
+var PlanRegressionLatencyRatio = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.latency_ratio",
+	"the factor by which the mean latency of a statement's new plan must exceed that of its previous plan to be reported as a plan regression",
+	2.0,
+	settings.FloatWithMinimum(1),
+	settings.WithPublic)
+
+// PlanRegressionMinExecutions is the number of executions each of the
+// previous and the current plan must have been observed for before their
+// latencies are compared, so that a handful of outliers don't trigger false
+// positives.
+var PlanRegressionMinExecutions = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.min_executions",
+	"the number of executions of both the previous and the new plan of a statement required before plan regressions are reported",
+	20,
+	settings.PositiveInt,
+	settings.WithPublic)
+
+// PlanRegressionLatencyThreshold sets the mean latency a regressed plan must
+// exceed to be reported, removing the noise of fast statements that merely got
+// a little less fast.
+var PlanRegressionLatencyThreshold = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.latency_threshold",
+	"the mean latency a statement's new plan must surpass to be reported as a plan regression",
+	50*time.Millisecond,
+	settings.NonNegativeDuration,
+	settings.WithPublic)
+
+// PlanRegressionMaxFingerprints restricts the number of statement fingerprints
+// whose plan history is tracked. The least recently executed fingerprints are
+// evicted first.
+var PlanRegressionMaxFingerprints = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.max_fingerprints",
+	"the maximum number of statement fingerprints whose plan history is tracked for plan regression detection",
+	10000,
+	settings.PositiveInt,
+	settings.WithPublic)
+
+// PlanRegressionPinPreviousPlanEnabled opts into pinning the indexes of the
+// previous plan of a statement fingerprint, using a plan_gist_indexes
+// statement hint, when a plan regression is detected for it.
+var PlanRegressionPinPreviousPlanEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.insights.plan_regression_detection.pin_previous_plan.enabled",
+	"when a plan regression is detected, pin the indexes of the statement's previous plan with a plan_gist_indexes statement hint",
+	false,
+	settings.WithPublic)
+
 // Metrics holds running measurements of various insights-related runtime stats.
 type Metrics struct {
 	// Fingerprints measures the number of statement fingerprints being monitored for
@@ -136,6 +201,7 @@ type PercentileValues struct {
 func New(st *cluster.Settings, metrics Metrics, knobs *TestingKnobs) *Provider {
 	store := newStore(st)
 	anomalyDetector := newAnomalyDetector(st, metrics)
+	planRegressions := newPlanRegressionDetector(st)
 
 	return &Provider{
 		store: store,
@@ -143,8 +209,10 @@ func New(st *cluster.Settings, metrics Metrics, knobs *TestingKnobs) *Provider {
 			newRegistry(st, &compositeDetector{detectors: []detector{
 				&latencyThresholdDetector{st: st},
 				anomalyDetector,
+				planRegressions,
 			}}, store, knobs),
 		),
 		anomalyDetector: anomalyDetector,
+		planRegressions: planRegressions,
 	}
 }
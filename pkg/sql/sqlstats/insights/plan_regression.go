@@ -0,0 +1,185 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package insights
+
+import (
+	"container/list"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// PlanPinner pins the indexes of the given plan for a statement fingerprint
+// using a statement hint. It is called from the insights ingester when a plan regression is
+// detected and sql.insights.plan_regression_detection.pin_previous_plan.enabled
+// is set, so implementations must not block: they are expected to hand the
+// work off to an asynchronous task.
+type PlanPinner func(fingerprintID appstatspb.StmtFingerprintID, query string, planGist string)
+
+// PlanRegressionDetector tracks, per statement fingerprint, the latencies of
+// the current and the previous plan chosen for the statement. An execution is
+// considered slow when the current plan has regressed against the previous
+// one: both plans have been observed often enough, the mean latency of the
+// current plan exceeds that of the previous one by the configured ratio, and
+// the execution itself is that much slower than the previous plan used to be.
+//
+// Slow executions are annotated with the previous plan, so that the resulting
+// insight reports both plans.
+type PlanRegressionDetector struct {
+	settings *cluster.Settings
+	store    *list.List
+	mu       struct {
+		syncutil.Mutex
+
+		index  map[appstatspb.StmtFingerprintID]*list.Element
+		pinner PlanPinner
+	}
+}
+
+var _ detector = &PlanRegressionDetector{}
+
+// planStats summarizes the executions of one plan of a statement fingerprint.
+type planStats struct {
+	gist  string
+	count int64
+	// meanLatency is the mean execution latency of the plan, in seconds. We
+	// keep a mean rather than a quantile summary so that the plan history can
+	// be seeded from persisted statement statistics.
+	meanLatency float64
+}
+
+func (p *planStats) record(count int64, meanLatency float64) {
+	if count <= 0 {
+		return
+	}
+	p.count += count
+	p.meanLatency += (meanLatency - p.meanLatency) * float64(count) / float64(p.count)
+}
+
+type planHistoryEntry struct {
+	key      appstatspb.StmtFingerprintID
+	current  planStats
+	previous planStats
+	// pinned is set once the previous plan has been pinned for the current
+	// regression, so that we only ask for it to be pinned once.
+	pinned bool
+}
+
+// observe records count executions of the given plan, with the given mean
+// latency, making it the current plan.
+func (e *planHistoryEntry) observe(gist string, count int64, meanLatency float64) {
+	switch gist {
+	case e.current.gist:
+	case e.previous.gist:
+		// We flipped back to the previous plan.
+		e.current, e.previous = e.previous, e.current
+		e.pinned = false
+	default:
+		e.previous = e.current
+		e.current = planStats{gist: gist}
+		e.pinned = false
+	}
+	e.current.record(count, meanLatency)
+}
+
+// regressed returns whether the current plan has regressed against the
+// previous one.
+func (e *planHistoryEntry) regressed(st *cluster.Settings) bool {
+	minExecutions := PlanRegressionMinExecutions.Get(&st.SV)
+	return e.previous.gist != "" &&
+		e.previous.count >= minExecutions &&
+		e.current.count >= minExecutions &&
+		e.current.meanLatency >= PlanRegressionLatencyRatio.Get(&st.SV)*e.previous.meanLatency &&
+		e.current.meanLatency >= PlanRegressionLatencyThreshold.Get(&st.SV).Seconds()
+}
+
+func newPlanRegressionDetector(settings *cluster.Settings) *PlanRegressionDetector {
+	d := &PlanRegressionDetector{
+		settings: settings,
+		store:    list.New(),
+	}
+	d.mu.index = make(map[appstatspb.StmtFingerprintID]*list.Element)
+	return d
+}
+
+func (d *PlanRegressionDetector) enabled() bool {
+	return PlanRegressionDetectionEnabled.Get(&d.settings.SV)
+}
+
+func (d *PlanRegressionDetector) isSlow(stmt *Statement) (decision bool) {
+	if !d.enabled() || stmt.PlanGist == "" || stmt.Status != Statement_Completed {
+		return false
+	}
+
+	var pin func()
+	func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		entry := d.getOrCreateLocked(stmt.FingerprintID)
+		entry.observe(stmt.PlanGist, 1 /* count */, stmt.LatencyInSeconds)
+		if !entry.regressed(d.settings) ||
+			stmt.LatencyInSeconds < PlanRegressionLatencyRatio.Get(&d.settings.SV)*entry.previous.meanLatency {
+			return
+		}
+		decision = true
+		stmt.PreviousPlanGist = entry.previous.gist
+		stmt.PreviousPlanLatencyInSeconds = entry.previous.meanLatency
+		if !entry.pinned && d.mu.pinner != nil &&
+			PlanRegressionPinPreviousPlanEnabled.Get(&d.settings.SV) {
+			entry.pinned = true
+			pinner, id, query, gist := d.mu.pinner, stmt.FingerprintID, stmt.Query, entry.previous.gist
+			pin = func() { pinner(id, query, gist) }
+		}
+	}()
+	if pin != nil {
+		pin()
+	}
+	return decision
+}
+
+// SeedPlanHistory records count executions of the given plan for the
+// statement fingerprint, with the given mean latency in seconds, making it the
+// fingerprint's current plan. It is used to restore the plan history from
+// persisted statement statistics, in which case the plans of each fingerprint
+// should be seeded from the oldest to the most recent.
+func (d *PlanRegressionDetector) SeedPlanHistory(
+	fingerprintID appstatspb.StmtFingerprintID, planGist string, count int64, meanLatency float64,
+) {
+	if planGist == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.getOrCreateLocked(fingerprintID).observe(planGist, count, meanLatency)
+}
+
+// SetPlanPinner installs the function used to pin the previous plan of a
+// statement fingerprint when a plan regression is detected.
+func (d *PlanRegressionDetector) SetPlanPinner(pinner PlanPinner) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.pinner = pinner
+}
+
+func (d *PlanRegressionDetector) getOrCreateLocked(
+	id appstatspb.StmtFingerprintID,
+) *planHistoryEntry {
+	if element, ok := d.mu.index[id]; ok {
+		d.store.MoveToFront(element) // Mark this plan history as recently used.
+		return element.Value.(*planHistoryEntry)
+	}
+	entry := &planHistoryEntry{key: id}
+	d.mu.index[id] = d.store.PushFront(entry)
+
+	// To control our memory usage, possibly evict the plan history for the
+	// least recently seen statement fingerprint.
+	if int64(d.store.Len()) > PlanRegressionMaxFingerprints.Get(&d.settings.SV) {
+		evicted := d.store.Remove(d.store.Back()).(*planHistoryEntry)
+		delete(d.mu.index, evicted.key)
+	}
+	return entry
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package insights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRegressionDetector(t *testing.T) {
+	ctx := context.Background()
+	const oldPlan, newPlan = "AgHQAQIAAgAAAAY=", "AgHQAQIABQAAAAY="
+	const fingerprintID = appstatspb.StmtFingerprintID(1)
+
+	makeDetector := func() (*cluster.Settings, *PlanRegressionDetector) {
+		st := cluster.MakeTestingClusterSettings()
+		PlanRegressionMinExecutions.Override(ctx, &st.SV, 3)
+		PlanRegressionLatencyThreshold.Override(ctx, &st.SV, 50*time.Millisecond)
+		return st, newPlanRegressionDetector(st)
+	}
+
+	execute := func(d *PlanRegressionDetector, gist string, latency float64) (*Statement, bool) {
+		stmt := &Statement{FingerprintID: fingerprintID, PlanGist: gist, LatencyInSeconds: latency}
+		return stmt, d.isSlow(stmt)
+	}
+
+	t.Run("enabled by cluster setting", func(t *testing.T) {
+		st, d := makeDetector()
+		require.True(t, d.enabled())
+		PlanRegressionDetectionEnabled.Override(ctx, &st.SV, false)
+		require.False(t, d.enabled())
+	})
+
+	t.Run("regression against the previous plan", func(t *testing.T) {
+		_, d := makeDetector()
+		for i := 0; i < 3; i++ {
+			_, slow := execute(d, oldPlan, 0.1)
+			require.False(t, slow)
+		}
+		// The new plan needs to be observed enough times to be judged.
+		for i := 0; i < 2; i++ {
+			_, slow := execute(d, newPlan, 0.5)
+			require.False(t, slow)
+		}
+		stmt, slow := execute(d, newPlan, 0.5)
+		require.True(t, slow)
+		require.Equal(t, oldPlan, stmt.PreviousPlanGist)
+		require.InDelta(t, 0.1, stmt.PreviousPlanLatencyInSeconds, 1e-9)
+
+		// A fast execution of the regressed plan is not slow.
+		stmt, slow = execute(d, newPlan, 0.15)
+		require.False(t, slow)
+		require.Empty(t, stmt.PreviousPlanGist)
+
+		// Flipping back to the previous plan ends the regression.
+		_, slow = execute(d, oldPlan, 0.1)
+		require.False(t, slow)
+	})
+
+	t.Run("no regression when the new plan is not slower", func(t *testing.T) {
+		_, d := makeDetector()
+		for i := 0; i < 3; i++ {
+			execute(d, oldPlan, 0.1)
+		}
+		for i := 0; i < 5; i++ {
+			_, slow := execute(d, newPlan, 0.15)
+			require.False(t, slow)
+		}
+	})
+
+	t.Run("no regression below the latency threshold", func(t *testing.T) {
+		_, d := makeDetector()
+		for i := 0; i < 3; i++ {
+			execute(d, oldPlan, 0.001)
+		}
+		for i := 0; i < 5; i++ {
+			_, slow := execute(d, newPlan, 0.01)
+			require.False(t, slow)
+		}
+	})
+
+	t.Run("seeded plan history", func(t *testing.T) {
+		_, d := makeDetector()
+		d.SeedPlanHistory(fingerprintID, oldPlan, 100, 0.1)
+		d.SeedPlanHistory(fingerprintID, newPlan, 2, 0.5)
+		stmt, slow := execute(d, newPlan, 0.5)
+		require.True(t, slow)
+		require.Equal(t, oldPlan, stmt.PreviousPlanGist)
+	})
+
+	t.Run("pins the previous plan once when enabled", func(t *testing.T) {
+		st, d := makeDetector()
+		var pinned []string
+		d.SetPlanPinner(func(id appstatspb.StmtFingerprintID, query string, gist string) {
+			require.Equal(t, fingerprintID, id)
+			pinned = append(pinned, gist)
+		})
+		d.SeedPlanHistory(fingerprintID, oldPlan, 100, 0.1)
+		d.SeedPlanHistory(fingerprintID, newPlan, 100, 0.5)
+
+		_, slow := execute(d, newPlan, 0.5)
+		require.True(t, slow)
+		require.Empty(t, pinned)
+
+		PlanRegressionPinPreviousPlanEnabled.Override(ctx, &st.SV, true)
+		for i := 0; i < 3; i++ {
+			_, slow = execute(d, newPlan, 0.5)
+			require.True(t, slow)
+		}
+		require.Equal(t, []string{oldPlan}, pinned)
+	})
+
+	t.Run("evicts the least recently used fingerprint", func(t *testing.T) {
+		st, d := makeDetector()
+		PlanRegressionMaxFingerprints.Override(ctx, &st.SV, 2)
+		for id := appstatspb.StmtFingerprintID(1); id <= 3; id++ {
+			d.SeedPlanHistory(id, oldPlan, 1, 0.1)
+		}
+		require.Equal(t, 2, d.store.Len())
+		require.NotContains(t, d.mu.index, appstatspb.StmtFingerprintID(1))
+	})
+}
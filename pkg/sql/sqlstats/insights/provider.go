@@ -16,6 +16,7 @@ type Provider struct {
 	store           *LockingStore
 	ingester        *ConcurrentBufferIngester
 	anomalyDetector *AnomalyDetector
+	planRegressions *PlanRegressionDetector
 }
 
 // Start launches the background tasks necessary for processing insights.
@@ -38,3 +39,9 @@ func (p *Provider) Store() *LockingStore {
 func (p *Provider) Anomalies() *AnomalyDetector {
 	return p.anomalyDetector
 }
+
+// PlanRegressions returns the object that tracks the plan history of each
+// statement fingerprint in support of plan regression detection.
+func (p *Provider) PlanRegressions() *PlanRegressionDetector {
+	return p.planRegressions
+}
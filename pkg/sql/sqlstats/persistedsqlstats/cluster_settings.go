@@ -143,3 +143,15 @@ var SQLStatsLimitTableCheckInterval = settings.RegisterDurationSetting(
 	1*time.Hour,
 	settings.NonNegativeDuration,
 )
+
+// PlanHistorySeedWindow is the cluster setting that controls how far back the
+// persisted statement statistics are read, on startup, to restore the plan
+// history of each statement fingerprint used to detect plan regressions.
+var PlanHistorySeedWindow = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.stats.plan_history.seed_window",
+	"the window of persisted SQL statistics from which the per-fingerprint plan "+
+		"history used for plan regression detection is restored on startup. Use 0 to disable.",
+	24*time.Hour,
+	settings.NonNegativeDuration,
+)
@@ -0,0 +1,106 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package persistedsqlstats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats/sqlstatsutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+)
+
+// startPlanHistorySeeding restores the plan history of each statement
+// fingerprint from the persisted statement statistics, so that plan
+// regressions can be detected across restarts.
+func (s *PersistedSQLStats) startPlanHistorySeeding(ctx context.Context, stopper *stop.Stopper) {
+	if s.cfg.PlanRegressions == nil {
+		return
+	}
+	s.tasksDoneWG.Add(1)
+	err := stopper.RunAsyncTask(ctx, "sql-stats-plan-history", func(ctx context.Context) {
+		defer s.tasksDoneWG.Done()
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		if err := s.seedPlanHistory(ctx); err != nil {
+			log.Warningf(ctx, "failed to restore plan history: %v", err)
+		}
+	})
+	if err != nil {
+		s.tasksDoneWG.Done()
+		log.Warningf(ctx, "failed to start sql-stats-plan-history: %v", err)
+	}
+}
+
+// seedPlanHistory reads, for each statement fingerprint, the execution count
+// and mean latency of each of its plans over the configured window, and
+// replays them into the plan regression detector from the least to the most
+// recently used plan.
+func (s *PersistedSQLStats) seedPlanHistory(ctx context.Context) (err error) {
+	window := PlanHistorySeedWindow.Get(&s.cfg.Settings.SV)
+	if window == 0 {
+		return nil
+	}
+
+	// Each row of system.statement_statistics records a single plan, whose
+	// gist is the first (and only) entry of its planGists.
+	query := fmt.Sprintf(`
+SELECT
+  fingerprint_id,
+  gist,
+  sum(execution_count)::INT8,
+  sum(service_latency * execution_count::FLOAT8) / sum(execution_count)::FLOAT8,
+  max(aggregated_ts) AS last_seen
+FROM (
+  SELECT
+    fingerprint_id,
+    statistics -> 'statistics' -> 'planGists' ->> 0 AS gist,
+    execution_count,
+    service_latency,
+    aggregated_ts
+  FROM system.statement_statistics %s
+  WHERE aggregated_ts >= $1 AND app_name NOT LIKE '$ internal%%' AND execution_count > 0
+)
+WHERE gist IS NOT NULL AND gist != ''
+GROUP BY fingerprint_id, gist
+ORDER BY fingerprint_id, last_seen`, s.cfg.Knobs.GetAOSTClause())
+
+	it, err := s.cfg.DB.Executor().QueryIteratorEx(
+		ctx,
+		"read-plan-history",
+		nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		query,
+		s.getTimeNow().Add(-window),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.CombineErrors(err, it.Close())
+	}()
+
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		var fingerprintID uint64
+		if fingerprintID, err = sqlstatsutil.DatumToUint64(row[0]); err != nil {
+			return err
+		}
+		s.cfg.PlanRegressions.SeedPlanHistory(
+			appstatspb.StmtFingerprintID(fingerprintID),
+			string(tree.MustBeDString(row[1])),
+			int64(tree.MustBeDInt(row[2])),
+			float64(tree.MustBeDFloat(row[3])),
+		)
+	}
+	return err
+}
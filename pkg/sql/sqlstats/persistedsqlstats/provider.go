@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/insights"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/sslocal"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -40,6 +41,10 @@ type Config struct {
 	SQLIDContainer          *base.SQLIDContainer
 	JobRegistry             *jobs.Registry
 
+	// PlanRegressions, if set, has its per-fingerprint plan history restored
+	// from the persisted statement statistics on startup.
+	PlanRegressions *insights.PlanRegressionDetector
+
 	// Metrics.
 	FlushesSuccessful       *metric.Counter
 	FlushLatency            metric.IHistogram
@@ -110,6 +115,7 @@ func New(cfg *Config, memSQLStats *sslocal.SQLStats) *PersistedSQLStats {
 // Start implements sqlstats.Provider interface.
 func (s *PersistedSQLStats) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.startSQLStatsFlushLoop(ctx, stopper)
+	s.startPlanHistorySeeding(ctx, stopper)
 	s.jobMonitor.start(ctx, stopper, s.drain, &s.tasksDoneWG)
 	stopper.AddCloser(stop.CloserFn(func() {
 		// TODO(knz,yahor): This really should be just Stop(), but there
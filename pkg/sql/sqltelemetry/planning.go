@@ -114,6 +114,9 @@ var ExplainDDLVerbose = telemetry.GetCounterOnce("sql.plan.explain-ddl-verbose")
 // ExplainDDLViz is to be incremented whenever EXPLAIN (DDL, VIZ) is run.
 var ExplainDDLViz = telemetry.GetCounterOnce("sql.plan.explain-ddl-viz")
 
+// ExplainDDLRanges is to be incremented whenever EXPLAIN (DDL, RANGES) is run.
+var ExplainDDLRanges = telemetry.GetCounterOnce("sql.plan.explain-ddl-ranges")
+
 // ExplainOptVerboseUseCounter is to be incremented whenever
 // EXPLAIN (OPT, VERBOSE) is run.
 var ExplainOptVerboseUseCounter = telemetry.GetCounterOnce("sql.plan.explain-opt-verbose")
@@ -0,0 +1,288 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stmthints
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/multitenant"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Enabled controls whether the hints of system.statement_hints are applied
+// when planning statements.
+var Enabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.statement_hints.enabled",
+	"apply the hints of system.statement_hints when planning statements",
+	true,
+	settings.WithPublic)
+
+var pollingInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.statement_hints.poll_interval",
+	"rate at which the statement hints cache polls system.statement_hints, set to zero to disable",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// Entry is a hint of system.statement_hints, along with its node-local usage.
+type Entry struct {
+	Hint
+	// Err is set if the hint is malformed, in which case it is never applied.
+	Err error
+	// AppliedCount is the number of times the hint was applied to a plan on
+	// this node since the node started.
+	AppliedCount int64
+	// LastApplied is the last time the hint was applied to a plan on this node.
+	LastApplied time.Time
+}
+
+// Cache maintains a view of system.statement_hints, which it polls
+// periodically, and tracks the usage of the hints on this node.
+type Cache struct {
+	mu struct {
+		// NOTE: This lock can't be held while the cache runs any statements
+		// internally; it'd deadlock.
+		syncutil.RWMutex
+		// hints maps statement fingerprints to their valid hints.
+		hints map[string][]Hint
+		// entries maps row IDs to all the hints of the table, valid or not.
+		entries map[int64]*Entry
+	}
+	st      *cluster.Settings
+	db      isql.DB
+	stopper *stop.Stopper
+}
+
+// NewCache returns a new Cache. Start needs to be called for it to be
+// populated.
+func NewCache(db isql.DB, st *cluster.Settings) *Cache {
+	c := &Cache{
+		db: db,
+		st: st,
+	}
+	c.mu.hints = make(map[string][]Hint)
+	c.mu.entries = make(map[int64]*Entry)
+	return c
+}
+
+// Start starts polling system.statement_hints.
+func (c *Cache) Start(ctx context.Context, stopper *stop.Stopper) {
+	c.stopper = stopper
+	ctx, _ = stopper.WithCancelOnQuiesce(ctx)
+
+	// Since the polling is not under user control, exclude it from cost
+	// accounting and control.
+	ctx = multitenant.WithTenantCostControlExemption(ctx)
+
+	// NB: The only error that should occur here would be if the server were
+	// shutting down so let's swallow it.
+	_ = stopper.RunAsyncTask(ctx, "stmt-hints-poll", c.poll)
+}
+
+func (c *Cache) poll(ctx context.Context) {
+	var (
+		timer               timeutil.Timer
+		lastPoll            time.Time
+		deadline            time.Time
+		pollIntervalChanged = make(chan struct{}, 1)
+		maybeResetTimer     = func() {
+			if interval := pollingInterval.Get(&c.st.SV); interval == 0 {
+				// Setting the interval to zero stops the polling.
+				timer.Stop()
+			} else {
+				newDeadline := lastPoll.Add(interval)
+				if deadline.IsZero() || !deadline.Equal(newDeadline) {
+					deadline = newDeadline
+					timer.Reset(timeutil.Until(deadline))
+				}
+			}
+		}
+		poll = func() {
+			if err := c.Refresh(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warningf(ctx, "error polling for statement hints: %s", err)
+			}
+			lastPoll = timeutil.Now()
+		}
+	)
+	pollingInterval.SetOnChange(&c.st.SV, func(ctx context.Context) {
+		select {
+		case pollIntervalChanged <- struct{}{}:
+		default:
+		}
+	})
+	for {
+		maybeResetTimer()
+		select {
+		case <-pollIntervalChanged:
+			continue // go back around and maybe reset the timer
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return
+		}
+		poll()
+	}
+}
+
+// Refresh reads system.statement_hints and replaces the contents of the
+// cache, keeping the usage of the hints that are still present.
+func (c *Cache) Refresh(ctx context.Context) (err error) {
+	if !c.st.Version.IsActive(ctx, clusterversion.V25_1_StatementHintsTable) {
+		return nil
+	}
+	it, err := c.db.Executor().QueryIteratorEx(ctx, "stmt-hints-poll", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT row_id, fingerprint, hint_type, table_name, hint_value, created_at
+			FROM system.statement_hints`,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.CombineErrors(err, it.Close())
+	}()
+	var rows []Hint
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		hint := Hint{
+			RowID:       int64(tree.MustBeDInt(row[0])),
+			Fingerprint: string(tree.MustBeDString(row[1])),
+			Type:        HintType(tree.MustBeDString(row[2])),
+			Value:       string(tree.MustBeDString(row[4])),
+			CreatedAt:   tree.MustBeDTimestampTZ(row[5]).Time,
+		}
+		if table, ok := row[3].(*tree.DString); ok {
+			hint.Table = string(*table)
+		}
+		rows = append(rows, hint)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hints := make(map[string][]Hint)
+	entries := make(map[int64]*Entry, len(rows))
+	for _, hint := range rows {
+		entry := &Entry{Hint: hint, Err: hint.Validate()}
+		if prev, ok := c.mu.entries[hint.RowID]; ok {
+			entry.AppliedCount, entry.LastApplied = prev.AppliedCount, prev.LastApplied
+		}
+		entries[hint.RowID] = entry
+		if entry.Err == nil {
+			hints[hint.Fingerprint] = append(hints[hint.Fingerprint], hint)
+		}
+	}
+	c.mu.hints, c.mu.entries = hints, entries
+	return nil
+}
+
+// Lookup returns the hints to apply when planning a statement with the given
+// fingerprint, or nil if there are none. The returned hints must not be
+// modified.
+func (c *Cache) Lookup(fingerprint string) []Hint {
+	if c == nil || !Enabled.Get(&c.st.SV) {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.mu.hints) == 0 {
+		return nil
+	}
+	return c.mu.hints[fingerprint]
+}
+
+// RecordApplied records that the given hints were applied to a plan.
+func (c *Cache) RecordApplied(applied []Hint) {
+	if len(applied) == 0 {
+		return
+	}
+	now := timeutil.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range applied {
+		if entry, ok := c.mu.entries[applied[i].RowID]; ok {
+			entry.AppliedCount++
+			entry.LastApplied = now
+		}
+	}
+}
+
+// Entries returns a snapshot of the hints of the cache along with their usage,
+// ordered by row ID.
+func (c *Cache) Entries() []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]Entry, 0, len(c.mu.entries))
+	for _, entry := range c.mu.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RowID < entries[j].RowID
+	})
+	return entries
+}
+
+// PinPlan asynchronously pins the indexes of the given plan for the statement
+// fingerprint with a plan_gist_indexes hint, unless the fingerprint already
+// has one. It implements insights.PlanPinner.
+func (c *Cache) PinPlan(_ appstatspb.StmtFingerprintID, fingerprint string, planGist string) {
+	if c.stopper == nil {
+		return
+	}
+	ctx, _ := c.stopper.WithCancelOnQuiesce(context.Background())
+	_ = c.stopper.RunAsyncTask(ctx, "stmt-hints-pin-plan", func(ctx context.Context) {
+		if err := c.pinPlan(ctx, fingerprint, planGist); err != nil {
+			log.Warningf(ctx, "failed to pin plan %s: %v", planGist, err)
+			return
+		}
+		if err := c.Refresh(ctx); err != nil {
+			log.Warningf(ctx, "error polling for statement hints: %s", err)
+		}
+	})
+}
+
+func (c *Cache) pinPlan(ctx context.Context, fingerprint string, planGist string) error {
+	if !c.st.Version.IsActive(ctx, clusterversion.V25_1_StatementHintsTable) {
+		return nil
+	}
+	n, err := c.db.Executor().ExecEx(ctx, "stmt-hints-pin-plan", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.statement_hints (fingerprint, hint_type, hint_value)
+			SELECT $1, $2, $3
+			WHERE NOT EXISTS (
+				SELECT 1 FROM system.statement_hints WHERE fingerprint = $1 AND hint_type = $2
+			)`,
+		fingerprint, string(PlanGistIndexes), planGist,
+	)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Infof(ctx, "pinned the indexes of plan %s for statement fingerprint %q", planGist, fingerprint)
+	}
+	return nil
+}
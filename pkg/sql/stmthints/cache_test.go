@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stmthints_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementHints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	runner := sqlutils.MakeSQLRunner(db)
+	cache := s.ExecutorConfig().(sql.ExecutorConfig).StatementHints
+
+	runner.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b INT, INDEX t_b_idx (b))`)
+	runner.Exec(t, `CREATE TABLE u (x INT PRIMARY KEY)`)
+
+	const fingerprint = `SELECT * FROM t WHERE a = _`
+	explain := func(query string) string {
+		rows := runner.QueryStr(t, `EXPLAIN `+query)
+		var b strings.Builder
+		for _, row := range rows {
+			b.WriteString(row[0])
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+	setHints := func(hints ...string) {
+		runner.Exec(t, `DELETE FROM system.statement_hints WHERE true`)
+		for _, hint := range hints {
+			runner.Exec(t, `INSERT INTO system.statement_hints (fingerprint, hint_type, table_name, hint_value) VALUES `+hint)
+		}
+		require.NoError(t, cache.Refresh(ctx))
+	}
+
+	t.Run("no hints", func(t *testing.T) {
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.NotContains(t, plan, "statement hints")
+		require.Contains(t, plan, "t@t_pkey")
+	})
+
+	t.Run("force index", func(t *testing.T) {
+		setHints(`('` + fingerprint + `', 'force_index', 't', 't_b_idx')`)
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.Contains(t, plan, "statement hints: force_index(t@t_b_idx)")
+		require.Contains(t, plan, "t@t_b_idx")
+
+		// Index hints of the statement take precedence.
+		plan = explain(`SELECT * FROM t@t_pkey WHERE a = 1`)
+		require.NotContains(t, plan, "statement hints")
+
+		// The usage of the hint is shown in crdb_internal.
+		runner.CheckQueryResults(t,
+			`SELECT hint_type, table_name, hint_value, error IS NULL, applied_count > 0
+         FROM crdb_internal.statement_hints`,
+			[][]string{{"force_index", "t", "t_b_idx", "true", "true"}},
+		)
+	})
+
+	t.Run("missing index is ignored", func(t *testing.T) {
+		setHints(`('` + fingerprint + `', 'force_index', 't', 'no_such_idx')`)
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.NotContains(t, plan, "statement hints")
+	})
+
+	t.Run("malformed hint", func(t *testing.T) {
+		setHints(`('` + fingerprint + `', 'join_algorithm', NULL, 'nested')`)
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.NotContains(t, plan, "statement hints")
+		runner.CheckQueryResults(t,
+			`SELECT error FROM crdb_internal.statement_hints`,
+			[][]string{{`unknown join algorithm "nested", expected one of hash, merge, lookup or inverted`}},
+		)
+	})
+
+	t.Run("join algorithm", func(t *testing.T) {
+		setHints(`('SELECT * FROM t JOIN u ON a = x', 'join_algorithm', NULL, 'hash')`)
+		plan := explain(`SELECT * FROM t JOIN u ON a = x`)
+		require.Contains(t, plan, "statement hints: join_algorithm(hash)")
+		require.Contains(t, plan, "hash join")
+	})
+
+	t.Run("plan gist", func(t *testing.T) {
+		gist := runner.QueryStr(t, `EXPLAIN (GIST) SELECT * FROM t@t_b_idx WHERE a = 1`)[0][0]
+		setHints(`('` + fingerprint + `', 'plan_gist_indexes', NULL, '` + gist + `')`)
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.Contains(t, plan, "statement hints: plan_gist_indexes("+gist+")")
+		require.Contains(t, plan, "t@t_b_idx")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		stmthints.Enabled.Override(ctx, &s.ClusterSettings().SV, false)
+		defer stmthints.Enabled.Override(ctx, &s.ClusterSettings().SV, true)
+		plan := explain(`SELECT * FROM t WHERE a = 1`)
+		require.NotContains(t, plan, "statement hints")
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package stmthints implements the hints that operators attach to statement
+// fingerprints in system.statement_hints, and that the optimizer applies
+// whenever a statement with that fingerprint is planned.
+package stmthints
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// HintType is the kind of a statement hint, as stored in the hint_type column
+// of system.statement_hints.
+type HintType string
+
+const (
+	// ForceIndex forces the scans of the table named by the hint to use the
+	// index named by its value, as if the statement read from
+	// table@{FORCE_INDEX=index}.
+	ForceIndex HintType = "force_index"
+	// JoinAlgorithm forces the joins of the statement that don't have a join
+	// hint of their own to use the algorithm named by the hint's value: one of
+	// hash, merge, lookup or inverted.
+	JoinAlgorithm HintType = "join_algorithm"
+	// JoinOrder fixes the order of the joins of the statement that don't have a
+	// join hint of their own. The only supported value is syntactic, which keeps
+	// the joins in the order in which they are written.
+	JoinOrder HintType = "join_order"
+	// PlanGistIndexes is an index hint derived from the plan encoded in the
+	// hint's value: each table read through a single index in that plan is
+	// planned as if it had a force_index hint for it. The rest of the plan, such
+	// as its join order and join algorithms, is not pinned.
+	PlanGistIndexes HintType = "plan_gist_indexes"
+)
+
+// SyntacticJoinOrder is the value of a join_order hint.
+const SyntacticJoinOrder = "syntactic"
+
+// joinAlgorithms maps the values of join_algorithm hints to the corresponding
+// join hints of the AST.
+var joinAlgorithms = map[string]string{
+	"hash":     tree.AstHash,
+	"merge":    tree.AstMerge,
+	"lookup":   tree.AstLookup,
+	"inverted": tree.AstInverted,
+}
+
+// Hint is a row of system.statement_hints.
+type Hint struct {
+	RowID int64
+	// Fingerprint is the fingerprint of the statements the hint applies to, in
+	// the format of the statement fingerprints of crdb_internal.statement_statistics.
+	Fingerprint string
+	Type        HintType
+	// Table is the unqualified name of the table the hint applies to. It is only
+	// set for force_index hints.
+	Table     string
+	Value     string
+	CreatedAt time.Time
+}
+
+// Validate returns an error if the hint is malformed. Hints that refer to
+// tables or indexes that don't exist are not malformed: they are ignored
+// when the statement is planned.
+func (h *Hint) Validate() error {
+	if h.Fingerprint == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "statement hint requires a fingerprint")
+	}
+	switch h.Type {
+	case ForceIndex:
+		if h.Table == "" {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s hint requires a table name", h.Type)
+		}
+		if h.Value == "" {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s hint requires an index name", h.Type)
+		}
+		return nil
+	case JoinAlgorithm:
+		if _, ok := joinAlgorithms[h.Value]; !ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unknown join algorithm %q, expected one of hash, merge, lookup or inverted", h.Value)
+		}
+	case JoinOrder:
+		if h.Value != SyntacticJoinOrder {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unknown join order %q, expected %s", h.Value, SyntacticJoinOrder)
+		}
+	case PlanGistIndexes:
+		if _, err := base64.StdEncoding.DecodeString(h.Value); err != nil || h.Value == "" {
+			return pgerror.Newf(pgcode.InvalidParameterValue, "invalid plan gist %q", h.Value)
+		}
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"unknown statement hint type %q, expected one of %s, %s, %s or %s",
+			h.Type, ForceIndex, JoinAlgorithm, JoinOrder, PlanGistIndexes)
+	}
+	if h.Table != "" {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s hint does not apply to a single table", h.Type)
+	}
+	return nil
+}
+
+// String formats the hint the way it is shown by EXPLAIN.
+func (h *Hint) String() string {
+	if h.Type == ForceIndex {
+		return fmt.Sprintf("%s(%s@%s)", h.Type, h.Table, h.Value)
+	}
+	return fmt.Sprintf("%s(%s)", h.Type, h.Value)
+}
+
+// indexHint is an index forced on a table, along with the stored hint it
+// originates from.
+type indexHint struct {
+	index  string
+	source *Hint
+}
+
+// Hints is the set of hints that apply to the planning of a single statement.
+// It records which of them were applied by the optimizer, and is not safe for
+// concurrent use.
+type Hints struct {
+	hints []Hint
+	// indexes maps table names to the index forced on them.
+	indexes  map[string]indexHint
+	join     *Hint
+	planGist *Hint
+	applied  map[int64]struct{}
+}
+
+// New returns the set of hints to apply when planning a statement, given the
+// hints stored for its fingerprint. When several hints of the same kind apply,
+// the most recently created one wins, except that force_index hints take
+// precedence over the indexes pinned by a plan_gist_indexes hint, and
+// join_algorithm hints take precedence over join_order hints.
+func New(hints []Hint) *Hints {
+	h := &Hints{hints: hints}
+	sorted := make([]*Hint, len(hints))
+	for i := range hints {
+		sorted[i] = &hints[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	for _, hint := range sorted {
+		switch hint.Type {
+		case ForceIndex:
+			if h.indexes == nil {
+				h.indexes = make(map[string]indexHint)
+			}
+			h.indexes[hint.Table] = indexHint{index: hint.Value, source: hint}
+		case JoinAlgorithm:
+			h.join = hint
+		case JoinOrder:
+			if h.join == nil || h.join.Type == JoinOrder {
+				h.join = hint
+			}
+		case PlanGistIndexes:
+			h.planGist = hint
+		}
+	}
+	return h
+}
+
+// PlanGist returns the plan gist whose indexes are pinned, or "" if there is
+// none. The caller is expected to decode it and call AddPlanGistIndex for each
+// of the tables of the plan.
+func (h *Hints) PlanGist() string {
+	if h == nil || h.planGist == nil {
+		return ""
+	}
+	return h.planGist.Value
+}
+
+// AddPlanGistIndex forces the given index on the given table on behalf of the
+// plan_gist_indexes hint, unless a force_index hint applies to the table.
+func (h *Hints) AddPlanGistIndex(table, index string) {
+	if _, ok := h.indexes[table]; ok {
+		return
+	}
+	if h.indexes == nil {
+		h.indexes = make(map[string]indexHint)
+	}
+	h.indexes[table] = indexHint{index: index, source: h.planGist}
+}
+
+// ForceIndex returns the name of the index forced on the given table, if any,
+// along with the hint that forces it. The hint is only recorded as applied once
+// passed to MarkApplied.
+func (h *Hints) ForceIndex(table string) (index string, source *Hint, ok bool) {
+	if h == nil {
+		return "", nil, false
+	}
+	ih, ok := h.indexes[table]
+	return ih.index, ih.source, ok
+}
+
+// JoinHint returns the AST join hint (tree.AstHash, tree.AstStraight, etc.)
+// to use for the joins that don't have a join hint of their own, if any, along
+// with the hint it originates from.
+func (h *Hints) JoinHint() (astHint string, source *Hint, ok bool) {
+	if h == nil || h.join == nil {
+		return "", nil, false
+	}
+	if h.join.Type == JoinOrder {
+		return tree.AstStraight, h.join, true
+	}
+	return joinAlgorithms[h.join.Value], h.join, true
+}
+
+// JoinHintApplied returns whether the join hint returned by JoinHint was
+// applied to the plan.
+func (h *Hints) JoinHintApplied() bool {
+	if h == nil || h.join == nil {
+		return false
+	}
+	_, ok := h.applied[h.join.RowID]
+	return ok
+}
+
+// DropJoinHint discards the join hint, along with the record of the hints
+// applied so far. Stored join hints are only preferences: when no plan
+// satisfies the join hint, the statement is planned again without it.
+func (h *Hints) DropJoinHint() {
+	h.join = nil
+	h.applied = nil
+}
+
+// MarkApplied records that the given hint was applied to the plan.
+func (h *Hints) MarkApplied(hint *Hint) {
+	if h.applied == nil {
+		h.applied = make(map[int64]struct{})
+	}
+	h.applied[hint.RowID] = struct{}{}
+}
+
+// Applied returns the hints that were applied to the plan, in the order in
+// which they were created.
+func (h *Hints) Applied() []Hint {
+	if h == nil || len(h.applied) == 0 {
+		return nil
+	}
+	applied := make([]Hint, 0, len(h.applied))
+	for i := range h.hints {
+		if _, ok := h.applied[h.hints[i].RowID]; ok {
+			applied = append(applied, h.hints[i])
+		}
+	}
+	sort.SliceStable(applied, func(i, j int) bool {
+		return applied[i].CreatedAt.Before(applied[j].CreatedAt)
+	})
+	return applied
+}
+
+// FormatApplied formats the applied hints the way they are shown by EXPLAIN.
+func FormatApplied(applied []Hint) string {
+	var b strings.Builder
+	for i := range applied {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(applied[i].String())
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stmthints
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHintValidate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const fingerprint = "SELECT * FROM t WHERE a = _"
+	for _, tc := range []struct {
+		hint Hint
+		err  string
+	}{
+		{hint: Hint{Type: ForceIndex, Table: "t", Value: "t_a_idx"}},
+		{hint: Hint{Type: ForceIndex, Value: "t_a_idx"}, err: "requires a table name"},
+		{hint: Hint{Type: ForceIndex, Table: "t"}, err: "requires an index name"},
+		{hint: Hint{Type: JoinAlgorithm, Value: "lookup"}},
+		{hint: Hint{Type: JoinAlgorithm, Value: "nested"}, err: "unknown join algorithm"},
+		{hint: Hint{Type: JoinAlgorithm, Table: "t", Value: "hash"}, err: "does not apply to a single table"},
+		{hint: Hint{Type: JoinOrder, Value: SyntacticJoinOrder}},
+		{hint: Hint{Type: JoinOrder, Value: "reverse"}, err: "unknown join order"},
+		{hint: Hint{Type: PlanGistIndexes, Value: "AgHQAQIAAgAAAAY="}},
+		{hint: Hint{Type: PlanGistIndexes, Value: "not a gist"}, err: "invalid plan gist"},
+		{hint: Hint{Type: "no_such_hint", Value: "x"}, err: "unknown statement hint type"},
+	} {
+		t.Run(tc.hint.String(), func(t *testing.T) {
+			tc.hint.Fingerprint = fingerprint
+			err := tc.hint.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestHints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := time.Now()
+	hint := func(rowID int64, typ HintType, table, value string) Hint {
+		return Hint{
+			RowID:     rowID,
+			Type:      typ,
+			Table:     table,
+			Value:     value,
+			CreatedAt: now.Add(time.Duration(rowID) * time.Second),
+		}
+	}
+
+	t.Run("no hints", func(t *testing.T) {
+		var h *Hints
+		_, _, ok := h.ForceIndex("t")
+		require.False(t, ok)
+		_, _, ok = h.JoinHint()
+		require.False(t, ok)
+		require.Empty(t, h.PlanGist())
+		require.Empty(t, h.Applied())
+	})
+
+	t.Run("most recent hint wins", func(t *testing.T) {
+		h := New([]Hint{
+			hint(2, ForceIndex, "t", "t_b_idx"),
+			hint(1, ForceIndex, "t", "t_a_idx"),
+			hint(3, JoinAlgorithm, "", "merge"),
+			hint(4, JoinAlgorithm, "", "hash"),
+		})
+		index, source, ok := h.ForceIndex("t")
+		require.True(t, ok)
+		require.Equal(t, "t_b_idx", index)
+		require.Equal(t, int64(2), source.RowID)
+		_, _, ok = h.ForceIndex("u")
+		require.False(t, ok)
+		astHint, _, ok := h.JoinHint()
+		require.True(t, ok)
+		require.Equal(t, tree.AstHash, astHint)
+	})
+
+	t.Run("join algorithm takes precedence over join order", func(t *testing.T) {
+		for _, hints := range [][]Hint{
+			{hint(1, JoinOrder, "", SyntacticJoinOrder), hint(2, JoinAlgorithm, "", "lookup")},
+			{hint(1, JoinAlgorithm, "", "lookup"), hint(2, JoinOrder, "", SyntacticJoinOrder)},
+		} {
+			astHint, _, ok := New(hints).JoinHint()
+			require.True(t, ok)
+			require.Equal(t, tree.AstLookup, astHint)
+		}
+		astHint, _, ok := New([]Hint{hint(1, JoinOrder, "", SyntacticJoinOrder)}).JoinHint()
+		require.True(t, ok)
+		require.Equal(t, tree.AstStraight, astHint)
+	})
+
+	t.Run("force index takes precedence over plan gist", func(t *testing.T) {
+		h := New([]Hint{
+			hint(1, ForceIndex, "t", "t_a_idx"),
+			hint(2, PlanGistIndexes, "", "AgHQAQIAAgAAAAY="),
+		})
+		require.Equal(t, "AgHQAQIAAgAAAAY=", h.PlanGist())
+		h.AddPlanGistIndex("t", "t_pkey")
+		h.AddPlanGistIndex("u", "u_pkey")
+		index, source, _ := h.ForceIndex("t")
+		require.Equal(t, "t_a_idx", index)
+		require.Equal(t, ForceIndex, source.Type)
+		index, source, _ = h.ForceIndex("u")
+		require.Equal(t, "u_pkey", index)
+		require.Equal(t, PlanGistIndexes, source.Type)
+	})
+
+	t.Run("applied hints", func(t *testing.T) {
+		h := New([]Hint{
+			hint(2, JoinOrder, "", SyntacticJoinOrder),
+			hint(1, ForceIndex, "t", "t_a_idx"),
+			hint(3, ForceIndex, "u", "u_a_idx"),
+		})
+		_, join, _ := h.JoinHint()
+		_, index, _ := h.ForceIndex("t")
+		h.MarkApplied(join)
+		h.MarkApplied(index)
+		h.MarkApplied(index)
+		applied := h.Applied()
+		require.Len(t, applied, 2)
+		require.Equal(t, "force_index(t@t_a_idx), join_order(syntactic)", FormatApplied(applied))
+	})
+
+	t.Run("drop join hint", func(t *testing.T) {
+		h := New([]Hint{
+			hint(1, JoinAlgorithm, "", "merge"),
+			hint(2, ForceIndex, "t", "t_a_idx"),
+		})
+		require.False(t, h.JoinHintApplied())
+		_, join, _ := h.JoinHint()
+		_, index, _ := h.ForceIndex("t")
+		h.MarkApplied(join)
+		h.MarkApplied(index)
+		require.True(t, h.JoinHintApplied())
+
+		// Planning again without the join hint starts over.
+		h.DropJoinHint()
+		_, _, ok := h.JoinHint()
+		require.False(t, ok)
+		require.False(t, h.JoinHintApplied())
+		require.Empty(t, h.Applied())
+		_, index, ok = h.ForceIndex("t")
+		require.True(t, ok)
+		h.MarkApplied(index)
+		require.Equal(t, "force_index(t@t_a_idx)", FormatApplied(h.Applied()))
+	})
+}
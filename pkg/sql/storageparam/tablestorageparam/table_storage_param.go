@@ -12,6 +12,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
@@ -118,6 +119,18 @@ func (po *Setter) getOrCreateRowLevelTTL() *catpb.RowLevelTTL {
 	return rowLevelTTL
 }
 
+// checkTTLArchiveSupported returns an error if the TTL archive storage
+// parameters can't be set yet. Nodes running v24.3 don't know about the archive
+// fields of the RowLevelTTL and would delete the expired rows without
+// archiving them.
+func checkTTLArchiveSupported(ctx context.Context, evalCtx *eval.Context, key string) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V25_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s unsupported in mixed-version cluster", key)
+	}
+	return nil
+}
+
 type tableParam struct {
 	onSet   func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error
 	onReset func(ctx context.Context, po *Setter, evalCtx *eval.Context, key string) error
@@ -451,6 +464,53 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`ttl_archive_uri`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if err := checkTTLArchiveSupported(ctx, evalCtx, key); err != nil {
+				return err
+			}
+			str, err := paramparse.DatumAsString(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if err := tabledesc.ValidateTTLArchiveURI(key, str); err != nil {
+				return err
+			}
+			rowLevelTTL := po.getOrCreateRowLevelTTL()
+			rowLevelTTL.ArchiveURI = str
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if po.hasRowLevelTTL() {
+				po.UpdatedRowLevelTTL.ArchiveURI = ""
+			}
+			return nil
+		},
+	},
+	`ttl_archive_format`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if err := checkTTLArchiveSupported(ctx, evalCtx, key); err != nil {
+				return err
+			}
+			str, err := paramparse.DatumAsString(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			str = strings.ToLower(str)
+			if err := tabledesc.ValidateTTLArchiveFormat(key, str); err != nil {
+				return err
+			}
+			rowLevelTTL := po.getOrCreateRowLevelTTL()
+			rowLevelTTL.ArchiveFormat = str
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if po.hasRowLevelTTL() {
+				po.UpdatedRowLevelTTL.ArchiveFormat = ""
+			}
+			return nil
+		},
+	},
 	`exclude_data_from_backup`: {
 		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext,
 			evalCtx *eval.Context, key string, datum tree.Datum) error {
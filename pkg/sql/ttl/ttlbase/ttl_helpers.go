@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -159,6 +160,15 @@ func GetChangefeedReplicationDisabled(
 	return changefeedReplicationDisabled.Get(settingsValues)
 }
 
+// GetArchiveFormat returns the file format in which deleted rows are
+// archived.
+func GetArchiveFormat(ttl *catpb.RowLevelTTL) string {
+	if ttl.ArchiveFormat != "" {
+		return ttl.ArchiveFormat
+	}
+	return tabledesc.TTLArchiveFormatCSV
+}
+
 // BuildScheduleLabel returns a string value intended for use as the
 // schedule_name/label column for the scheduled job created by row level TTL.
 func BuildScheduleLabel(tbl *tabledesc.Mutable) string {
@@ -265,6 +275,23 @@ func BuildSelectQuery(
 	return buf.String()
 }
 
+// BuildArchiveDeleteQuery returns a DELETE statement like BuildDeleteQuery
+// that also returns the values of returningColNames for each deleted row so
+// that they can be archived.
+func BuildArchiveDeleteQuery(
+	relationName string,
+	pkColNames []string,
+	ttlExpr catpb.Expression,
+	numRows int,
+	returningColNames []string,
+) string {
+	var buf bytes.Buffer
+	buf.WriteString(BuildDeleteQuery(relationName, pkColNames, ttlExpr, numRows))
+	buf.WriteString("\nRETURNING ")
+	buf.WriteString(strings.Join(returningColNames, ", "))
+	return buf.String()
+}
+
 func BuildDeleteQuery(
 	relationName string, pkColNames []string, ttlExpr catpb.Expression, numRows int,
 ) string {
@@ -391,3 +391,20 @@ AND (col0, col1, col2) IN (($2, $3, $4), ($5, $6, $7), ($8, $9, $10))`,
 		})
 	}
 }
+
+func TestBuildArchiveDeleteQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	actualQuery := BuildArchiveDeleteQuery(
+		relationName,
+		GenPKColNames(2),
+		ttlExpr,
+		2, /* numRows */
+		[]string{"col0", "col1", "val"},
+	)
+	require.Equal(t, `DELETE FROM relation_name
+WHERE ((expire_at) <= $1)
+AND (col0, col1) IN (($2, $3), ($4, $5))
+RETURNING col0, col1, val`, actualQuery)
+}
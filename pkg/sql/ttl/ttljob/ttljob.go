@@ -187,6 +187,7 @@ func (t rowLevelTTLResumer) Resume(ctx context.Context, execCtx interface{}) (re
 		selectRateLimit := ttlbase.GetSelectRateLimit(settingsValues, rowLevelTTL)
 		deleteRateLimit := ttlbase.GetDeleteRateLimit(settingsValues, rowLevelTTL)
 		disableChangefeedReplication := ttlbase.GetChangefeedReplicationDisabled(settingsValues, rowLevelTTL)
+		archiveFormat := ttlbase.GetArchiveFormat(rowLevelTTL)
 		newTTLSpec := func(spans []roachpb.Span) *execinfrapb.TTLSpec {
 			return &execinfrapb.TTLSpec{
 				JobID:                        jobID,
@@ -202,6 +203,8 @@ func (t rowLevelTTLResumer) Resume(ctx context.Context, execCtx interface{}) (re
 				PreSelectStatement:           knobs.PreSelectStatement,
 				AOSTDuration:                 aostDuration,
 				DisableChangefeedReplication: disableChangefeedReplication,
+				ArchiveURI:                   rowLevelTTL.ArchiveURI,
+				ArchiveFormat:                archiveFormat,
 			}
 		}
 
@@ -280,6 +283,16 @@ func (t rowLevelTTLResumer) Resume(ctx context.Context, execCtx interface{}) (re
 
 		return metadataCallbackWriter.Err()
 	}()
+	if rowLevelTTL.ArchiveURI != "" {
+		// Remove the files of deletes that did not commit whether or not the
+		// processors succeeded, since a retry of the job archives to new files.
+		if cleanupErr := cleanupArchive(
+			ctx, execCfg.DistSQLSrv.ExternalStorageFromURI, execCfg.InternalDB,
+			details.TableID, t.job.ID(), rowLevelTTL.ArchiveURI,
+		); cleanupErr != nil {
+			err = errors.CombineErrors(err, errors.Wrap(cleanupErr, "cleaning up TTL archive"))
+		}
+	}
 	if err != nil {
 		return err
 	}
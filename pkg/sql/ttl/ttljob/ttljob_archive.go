@@ -0,0 +1,250 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ttljob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// archiveInfoKeyPrefix prefixes the job info keys that record the archive
+// files whose rows were deleted. A file is recorded in the transaction that
+// deletes its rows, so a file without a record was written by a transaction
+// that did not commit and its rows were not deleted.
+const archiveInfoKeyPrefix = "ttl-archive-file-"
+
+// archiveJobDir returns the directory of the archive files written by a TTL
+// job.
+func archiveJobDir(tableID descpb.ID, jobID jobspb.JobID) string {
+	return fmt.Sprintf("%d/%d", tableID, jobID)
+}
+
+// rowArchiver writes the rows deleted by a ttlProcessor to external storage.
+type rowArchiver struct {
+	store  cloud.ExternalStorage
+	jobID  jobspb.JobID
+	format string
+	// colNames and colTypes describe the archived columns. quotedColNames are
+	// the same names quoted for use in SQL statements.
+	colNames       []string
+	quotedColNames []string
+	colTypes       []*types.T
+	// dir is the directory written to by this processor. It is unique to each
+	// run of the processor so that files of a resumed job are never
+	// overwritten.
+	dir string
+	seq atomic.Int64
+}
+
+func newRowArchiver(
+	ctx context.Context,
+	storeFactory cloud.ExternalStorageFromURIFactory,
+	db descs.DB,
+	descsCol *descs.Collection,
+	tableID descpb.ID,
+	jobID jobspb.JobID,
+	uri string,
+	format string,
+) (*rowArchiver, error) {
+	a := &rowArchiver{
+		jobID:  jobID,
+		format: format,
+		dir:    path.Join(archiveJobDir(tableID, jobID), uuid.MakeV4().String()),
+	}
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		desc, err := descsCol.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, tableID)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		for _, col := range desc.PublicColumns() {
+			a.colNames = append(a.colNames, col.GetName())
+			lexbase.EncodeRestrictedSQLIdent(&buf, col.GetName(), lexbase.EncNoFlags)
+			a.quotedColNames = append(a.quotedColNames, buf.String())
+			buf.Reset()
+			a.colTypes = append(a.colTypes, col.GetType())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	store, err := storeFactory(ctx, uri, username.NodeUserName())
+	if err != nil {
+		return nil, errors.Wrap(err, "opening TTL archive")
+	}
+	a.store = store
+	return a, nil
+}
+
+// nextFileName returns the name of the file to which the next batch of rows
+// is archived. It must be called once per batch, outside of the transaction
+// deleting the batch, so that retries of the transaction overwrite the same
+// file.
+func (a *rowArchiver) nextFileName() string {
+	return path.Join(a.dir, fmt.Sprintf("%08d.%s", a.seq.Add(1), a.format))
+}
+
+// write writes rows to the named file and records the file in the job's info
+// storage as part of txn, which must be the transaction deleting rows.
+func (a *rowArchiver) write(
+	ctx context.Context, txn isql.Txn, name string, rows []tree.Datums,
+) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	var err error
+	switch a.format {
+	case tabledesc.TTLArchiveFormatParquet:
+		err = a.encodeParquet(&buf, rows)
+	default:
+		err = a.encodeCSV(&buf, rows)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "encoding TTL archive file %s", name)
+	}
+	if err := cloud.WriteFile(ctx, a.store, name, &buf); err != nil {
+		return errors.Wrapf(err, "writing TTL archive file %s", name)
+	}
+	return jobs.InfoStorageForJob(txn, a.jobID).Write(ctx, archiveInfoKeyPrefix+name, nil /* value */)
+}
+
+// encodeCSV writes rows as CSV with a header row. Following PostgreSQL's CSV
+// format, NULLs are written as unquoted empty fields and empty strings as
+// quoted empty fields.
+func (a *rowArchiver) encodeCSV(buf *bytes.Buffer, rows []tree.Datums) error {
+	w := csv.NewWriter(buf)
+	if err := w.Write(a.colNames); err != nil {
+		return err
+	}
+	f := tree.NewFmtCtx(tree.FmtExport)
+	defer f.Close()
+	var field bytes.Buffer
+	for _, row := range rows {
+		for _, d := range row {
+			if d == tree.DNull {
+				field.Reset()
+				if err := w.WriteField(&field); err != nil {
+					return err
+				}
+				continue
+			}
+			f.Reset()
+			d.Format(f)
+			if f.Buffer.Len() == 0 {
+				if err := w.ForceEmptyField(); err != nil {
+					return err
+				}
+				continue
+			}
+			field.Reset()
+			field.Write(f.Bytes())
+			if err := w.WriteField(&field); err != nil {
+				return err
+			}
+		}
+		if err := w.FinishRecord(); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (a *rowArchiver) encodeParquet(buf *bytes.Buffer, rows []tree.Datums) error {
+	sch, err := parquet.NewSchema(a.colNames, a.colTypes)
+	if err != nil {
+		return err
+	}
+	w, err := parquet.NewWriter(sch, buf)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.AddRow(row); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// Close releases the archiver's external storage.
+func (a *rowArchiver) Close() error {
+	return a.store.Close()
+}
+
+// cleanupArchive deletes the archive files of a job that are not recorded in
+// its info storage, which were written by transactions that did not commit. It
+// must only be called while none of the job's processors are running.
+func cleanupArchive(
+	ctx context.Context,
+	storeFactory cloud.ExternalStorageFromURIFactory,
+	db isql.DB,
+	tableID descpb.ID,
+	jobID jobspb.JobID,
+	uri string,
+) error {
+	committed := make(map[string]struct{})
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return jobs.InfoStorageForJob(txn, jobID).Iterate(ctx, archiveInfoKeyPrefix,
+			func(infoKey string, _ []byte) error {
+				committed[strings.TrimPrefix(infoKey, archiveInfoKeyPrefix)] = struct{}{}
+				return nil
+			})
+	}); err != nil {
+		return err
+	}
+
+	store, err := storeFactory(ctx, uri, username.NodeUserName())
+	if err != nil {
+		return errors.Wrap(err, "opening TTL archive")
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Warningf(ctx, "failed to close TTL archive: %v", err)
+		}
+	}()
+
+	dir := archiveJobDir(tableID, jobID)
+	var orphaned []string
+	if err := store.List(ctx, dir+"/", "", func(name string) error {
+		name = path.Join(dir, strings.TrimPrefix(name, "/"))
+		if _, ok := committed[name]; !ok {
+			orphaned = append(orphaned, name)
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "listing TTL archive")
+	}
+	for _, name := range orphaned {
+		log.VInfof(ctx, 2, "deleting uncommitted TTL archive file %s", name)
+		if err := store.Delete(ctx, name); err != nil {
+			return errors.Wrapf(err, "deleting uncommitted TTL archive file %s", name)
+		}
+	}
+	return nil
+}
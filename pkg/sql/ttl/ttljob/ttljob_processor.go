@@ -154,6 +154,24 @@ func (t *ttlProcessor) work(ctx context.Context) error {
 		return err
 	}
 
+	var archiver *rowArchiver
+	var archiveColNames []string
+	if ttlSpec.ArchiveURI != "" {
+		archiver, err = newRowArchiver(
+			ctx, serverCfg.ExternalStorageFromURI, db, descsCol, tableID, ttlSpec.JobID,
+			ttlSpec.ArchiveURI, ttlSpec.ArchiveFormat,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := archiver.Close(); err != nil {
+				log.Warningf(ctx, "failed to close TTL archive: %v", err)
+			}
+		}()
+		archiveColNames = archiver.quotedColNames
+	}
+
 	jobRegistry := serverCfg.JobRegistry
 	metrics := jobRegistry.MetricsStruct().RowLevelTTL.(*RowLevelTTLAggMetrics).loadMetrics(
 		labelMetrics,
@@ -247,6 +265,7 @@ func (t *ttlProcessor) work(ctx context.Context) error {
 							TTLExpr:           ttlExpr,
 							DeleteDuration:    metrics.DeleteDuration,
 							DeleteRateLimiter: deleteRateLimiter,
+							ArchiveColNames:   archiveColNames,
 						},
 						cutoff,
 					)
@@ -255,6 +274,7 @@ func (t *ttlProcessor) work(ctx context.Context) error {
 						metrics,
 						selectBuilder,
 						deleteBuilder,
+						archiver,
 					)
 					// add before returning err in case of partial success
 					processorRowCount.Add(spanRowCount)
@@ -353,12 +373,14 @@ func (t *ttlProcessor) work(ctx context.Context) error {
 
 // runTTLOnQueryBounds runs the SELECT/DELETE loop for a single DistSQL span.
 // spanRowCount should be checked even if the function returns an error
-// because it may have partially succeeded.
+// because it may have partially succeeded. If archiver is non-nil, the deleted
+// rows are archived in the transaction that deletes them.
 func (t *ttlProcessor) runTTLOnQueryBounds(
 	ctx context.Context,
 	metrics rowLevelTTLMetrics,
 	selectBuilder SelectQueryBuilder,
 	deleteBuilder DeleteQueryBuilder,
+	archiver *rowArchiver,
 ) (spanRowCount int64, err error) {
 	metrics.NumActiveSpans.Inc(1)
 	defer metrics.NumActiveSpans.Dec(1)
@@ -412,6 +434,10 @@ func (t *ttlProcessor) runTTLOnQueryBounds(
 			}
 			deleteBatch := expiredRowsPKs[startRowIdx:until]
 			var batchRowCount int64
+			var archiveFileName string
+			if archiver != nil {
+				archiveFileName = archiver.nextFileName()
+			}
 			do := func(ctx context.Context, txn isql.Txn) error {
 				txn.KV().SetDebugName("ttljob-delete-batch")
 				if ttlSpec.DisableChangefeedReplication {
@@ -429,11 +455,16 @@ func (t *ttlProcessor) runTTLOnQueryBounds(
 						desc.GetModificationTime().GoTime().Format(time.RFC3339),
 					)
 				}
-				batchRowCount, err = deleteBuilder.Run(ctx, txn, deleteBatch)
+				if archiver == nil {
+					batchRowCount, err = deleteBuilder.Run(ctx, txn, deleteBatch)
+					return err
+				}
+				deletedRows, err := deleteBuilder.RunReturning(ctx, txn, deleteBatch)
 				if err != nil {
 					return err
 				}
-				return nil
+				batchRowCount = int64(len(deletedRows))
+				return archiver.write(ctx, txn, archiveFileName, deletedRows)
 			}
 			if err := serverCfg.DB.Txn(
 				ctx, do, isql.SteppingEnabled(), isql.WithPriority(admissionpb.BulkLowPri),
@@ -184,6 +184,9 @@ type DeleteQueryParams struct {
 	TTLExpr           catpb.Expression
 	DeleteDuration    *aggmetric.Histogram
 	DeleteRateLimiter *quotapool.RateLimiter
+	// ArchiveColNames are the columns returned for each deleted row by
+	// RunReturning.
+	ArchiveColNames []string
 }
 
 // DeleteQueryBuilder is responsible for maintaining state around the DELETE
@@ -214,6 +217,15 @@ func MakeDeleteQueryBuilder(params DeleteQueryParams, cutoff time.Time) DeleteQu
 }
 
 func (b *DeleteQueryBuilder) buildQuery(numRows int) string {
+	if len(b.ArchiveColNames) > 0 {
+		return ttlbase.BuildArchiveDeleteQuery(
+			b.RelationName,
+			b.PKColNames,
+			b.TTLExpr,
+			numRows,
+			b.ArchiveColNames,
+		)
+	}
 	return ttlbase.BuildDeleteQuery(
 		b.RelationName,
 		b.PKColNames,
@@ -225,25 +237,8 @@ func (b *DeleteQueryBuilder) buildQuery(numRows int) string {
 func (b *DeleteQueryBuilder) Run(
 	ctx context.Context, txn isql.Txn, rows []tree.Datums,
 ) (int64, error) {
-	numRows := len(rows)
-	var query string
-	if int64(numRows) == b.DeleteBatchSize {
-		if b.cachedQuery == "" {
-			b.cachedQuery = b.buildQuery(numRows)
-		}
-		query = b.cachedQuery
-	} else {
-		query = b.buildQuery(numRows)
-	}
-
-	deleteArgs := b.cachedArgs[:1]
-	for _, row := range rows {
-		for _, col := range row {
-			deleteArgs = append(deleteArgs, col)
-		}
-	}
-
-	tokens, err := b.DeleteRateLimiter.Acquire(ctx, int64(numRows))
+	query, deleteArgs := b.prepare(rows)
+	tokens, err := b.DeleteRateLimiter.Acquire(ctx, int64(len(rows)))
 	if err != nil {
 		return 0, err
 	}
@@ -264,3 +259,57 @@ func (b *DeleteQueryBuilder) Run(
 	b.DeleteDuration.RecordValue(int64(timeutil.Since(start)))
 	return int64(rowCount), nil
 }
+
+// RunReturning deletes rows like Run, returning the ArchiveColNames values of
+// each deleted row.
+func (b *DeleteQueryBuilder) RunReturning(
+	ctx context.Context, txn isql.Txn, rows []tree.Datums,
+) ([]tree.Datums, error) {
+	if len(b.ArchiveColNames) == 0 {
+		return nil, errors.AssertionFailedf("ArchiveColNames is empty")
+	}
+	query, deleteArgs := b.prepare(rows)
+	tokens, err := b.DeleteRateLimiter.Acquire(ctx, int64(len(rows)))
+	if err != nil {
+		return nil, err
+	}
+	defer tokens.Consume()
+
+	start := timeutil.Now()
+	deleted, err := txn.QueryBufferedEx(
+		ctx,
+		b.deleteOpName,
+		txn.KV(),
+		getInternalExecutorOverride(sessiondatapb.BulkLowQoS),
+		query,
+		deleteArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	b.DeleteDuration.RecordValue(int64(timeutil.Since(start)))
+	return deleted, nil
+}
+
+// prepare returns the query and arguments to delete rows.
+func (b *DeleteQueryBuilder) prepare(rows []tree.Datums) (string, []interface{}) {
+	numRows := len(rows)
+	var query string
+	if int64(numRows) == b.DeleteBatchSize {
+		if b.cachedQuery == "" {
+			b.cachedQuery = b.buildQuery(numRows)
+		}
+		query = b.cachedQuery
+	} else {
+		query = b.buildQuery(numRows)
+	}
+
+	deleteArgs := b.cachedArgs[:1]
+	for _, row := range rows {
+		for _, col := range row {
+			deleteArgs = append(deleteArgs, col)
+		}
+	}
+
+	return query, deleteArgs
+}
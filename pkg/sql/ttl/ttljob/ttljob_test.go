@@ -8,8 +8,13 @@ package ttljob_test
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -58,6 +63,7 @@ type rowLevelTTLTestJobTestHelper struct {
 	testCluster      serverutils.TestClusterInterface
 	sqlDB            *sqlutils.SQLRunner
 	kvDB             *kv.DB
+	externalIODir    string
 	executeSchedules func() error
 }
 
@@ -65,6 +71,7 @@ func newRowLevelTTLTestJobTestHelper(
 	t *testing.T, testingKnobs *sql.TTLTestingKnobs, testMultiTenant bool, numNodes int,
 ) (*rowLevelTTLTestJobTestHelper, func()) {
 	th := &rowLevelTTLTestJobTestHelper{
+		externalIODir: t.TempDir(),
 		env: jobstest.NewJobSchedulerTestEnv(
 			jobstest.UseSystemTables,
 			timeutil.Now(),
@@ -101,6 +108,7 @@ func newRowLevelTTLTestJobTestHelper(
 			DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSecondaryTenantsButDoesntYet(109391),
 			Knobs:             baseTestingKnobs,
 			InsecureWebAccess: true,
+			ExternalIODir:     th.externalIODir,
 		},
 	})
 	th.testCluster = testCluster
@@ -1086,6 +1094,92 @@ func TestInboundForeignKeyOnDeleteRestrictNull(t *testing.T) {
 	require.Len(t, results, 1)
 }
 
+func TestRowLevelTTLArchive(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, format := range []string{"csv", "parquet"} {
+		t.Run(format, func(t *testing.T) {
+			th, cleanupFunc := newRowLevelTTLTestJobTestHelper(
+				t,
+				&sql.TTLTestingKnobs{
+					AOSTDuration:     &zeroDuration,
+					ReturnStatsError: true,
+				},
+				false, /* testMultiTenant */
+				1,     /* numNodes */
+			)
+			defer cleanupFunc()
+
+			sqlDB := th.sqlDB
+			sqlDB.Exec(t, "CREATE EXTERNAL CONNECTION ttl_archive AS 'nodelocal://1/ttl'")
+			sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE tbl (
+	id INT PRIMARY KEY,
+	s STRING,
+	expire_at TIMESTAMPTZ
+) WITH (
+	ttl_expiration_expression = 'expire_at',
+	ttl_archive_uri = 'external://ttl_archive',
+	ttl_archive_format = '%s',
+	ttl_delete_batch_size = 2
+)`, format))
+			sqlDB.Exec(t, `INSERT INTO tbl VALUES
+	(1, 'a', '2020-01-01'),
+	(2, NULL, '2020-01-01'),
+	(3, '', '2020-01-01'),
+	(4, 'd', '2200-01-01')`)
+
+			// Force the schedule to execute.
+			th.waitForScheduledJob(t, jobs.StatusSucceeded, "")
+			sqlDB.CheckQueryResults(t, "SELECT id FROM tbl", [][]string{{"4"}})
+
+			// Each delete transaction is retried once by the helper's request
+			// filter, so every batch is written twice to the same file, and the
+			// job's info storage has one record per file.
+			var tableID int
+			sqlDB.QueryRow(t, "SELECT 'tbl'::REGCLASS::INT").Scan(&tableID)
+			var files []string
+			require.NoError(t, filepath.WalkDir(
+				filepath.Join(th.externalIODir, "ttl", strconv.Itoa(tableID)),
+				func(path string, d fs.DirEntry, err error) error {
+					if err == nil && !d.IsDir() {
+						files = append(files, path)
+					}
+					return err
+				},
+			))
+			require.Len(t, files, 2)
+			for _, f := range files {
+				require.Equal(t, "."+format, filepath.Ext(f))
+			}
+			sqlDB.CheckQueryResults(t, `
+SELECT count(*) FROM system.job_info
+WHERE info_key LIKE 'ttl-archive-file-%'
+AND job_id = (SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'ROW LEVEL TTL')`,
+				[][]string{{"2"}},
+			)
+
+			if format != "csv" {
+				return
+			}
+			var records [][]string
+			for _, f := range files {
+				data, err := os.ReadFile(f)
+				require.NoError(t, err)
+				fileRecords, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+				require.NoError(t, err)
+				require.Equal(t, []string{"id", "s", "expire_at"}, fileRecords[0])
+				records = append(records, fileRecords[1:]...)
+			}
+			sort.Slice(records, func(i, j int) bool { return records[i][0] < records[j][0] })
+			require.Len(t, records, 3)
+			require.Equal(t, []string{"1", "a"}, records[0][:2])
+			require.Equal(t, []string{"2", ""}, records[1][:2])
+			require.Equal(t, []string{"3", ""}, records[2][:2])
+		})
+	}
+}
+
 func TestMakeTTLJobDescription(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
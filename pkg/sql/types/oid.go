@@ -55,18 +55,23 @@ var (
 // Note that additional elements for the array Oid types are added in init().
 var OidToType = map[oid.Oid]*T{
 	oid.T_anyelement: Any,
+	oid.T_anyrange:   AnyRange,
 	oid.T_bit:        typeBit,
 	oid.T_bool:       Bool,
 	oid.T_bpchar:     BPChar,
 	oid.T_bytea:      Bytes,
 	oid.T_char:       QChar,
+	oid.T_cidr:       CIDR,
 	oid.T_date:       Date,
+	oid.T_daterange:  DateRange,
 	oid.T_float4:     Float4,
 	oid.T_float8:     Float,
 	oid.T_int2:       Int2,
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
+	oid.T_int4range:  Int4Range,
 	oid.T_int8:       Int,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -74,8 +79,11 @@ var OidToType = map[oid.Oid]*T{
 	// existing tables.
 	// oid.T_json:      Json,
 	oid.T_jsonb:        Jsonb,
+	oid.T_macaddr:      MacAddr,
+	oid.T_money:        Money,
 	oid.T_name:         Name,
 	oid.T_numeric:      Decimal,
+	oid.T_numrange:     NumRange,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
 	oid.T_pg_lsn:       PGLSN,
@@ -94,6 +102,8 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timestamptz:  TimestampTZ,
 	oid.T_trigger:      Trigger,
 	oid.T_tsquery:      TSQuery,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
@@ -105,6 +115,8 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
 	oidext.T_pgvector:  PGVector,
+	oidext.T_macaddr8:  MacAddr8,
+	oidext.T_jsonpath:  Jsonpath,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -115,18 +127,25 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_bpchar:       oid.T__bpchar,
 	oid.T_bytea:        oid.T__bytea,
 	oid.T_char:         oid.T__char,
+	oid.T_cidr:         oid.T__cidr,
 	oid.T_date:         oid.T__date,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
 	oid.T_inet:         oid.T__inet,
 	oid.T_int2:         oid.T__int2,
 	oid.T_int2vector:   oid.T__int2vector,
 	oid.T_int4:         oid.T__int4,
+	oid.T_int4range:    oid.T__int4range,
 	oid.T_int8:         oid.T__int8,
+	oid.T_int8range:    oid.T__int8range,
 	oid.T_interval:     oid.T__interval,
 	oid.T_jsonb:        oid.T__jsonb,
+	oid.T_macaddr:      oid.T__macaddr,
+	oid.T_money:        oid.T__money,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
+	oid.T_numrange:     oid.T__numrange,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
@@ -144,6 +163,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_timestamp:    oid.T__timestamp,
 	oid.T_timestamptz:  oid.T__timestamptz,
 	oid.T_tsquery:      oid.T__tsquery,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
 	oid.T_tsvector:     oid.T__tsvector,
 	oid.T_uuid:         oid.T__uuid,
 	oid.T_varbit:       oid.T__varbit,
@@ -153,6 +174,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
 	oidext.T_pgvector:  oidext.T__pgvector,
+	oidext.T_macaddr8:  oidext.T__macaddr8,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -171,7 +194,11 @@ var familyToOid = map[Family]oid.Oid{
 	TimestampTZFamily:    oid.T_timestamptz,
 	CollatedStringFamily: oid.T_text,
 	OidFamily:            oid.T_oid,
+	MacAddrFamily:        oid.T_macaddr,
+	MoneyFamily:          oid.T_money,
+	JsonpathFamily:       oidext.T_jsonpath,
 	PGLSNFamily:          oid.T_pg_lsn,
+	RangeFamily:          oid.T_int8range,
 	RefCursorFamily:      oid.T_refcursor,
 	UnknownFamily:        oid.T_unknown,
 	UuidFamily:           oid.T_uuid,
@@ -206,6 +233,9 @@ func init() {
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
 	o := elemTyp.Oid()
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	switch elemTyp.Family() {
 	case ArrayFamily:
 		// Postgres nested arrays return the OID of the nested array (i.e. the
@@ -225,6 +255,13 @@ func CalcArrayOid(elemTyp *T) oid.Oid {
 		// previous versions of CRDB returned for this case.
 		return unknownArrayOid
 
+	case AnyFamily:
+		// ANYCOMPATIBLE is not included in the OidToType map, so its array OID
+		// cannot be looked up below.
+		if o == oidext.T_anycompatible {
+			return oidext.T_anycompatiblearray
+		}
+
 	case EnumFamily:
 		return elemTyp.UserDefinedArrayOID()
 
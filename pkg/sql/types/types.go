@@ -82,10 +82,15 @@ import (
 // | OID               | OID            | T_oid         | 0         | 0     |
 // | UUID              | UUID           | T_uuid        | 0         | 0     |
 // | INET              | INET           | T_inet        | 0         | 0     |
+// | CIDR              | INET           | T_cidr        | 0         | 0     |
+// | MACADDR           | MACADDR        | T_macaddr     | 0         | 0     |
+// | MACADDR8          | MACADDR        | T_macaddr8    | 0         | 0     |
+// | MONEY             | MONEY          | T_money       | 0         | 0     |
 // | TIME              | TIME           | T_time        | 0         | 0     |
 // | TIMETZ            | TIMETZ         | T_timetz      | 0         | 0     |
 // | JSON              | JSONB          | T_json        | 0         | 0     |
 // | JSONB             | JSONB          | T_jsonb       | 0         | 0     |
+// | JSONPATH          | JSONPATH       | T_jsonpath    | 0         | 0     |
 // |                   |                |               |           |       |
 // | BYTES             | BYTES          | T_bytea       | 0         | 0     |
 // |                   |                |               |           |       |
@@ -148,6 +153,16 @@ import (
 // |                 | of a scalar type                                        |
 // | ArrayContents   | Type of array elements (scalar, array, or tuple)        |
 //
+// Range types
+// -----------
+//
+// | Field           | Description                                             |
+// |-----------------|---------------------------------------------------------|
+// | Family          | RangeFamily                                             |
+// | Oid             | T_XXXrange, which determines the type of the bounds     |
+//
+// The type of the bounds of a range type is returned by RangeContents.
+//
 // There are two special ARRAY types:
 //
 // | SQL type          | Family         | Oid           | ArrayContents |
@@ -212,6 +227,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -238,6 +256,23 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// Constraints are the CHECK constraints of the domain. Each expression
+	// refers to the checked value as VALUE.
+	Constraints []DomainConstraint
+}
+
+// DomainConstraint is a CHECK constraint on a DOMAIN.
+type DomainConstraint struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized check expression.
+	Expr string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -454,6 +489,38 @@ var (
 	INet = &T{InternalType: InternalType{
 		Family: INetFamily, Oid: oid.T_inet, Locale: &emptyLocale}}
 
+	// CIDR is the type of an IPv4 or IPv6 network specification. It shares
+	// the representation of INet, but values never have bits set to the right
+	// of the netmask. For example:
+	//
+	//   192.168.100.128/25
+	//   2001:4f8:3:ba::/64
+	//
+	CIDR = &T{InternalType: InternalType{
+		Family: INetFamily, Oid: oid.T_cidr, Locale: &emptyLocale}}
+
+	// MacAddr is the type of a 6-byte (EUI-48) MAC address. For example:
+	//
+	//   08:00:2b:01:02:03
+	//
+	MacAddr = &T{InternalType: InternalType{
+		Family: MacAddrFamily, Oid: oid.T_macaddr, Locale: &emptyLocale}}
+
+	// MacAddr8 is the type of an 8-byte (EUI-64) MAC address. For example:
+	//
+	//   08:00:2b:01:02:03:04:05
+	//
+	MacAddr8 = &T{InternalType: InternalType{
+		Family: MacAddrFamily, Oid: oidext.T_macaddr8, Locale: &emptyLocale}}
+
+	// Money is the type of a currency amount with a fixed fractional precision
+	// of two digits. For example:
+	//
+	//   $1,234.56
+	//
+	Money = &T{InternalType: InternalType{
+		Family: MoneyFamily, Oid: oid.T_money, Locale: &emptyLocale}}
+
 	// Geometry is the type of a geospatial Geometry object.
 	Geometry = &T{
 		InternalType: InternalType{
@@ -531,6 +598,19 @@ var (
 		},
 	}
 
+	// Jsonpath is the jsonpath type, which represents a SQL/JSON path
+	// expression. For example:
+	//
+	//   strict $.a[*] ? (@ > 1)
+	//
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// TSVector is the tsvector type which represents a document compressed in
 	// a form that a tsquery query can operate on.
 	TSVector = &T{
@@ -551,6 +631,71 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int4range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int8range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_numrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tsrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tstzrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_daterange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// RangeTypes contains the built-in range types. The corresponding
+	// multirange types are not supported.
+	RangeTypes = []*T{
+		Int4Range,
+		Int8Range,
+		NumRange,
+		TSRange,
+		TSTZRange,
+		DateRange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -574,6 +719,8 @@ var (
 		Oid,
 		Uuid,
 		INet,
+		MacAddr,
+		Money,
 		PGLSN,
 		RefCursor,
 		Time,
@@ -596,12 +743,33 @@ var (
 	AnyArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: Any, Oid: oid.T_anyarray, Locale: &emptyLocale}}
 
+	// AnyCompatible is a special type used only during static analysis as a
+	// wildcard type for the parameters of polymorphic routines. Unlike Any, the
+	// arguments supplied for AnyCompatible parameters do not need to have the
+	// same type, as long as they can be implicitly cast to a common type.
+	// Execution-time values should never have this type.
+	AnyCompatible = &T{InternalType: InternalType{
+		Family: AnyFamily, Oid: oidext.T_anycompatible, Locale: &emptyLocale}}
+
+	// AnyCompatibleArray is a special type used only during static analysis as
+	// a wildcard type that matches an array whose element type is resolved
+	// together with AnyCompatible parameters. Execution-time values should never
+	// have this type.
+	AnyCompatibleArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: AnyCompatible, Oid: oidext.T_anycompatiblearray, Locale: &emptyLocale}}
+
 	// AnyEnum is a special type only used during static analysis as a wildcard
 	// type that matches an possible enum value. Execution-time values should
 	// never have this type.
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type used only during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1204,6 +1372,23 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain over the given base type,
+// with the given stable type ID. The domain shares the family and all other
+// attributes of its base type, which must not be a user defined type. Note
+// that it does not hydrate cached fields on the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	if base.UserDefined() {
+		panic(errors.AssertionFailedf("domain base type %s must not be user defined", base.SQLString()))
+	}
+	internalType := base.InternalType
+	internalType.Oid = typeOID
+	internalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+		BaseTypeOID:  base.Oid(),
+	}
+	return &T{InternalType: internalType}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1480,6 +1665,48 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the bounds of a range type. This is nil
+// for types that are not in the RangeFamily.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	return rangeContents[t.Oid()]
+}
+
+// rangeContents maps the Oid of each range type to the type of its bounds.
+var rangeContents = map[oid.Oid]*T{
+	oid.T_anyrange:  Any,
+	oid.T_int4range: Int4,
+	oid.T_int8range: Int,
+	oid.T_numrange:  Decimal,
+	oid.T_tsrange:   Timestamp,
+	oid.T_tstzrange: TimestampTZ,
+	oid.T_daterange: Date,
+}
+
+// RangeOf returns the built-in range type whose bounds have the type family
+// of the given type, if any. For example, the range of INT2 values is
+// INT8RANGE.
+func RangeOf(contents *T) (*T, bool) {
+	switch contents.Family() {
+	case IntFamily:
+		if contents.Width() == 32 {
+			return Int4Range, true
+		}
+		return Int8Range, true
+	case DecimalFamily:
+		return NumRange, true
+	case TimestampFamily:
+		return TSRange, true
+	case TimestampTZFamily:
+		return TSTZRange, true
+	case DateFamily:
+		return DateRange, true
+	}
+	return nil, false
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1518,6 +1745,33 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// IsDomain returns whether or not t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.BaseTypeOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type. It returns t itself if
+// t is not a domain.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.BaseTypeOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
+// domainName returns the name of a domain type, which is used in place of the
+// name of its base type.
+func (t *T) domainName() string {
+	// This can be nil during unit testing.
+	if t.TypeMeta.Name == nil {
+		return "unknown_domain"
+	}
+	return t.TypeMeta.Name.Basename()
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1547,9 +1801,13 @@ var familyNames = map[Family]redact.SafeString{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
+	MacAddrFamily:        "macaddr",
+	MoneyFamily:          "money",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
 	PGVectorFamily:       "vector",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
@@ -1585,8 +1843,14 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
+		if t.Oid() == oidext.T_anycompatible {
+			return "anycompatible"
+		}
 		return "anyelement"
 
 	case ArrayFamily:
@@ -1597,6 +1861,8 @@ func (t *T) Name() string {
 			return "int2vector"
 		case oid.T_anyarray:
 			return "anyarray"
+		case oidext.T_anycompatiblearray:
+			return "anycompatiblearray"
 		}
 		return t.ArrayContents().Name() + "[]"
 
@@ -1628,9 +1894,15 @@ func (t *T) Name() string {
 			panic(errors.AssertionFailedf("programming error: unknown int width: %d", t.Width()))
 		}
 
+	case INetFamily, MacAddrFamily:
+		return t.SQLStandardName()
+
 	case OidFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily:
+		return t.SQLStandardName()
+
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1727,9 +1999,15 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
+		if t.Oid() == oidext.T_anycompatible {
+			return "anycompatible"
+		}
 		return "anyelement"
 	case ArrayFamily:
 		switch t.Oid() {
@@ -1739,6 +2017,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "int2vector"
 		case oid.T_anyarray:
 			return "anyarray"
+		case oidext.T_anycompatiblearray:
+			return "anycompatiblearray"
 		}
 		// If we have a typemod specified then pass it down when
 		// formatting the array type.
@@ -1793,6 +2073,9 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case GeometryFamily, GeographyFamily:
 		return t.Name() + t.InternalType.GeoMetadata.SQLString()
 	case INetFamily:
+		if t.Oid() == oid.T_cidr {
+			return "cidr"
+		}
 		return "inet"
 	case IntFamily:
 		switch t.Width() {
@@ -1814,6 +2097,15 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JsonpathFamily:
+		return "jsonpath"
+	case MacAddrFamily:
+		if t.Oid() == oidext.T_macaddr8 {
+			return "macaddr8"
+		}
+		return "macaddr"
+	case MoneyFamily:
+		return "money"
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1837,6 +2129,25 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "pg_lsn"
 	case PGVectorFamily:
 		return "vector"
+	case RangeFamily:
+		switch t.Oid() {
+		case oid.T_anyrange:
+			return "anyrange"
+		case oid.T_int4range:
+			return "int4range"
+		case oid.T_int8range:
+			return "int8range"
+		case oid.T_numrange:
+			return "numrange"
+		case oid.T_tsrange:
+			return "tsrange"
+		case oid.T_tstzrange:
+			return "tstzrange"
+		case oid.T_daterange:
+			return "daterange"
+		default:
+			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
+		}
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1931,6 +2242,10 @@ func (t *T) InformationSchemaName() string {
 	if t.Family() == ArrayFamily {
 		return "ARRAY"
 	}
+	// Like Postgres, domains report the name of their base type.
+	if t.IsDomain() {
+		return t.DomainBaseType().InformationSchemaName()
+	}
 	// TypeMeta attributes are populated only when it is user defined type.
 	if t.TypeMeta.Name != nil {
 		return "USER-DEFINED"
@@ -1942,6 +2257,13 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		// See the comment for user defined enums below.
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+	}
 	switch t.Family() {
 	case BitFamily:
 		switch t.Oid() {
@@ -2036,6 +2358,8 @@ func (t *T) SQLString() string {
 			return "OIDVECTOR"
 		case oid.T_int2vector:
 			return "INT2VECTOR"
+		case oidext.T_anycompatiblearray:
+			return "ANYCOMPATIBLEARRAY"
 		}
 		if t.ArrayContents().Family() == CollatedStringFamily {
 			return t.ArrayContents().collatedStringTypeSQL(true /* isArray */)
@@ -2113,12 +2437,14 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		// Show the redacted SQLString output with an un-redacted prefix to indicate
 		// that the type is user defined (and possibly enum or record).
 		prefix := "TYPE"
-		switch t.Family() {
-		case EnumFamily:
+		switch {
+		case t.IsDomain():
+			prefix = "DOMAIN"
+		case t.Family() == EnumFamily:
 			prefix = "ENUM"
-		case TupleFamily:
+		case t.Family() == TupleFamily:
 			prefix = "RECORD"
-		case ArrayFamily:
+		case t.Family() == ArrayFamily:
 			prefix = "ARRAY"
 		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
@@ -2133,7 +2459,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily, RangeFamily,
+		MacAddrFamily, MoneyFamily, JsonpathFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2208,6 +2535,16 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily:
+		// Ranges of different types are not compatible. anyrange is used when
+		// matching overloads.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2271,7 +2608,8 @@ func (t *T) Equal(other *T) bool {
 // static analysis, and cannot be used during execution.
 func (t *T) IsWildcardType() bool {
 	for _, wildcard := range []*T{
-		Any, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyTuple, AnyTupleArray,
+		Any, AnyArray, AnyCollatedString, AnyCompatible, AnyCompatibleArray, AnyEnum, AnyEnumArray,
+		AnyRange, AnyTuple, AnyTupleArray,
 	} {
 		// Note that pointer comparison is insufficient since we might have
 		// deserialized t from disk.
@@ -2286,7 +2624,7 @@ func (t *T) IsWildcardType() bool {
 // return-type of a polymorphic function. Note that this does not include RECORD
 // (AnyTuple) or RECORD[].
 func (t *T) IsPolymorphicType() bool {
-	for _, poly := range []*T{Any, AnyArray, AnyEnum, AnyEnumArray} {
+	for _, poly := range []*T{Any, AnyArray, AnyCompatible, AnyCompatibleArray, AnyEnum, AnyEnumArray} {
 		if t.Identical(poly) {
 			return true
 		}
@@ -2294,6 +2632,13 @@ func (t *T) IsPolymorphicType() bool {
 	return false
 }
 
+// IsAnyCompatibleType returns true if the type is one of the polymorphic
+// "anycompatible" family types, for which the concrete type is resolved
+// separately from the other polymorphic types.
+func (t *T) IsAnyCompatibleType() bool {
+	return t.Identical(AnyCompatible) || t.Identical(AnyCompatibleArray)
+}
+
 // IsPseudoType returns true if the type is a pseudotype.
 func (t *T) IsPseudoType() bool {
 	return t.Identical(Trigger) || t.IsPolymorphicType()
@@ -2386,7 +2731,8 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		}
 	}
 	if t.UDTMetadata != nil && other.UDTMetadata != nil {
-		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
+		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID ||
+			t.UDTMetadata.BaseTypeOID != other.UDTMetadata.BaseTypeOID {
 			return false
 		}
 	} else if t.UDTMetadata != nil {
@@ -2728,6 +3074,9 @@ func (t *T) downgradeType() error {
 // TODO(andyk): It'd be nice to have this return SqlString() method output,
 // since that is more descriptive.
 func (t *T) String() string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	switch t.Family() {
 	case CollatedStringFamily:
 		if t.Locale() == "" {
@@ -2822,6 +3171,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily:
+		return t.Oid() == oid.T_anyrange
 	}
 	return false
 }
@@ -3109,6 +3460,12 @@ var unreservedTypeTokens = map[string]*T{
 	"regrole":      RegRole,
 	"regtype":      RegType,
 
+	// Postgres polymorphic pseudo-types that are only used as the parameter and
+	// return types of routines.
+	"anycompatible":      AnyCompatible,
+	"anycompatiblearray": AnyCompatibleArray,
+	"anyenum":            AnyEnum,
+
 	"serial2":     &Serial2Type,
 	"serial4":     &Serial4Type,
 	"serial8":     &Serial8Type,
@@ -3124,18 +3481,20 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
-	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
-	"macaddr":       45813,
-	"macaddr8":      45813,
-	"money":         41578,
-	"path":          21286,
-	"txid_snapshot": -1,
-	"xml":           43355,
+	"anymultirange":  -1,
+	"box":            21286,
+	"circle":         21286,
+	"datemultirange": -1,
+	"int4multirange": -1,
+	"int8multirange": -1,
+	"line":           21286,
+	"lseg":           21286,
+	"nummultirange":  -1,
+	"path":           21286,
+	"tsmultirange":   -1,
+	"tstzmultirange": -1,
+	"txid_snapshot":  -1,
+	"xml":            43355,
 }
 
 // SQLString outputs the GeoMetadata in a SQL-compatible string.
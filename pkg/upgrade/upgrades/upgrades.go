@@ -90,6 +90,20 @@ var upgrades = []upgradebase.Upgrade{
 		backfillJobsTablesAndColumns,
 		upgrade.RestoreActionNotRequired("cluster restore does not restore jobs tables"),
 	),
+	upgrade.NewTenantUpgrade(
+		"create statement_hints table",
+		clusterversion.V25_1_StatementHintsTable.Version(),
+		upgrade.NoPrecondition,
+		createStatementHintsTable,
+		upgrade.RestoreActionNotRequired("backups taken before this upgrade contain no statement hints"),
+	),
+	upgrade.NewTenantUpgrade(
+		"create sql_event_aggregates table",
+		clusterversion.V25_1_SQLEventAggregatesTable.Version(),
+		upgrade.NoPrecondition,
+		createSQLEventAggregatesTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
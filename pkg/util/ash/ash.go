@@ -0,0 +1,162 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package ash provides the building blocks of the active session history: an
+// Activity describes what a statement is doing at a given moment and is
+// carried in the context of the statement execution, so that the layers below
+// SQL (the KV client, admission control, the lock table) can record the
+// reason the statement is waiting. The Activities are periodically sampled by
+// the SQL layer.
+package ash
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/redact"
+)
+
+// WaitState describes what an active statement is doing when it is sampled.
+type WaitState int32
+
+const (
+	// CPU is the state of a statement that is not waiting on anything, i.e.
+	// that is running or runnable.
+	CPU WaitState = iota
+	// LockWait is the state of a statement waiting in the lock table for a
+	// conflicting transaction to release its lock.
+	LockWait
+	// AdmissionQueue is the state of a statement waiting to be admitted by
+	// admission control.
+	AdmissionQueue
+	// Network is the state of a statement waiting for the response of a remote
+	// KV request or for rows from a remote DistSQL flow.
+	Network
+	// IO is the state of a statement waiting for the storage layer to evaluate
+	// a request or to replicate a write.
+	IO
+)
+
+var waitStateNames = [...]string{
+	CPU:            "CPU",
+	LockWait:       "LockWait",
+	AdmissionQueue: "AdmissionQueue",
+	Network:        "Network",
+	IO:             "IO",
+}
+
+// String implements fmt.Stringer.
+func (s WaitState) String() string {
+	if s < 0 || int(s) >= len(waitStateNames) {
+		return "unknown"
+	}
+	return waitStateNames[s]
+}
+
+// SafeValue implements redact.SafeValue.
+func (WaitState) SafeValue() {}
+
+var _ redact.SafeValue = WaitState(0)
+
+// Activity is the state of a single active statement which is recorded by the
+// layers executing it and read by the sampler. All methods are safe for
+// concurrent use and can be called on a nil receiver.
+//
+// A statement has a single wait state even if it is executed by concurrent
+// goroutines, in which case the state is that of the goroutine that changed it
+// last. This is good enough for sampling.
+type Activity struct {
+	// Fingerprint is the fingerprint of the statement. It is immutable.
+	Fingerprint string
+
+	state atomic.Int32
+	mu    struct {
+		syncutil.Mutex
+		// contendedKey is the key the statement is waiting on in the LockWait
+		// state.
+		contendedKey []byte
+	}
+}
+
+// NewActivity returns a new Activity for a statement with the given
+// fingerprint, in the CPU state.
+func NewActivity(fingerprint string) *Activity {
+	return &Activity{Fingerprint: fingerprint}
+}
+
+// State returns the current wait state of the statement, as well as the key it
+// is waiting on, if known.
+func (a *Activity) State() (_ WaitState, contendedKey []byte) {
+	if a == nil {
+		return CPU, nil
+	}
+	s := WaitState(a.state.Load())
+	if s == LockWait {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		contendedKey = a.mu.contendedKey
+	}
+	return s, contendedKey
+}
+
+func (a *Activity) setContendedKey(key []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mu.contendedKey = key
+}
+
+type activityKey struct{}
+
+// ContextWithActivity returns a context carrying the given Activity, so that
+// the wait states of the work done with this context are recorded in it.
+func ContextWithActivity(ctx context.Context, a *Activity) context.Context {
+	return context.WithValue(ctx, activityKey{}, a)
+}
+
+// ActivityFromContext returns the Activity carried by the context, if any.
+func ActivityFromContext(ctx context.Context) *Activity {
+	a, _ := ctx.Value(activityKey{}).(*Activity)
+	return a
+}
+
+// WaitStateToken is returned by SetWaitState to restore the previous wait
+// state.
+type WaitStateToken struct {
+	a    *Activity
+	prev WaitState
+}
+
+// SetWaitState records that the statement executing with the given context
+// (if any) entered the given wait state. The previous state is restored by
+// calling Restore on the returned token, which is usually deferred:
+//
+//	defer ash.SetWaitState(ctx, ash.Network).Restore()
+func SetWaitState(ctx context.Context, s WaitState) WaitStateToken {
+	a := ActivityFromContext(ctx)
+	if a == nil {
+		return WaitStateToken{}
+	}
+	return WaitStateToken{a: a, prev: WaitState(a.state.Swap(int32(s)))}
+}
+
+// Restore restores the wait state that was current when the token was
+// obtained.
+func (t WaitStateToken) Restore() {
+	if t.a == nil {
+		return
+	}
+	if WaitState(t.a.state.Swap(int32(t.prev))) == LockWait && t.prev != LockWait {
+		t.a.setContendedKey(nil)
+	}
+}
+
+// SetContendedKey records the key that the statement executing with the given
+// context (if any) is waiting on in the LockWait state.
+func SetContendedKey(ctx context.Context, key []byte) {
+	if a := ActivityFromContext(ctx); a != nil {
+		a.setContendedKey(key)
+	}
+}
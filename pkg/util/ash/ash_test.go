@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ash
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Without an Activity in the context, recording the wait states is a no-op.
+	ctx := context.Background()
+	SetWaitState(ctx, Network).Restore()
+	SetContendedKey(ctx, []byte("a"))
+
+	a := NewActivity("SELECT _")
+	ctx = ContextWithActivity(ctx, a)
+	require.Equal(t, a, ActivityFromContext(ctx))
+	checkState := func(expState WaitState, expKey string) {
+		t.Helper()
+		s, key := a.State()
+		require.Equal(t, expState, s)
+		require.Equal(t, expKey, string(key))
+	}
+	checkState(CPU, "")
+
+	network := SetWaitState(ctx, Network)
+	checkState(Network, "")
+	// A local request can wait on a lock while the statement is waiting for
+	// the response.
+	lockWait := SetWaitState(ctx, LockWait)
+	SetContendedKey(ctx, []byte("b"))
+	checkState(LockWait, "b")
+	lockWait.Restore()
+	checkState(Network, "")
+	network.Restore()
+	checkState(CPU, "")
+
+	require.Equal(t, "AdmissionQueue", AdmissionQueue.String())
+	var nilActivity *Activity
+	s, key := nilActivity.State()
+	require.Equal(t, CPU, s)
+	require.Nil(t, key)
+}
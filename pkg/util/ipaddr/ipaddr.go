@@ -127,6 +127,16 @@ func (ipAddr IPAddr) String() string {
 	return ip.String() + "/" + strconv.Itoa(int(ipAddr.Mask))
 }
 
+// CIDRString is like String, but always includes the mask. This is the
+// representation of the postgres CIDR type.
+func (ipAddr IPAddr) CIDRString() string {
+	ip := net.IP(uint128.Uint128(ipAddr.Addr).GetBytes())
+	if ipAddr.Family == IPv6family && ip.Equal(ip.To4()) {
+		return "::ffff:" + ip.String() + "/" + strconv.Itoa(int(ipAddr.Mask))
+	}
+	return ip.String() + "/" + strconv.Itoa(int(ipAddr.Mask))
+}
+
 // Compare two IPAddrs. IPv4-mapped IPv6 addresses are not equal to their IPv4
 // mapping. The order of order importance goes Family > Mask > IP-bytes.
 func (ipAddr IPAddr) Compare(other *IPAddr) int {
@@ -315,6 +325,23 @@ func (ipAddr *IPAddr) Netmask() IPAddr {
 	return newIPAddr
 }
 
+// Network returns a new IPAddr with the same mask where the bits of the IP
+// address that are not masked are zeroed, i.e. the network part of the IP.
+func (ipAddr *IPAddr) Network() IPAddr {
+	netmask := ipAddr.Netmask()
+	// And cannot fail, since both addresses have the same family.
+	newIPAddr, _ := ipAddr.And(&netmask)
+	newIPAddr.Mask = ipAddr.Mask
+	return newIPAddr
+}
+
+// IsNetwork returns true if none of the bits of the IP address that are not
+// masked are set, which is required of postgres CIDR values.
+func (ipAddr *IPAddr) IsNetwork() bool {
+	network := ipAddr.Network()
+	return network.Addr.Equal(ipAddr.Addr)
+}
+
 // Broadcast returns a new IPAddr where the host mask of the IP address is a
 // full mask, i.e. 0xFF bytes.
 func (ipAddr *IPAddr) Broadcast() IPAddr {
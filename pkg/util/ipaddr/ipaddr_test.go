@@ -263,6 +263,64 @@ func TestIPAddrString(t *testing.T) {
 	}
 }
 
+func TestIPAddrCIDRString(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+	}{
+		{"0.0.0.0/0", "0.0.0.0/0"},
+		{"192.168.1.0/24", "192.168.1.0/24"},
+		{"192.168.1.2", "192.168.1.2/32"},
+		{"::ffff/128", "::ffff/128"},
+		{"2001:4f8:3:ba::/64", "2001:4f8:3:ba::/64"},
+		{"::ffff:192.168.1.0/120", "::ffff:192.168.1.0/120"},
+	}
+	for i, testCase := range testCases {
+		var ip IPAddr
+		if err := ParseINet(testCase.s, &ip); err != nil {
+			t.Fatalf("%d: Bad test input s:%s", i, testCase.s)
+		}
+		actual := ip.CIDRString()
+		if actual != testCase.exp {
+			t.Errorf("%d: CIDRString(%q) actual:%v does not match expected:%v", i, testCase.s, actual,
+				testCase.exp)
+		}
+	}
+}
+
+func TestIPAddrNetwork(t *testing.T) {
+	testCases := []struct {
+		s         string
+		exp       string
+		isNetwork bool
+	}{
+		{"192.168.1.2", "192.168.1.2", true},
+		{"192.168.1.2/24", "192.168.1.0/24", false},
+		{"192.168.1.0/24", "192.168.1.0/24", true},
+		{"192.168.1.2/10", "192.128.0.0/10", false},
+		{"10.1.2.3/0", "0.0.0.0/0", false},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/64", "2001:4f8:3:ba::/64", false},
+		{"2001:4f8:3:ba::/64", "2001:4f8:3:ba::/64", true},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/100", "2001:4f8:3:ba:2e0:81ff:f000:0/100", false},
+		{"::ffff:1.2.3.1/120", "::ffff:1.2.3.0/120", false},
+	}
+	for i, testCase := range testCases {
+		var ip IPAddr
+		if err := ParseINet(testCase.s, &ip); err != nil {
+			t.Fatalf("%d: bad test case: %s got error %s", i, testCase.s, err)
+		}
+		actual := ip.Network()
+		if actual.String() != testCase.exp {
+			t.Errorf("%d: Network(%s) actual:%s does not match expected:%s", i, testCase.s, actual.String(),
+				testCase.exp)
+		}
+		if ip.IsNetwork() != testCase.isNetwork {
+			t.Errorf("%d: IsNetwork(%s) actual:%t does not match expected:%t", i, testCase.s,
+				ip.IsNetwork(), testCase.isNetwork)
+		}
+	}
+}
+
 func TestIPAddrBroadcast(t *testing.T) {
 	testCases := []struct {
 		s   string
@@ -0,0 +1,1021 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	// decimalCtx is the context for arithmetic on numbers. It matches the
+	// default context used for the SQL DECIMAL type.
+	decimalCtx = &apd.Context{
+		Precision:   20,
+		Rounding:    apd.RoundHalfUp,
+		MaxExponent: 2000,
+		MinExponent: -2000,
+		Traps:       apd.DefaultTraps,
+	}
+	// exactCtx is used for the arithmetic operations whose result is exact.
+	exactCtx = decimalCtx.WithPrecision(0)
+	// highPrecisionCtx is used for the remainder operation, which needs
+	// enough precision to hold the integer quotient.
+	highPrecisionCtx = decimalCtx.WithPrecision(2000)
+	// truncCtx rounds towards zero, to truncate array subscripts.
+	truncCtx = func() *apd.Context {
+		ctx := *exactCtx
+		ctx.Rounding = apd.RoundDown
+		return &ctx
+	}()
+)
+
+// errSuppressible marks the errors that are suppressed when a path is
+// evaluated in silent mode, and that make a predicate evaluate to unknown.
+// These correspond to the data-dependent errors of the SQL/JSON standard:
+// missing keys and array elements, items of an unexpected type, and numeric
+// errors. Other errors, such as references to undefined variables, are
+// always reported.
+var errSuppressible = errors.New("suppressible jsonpath error")
+
+func suppressible(code pgcode.Code, format string, args ...interface{}) error {
+	return errors.Mark(pgerror.Newf(code, format, args...), errSuppressible)
+}
+
+// isSuppressible returns whether err is a data-dependent evaluation error.
+func isSuppressible(err error) bool {
+	return errors.Is(err, errSuppressible)
+}
+
+// predicateResult is the result of a predicate, in three-valued logic.
+type predicateResult int
+
+const (
+	predFalse predicateResult = iota
+	predTrue
+	predUnknown
+)
+
+func (r predicateResult) toJSON() json.JSON {
+	switch r {
+	case predTrue:
+		return json.TrueJSONValue
+	case predFalse:
+		return json.FalseJSONValue
+	}
+	return json.NullJSONValue
+}
+
+// evaluator holds the state of the evaluation of a path.
+type evaluator struct {
+	root   json.JSON
+	vars   json.JSON
+	strict bool
+	// current is the item bound to `@` by the innermost filter.
+	current json.JSON
+	// lastIndex is the value of `last`, the last index of the array being
+	// subscripted, or -1 outside of array subscripts.
+	lastIndex int
+	// nextKeyValueID is the id assigned to the next object expanded by the
+	// keyvalue() item method.
+	nextKeyValueID int
+}
+
+// Query evaluates the path against the target document and returns the
+// resulting sequence of items. Variables referenced by the path are looked up
+// in vars, which must be an object, or nil if there are no variables. If
+// silent is true, data-dependent errors are suppressed and yield an empty
+// sequence.
+func (j Jsonpath) Query(target, vars json.JSON, silent bool) ([]json.JSON, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, errors.WithDetail(
+			pgerror.New(pgcode.InvalidParameterValue, `"vars" argument is not an object`),
+			`Jsonpath parameters should be encoded as key-value pairs of "vars" object.`,
+		)
+	}
+	e := evaluator{root: target, vars: vars, strict: j.Strict, lastIndex: -1}
+	res, err := e.eval(j.expr)
+	if err != nil {
+		if silent && isSuppressible(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// Exists returns whether the path returns any items for the target document.
+// If silent is true and a data-dependent error occurs, isNull is true.
+func (j Jsonpath) Exists(target, vars json.JSON, silent bool) (result, isNull bool, _ error) {
+	res, err := j.Query(target, vars, false /* silent */)
+	if err != nil {
+		if silent && isSuppressible(err) {
+			return false, true, nil
+		}
+		return false, false, err
+	}
+	return len(res) > 0, false, nil
+}
+
+// Match returns the result of a predicate check path for the target document,
+// which must be a single boolean. If the result is unknown, or if silent is
+// true and the path does not produce a single boolean, isNull is true.
+func (j Jsonpath) Match(target, vars json.JSON, silent bool) (result, isNull bool, _ error) {
+	res, err := j.Query(target, vars, silent)
+	if err != nil {
+		return false, false, err
+	}
+	if len(res) == 1 {
+		switch res[0].Type() {
+		case json.TrueJSONType:
+			return true, false, nil
+		case json.FalseJSONType:
+			return false, false, nil
+		case json.NullJSONType:
+			return false, true, nil
+		}
+	}
+	if silent {
+		return false, true, nil
+	}
+	return false, false, pgerror.New(pgcode.SingletonSQLJSONItemRequired, "single boolean result is expected")
+}
+
+// structuralError returns the error for a structural mismatch between the
+// path and the document, such as a missing key. Structural errors are only
+// reported in strict mode, and are ignored in lax mode or when ignore is
+// true, in which case the accessor returns an empty sequence.
+func (e *evaluator) structuralError(
+	ignore bool, code pgcode.Code, format string, args ...interface{},
+) error {
+	if !e.strict || ignore {
+		return nil
+	}
+	return suppressible(code, format, args...)
+}
+
+// eval evaluates a node and returns the resulting sequence of items.
+func (e *evaluator) eval(n node) ([]json.JSON, error) {
+	switch t := n.(type) {
+	case root:
+		return []json.JSON{e.root}, nil
+
+	case current:
+		return []json.JSON{e.current}, nil
+
+	case last:
+		if e.lastIndex < 0 {
+			return nil, errors.AssertionFailedf("evaluating jsonpath LAST outside of array subscript")
+		}
+		return []json.JSON{json.FromInt(e.lastIndex)}, nil
+
+	case variable:
+		var v json.JSON
+		if e.vars != nil {
+			var err error
+			if v, err = e.vars.FetchValKey(string(t)); err != nil {
+				return nil, err
+			}
+		}
+		if v == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "could not find jsonpath variable %q", string(t))
+		}
+		return []json.JSON{v}, nil
+
+	case *literal:
+		return []json.JSON{t.val}, nil
+
+	case *chain:
+		return e.evalChain(t)
+
+	case *binary:
+		if t.op.isArithmetic() {
+			res, err := e.evalArithmetic(t)
+			if err != nil {
+				return nil, err
+			}
+			return []json.JSON{res}, nil
+		}
+
+	case *unary:
+		if t.op != opNot {
+			return e.evalUnaryArithmetic(t)
+		}
+	}
+	// The remaining nodes are predicates, which evaluate to a single boolean,
+	// or null if the result is unknown.
+	res, err := e.evalPredicate(n)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// evalUnwrapped evaluates a node and, in lax mode, replaces the arrays in the
+// result by their elements.
+func (e *evaluator) evalUnwrapped(n node) ([]json.JSON, error) {
+	seq, err := e.eval(n)
+	if err != nil || e.strict {
+		return seq, err
+	}
+	var res []json.JSON
+	for _, item := range seq {
+		if elems, ok := item.AsArray(); ok {
+			res = append(res, elems...)
+		} else {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalChain(c *chain) ([]json.JSON, error) {
+	seq, err := e.eval(c.head)
+	if err != nil {
+		return nil, err
+	}
+	// Structural errors are ignored in the steps that follow a `.**`
+	// accessor, since it visits items of all types.
+	ignoreStructural := false
+	for _, s := range c.steps {
+		var next []json.JSON
+		for _, item := range seq {
+			res, err := e.evalStep(s, item, true /* unwrap */, ignoreStructural)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, res...)
+		}
+		seq = next
+		if _, ok := s.(anyPathStep); ok {
+			ignoreStructural = true
+		}
+	}
+	return seq, nil
+}
+
+// unwrapsTarget returns whether a step is applied to the elements of an
+// array, rather than to the array itself, in lax mode.
+func unwrapsTarget(s step) bool {
+	switch t := s.(type) {
+	case keyStep, anyKeyStep, *filterStep:
+		return true
+	case methodStep:
+		return methodKind(t) != methodType && methodKind(t) != methodSize
+	}
+	return false
+}
+
+// evalStep applies a step to a single item. If unwrap is true and the item
+// is an array, steps that unwrap their target are applied to each of the
+// elements of the array in lax mode.
+func (e *evaluator) evalStep(
+	s step, item json.JSON, unwrap, ignoreStructural bool,
+) ([]json.JSON, error) {
+	if unwrap && !e.strict && item.Type() == json.ArrayJSONType && unwrapsTarget(s) {
+		elems, _ := item.AsArray()
+		var res []json.JSON
+		for _, elem := range elems {
+			r, err := e.evalStep(s, elem, false /* unwrap */, ignoreStructural)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, r...)
+		}
+		return res, nil
+	}
+
+	switch t := s.(type) {
+	case keyStep:
+		if item.Type() != json.ObjectJSONType {
+			return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONMemberNotFound,
+				"jsonpath member accessor can only be applied to an object")
+		}
+		v, err := item.FetchValKey(string(t))
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONMemberNotFound,
+				"JSON object does not contain key %q", string(t))
+		}
+		return []json.JSON{v}, nil
+
+	case anyKeyStep:
+		if item.Type() != json.ObjectJSONType {
+			return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONObjectNotFound,
+				"jsonpath wildcard member accessor can only be applied to an object")
+		}
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		var res []json.JSON
+		for it.Next() {
+			res = append(res, it.Value())
+		}
+		return res, nil
+
+	case anyArrayStep:
+		if elems, ok := item.AsArray(); ok {
+			return elems, nil
+		}
+		if !e.strict {
+			return []json.JSON{item}, nil
+		}
+		return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONArrayNotFound,
+			"jsonpath wildcard array accessor can only be applied to an array")
+
+	case subscriptsStep:
+		return e.evalSubscripts(t, item, ignoreStructural)
+
+	case anyPathStep:
+		var res []json.JSON
+		if t.first == 0 {
+			res = append(res, item)
+		}
+		if err := collectAnyPath(item, 1, t.first, t.last, &res); err != nil {
+			return nil, err
+		}
+		return res, nil
+
+	case *filterStep:
+		res, err := e.evalNestedPredicate(t.cond, item)
+		if err != nil {
+			return nil, err
+		}
+		if res != predTrue {
+			return nil, nil
+		}
+		return []json.JSON{item}, nil
+
+	case methodStep:
+		return e.evalMethod(methodKind(t), item, ignoreStructural)
+	}
+	return nil, errors.AssertionFailedf("unknown jsonpath step %T", s)
+}
+
+// collectAnyPath appends the items nested in item at the levels between
+// first and last to res. level is the nesting level of the children of
+// item. If both first and last are `last`, only the leaves are collected.
+func collectAnyPath(item json.JSON, level, first, last uint32, res *[]json.JSON) error {
+	var children []json.JSON
+	switch item.Type() {
+	case json.ArrayJSONType:
+		children, _ = item.AsArray()
+	case json.ObjectJSONType:
+		it, err := item.ObjectIter()
+		if err != nil {
+			return err
+		}
+		for it.Next() {
+			children = append(children, it.Value())
+		}
+	default:
+		return nil
+	}
+	for _, child := range children {
+		isContainer := child.Type() == json.ArrayJSONType || child.Type() == json.ObjectJSONType
+		if level >= first || (first == lastLevel && last == lastLevel && !isContainer) {
+			if level <= last {
+				*res = append(*res, child)
+			}
+		}
+		if isContainer && level < last {
+			if err := collectAnyPath(child, level+1, first, last, res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *evaluator) evalSubscripts(
+	s subscriptsStep, item json.JSON, ignoreStructural bool,
+) ([]json.JSON, error) {
+	elems, isArray := item.AsArray()
+	if !isArray {
+		if e.strict {
+			return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONArrayNotFound,
+				"jsonpath array accessor can only be applied to an array")
+		}
+		// In lax mode, a non-array item is treated as an array containing only
+		// that item.
+		elems = []json.JSON{item}
+	}
+	savedLastIndex := e.lastIndex
+	e.lastIndex = len(elems) - 1
+	defer func() { e.lastIndex = savedLastIndex }()
+
+	var res []json.JSON
+	for _, sub := range s {
+		from, err := e.evalSubscript(sub.from)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if sub.to != nil {
+			if to, err = e.evalSubscript(sub.to); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if err := e.structuralError(ignoreStructural, pgcode.InvalidSQLJSONSubscript,
+				"jsonpath array subscript is out of bounds"); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to >= len(elems) {
+			to = len(elems) - 1
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalSubscript evaluates an array subscript, which must be a single number.
+// The number is truncated to an integer.
+func (e *evaluator) evalSubscript(n node) (int, error) {
+	seq, err := e.evalUnwrapped(n)
+	if err != nil {
+		return 0, err
+	}
+	if len(seq) != 1 {
+		return 0, suppressible(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	d, ok := seq[0].AsDecimal()
+	if !ok {
+		return 0, suppressible(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	var truncated apd.Decimal
+	if _, err := truncCtx.RoundToIntegralValue(&truncated, d); err != nil {
+		return 0, err
+	}
+	i, err := truncated.Int64()
+	if err != nil || i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, suppressible(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is out of integer range")
+	}
+	return int(i), nil
+}
+
+// evalArithmetic evaluates a binary arithmetic operation, whose operands must
+// each be a single number.
+func (e *evaluator) evalArithmetic(b *binary) (json.JSON, error) {
+	left, err := e.evalUnwrapped(b.left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.evalUnwrapped(b.right)
+	if err != nil {
+		return nil, err
+	}
+	var l, r *apd.Decimal
+	var ok bool
+	if len(left) == 1 {
+		l, ok = left[0].AsDecimal()
+	}
+	if !ok {
+		return nil, suppressible(pgcode.SingletonSQLJSONItemRequired,
+			"left operand of jsonpath operator %s is not a single numeric value", b.op)
+	}
+	ok = false
+	if len(right) == 1 {
+		r, ok = right[0].AsDecimal()
+	}
+	if !ok {
+		return nil, suppressible(pgcode.SingletonSQLJSONItemRequired,
+			"right operand of jsonpath operator %s is not a single numeric value", b.op)
+	}
+
+	var res apd.Decimal
+	switch b.op {
+	case opAdd:
+		_, err = exactCtx.Add(&res, l, r)
+	case opSub:
+		_, err = exactCtx.Sub(&res, l, r)
+	case opMul:
+		_, err = exactCtx.Mul(&res, l, r)
+	case opDiv, opMod:
+		if r.IsZero() {
+			return nil, suppressible(pgcode.DivisionByZero, "division by zero")
+		}
+		if b.op == opDiv {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = highPrecisionCtx.Rem(&res, l, r)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unknown arithmetic operator %s", b.op)
+	}
+	if err != nil {
+		return nil, errors.Mark(
+			pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange), errSuppressible)
+	}
+	return json.FromDecimal(res), nil
+}
+
+// evalUnaryArithmetic applies a unary `+` or `-` to each item of the operand,
+// which must all be numbers.
+func (e *evaluator) evalUnaryArithmetic(u *unary) ([]json.JSON, error) {
+	seq, err := e.evalUnwrapped(u.arg)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]json.JSON, len(seq))
+	for i, item := range seq {
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, suppressible(pgcode.SQLJSONNumberNotFound,
+				"operand of unary jsonpath operator %s is not a numeric value", u.op)
+		}
+		if u.op == opMinus {
+			var neg apd.Decimal
+			neg.Neg(d)
+			d = &neg
+		}
+		res[i] = json.FromDecimal(*d)
+	}
+	return res, nil
+}
+
+// evalNestedPredicate evaluates a filter condition with `@` bound to item.
+func (e *evaluator) evalNestedPredicate(n node, item json.JSON) (predicateResult, error) {
+	saved := e.current
+	e.current = item
+	defer func() { e.current = saved }()
+	return e.evalPredicate(n)
+}
+
+// evalPredicateOperand evaluates an operand of a predicate. Data-dependent
+// errors are reported through ok, which is false if the predicate is unknown.
+func (e *evaluator) evalPredicateOperand(n node, unwrap bool) (_ []json.JSON, ok bool, _ error) {
+	var seq []json.JSON
+	var err error
+	if unwrap {
+		seq, err = e.evalUnwrapped(n)
+	} else {
+		seq, err = e.eval(n)
+	}
+	if err != nil {
+		if isSuppressible(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return seq, true, nil
+}
+
+// evalPredicate evaluates a predicate in three-valued logic.
+func (e *evaluator) evalPredicate(n node) (predicateResult, error) {
+	switch t := n.(type) {
+	case *binary:
+		switch t.op {
+		case opAnd:
+			left, err := e.evalPredicate(t.left)
+			if err != nil || left == predFalse {
+				return predFalse, err
+			}
+			right, err := e.evalPredicate(t.right)
+			if err != nil {
+				return predFalse, err
+			}
+			if right == predTrue {
+				return left, nil
+			}
+			return right, nil
+
+		case opOr:
+			left, err := e.evalPredicate(t.left)
+			if err != nil || left == predTrue {
+				return left, err
+			}
+			right, err := e.evalPredicate(t.right)
+			if err != nil {
+				return predFalse, err
+			}
+			if right == predFalse {
+				return left, nil
+			}
+			return right, nil
+		}
+		if t.op.isComparison() {
+			return e.evalExistential(t.left, t.right, true /* unwrapRight */, func(l, r json.JSON) predicateResult {
+				return compareItems(t.op, l, r)
+			})
+		}
+
+	case *unary:
+		if t.op == opNot {
+			res, err := e.evalPredicate(t.arg)
+			switch res {
+			case predTrue:
+				return predFalse, err
+			case predFalse:
+				return predTrue, err
+			}
+			return res, err
+		}
+
+	case *isUnknown:
+		res, err := e.evalPredicate(t.arg)
+		if res == predUnknown {
+			return predTrue, err
+		}
+		return predFalse, err
+
+	case *exists:
+		seq, ok, err := e.evalPredicateOperand(t.arg, false /* unwrap */)
+		if err != nil || !ok {
+			return predUnknown, err
+		}
+		if len(seq) > 0 {
+			return predTrue, nil
+		}
+		return predFalse, nil
+
+	case *likeRegex:
+		return e.evalExistential(t.arg, nil /* right */, false /* unwrapRight */, func(l, _ json.JSON) predicateResult {
+			s, ok := asString(l)
+			if !ok {
+				return predUnknown
+			}
+			if t.re.MatchString(s) {
+				return predTrue
+			}
+			return predFalse
+		})
+
+	case *startsWith:
+		return e.evalExistential(t.arg, t.prefix, false /* unwrapRight */, func(l, r json.JSON) predicateResult {
+			whole, ok := asString(l)
+			if !ok {
+				return predUnknown
+			}
+			prefix, ok := asString(r)
+			if !ok {
+				return predUnknown
+			}
+			if strings.HasPrefix(whole, prefix) {
+				return predTrue
+			}
+			return predFalse
+		})
+	}
+	// Any other expression used as a predicate is a syntax error, which the
+	// parser rejects.
+	return predUnknown, errors.AssertionFailedf("jsonpath node %T is not a predicate", n)
+}
+
+// evalExistential evaluates a predicate with existential semantics: it is
+// true if fn is true for any pair of items of the left and right operands.
+// If right is nil, fn is called with a nil right item. In strict mode, the
+// predicate is unknown if fn is unknown for any pair; in lax mode, it is
+// true as soon as any pair is found to be true.
+func (e *evaluator) evalExistential(
+	left, right node, unwrapRight bool, fn func(l, r json.JSON) predicateResult,
+) (predicateResult, error) {
+	lseq, ok, err := e.evalPredicateOperand(left, true /* unwrap */)
+	if err != nil || !ok {
+		return predUnknown, err
+	}
+	rseq := []json.JSON{nil}
+	if right != nil {
+		rseq, ok, err = e.evalPredicateOperand(right, unwrapRight)
+		if err != nil || !ok {
+			return predUnknown, err
+		}
+	}
+	found, unknown := false, false
+	for _, l := range lseq {
+		for _, r := range rseq {
+			switch fn(l, r) {
+			case predUnknown:
+				if e.strict {
+					return predUnknown, nil
+				}
+				unknown = true
+			case predTrue:
+				if !e.strict {
+					return predTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return predTrue, nil
+	}
+	if unknown {
+		return predUnknown, nil
+	}
+	return predFalse, nil
+}
+
+func asString(j json.JSON) (string, bool) {
+	if j == nil || j.Type() != json.StringJSONType {
+		return "", false
+	}
+	s, err := j.AsText()
+	if err != nil || s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+// compareItems compares two items. Nulls are only equal to nulls, and are
+// otherwise neither less nor greater than any other item. Items of other
+// different types, as well as arrays and objects, are not comparable.
+func compareItems(op opKind, l, r json.JSON) predicateResult {
+	lt, rt := l.Type(), r.Type()
+	isBool := func(t json.Type) bool { return t == json.TrueJSONType || t == json.FalseJSONType }
+	if lt != rt && !(isBool(lt) && isBool(rt)) {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			if op == opNe {
+				return predTrue
+			}
+			return predFalse
+		}
+		return predUnknown
+	}
+	var cmp int
+	switch lt {
+	case json.NullJSONType:
+		cmp = 0
+	case json.TrueJSONType, json.FalseJSONType, json.NumberJSONType, json.StringJSONType:
+		c, err := l.Compare(r)
+		if err != nil {
+			return predUnknown
+		}
+		cmp = c
+	default:
+		return predUnknown
+	}
+	var res bool
+	switch op {
+	case opEq:
+		res = cmp == 0
+	case opNe:
+		res = cmp != 0
+	case opLt:
+		res = cmp < 0
+	case opLe:
+		res = cmp <= 0
+	case opGt:
+		res = cmp > 0
+	case opGe:
+		res = cmp >= 0
+	}
+	if res {
+		return predTrue
+	}
+	return predFalse
+}
+
+// evalMethod applies an item method to an item.
+func (e *evaluator) evalMethod(
+	m methodKind, item json.JSON, ignoreStructural bool,
+) ([]json.JSON, error) {
+	switch m {
+	case methodType:
+		return []json.JSON{json.FromString(typeName(item))}, nil
+
+	case methodSize:
+		if elems, ok := item.AsArray(); ok {
+			return []json.JSON{json.FromInt(len(elems))}, nil
+		}
+		if !e.strict {
+			return []json.JSON{json.FromInt(1)}, nil
+		}
+		return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONArrayNotFound,
+			"jsonpath item method .%s() can only be applied to an array", m)
+
+	case methodKeyValue:
+		if item.Type() != json.ObjectJSONType {
+			return nil, e.structuralError(ignoreStructural, pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .%s() can only be applied to an object", m)
+		}
+		// Postgres identifies the object that a pair belongs to by its offset
+		// in the document. We number the objects in the order in which they
+		// are expanded instead, which is also unique within the evaluation.
+		id := json.FromInt(e.nextKeyValueID)
+		e.nextKeyValueID++
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		var res []json.JSON
+		for it.Next() {
+			b := json.NewObjectBuilder(3)
+			b.Add("id", id)
+			b.Add("key", json.FromString(it.Key()))
+			b.Add("value", it.Value())
+			res = append(res, b.Build())
+		}
+		return res, nil
+	}
+
+	res, err := evalConversionMethod(m, item)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res}, nil
+}
+
+// evalConversionMethod applies an item method that converts a scalar item.
+func evalConversionMethod(m methodKind, item json.JSON) (json.JSON, error) {
+	d, isNumber := item.AsDecimal()
+	s, isString := asString(item)
+	switch m {
+	case methodCeiling, methodFloor, methodAbs:
+		if !isNumber {
+			return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m)
+		}
+		var res apd.Decimal
+		var err error
+		switch m {
+		case methodCeiling:
+			_, err = exactCtx.Ceil(&res, d)
+		case methodFloor:
+			_, err = exactCtx.Floor(&res, d)
+		default:
+			res.Abs(d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return json.FromDecimal(res), nil
+
+	case methodDouble:
+		if isNumber {
+			if _, err := strconv.ParseFloat(d.String(), 64); err != nil {
+				return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m)
+			}
+			return item, nil
+		}
+		if isString {
+			f, ok := parseFloat(s)
+			if !ok {
+				return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m)
+			}
+			return json.FromFloat64(f)
+		}
+		return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+
+	case methodNumber:
+		if isNumber {
+			return item, nil
+		}
+		if isString {
+			res, _, err := apd.NewFromString(strings.TrimSpace(s))
+			if err != nil || res.Form != apd.Finite {
+				return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+					"argument %q of jsonpath item method .%s() is invalid for type numeric", s, m)
+			}
+			return json.FromDecimal(*res), nil
+		}
+		return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+
+	case methodInteger, methodBigint:
+		lo, hi, typ := int64(math.MinInt32), int64(math.MaxInt32), "integer"
+		if m == methodBigint {
+			lo, hi, typ = math.MinInt64, math.MaxInt64, "bigint"
+		}
+		var i int64
+		var ok bool
+		switch {
+		case isNumber:
+			i, ok = roundToInt64(d)
+		case isString:
+			var err error
+			i, err = strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			ok = err == nil
+		default:
+			return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+		}
+		if !ok || i < lo || i > hi {
+			return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+				"argument %q of jsonpath item method .%s() is invalid for type %s", item.String(), m, typ)
+		}
+		return json.FromInt64(i), nil
+
+	case methodString:
+		switch {
+		case isString:
+			return item, nil
+		case isNumber:
+			return json.FromString(d.String()), nil
+		case item.Type() == json.TrueJSONType:
+			return json.FromString("true"), nil
+		case item.Type() == json.FalseJSONType:
+			return json.FromString("false"), nil
+		}
+		return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a boolean, string, or numeric value", m)
+
+	case methodBoolean:
+		switch {
+		case item.Type() == json.TrueJSONType || item.Type() == json.FalseJSONType:
+			return item, nil
+		case isNumber:
+			i, ok := roundToInt64(d)
+			var integral apd.Decimal
+			integral.SetInt64(i)
+			if !ok || integral.Cmp(d) != 0 {
+				return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+					"argument %q of jsonpath item method .%s() is invalid for type boolean", item.String(), m)
+			}
+			return json.FromBool(i != 0), nil
+		case isString:
+			b, ok := parseBool(s)
+			if !ok {
+				return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+					"argument %q of jsonpath item method .%s() is invalid for type boolean", s, m)
+			}
+			return json.FromBool(b), nil
+		}
+		return nil, suppressible(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a boolean, string, or numeric value", m)
+	}
+	return nil, errors.AssertionFailedf("unknown jsonpath item method %d", m)
+}
+
+func typeName(j json.JSON) string {
+	switch j.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	}
+	return "object"
+}
+
+// roundToInt64 rounds d to the nearest integer, with ties rounded away from
+// zero, and returns false if the result does not fit in an int64.
+func roundToInt64(d *apd.Decimal) (int64, bool) {
+	var rounded apd.Decimal
+	if _, err := exactCtx.RoundToIntegralValue(&rounded, d); err != nil {
+		return 0, false
+	}
+	i, err := rounded.Int64()
+	return i, err == nil
+}
+
+// parseFloat parses a double precision number in the formats accepted by
+// Postgres. Infinite and NaN values are rejected.
+func parseFloat(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "xX_pP") {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, false
+	}
+	return f, true
+}
+
+// parseBool parses a boolean in the formats accepted by Postgres: a prefix of
+// "true", "false", "yes" or "no", "on" or "off", or "1" or "0", ignoring case.
+func parseBool(s string) (bool, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return false, false
+	}
+	switch {
+	case strings.HasPrefix("true", s), strings.HasPrefix("yes", s), s == "on", s == "1":
+		return true, true
+	case strings.HasPrefix("false", s), strings.HasPrefix("no", s), s == "off" || s == "of", s == "0":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,237 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		vars   string
+		// expected is the resulting sequence, with the items separated by
+		// semicolons, or the expected error.
+		expected string
+		err      string
+	}{
+		{target: `{"a": {"b": 1}}`, path: `$.a`, expected: `{"b": 1}`},
+		{target: `{"a": {"b": 1}}`, path: `$.a.b`, expected: `1`},
+		{target: `{"a": {"b": 1}}`, path: `$."a"."b"`, expected: `1`},
+
+		// Missing keys.
+		{target: `{}`, path: `$.x`, expected: ``},
+		{target: `{}`, path: `strict $.x`, err: `JSON object does not contain key "x"`},
+		{target: `1`, path: `$.x`, expected: ``},
+		{target: `1`, path: `strict $.x`, err: `jsonpath member accessor can only be applied to an object`},
+
+		// Arrays are unwrapped in lax mode.
+		{target: `{"a": [{"b": 1}, {"b": 2}, {"c": 3}]}`, path: `$.a.b`, expected: `1;2`},
+		{target: `{"a": [{"b": 1}, {"b": 2}]}`, path: `strict $.a.b`,
+			err: `jsonpath member accessor can only be applied to an object`},
+		{target: `{"a": [{"b": 1}, {"b": 2}]}`, path: `strict $.a[*].b`, expected: `1;2`},
+
+		// Array accessors.
+		{target: `[0, 1, 2, 3]`, path: `$[*]`, expected: `0;1;2;3`},
+		{target: `[0, 1, 2, 3]`, path: `$[1 to 2]`, expected: `1;2`},
+		{target: `[0, 1, 2, 3]`, path: `$[last]`, expected: `3`},
+		{target: `[0, 1, 2, 3]`, path: `$[last - 1, 0]`, expected: `2;0`},
+		{target: `[0, 1, 2, 3]`, path: `$[1.9]`, expected: `1`},
+		{target: `[0, 1, 2, 3]`, path: `$[2 to 10]`, expected: `2;3`},
+		{target: `[0, 1, 2, 3]`, path: `$[5]`, expected: ``},
+		{target: `[0, 1, 2, 3]`, path: `strict $[5]`, err: `jsonpath array subscript is out of bounds`},
+		{target: `[0, 1, 2, 3]`, path: `$["a"]`, err: `jsonpath array subscript is not a single numeric value`},
+		{target: `1`, path: `$[*]`, expected: `1`},
+		{target: `1`, path: `$[0]`, expected: `1`},
+		{target: `1`, path: `strict $[*]`, err: `jsonpath wildcard array accessor can only be applied to an array`},
+		{target: `1`, path: `strict $[0]`, err: `jsonpath array accessor can only be applied to an array`},
+
+		// Wildcards.
+		{target: `{"a": 1, "b": [2]}`, path: `$.*`, expected: `1;[2]`},
+		{target: `[{"a": 1}, {"b": 2}]`, path: `$.*`, expected: `1;2`},
+		{target: `1`, path: `strict $.*`, err: `jsonpath wildcard member accessor can only be applied to an object`},
+		{target: `{"a": {"b": [1]}}`, path: `$.**`, expected: `{"a": {"b": [1]}};{"b": [1]};[1];1`},
+		{target: `{"a": {"b": [1]}}`, path: `$.**{1}`, expected: `{"b": [1]}`},
+		{target: `{"a": {"b": [1]}}`, path: `$.**{2 to last}`, expected: `[1];1`},
+		{target: `{"a": {"b": [1]}}`, path: `$.**{last}`, expected: `1`},
+		{target: `{"a": {"b": 1}, "c": 2}`, path: `strict $.**.b`, expected: `1`},
+
+		// Filters.
+		{target: `{"a": [1, 2, 3, 4, 5]}`, path: `$.a[*] ? (@ >= $min && @ <= $max)`,
+			vars: `{"min": 2, "max": 4}`, expected: `2;3;4`},
+		{target: `{"a": [1, 2, 3]}`, path: `$.a ? (@ > 1)`, expected: `2;3`},
+		{target: `{"a": [1, 2, 3]}`, path: `strict $.a ? (@ > 1)`, expected: ``},
+		{target: `[{"a": 1}, {"a": 2}]`, path: `$ ? (@.a == 2)`, expected: `{"a": 2}`},
+		{target: `[1, null]`, path: `$[*] ? (@ == null)`, expected: `null`},
+		{target: `[1, null]`, path: `$[*] ? (@ != null)`, expected: `1`},
+		{target: `[2, "a"]`, path: `$[*] ? (@ > 1)`, expected: `2`},
+		{target: `[2, "a"]`, path: `$[*] ? ((@ > 1) is unknown)`, expected: `"a"`},
+		{target: `[2, "a"]`, path: `$[*] ? (!(@ > 1))`, expected: ``},
+		{target: `[true, false]`, path: `$[*] ? (@ == true)`, expected: `true`},
+		{target: `[[1, 2], [3]]`, path: `$[*] ? (@ == 3)`, expected: `3`},
+		{target: `[[1, 2], [3]]`, path: `strict $[*] ? (@[*] == 3)`, expected: `[3]`},
+		{target: `["abc", "abd", "aXc", "ABC"]`, path: `$[*] ? (@ like_regex "^a.c$")`, expected: `"abc";"aXc"`},
+		{target: `["abc", "ABC", "a.c"]`, path: `$[*] ? (@ like_regex "a.c" flag "iq")`, expected: `"a.c"`},
+		{target: `["abc", "xab", 1]`, path: `$[*] ? (@ starts with "ab")`, expected: `"abc"`},
+		{target: `["abc", "xab"]`, path: `$[*] ? (@ starts with $p)`, vars: `{"p": "x"}`, expected: `"xab"`},
+		{target: `[{"a": 1}, {"b": 2}]`, path: `$[*] ? (exists (@.a))`, expected: `{"a": 1}`},
+		{target: `{"a": 1, "b": 2}`, path: `$ ? (@.a == 1 || @.c == 1)`, expected: `{"a": 1, "b": 2}`},
+		{target: `{"a": 1, "b": 2}`, path: `strict $ ? (@.a == 1 && @.c == 1)`, expected: ``},
+
+		// Predicate check expressions.
+		{target: `{"a": 2}`, path: `$.a > 1`, expected: `true`},
+		{target: `{"a": [1, 2, 3]}`, path: `$.a[*] > 2`, expected: `true`},
+		{target: `{"a": [1, 2, 3]}`, path: `$.a[*] > 5`, expected: `false`},
+		{target: `{"a": [1, "x"]}`, path: `$.a[*] > 0`, expected: `true`},
+		{target: `{"a": [1, "x"]}`, path: `strict $.a[*] > 0`, expected: `null`},
+
+		// Arithmetic.
+		{target: `{"a": 2}`, path: `$.a + 3`, expected: `5`},
+		{target: `{"a": 2}`, path: `$.a * 2.5 - 1`, expected: `4.0`},
+		{target: `{"a": 7}`, path: `$.a % 4`, expected: `3`},
+		{target: `1`, path: `$ / 3`, expected: `0.33333333333333333333`},
+		{target: `[1, 2]`, path: `-$[*]`, expected: `-1;-2`},
+		{target: `{"a": [2]}`, path: `$.a + 1`, expected: `3`},
+		{target: `1`, path: `$ / 0`, err: `division by zero`},
+		{target: `[1, 2]`, path: `$[*] + 1`, err: `left operand of jsonpath operator + is not a single numeric value`},
+		{target: `"a"`, path: `1 - $`, err: `right operand of jsonpath operator - is not a single numeric value`},
+		{target: `"a"`, path: `-$`, err: `operand of unary jsonpath operator - is not a numeric value`},
+
+		// Item methods.
+		{target: `{"a": [1, "x", {}]}`, path: `$.a[*].type()`, expected: `"number";"string";"object"`},
+		{target: `[null, true, []]`, path: `$.type()`, expected: `"array"`},
+		{target: `{"a": [1, 2]}`, path: `$.a.size()`, expected: `2`},
+		{target: `1`, path: `$.size()`, expected: `1`},
+		{target: `1`, path: `strict $.size()`, err: `jsonpath item method .size() can only be applied to an array`},
+		{target: `[1.3, -1.5]`, path: `$.ceiling()`, expected: `2;-1`},
+		{target: `[1.3, -1.5]`, path: `$.floor()`, expected: `1;-2`},
+		{target: `[1.3, -1.5]`, path: `$.abs()`, expected: `1.3;1.5`},
+		{target: `"1"`, path: `$.abs()`, err: `jsonpath item method .abs() can only be applied to a numeric value`},
+		{target: `["1.5", 2]`, path: `$.double()`, expected: `1.5;2`},
+		{target: `"abc"`, path: `$.double()`,
+			err: `string argument of jsonpath item method .double() is not a valid representation of a double precision number`},
+		{target: `["1.50", 2]`, path: `$.number()`, expected: `1.50;2`},
+		{target: `[1.5, "12", -2.5]`, path: `$.integer()`, expected: `2;12;-3`},
+		{target: `"1.5"`, path: `$.integer()`, err: `argument "\"1.5\"" of jsonpath item method .integer() is invalid for type integer`},
+		{target: `9876543219`, path: `$.integer()`, err: `is invalid for type integer`},
+		{target: `9876543219`, path: `$.bigint()`, expected: `9876543219`},
+		{target: `[1, "a", true]`, path: `$.string()`, expected: `"1";"a";"true"`},
+		{target: `[1, 0, "yes", "f", false]`, path: `$.boolean()`, expected: `true;false;true;false;false`},
+		{target: `1.5`, path: `$.boolean()`, err: `is invalid for type boolean`},
+		{target: `{"a": 1, "b": [1, 2]}`, path: `$.keyvalue()`,
+			expected: `{"id": 0, "key": "a", "value": 1};{"id": 0, "key": "b", "value": [1, 2]}`},
+		{target: `[{"a": 1}, {"b": 2}]`, path: `$.keyvalue().key`, expected: `"a";"b"`},
+		{target: `[{"a": 1}, {"b": 2}]`, path: `$.keyvalue().id`, expected: `0;1`},
+
+		// Variables.
+		{target: `1`, path: `$x`, vars: `{"x": [1]}`, expected: `[1]`},
+		{target: `1`, path: `$x`, err: `could not find jsonpath variable "x"`},
+		{target: `1`, path: `$`, vars: `[]`, err: `"vars" argument is not an object`},
+	} {
+		t.Run(tc.target+" "+tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			vars := tc.vars
+			if vars == "" {
+				vars = "{}"
+			}
+			v, err := json.ParseJSON(vars)
+			require.NoError(t, err)
+			res, err := MustParse(tc.path).Query(target, v, false /* silent */)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			items := make([]string, len(res))
+			for i := range res {
+				items[i] = res[i].String()
+			}
+			require.Equal(t, tc.expected, strings.Join(items, ";"))
+		})
+	}
+}
+
+func TestSilent(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2]}`)
+	require.NoError(t, err)
+	vars, err := json.ParseJSON(`{}`)
+	require.NoError(t, err)
+
+	// Data-dependent errors are suppressed.
+	j := MustParse(`strict $.b`)
+	res, err := j.Query(target, vars, true /* silent */)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	_, isNull, err := j.Exists(target, vars, true /* silent */)
+	require.NoError(t, err)
+	require.True(t, isNull)
+	_, _, err = j.Exists(target, vars, false /* silent */)
+	require.Error(t, err)
+
+	// Undefined variables are not.
+	_, err = MustParse(`$x`).Query(target, vars, true /* silent */)
+	require.Error(t, err)
+	_, err = MustParse(`$x`).Query(target, nil /* vars */, true /* silent */)
+	require.Error(t, err)
+}
+
+func TestExistsAndMatch(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		// exists and match are "true", "false", "null" or "error".
+		exists string
+		match  string
+	}{
+		{`{"a": [1, 2, 3]}`, `$.a[*] ? (@ > 2)`, "true", "error"},
+		{`{"a": [1, 2, 3]}`, `$.a[*] ? (@ > 5)`, "false", "error"},
+		{`{"a": [1, 2, 3]}`, `$.a[*] > 2`, "true", "true"},
+		{`{"a": [1, 2, 3]}`, `$.a[*] > 5`, "true", "false"},
+		{`{"a": [1, "x"]}`, `strict $.a[*] > 0`, "true", "null"},
+		{`{"a": true}`, `$.a`, "true", "true"},
+		{`{"a": null}`, `$.a`, "true", "null"},
+		{`{}`, `$.a`, "false", "error"},
+		{`{}`, `strict $.a`, "error", "error"},
+	} {
+		t.Run(tc.target+" "+tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			vars, err := json.ParseJSON(`{}`)
+			require.NoError(t, err)
+			j := MustParse(tc.path)
+			format := func(res, isNull bool, err error) string {
+				switch {
+				case err != nil:
+					return "error"
+				case isNull:
+					return "null"
+				case res:
+					return "true"
+				}
+				return "false"
+			}
+			require.Equal(t, tc.exists, format(j.Exists(target, vars, false /* silent */)))
+			require.Equal(t, tc.match, format(j.Match(target, vars, false /* silent */)))
+
+			// In silent mode, errors become nulls.
+			expected := func(s string) string {
+				if s == "error" {
+					return "null"
+				}
+				return s
+			}
+			require.Equal(t, expected(tc.exists), format(j.Exists(target, vars, true /* silent */)))
+			require.Equal(t, expected(tc.match), format(j.Match(target, vars, true /* silent */)))
+		})
+	}
+}
@@ -0,0 +1,481 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package jsonpath implements the SQL/JSON path language: parsing of jsonpath
+// expressions, their canonical text representation, and their evaluation
+// against JSON documents.
+package jsonpath
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Jsonpath is a parsed SQL/JSON path expression.
+type Jsonpath struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors (missing keys, subscripts out of bounds, accessors
+	// applied to values of the wrong type) are reported. Otherwise the path is
+	// evaluated in lax mode, which suppresses structural errors and unwraps
+	// arrays automatically.
+	Strict bool
+	// expr is the root of the expression tree.
+	expr node
+}
+
+// String returns the canonical text representation of the path, which is
+// the same as the one produced by Postgres.
+func (j Jsonpath) String() string {
+	var buf strings.Builder
+	if j.Strict {
+		buf.WriteString("strict ")
+	}
+	j.expr.format(&buf, true /* parens */)
+	return buf.String()
+}
+
+// IsPredicate returns whether the path is a predicate check expression, such
+// as `$.a > 1`, which evaluates to a single boolean (or null when the result
+// is unknown).
+func (j Jsonpath) IsPredicate() bool {
+	return isPredicate(j.expr)
+}
+
+// node is a single node of a jsonpath expression tree.
+type node interface {
+	// format writes the canonical representation of the node to buf. If
+	// parens is true, operators are enclosed in parentheses.
+	format(buf *strings.Builder, parens bool)
+}
+
+// opKind identifies the operator of an operation node.
+type opKind int
+
+const (
+	opInvalid opKind = iota
+	opOr
+	opAnd
+	opNot
+	opEq
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opMod
+	opPlus
+	opMinus
+)
+
+func (o opKind) String() string {
+	switch o {
+	case opOr:
+		return "||"
+	case opAnd:
+		return "&&"
+	case opNot:
+		return "!"
+	case opEq:
+		return "=="
+	case opNe:
+		return "!="
+	case opLt:
+		return "<"
+	case opLe:
+		return "<="
+	case opGt:
+		return ">"
+	case opGe:
+		return ">="
+	case opAdd, opPlus:
+		return "+"
+	case opSub, opMinus:
+		return "-"
+	case opMul:
+		return "*"
+	case opDiv:
+		return "/"
+	case opMod:
+		return "%"
+	}
+	return "?"
+}
+
+// isComparison returns whether the operator compares its operands.
+func (o opKind) isComparison() bool {
+	return o >= opEq && o <= opGe
+}
+
+// isArithmetic returns whether the operator is a binary arithmetic
+// operator.
+func (o opKind) isArithmetic() bool {
+	return o >= opAdd && o <= opMod
+}
+
+// Operator priorities used when formatting, from least to most tightly
+// binding.
+const (
+	priorityOr = iota
+	priorityAnd
+	priorityComparison
+	priorityAdditive
+	priorityMultiplicative
+	priorityUnary
+	priorityPrimary
+)
+
+// priority returns the binding priority of a node for formatting.
+func priority(n node) int {
+	switch t := n.(type) {
+	case *binary:
+		switch {
+		case t.op == opOr:
+			return priorityOr
+		case t.op == opAnd:
+			return priorityAnd
+		case t.op.isComparison():
+			return priorityComparison
+		case t.op == opAdd || t.op == opSub:
+			return priorityAdditive
+		default:
+			return priorityMultiplicative
+		}
+	case *unary:
+		if t.op == opNot {
+			return priorityPrimary
+		}
+		return priorityUnary
+	case *likeRegex, *startsWith:
+		return priorityComparison
+	}
+	return priorityPrimary
+}
+
+// isPredicate returns whether a node evaluates to a boolean.
+func isPredicate(n node) bool {
+	switch t := n.(type) {
+	case *binary:
+		return t.op == opOr || t.op == opAnd || t.op.isComparison()
+	case *unary:
+		return t.op == opNot
+	case *exists, *isUnknown, *likeRegex, *startsWith:
+		return true
+	}
+	return false
+}
+
+// root is the `$` node, which refers to the JSON document being queried.
+type root struct{}
+
+// current is the `@` node, which refers to the item being filtered.
+type current struct{}
+
+// last is the `last` node, which refers to the last index of the array being
+// subscripted.
+type last struct{}
+
+// variable is a `$name` node, which refers to a named variable passed along
+// with the path.
+type variable string
+
+// literal is a number, string, boolean or null constant.
+type literal struct {
+	val json.JSON
+}
+
+// binary is an arithmetic, comparison or logical binary operation.
+type binary struct {
+	op          opKind
+	left, right node
+}
+
+// unary is the unary arithmetic `+` and `-` operators, or the logical `!`
+// operator.
+type unary struct {
+	op  opKind
+	arg node
+}
+
+// exists is the `exists (expr)` predicate, which is true if the expression
+// returns a non-empty sequence.
+type exists struct {
+	arg node
+}
+
+// isUnknown is the `(predicate) is unknown` predicate.
+type isUnknown struct {
+	arg node
+}
+
+// likeRegex is the `expr like_regex "pattern" flag "flags"` predicate.
+type likeRegex struct {
+	arg     node
+	pattern string
+	flags   string
+	re      *regexp.Regexp
+}
+
+// startsWith is the `expr starts with prefix` predicate.
+type startsWith struct {
+	arg, prefix node
+}
+
+// chain is a primary expression followed by a sequence of accessors, item
+// methods and filters, for example `$.a[*].b ? (@ > 1).size()`.
+type chain struct {
+	head  node
+	steps []step
+}
+
+// step is a single accessor, item method or filter of a chain.
+type step interface {
+	formatStep(buf *strings.Builder)
+}
+
+// keyStep is the `.key` member accessor.
+type keyStep string
+
+// anyKeyStep is the `.*` wildcard member accessor.
+type anyKeyStep struct{}
+
+// anyArrayStep is the `[*]` wildcard array accessor.
+type anyArrayStep struct{}
+
+// subscript is a single array subscript, either an index or a range of
+// indexes `from to to`.
+type subscript struct {
+	from node
+	// to is nil if the subscript is a single index.
+	to node
+}
+
+// subscriptsStep is the `[subscript, ...]` array accessor.
+type subscriptsStep []subscript
+
+// lastLevel represents `last` in the level bounds of an anyPathStep.
+const lastLevel = math.MaxUint32
+
+// anyPathStep is the `.**` recursive wildcard accessor, optionally restricted
+// to the given range of nesting levels, for example `.**{2 to last}`.
+type anyPathStep struct {
+	first, last uint32
+}
+
+// filterStep is the `? (predicate)` filter.
+type filterStep struct {
+	cond node
+}
+
+// methodKind identifies an item method.
+type methodKind int
+
+const (
+	methodInvalid methodKind = iota
+	methodType
+	methodSize
+	methodDouble
+	methodCeiling
+	methodFloor
+	methodAbs
+	methodKeyValue
+	methodNumber
+	methodString
+	methodBoolean
+	methodInteger
+	methodBigint
+)
+
+var methodNames = [...]string{
+	methodType:     "type",
+	methodSize:     "size",
+	methodDouble:   "double",
+	methodCeiling:  "ceiling",
+	methodFloor:    "floor",
+	methodAbs:      "abs",
+	methodKeyValue: "keyvalue",
+	methodNumber:   "number",
+	methodString:   "string",
+	methodBoolean:  "boolean",
+	methodInteger:  "integer",
+	methodBigint:   "bigint",
+}
+
+func (m methodKind) String() string {
+	return methodNames[m]
+}
+
+// methodStep is an item method such as `.size()`.
+type methodStep methodKind
+
+func (root) format(buf *strings.Builder, _ bool) { buf.WriteByte('$') }
+
+func (current) format(buf *strings.Builder, _ bool) { buf.WriteByte('@') }
+
+func (last) format(buf *strings.Builder, _ bool) { buf.WriteString("last") }
+
+func (v variable) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('$')
+	writeString(buf, string(v))
+}
+
+func (l *literal) format(buf *strings.Builder, _ bool) {
+	if d, ok := l.val.AsDecimal(); ok {
+		buf.WriteString(d.Text('f'))
+		return
+	}
+	buf.WriteString(l.val.String())
+}
+
+func (b *binary) format(buf *strings.Builder, parens bool) {
+	if parens {
+		buf.WriteByte('(')
+	}
+	p := priority(b)
+	b.left.format(buf, priority(b.left) <= p)
+	buf.WriteByte(' ')
+	buf.WriteString(b.op.String())
+	buf.WriteByte(' ')
+	b.right.format(buf, priority(b.right) <= p)
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+func (u *unary) format(buf *strings.Builder, parens bool) {
+	if u.op == opNot {
+		buf.WriteString("!(")
+		u.arg.format(buf, false /* parens */)
+		buf.WriteByte(')')
+		return
+	}
+	if parens {
+		buf.WriteByte('(')
+	}
+	buf.WriteString(u.op.String())
+	u.arg.format(buf, priority(u.arg) <= priority(u))
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+func (e *exists) format(buf *strings.Builder, _ bool) {
+	buf.WriteString("exists (")
+	e.arg.format(buf, false /* parens */)
+	buf.WriteByte(')')
+}
+
+func (u *isUnknown) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('(')
+	u.arg.format(buf, false /* parens */)
+	buf.WriteString(") is unknown")
+}
+
+func (l *likeRegex) format(buf *strings.Builder, parens bool) {
+	if parens {
+		buf.WriteByte('(')
+	}
+	l.arg.format(buf, priority(l.arg) <= priority(l))
+	buf.WriteString(" like_regex ")
+	writeString(buf, l.pattern)
+	if l.flags != "" {
+		buf.WriteString(" flag ")
+		writeString(buf, l.flags)
+	}
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+func (s *startsWith) format(buf *strings.Builder, parens bool) {
+	if parens {
+		buf.WriteByte('(')
+	}
+	s.arg.format(buf, priority(s.arg) <= priority(s))
+	buf.WriteString(" starts with ")
+	s.prefix.format(buf, priority(s.prefix) <= priority(s))
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+func (c *chain) format(buf *strings.Builder, parens bool) {
+	// Operations followed by accessors are always parenthesized, so that the
+	// accessors are not mistaken to apply to the last operand only.
+	c.head.format(buf, parens || (len(c.steps) > 0 && priority(c.head) != priorityPrimary))
+	for _, s := range c.steps {
+		s.formatStep(buf)
+	}
+}
+
+func (k keyStep) formatStep(buf *strings.Builder) {
+	buf.WriteByte('.')
+	writeString(buf, string(k))
+}
+
+func (anyKeyStep) formatStep(buf *strings.Builder) { buf.WriteString(".*") }
+
+func (anyArrayStep) formatStep(buf *strings.Builder) { buf.WriteString("[*]") }
+
+func (s subscriptsStep) formatStep(buf *strings.Builder) {
+	buf.WriteByte('[')
+	for i, sub := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		sub.from.format(buf, false /* parens */)
+		if sub.to != nil {
+			buf.WriteString(" to ")
+			sub.to.format(buf, false /* parens */)
+		}
+	}
+	buf.WriteByte(']')
+}
+
+func (s anyPathStep) formatStep(buf *strings.Builder) {
+	buf.WriteString(".**")
+	if s.first == 0 && s.last == lastLevel {
+		return
+	}
+	buf.WriteByte('{')
+	writeLevel(buf, s.first)
+	if s.first != s.last {
+		buf.WriteString(" to ")
+		writeLevel(buf, s.last)
+	}
+	buf.WriteByte('}')
+}
+
+func writeLevel(buf *strings.Builder, level uint32) {
+	if level == lastLevel {
+		buf.WriteString("last")
+		return
+	}
+	buf.WriteString(strconv.FormatUint(uint64(level), 10))
+}
+
+func (f *filterStep) formatStep(buf *strings.Builder) {
+	buf.WriteString("?(")
+	f.cond.format(buf, false /* parens */)
+	buf.WriteByte(')')
+}
+
+func (m methodStep) formatStep(buf *strings.Builder) {
+	buf.WriteByte('.')
+	buf.WriteString(methodKind(m).String())
+	buf.WriteString("()")
+}
+
+// writeString writes s to buf as a double-quoted JSON string.
+func writeString(buf *strings.Builder, s string) {
+	buf.WriteString(json.FromString(s).String())
+}
@@ -0,0 +1,907 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// tokenKind is the kind of a lexical token of a jsonpath expression.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokPunct is an operator or punctuation, such as `.`, `==` or `[`.
+	tokPunct
+	// tokIdent is an unquoted identifier, which is either a keyword or an
+	// object key.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a `$name` or `$"name"` variable reference.
+	tokVariable
+)
+
+type token struct {
+	kind tokenKind
+	// val is the punctuation, the identifier, the unescaped string, the
+	// number, or the variable name, depending on kind.
+	val string
+}
+
+func (t token) is(kind tokenKind, val string) bool {
+	return t.kind == kind && t.val == val
+}
+
+// punctuation lists the multi-character operators first, so that the longest
+// match wins.
+var punctuation = []string{
+	".**", ".*", "==", "!=", "<>", "<=", ">=", "&&", "||",
+	"$", "@", ".", "[", "]", "(", ")", "{", "}", ",", "?",
+	"<", ">", "!", "+", "-", "*", "/", "%",
+}
+
+func syntaxError(tok token) error {
+	if tok.kind == tokEOF {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	val := tok.val
+	if tok.kind == tokString || tok.kind == tokVariable {
+		val = strconv.Quote(val)
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", val)
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || r >= utf8.RuneSelf
+}
+
+func isIdentChar(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// tokenize splits a jsonpath expression into tokens.
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+
+		case c == '"':
+			str, n, err := scanString(s[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, val: str})
+			i += n
+
+		case isDigit(c):
+			n := scanNumber(s[i:])
+			toks = append(toks, token{kind: tokNumber, val: s[i : i+n]})
+			i += n
+
+		case c == '$' && i+1 < len(s) && s[i+1] == '"':
+			str, n, err := scanString(s[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokVariable, val: str})
+			i += n + 1
+
+		case c == '$' && i+1 < len(s) && isIdentCharAt(s, i+1):
+			n := scanIdent(s[i+1:])
+			toks = append(toks, token{kind: tokVariable, val: s[i+1 : i+1+n]})
+			i += n + 1
+
+		default:
+			if r, _ := utf8.DecodeRuneInString(s[i:]); isIdentStart(r) {
+				n := scanIdent(s[i:])
+				toks = append(toks, token{kind: tokIdent, val: s[i : i+n]})
+				i += n
+				continue
+			}
+			matched := false
+			for _, p := range punctuation {
+				if strings.HasPrefix(s[i:], p) {
+					toks = append(toks, token{kind: tokPunct, val: p})
+					i += len(p)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				r, _ := utf8.DecodeRuneInString(s[i:])
+				return nil, syntaxError(token{kind: tokPunct, val: string(r)})
+			}
+		}
+	}
+	return toks, nil
+}
+
+func isIdentCharAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return isIdentChar(r)
+}
+
+// scanIdent returns the length of the identifier at the start of s.
+func scanIdent(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !isIdentChar(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// scanNumber returns the length of the numeric literal at the start of s. A
+// dot is only consumed if it is followed by a digit, so that `1.type()` is
+// an item method applied to 1.
+func scanNumber(s string) int {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	if n+1 < len(s) && s[n] == '.' && isDigit(s[n+1]) {
+		n++
+		for n < len(s) && isDigit(s[n]) {
+			n++
+		}
+	}
+	if n < len(s) && (s[n] == 'e' || s[n] == 'E') {
+		m := n + 1
+		if m < len(s) && (s[m] == '+' || s[m] == '-') {
+			m++
+		}
+		if m < len(s) && isDigit(s[m]) {
+			for m < len(s) && isDigit(s[m]) {
+				m++
+			}
+			n = m
+		}
+	}
+	return n
+}
+
+// scanString unescapes the double-quoted string at the start of s, returning
+// the string and the number of bytes consumed.
+func scanString(s string) (string, int, error) {
+	var buf strings.Builder
+	for i := 1; i < len(s); {
+		c := s[i]
+		switch c {
+		case '"':
+			return buf.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, pgerror.New(pgcode.Syntax, "unexpected end after backslash in jsonpath input")
+			}
+			i++
+			switch e := s[i]; e {
+			case 'b':
+				buf.WriteByte('\b')
+			case 'f':
+				buf.WriteByte('\f')
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'v':
+				buf.WriteByte('\v')
+			case 'x':
+				if i+2 >= len(s) {
+					return "", 0, invalidEscapeError()
+				}
+				v, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+				if err != nil || v == 0 {
+					return "", 0, invalidEscapeError()
+				}
+				buf.WriteRune(rune(v))
+				i += 2
+			case 'u':
+				r, n, err := scanUnicodeEscape(s[i+1:])
+				if err != nil {
+					return "", 0, err
+				}
+				buf.WriteRune(r)
+				i += n
+			default:
+				// Any other escaped character, including \" and \\, stands for
+				// itself.
+				buf.WriteByte(e)
+			}
+			i++
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, pgerror.New(pgcode.Syntax, "unexpected end of quoted string in jsonpath input")
+}
+
+func invalidEscapeError() error {
+	return pgerror.New(pgcode.Syntax, "invalid hexadecimal character sequence in jsonpath input")
+}
+
+// scanUnicodeEscape decodes the code point of a `\uXXXX` or `\u{X...}`
+// escape, given the text following `\u`. Surrogate pairs of `\uXXXX` escapes
+// are combined.
+func scanUnicodeEscape(s string) (rune, int, error) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 2 || end > 7 {
+			return 0, 0, invalidUnicodeError()
+		}
+		v, err := strconv.ParseUint(s[1:end], 16, 32)
+		if err != nil || v == 0 || !utf8.ValidRune(rune(v)) {
+			return 0, 0, invalidUnicodeError()
+		}
+		return rune(v), end + 1, nil
+	}
+	if len(s) < 4 {
+		return 0, 0, invalidUnicodeError()
+	}
+	v, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil || v == 0 {
+		return 0, 0, invalidUnicodeError()
+	}
+	r := rune(v)
+	if r >= 0xd800 && r < 0xdc00 {
+		// A high surrogate must be followed by a low surrogate.
+		if len(s) < 10 || s[4:6] != `\u` {
+			return 0, 0, invalidUnicodeError()
+		}
+		lo, err := strconv.ParseUint(s[6:10], 16, 16)
+		if err != nil || lo < 0xdc00 || lo >= 0xe000 {
+			return 0, 0, invalidUnicodeError()
+		}
+		return (r-0xd800)<<10 + (rune(lo) - 0xdc00) + 0x10000, 10, nil
+	}
+	if r >= 0xdc00 && r < 0xe000 {
+		return 0, 0, invalidUnicodeError()
+	}
+	return r, 4, nil
+}
+
+func invalidUnicodeError() error {
+	return pgerror.New(pgcode.Syntax, "invalid Unicode escape sequence in jsonpath input")
+}
+
+// parser is a recursive descent parser for jsonpath expressions.
+type parser struct {
+	toks []token
+	pos  int
+	// filterDepth is the number of filters enclosing the current position,
+	// where `@` is allowed.
+	filterDepth int
+	// subscriptDepth is the number of array subscripts enclosing the current
+	// position, where `last` is allowed.
+	subscriptDepth int
+}
+
+// Parse parses a jsonpath expression.
+func Parse(s string) (Jsonpath, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return Jsonpath{}, err
+	}
+	p := parser{toks: toks}
+	var j Jsonpath
+	if t := p.peek(); t.kind == tokIdent && (t.val == "strict" || t.val == "lax") {
+		j.Strict = t.val == "strict"
+		p.pos++
+	}
+	if j.expr, err = p.parseOr(); err != nil {
+		return Jsonpath{}, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return Jsonpath{}, syntaxError(t)
+	}
+	return j, nil
+}
+
+func (p *parser) peek() token {
+	return p.peekN(0)
+}
+
+func (p *parser) peekN(n int) token {
+	if p.pos+n < len(p.toks) {
+		return p.toks[p.pos+n]
+	}
+	return token{kind: tokEOF}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it matches.
+func (p *parser) accept(kind tokenKind, val string) bool {
+	if p.peek().is(kind, val) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect consumes the next token, which must match.
+func (p *parser) expect(kind tokenKind, val string) error {
+	if t := p.next(); !t.is(kind, val) {
+		return syntaxError(t)
+	}
+	return nil
+}
+
+// expectPredicate returns a syntax error if n is not a predicate. The error
+// refers to the token that follows n.
+func (p *parser) expectPredicate(n node) error {
+	if !isPredicate(n) {
+		return syntaxError(p.peek())
+	}
+	return nil
+}
+
+// expectValue returns a syntax error if n is a predicate.
+func (p *parser) expectValue(n node) error {
+	if isPredicate(n) {
+		return syntaxError(p.peek())
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokPunct, "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &binary{op: opOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokPunct, "&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &binary{op: opAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if !p.accept(tokPunct, "!") {
+		return p.parseComparison()
+	}
+	arg, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPredicate(arg); err != nil {
+		return nil, err
+	}
+	return &unary{op: opNot, arg: arg}, nil
+}
+
+var comparisonOps = map[string]opKind{
+	"==": opEq,
+	"!=": opNe,
+	"<>": opNe,
+	"<":  opLt,
+	"<=": opLe,
+	">":  opGt,
+	">=": opGe,
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokPunct && comparisonOps[t.val] != opInvalid:
+		p.pos++
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		return &binary{op: comparisonOps[t.val], left: left, right: right}, nil
+
+	case t.is(tokIdent, "like_regex"):
+		p.pos++
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		pattern := p.next()
+		if pattern.kind != tokString {
+			return nil, syntaxError(pattern)
+		}
+		var flags string
+		if p.accept(tokIdent, "flag") {
+			f := p.next()
+			if f.kind != tokString {
+				return nil, syntaxError(f)
+			}
+			flags = f.val
+		}
+		return makeLikeRegex(left, pattern.val, flags)
+
+	case t.is(tokIdent, "starts") && p.peekN(1).is(tokIdent, "with"):
+		p.pos += 2
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		var prefix node
+		switch t := p.next(); t.kind {
+		case tokString:
+			prefix = &literal{val: json.FromString(t.val)}
+		case tokVariable:
+			prefix = variable(t.val)
+		default:
+			return nil, syntaxError(t)
+		}
+		return &startsWith{arg: left, prefix: prefix}, nil
+	}
+	return left, nil
+}
+
+// makeLikeRegex validates the pattern and flags of a like_regex predicate
+// and compiles the pattern.
+func makeLikeRegex(arg node, pattern, flags string) (node, error) {
+	var icase, dotall, multiline, quote bool
+	for _, f := range flags {
+		switch f {
+		case 'i':
+			icase = true
+		case 's':
+			dotall = true
+		case 'm':
+			multiline = true
+		case 'q':
+			quote = true
+		case 'x':
+			return nil, unimplementedError(
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate",
+				f)
+		}
+	}
+	// Normalize the flags to the order in which Postgres prints them.
+	var canonical, prefix strings.Builder
+	for _, f := range []struct {
+		set  bool
+		flag byte
+	}{{icase, 'i'}, {dotall, 's'}, {multiline, 'm'}, {quote, 'q'}} {
+		if f.set {
+			canonical.WriteByte(f.flag)
+			if f.flag != 'q' {
+				prefix.WriteByte(f.flag)
+			}
+		}
+	}
+	expr := pattern
+	if quote {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if prefix.Len() > 0 {
+		expr = "(?" + prefix.String() + ")" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return &likeRegex{arg: arg, pattern: pattern, flags: canonical.String(), re: re}, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op opKind
+		switch {
+		case p.accept(tokPunct, "+"):
+			op = opAdd
+		case p.accept(tokPunct, "-"):
+			op = opSub
+		default:
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op opKind
+		switch {
+		case p.accept(tokPunct, "*"):
+			op = opMul
+		case p.accept(tokPunct, "/"):
+			op = opDiv
+		case p.accept(tokPunct, "%"):
+			op = opMod
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	var op opKind
+	switch {
+	case p.accept(tokPunct, "+"):
+		op = opPlus
+	case p.accept(tokPunct, "-"):
+		op = opMinus
+	default:
+		return p.parseAccessors()
+	}
+	arg, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectValue(arg); err != nil {
+		return nil, err
+	}
+	return &unary{op: op, arg: arg}, nil
+}
+
+// parseAccessors parses a primary expression followed by any number of
+// accessors, item methods and filters.
+func (p *parser) parseAccessors() (node, error) {
+	head, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var steps []step
+	for {
+		s, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			break
+		}
+		steps = append(steps, s)
+	}
+	if len(steps) == 0 {
+		return head, nil
+	}
+	return &chain{head: head, steps: steps}, nil
+}
+
+var methods = map[string]methodKind{
+	"type":     methodType,
+	"size":     methodSize,
+	"double":   methodDouble,
+	"ceiling":  methodCeiling,
+	"floor":    methodFloor,
+	"abs":      methodAbs,
+	"keyvalue": methodKeyValue,
+	"number":   methodNumber,
+	"string":   methodString,
+	"boolean":  methodBoolean,
+	"integer":  methodInteger,
+	"bigint":   methodBigint,
+}
+
+// parseStep parses a single accessor, item method or filter, returning nil if
+// the next token does not start one.
+func (p *parser) parseStep() (step, error) {
+	t := p.peek()
+	switch {
+	case t.is(tokPunct, ".*"):
+		p.pos++
+		return anyKeyStep{}, nil
+
+	case t.is(tokPunct, ".**"):
+		p.pos++
+		return p.parseAnyPath()
+
+	case t.is(tokPunct, "."):
+		p.pos++
+		key := p.next()
+		switch key.kind {
+		case tokString:
+			return keyStep(key.val), nil
+		case tokIdent:
+			if !p.peek().is(tokPunct, "(") {
+				return keyStep(key.val), nil
+			}
+			m, ok := methods[key.val]
+			if !ok {
+				if key.val == "datetime" || key.val == "decimal" || key.val == "date" ||
+					strings.HasPrefix(key.val, "time") {
+					return nil, unimplementedError("jsonpath item method ." + key.val + "() is not supported")
+				}
+				return nil, syntaxError(p.peek())
+			}
+			p.pos++
+			if err := p.expect(tokPunct, ")"); err != nil {
+				return nil, err
+			}
+			return methodStep(m), nil
+		}
+		return nil, syntaxError(key)
+
+	case t.is(tokPunct, "["):
+		p.pos++
+		if p.peek().is(tokPunct, "*") && p.peekN(1).is(tokPunct, "]") {
+			p.pos += 2
+			return anyArrayStep{}, nil
+		}
+		return p.parseSubscripts()
+
+	case t.is(tokPunct, "?"):
+		p.pos++
+		if err := p.expect(tokPunct, "("); err != nil {
+			return nil, err
+		}
+		p.filterDepth++
+		cond, err := p.parseOr()
+		p.filterDepth--
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(cond); err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokPunct, ")"); err != nil {
+			return nil, err
+		}
+		return &filterStep{cond: cond}, nil
+	}
+	return nil, nil
+}
+
+// parseSubscripts parses the subscripts of an array accessor, following the
+// opening bracket.
+func (p *parser) parseSubscripts() (step, error) {
+	var subs subscriptsStep
+	p.subscriptDepth++
+	defer func() { p.subscriptDepth-- }()
+	for {
+		var sub subscript
+		var err error
+		if sub.from, err = p.parseSubscriptExpr(); err != nil {
+			return nil, err
+		}
+		if p.accept(tokIdent, "to") {
+			if sub.to, err = p.parseSubscriptExpr(); err != nil {
+				return nil, err
+			}
+		}
+		subs = append(subs, sub)
+		if p.accept(tokPunct, "]") {
+			return subs, nil
+		}
+		if err := p.expect(tokPunct, ","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseSubscriptExpr() (node, error) {
+	n, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectValue(n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// parseAnyPath parses the optional level bounds of a `.**` accessor.
+func (p *parser) parseAnyPath() (step, error) {
+	s := anyPathStep{first: 0, last: lastLevel}
+	if !p.accept(tokPunct, "{") {
+		return s, nil
+	}
+	var err error
+	if s.first, err = p.parseLevel(); err != nil {
+		return nil, err
+	}
+	s.last = s.first
+	if p.accept(tokIdent, "to") {
+		if s.last, err = p.parseLevel(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokPunct, "}"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *parser) parseLevel() (uint32, error) {
+	t := p.next()
+	if t.is(tokIdent, "last") {
+		return lastLevel, nil
+	}
+	if t.kind != tokNumber {
+		return 0, syntaxError(t)
+	}
+	v, err := strconv.ParseUint(t.val, 10, 32)
+	if err != nil || v == lastLevel {
+		return 0, syntaxError(t)
+	}
+	return uint32(v), nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokPunct:
+		switch t.val {
+		case "$":
+			return root{}, nil
+		case "@":
+			if p.filterDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			return current{}, nil
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokPunct, ")"); err != nil {
+				return nil, err
+			}
+			if p.peek().is(tokIdent, "is") && p.peekN(1).is(tokIdent, "unknown") {
+				p.pos += 2
+				if !isPredicate(inner) {
+					return nil, syntaxError(token{kind: tokIdent, val: "is"})
+				}
+				return &isUnknown{arg: inner}, nil
+			}
+			return inner, nil
+		}
+
+	case tokVariable:
+		return variable(t.val), nil
+
+	case tokString:
+		return &literal{val: json.FromString(t.val)}, nil
+
+	case tokNumber:
+		d, _, err := apd.NewFromString(t.val)
+		if err != nil {
+			return nil, syntaxError(t)
+		}
+		return &literal{val: json.FromDecimal(*d)}, nil
+
+	case tokIdent:
+		switch t.val {
+		case "true":
+			return &literal{val: json.TrueJSONValue}, nil
+		case "false":
+			return &literal{val: json.FalseJSONValue}, nil
+		case "null":
+			return &literal{val: json.NullJSONValue}, nil
+		case "last":
+			if p.subscriptDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			return last{}, nil
+		case "exists":
+			if err := p.expect(tokPunct, "("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectValue(arg); err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokPunct, ")"); err != nil {
+				return nil, err
+			}
+			return &exists{arg: arg}, nil
+		}
+	}
+	return nil, syntaxError(t)
+}
+
+func unimplementedError(msg string) error {
+	return pgerror.New(pgcode.FeatureNotSupported, msg)
+}
+
+// MustParse parses a jsonpath expression, panicking on error. It is intended
+// for tests.
+func MustParse(s string) Jsonpath {
+	j, err := Parse(s)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "parsing %q", s))
+	}
+	return j
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`lax $`, `$`},
+		{`strict $`, `strict $`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b.c`, `$."a"."b"."c"`},
+		{`$.last.to`, `$."last"."to"`},
+		{`$."A\x42\n"`, `$."AB\n"`},
+		{`$.*`, `$.*`},
+		{`$.a[*]`, `$."a"[*]`},
+		{`$.a[1,2 to last]`, `$."a"[1,2 to last]`},
+		{`$[last - 1]`, `$[last - 1]`},
+		{`$.**`, `$.**`},
+		{`$.**{2}`, `$.**{2}`},
+		{`$.**{1 to last}`, `$.**{1 to last}`},
+		{`$ ? (@ > 1)`, `$?(@ > 1)`},
+		{`$.a ? (@.b == "x" && @.c < 2 || !(@.d == null))`,
+			`$."a"?(@."b" == "x" && @."c" < 2 || !(@."d" == null))`},
+		{`$ ? (@.a < 1 && (@.b > 2 || @.c <> 3))`, `$?(@."a" < 1 && (@."b" > 2 || @."c" != 3))`},
+		{`$.a == 1`, `($."a" == 1)`},
+		{`$.a + 1`, `($."a" + 1)`},
+		{`$.a + 1 * 2`, `($."a" + 1 * 2)`},
+		{`($.a + 1) * 2`, `(($."a" + 1) * 2)`},
+		{`$.a - (1 - 2)`, `($."a" - (1 - 2))`},
+		{`-$.a`, `(-$."a")`},
+		{`$ ? (@ > -1)`, `$?(@ > -1)`},
+		{`1.5e2`, `150`},
+		{`0.10`, `0.10`},
+		{`"str"`, `"str"`},
+		{`true`, `true`},
+		{`null`, `null`},
+		{`$var`, `$"var"`},
+		{`$"my var"`, `$"my var"`},
+		{`$.a.type()`, `$."a".type()`},
+		{`$.size().double()`, `$.size().double()`},
+		{`1.type()`, `1.type()`},
+		{`($.a + 1).abs()`, `($."a" + 1).abs()`},
+		{`$ ? (@ like_regex "^ab")`, `$?(@ like_regex "^ab")`},
+		{`$ ? (@ like_regex "^ab" flag "qsi")`, `$?(@ like_regex "^ab" flag "isq")`},
+		{`$ ? (@ starts with "a")`, `$?(@ starts with "a")`},
+		{`$ ? (@ starts with $x)`, `$?(@ starts with $"x")`},
+		{`$ ? (exists(@.a))`, `$?(exists (@."a"))`},
+		{`$ ? ((@ > 1) is unknown)`, `$?((@ > 1) is unknown)`},
+		{`exists($.a) && $.b == 1`, `(exists ($."a") && $."b" == 1)`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			j, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, j.String())
+
+			// The canonical representation parses to the same path.
+			j2, err := Parse(j.String())
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, j2.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$ +`, `syntax error at end of jsonpath input`},
+		{`$ $`, `syntax error at or near "$" of jsonpath input`},
+		{`$ ? (1)`, `syntax error at or near ")" of jsonpath input`},
+		{`$ ? (@ > 1`, `syntax error at end of jsonpath input`},
+		{`($ > 1) + 1`, `syntax error at end of jsonpath input`},
+		{`$.a.foo()`, `syntax error at or near "(" of jsonpath input`},
+		{`$ # 1`, `syntax error at or near "#" of jsonpath input`},
+		{`"abc`, `unexpected end of quoted string in jsonpath input`},
+		{`"\u00"`, `invalid Unicode escape sequence in jsonpath input`},
+		{`@`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$.datetime()`, `jsonpath item method .datetime() is not supported`},
+		{`$ ? (@ like_regex "a" flag "z")`, `unrecognized flag character 'z' in LIKE_REGEX predicate`},
+		{`$ ? (@ like_regex "a" flag "x")`, `XQuery "x" flag (expanded regular expressions) is not implemented`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -117,6 +117,9 @@ func ApplyConfig(
 	// fd2CaptureCleanupFn is the cleanup function for the fd2 capture,
 	// which is populated if fd2 capture is enabled, below.
 	fd2CaptureCleanupFn := func() {}
+	// otlpSinks collects the OTLP sinks derived by the configuration,
+	// whose connections are closed during shutdown.
+	var otlpSinks []*otlpSink
 
 	closer := newBufferedSinkCloser()
 	// logShutdownFn is the returned cleanup function, whose purpose
@@ -130,6 +133,9 @@ func ApplyConfig(
 		if err := closer.Close(defaultCloserTimeout); err != nil {
 			fmt.Printf("# WARNING: %s\n", err.Error())
 		}
+		for _, s := range otlpSinks {
+			s.close()
+		}
 		for _, l := range secLoggers {
 			logging.allLoggers.del(l)
 		}
@@ -366,6 +372,20 @@ func ApplyConfig(
 		attachSinkInfo(httpSinkInfo, &fc.Channels)
 	}
 
+	// Create the OTLP sinks.
+	for _, fc := range config.Sinks.OTLPServers {
+		if fc.Filter == severity.NONE {
+			continue
+		}
+		otlpSinkInfo, netSink, err := newOTLPSinkInfo(*fc)
+		if err != nil {
+			return nil, err
+		}
+		otlpSinks = append(otlpSinks, netSink)
+		attachBufferWrapper(otlpSinkInfo, fc.CommonSinkConfig.Buffering, closer)
+		attachSinkInfo(otlpSinkInfo, &fc.Channels)
+	}
+
 	// Prepend the interceptor sink to all channels.
 	// We prepend it because we want the interceptors
 	// to see every event before they make their way to disk/network.
@@ -433,6 +453,25 @@ func newHTTPSinkInfo(c logconfig.HTTPSinkConfig) (*sinkInfo, error) {
 	return info, nil
 }
 
+// newOTLPSinkInfo creates a new otlpSink and its accompanying sinkInfo
+// from the provided configuration.
+func newOTLPSinkInfo(c logconfig.OTLPSinkConfig) (*sinkInfo, *otlpSink, error) {
+	info := &sinkInfo{}
+	// OTLP sinks always use the otlp format, which is not available to
+	// the other sinks.
+	if err := info.applyConfigWithFormatter(c.CommonSinkConfig, &formatOTLP{}); err != nil {
+		return nil, nil, err
+	}
+	info.applyFilters(c.Channels)
+
+	netSink, err := newOTLPSink(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	info.sink = netSink
+	return info, netSink, nil
+}
+
 // applyFilters applies the channel filters to a sinkInfo.
 func (l *sinkInfo) applyFilters(chs logconfig.ChannelFilters) {
 	for ch, threshold := range chs.ChannelFilters {
@@ -465,17 +504,26 @@ func attachBufferWrapper(
 
 // applyConfig applies a common sink configuration to a sinkInfo.
 func (l *sinkInfo) applyConfig(c logconfig.CommonSinkConfig) error {
-	l.threshold.setAll(severity.NONE)
-	l.redact = *c.Redact
-	l.redactable = *c.Redactable
-	l.editor = getEditor(SelectEditMode(*c.Redact, *c.Redactable))
-	l.criticality = *c.Criticality
 	f, ok := formatters[*c.Format]
 	if !ok {
 		return errors.WithHintf(errors.Newf("unknown format: %q", *c.Format),
 			"Supported formats: %s.", redact.Safe(strings.Join(formatNames, ", ")))
 	}
-	l.formatter = f()
+	return l.applyConfigWithFormatter(c, f())
+}
+
+// applyConfigWithFormatter applies a common sink configuration to a
+// sinkInfo, using the provided formatter instead of the one named by
+// the configuration.
+func (l *sinkInfo) applyConfigWithFormatter(
+	c logconfig.CommonSinkConfig, formatter logFormatter,
+) error {
+	l.threshold.setAll(severity.NONE)
+	l.redact = *c.Redact
+	l.redactable = *c.Redactable
+	l.editor = getEditor(SelectEditMode(*c.Redact, *c.Redactable))
+	l.criticality = *c.Criticality
+	l.formatter = formatter
 	for k, v := range c.FormatOptions {
 		if err := l.formatter.setOption(k, v); err != nil {
 			return err
@@ -662,6 +710,28 @@ func DescribeAppliedConfig() string {
 		return nil
 	})
 
+	// Describe the OTLP sinks.
+	config.Sinks.OTLPServers = make(map[string]*logconfig.OTLPSinkConfig)
+	sIdx = 1
+	_ = logging.allSinkInfos.iter(func(l *sinkInfo) error {
+		netSink, ok := l.sink.(*otlpSink)
+		if !ok {
+			// Check to see if it's an otlpSink wrapped in a bufferedSink.
+			bufferedSink, ok := l.sink.(*bufferedSink)
+			if !ok {
+				return nil
+			}
+			netSink, ok = bufferedSink.child.(*otlpSink)
+			if !ok {
+				return nil
+			}
+		}
+		skey := fmt.Sprintf("s%d", sIdx)
+		sIdx++
+		config.Sinks.OTLPServers[skey] = netSink.config
+		return nil
+	})
+
 	// Note: we cannot return 'config' directly, because this captures
 	// certain variables from the loggers by reference and thus could be
 	// invalidated by concurrent uses of ApplyConfig().
@@ -35,6 +35,10 @@ const DefaultFluentFormat = `json-fluent-compact`
 // when not specified in a configuration.
 const DefaultHTTPFormat = `json-compact`
 
+// DefaultOTLPFormat is the entry format for OTLP sinks. It is the only
+// format supported by OTLP sinks.
+const DefaultOTLPFormat = `otlp`
+
 // DefaultFilePerms is the default permissions used in file-defaults. It
 // is applied literally via os.Chmod, without considering the umask.
 const DefaultFilePerms = FilePermissions(0o640)
@@ -72,6 +76,16 @@ http-defaults:
       max-staleness: 5s	
       flush-trigger-size: 1mib
       max-buffer-size: 50mib
+otlp-defaults:
+    filter: INFO
+    format: ` + DefaultOTLPFormat + `
+    redactable: true
+    exit-on-error: false
+    timeout: 2s
+    buffering:
+      max-staleness: 5s
+      flush-trigger-size: 1mib
+      max-buffer-size: 50mib
 sinks:
   stderr:
     filter: NONE
@@ -125,6 +139,11 @@ type Config struct {
 	// configuration value.
 	HTTPDefaults HTTPDefaults `yaml:"http-defaults,omitempty"`
 
+	// OTLPDefaults represents the default configuration for OTLP sinks,
+	// inherited when a specific OTLP sink config does not provide a
+	// configuration value.
+	OTLPDefaults OTLPDefaults `yaml:"otlp-defaults,omitempty"`
+
 	// Sinks represents the sink configurations.
 	Sinks SinkConfig `yaml:",omitempty"`
 
@@ -249,6 +268,8 @@ type SinkConfig struct {
 	FluentServers map[string]*FluentSinkConfig `yaml:"fluent-servers,omitempty"`
 	// HTTPServers represents the list of configured http sinks.
 	HTTPServers map[string]*HTTPSinkConfig `yaml:"http-servers,omitempty"`
+	// OTLPServers represents the list of configured OTLP sinks.
+	OTLPServers map[string]*OTLPSinkConfig `yaml:"otlp-servers,omitempty"`
 	// Stderr represents the configuration for the stderr sink.
 	Stderr StderrSinkConfig `yaml:",omitempty"`
 }
@@ -569,6 +590,96 @@ type HTTPSinkConfig struct {
 	sinkName string
 }
 
+// OTLPDefaults represents the configuration defaults for OTLP sinks.
+type OTLPDefaults struct {
+	// Address is the network address of the OpenTelemetry collector.
+	// In grpc mode, this is a host and port separated with a colon,
+	// e.g. 127.0.0.1:4317. In http mode, this is the URL of the
+	// collector, e.g. http://127.0.0.1:4318; the path defaults to
+	// /v1/logs if not specified.
+	Address *string `yaml:",omitempty"`
+
+	// Mode is the OTLP transport to use. grpc and http are
+	// supported; defaults to grpc.
+	Mode *OTLPSinkMode `yaml:",omitempty"`
+
+	// Insecure disables TLS for the grpc transport. In http mode,
+	// TLS is controlled by the scheme of the address.
+	// Defaults to false.
+	Insecure *bool `yaml:",omitempty"`
+
+	// Timeout is the timeout for each export request.
+	// Defaults to 0 for no timeout.
+	Timeout *time.Duration `yaml:",omitempty"`
+
+	// Headers is a list of headers (gRPC metadata in grpc mode) to
+	// attach to each export request.
+	Headers map[string]string `yaml:",omitempty,flow"`
+
+	// Compression can be "none" or "gzip" to enable gzip compression.
+	// Set to "gzip" by default.
+	Compression *string `yaml:",omitempty"`
+
+	CommonSinkConfig `yaml:",inline"`
+}
+
+// OTLPSinkConfig represents the configuration for one OTLP sink.
+//
+// User-facing documentation follows.
+// TITLE: Output to OpenTelemetry collectors.
+//
+// This sink type causes logging data to be sent over the network
+// to an OpenTelemetry collector using the OpenTelemetry protocol
+// (OTLP), either over gRPC or over HTTP.
+//
+// Each log entry is sent as an OTLP log record. The severity is
+// reported using the OTLP severity number and text, and the
+// channel, the redactable flag, the logging tags and the node
+// identifiers are reported as record attributes. Structured
+// events are sent with their JSON payload as record body.
+//
+// The configuration key under the `sinks` key in the YAML
+// configuration is `otlp-servers`. Example configuration:
+//
+//	sinks:
+//	   otlp-servers:
+//	      audit:
+//	         channels: [SENSITIVE_ACCESS, SQL_EXEC]
+//	         address: 127.0.0.1:4317
+//	         insecure: true
+//
+// Every new server sink configured automatically inherits the configuration set in the `otlp-defaults` section.
+//
+// For example:
+//
+//	otlp-defaults:
+//	    mode: http
+//	sinks:
+//	  otlp-servers:
+//	    audit:
+//	       channels: SENSITIVE_ACCESS
+//	       # This sink uses OTLP/HTTP,
+//	       # as the setting is inherited from otlp-defaults
+//	       # unless overridden here.
+//	       address: https://collector.example.com
+//
+// The format of OTLP sinks is always `otlp`. The buffer format
+// configured under `buffering` is ignored: the sink assembles the
+// buffered records into OTLP export requests itself.
+//
+// {{site.data.alerts.callout_info}}
+// Run `cockroach debug check-log-config` to verify the effect of defaults inheritance.
+// {{site.data.alerts.end}}
+type OTLPSinkConfig struct {
+	// Channels is the list of logging channels that use this sink.
+	Channels ChannelFilters `yaml:",omitempty,flow"`
+
+	OTLPDefaults `yaml:",inline"`
+
+	// sinkName is populated during validation.
+	sinkName string
+}
+
 // IterateDirectories calls the provided fn on every directory linked to
 // by the configuration.
 func (c *Config) IterateDirectories(fn func(d string) error) error {
@@ -1157,6 +1268,43 @@ func (hsm *HTTPSinkMethod) UnmarshalYAML(fn func(interface{}) error) error {
 	return unmarshalYAMLConstrainedString(hsm, fn)
 }
 
+const (
+	// OTLPModeGRPC sends log records using OTLP/gRPC.
+	OTLPModeGRPC OTLPSinkMode = "grpc"
+	// OTLPModeHTTP sends log records using OTLP/HTTP with protobuf payloads.
+	OTLPModeHTTP OTLPSinkMode = "http"
+)
+
+// OTLPSinkMode is a string restricted to "grpc" and "http".
+type OTLPSinkMode string
+
+var _ constrainedString = (*OTLPSinkMode)(nil)
+
+// Accept implements the constrainedString interface.
+func (m *OTLPSinkMode) Accept(s string) {
+	*m = OTLPSinkMode(s)
+}
+
+// Canonicalize implements the constrainedString interface.
+func (OTLPSinkMode) Canonicalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// AllowedSet implements the constrainedString interface.
+func (OTLPSinkMode) AllowedSet() []string {
+	return []string{string(OTLPModeGRPC), string(OTLPModeHTTP)}
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (m OTLPSinkMode) MarshalYAML() (interface{}, error) {
+	return string(m), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (m *OTLPSinkMode) UnmarshalYAML(fn func(interface{}) error) error {
+	return unmarshalYAMLConstrainedString(m, fn)
+}
+
 // constrainedString is an interface to make it easy to unmarshal
 // a string constrained to a small set of accepted values.
 type constrainedString interface {
@@ -111,14 +111,36 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}(),
 		Compression: &GzipCompression,
 	}
+	baseOTLPDefaults := OTLPDefaults{
+		CommonSinkConfig: CommonSinkConfig{
+			Format: func() *string { s := DefaultOTLPFormat; return &s }(),
+			Buffering: CommonBufferSinkConfigWrapper{
+				CommonBufferSinkConfig: CommonBufferSinkConfig{
+					MaxStaleness:     &defaultBufferedStaleness,
+					FlushTriggerSize: &defaultFlushTriggerSize,
+					MaxBufferSize:    &defaultMaxBufferSize,
+					Format:           &bufferFmt,
+				},
+			},
+		},
+		Mode:     func() *OTLPSinkMode { m := OTLPModeGRPC; return &m }(),
+		Insecure: &bf,
+		Timeout: func() *time.Duration {
+			twoS := 2 * time.Second
+			return &twoS
+		}(),
+		Compression: &GzipCompression,
+	}
 
 	propagateCommonDefaults(&baseFileDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseFluentDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseHTTPDefaults.CommonSinkConfig, baseCommonSinkConfig)
+	propagateCommonDefaults(&baseOTLPDefaults.CommonSinkConfig, baseCommonSinkConfig)
 
 	propagateFileDefaults(&c.FileDefaults, baseFileDefaults)
 	propagateFluentDefaults(&c.FluentDefaults, baseFluentDefaults)
 	propagateHTTPDefaults(&c.HTTPDefaults, baseHTTPDefaults)
+	propagateOTLPDefaults(&c.OTLPDefaults, baseOTLPDefaults)
 
 	// Normalize the directory.
 	if err := normalizeDir(&c.FileDefaults.Dir); err != nil {
@@ -160,6 +182,17 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, fc := range c.Sinks.OTLPServers {
+		if fc == nil {
+			fc = &OTLPSinkConfig{Channels: SelectChannels()}
+			c.Sinks.OTLPServers[sinkName] = fc
+		}
+		fc.sinkName = sinkName
+		if err := c.validateOTLPSinkConfig(fc); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+		}
+	}
+
 	// Defaults for stderr.
 	if c.Sinks.Stderr.Filter == logpb.Severity_UNKNOWN {
 		c.Sinks.Stderr.Filter = logpb.Severity_NONE
@@ -245,6 +278,18 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, fc := range c.Sinks.OTLPServers {
+		if len(fc.Channels.Filters) == 0 {
+			fmt.Fprintf(&errBuf, "otlp server %q: no channel selected\n", sinkName)
+		}
+		// Propagate the sink-wide default filter to all channels that don't
+		// have a filter yet.
+		if err := fc.Channels.Validate(fc.Filter); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+			continue
+		}
+	}
+
 	// If capture-stray-errors was enabled, then perform some additional
 	// validation on it.
 	if c.CaptureFd2.Enable {
@@ -350,6 +395,14 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	// Elide all the OTLP sinks where all channels have
+	// severity set to NONE.
+	for serverName, fc := range c.Sinks.OTLPServers {
+		if fc.Channels.noChannelsSelected() {
+			delete(c.Sinks.OTLPServers, serverName)
+		}
+	}
+
 	return nil
 }
 
@@ -478,6 +531,36 @@ func (c *Config) validateHTTPSinkConfig(hsc *HTTPSinkConfig) error {
 	return c.ValidateCommonSinkConfig(hsc.CommonSinkConfig)
 }
 
+func (c *Config) validateOTLPSinkConfig(oc *OTLPSinkConfig) error {
+	propagateOTLPDefaults(&oc.OTLPDefaults, c.OTLPDefaults)
+	if oc.Address == nil || len(*oc.Address) == 0 {
+		return errors.New("address cannot be empty")
+	}
+	if *oc.Format != DefaultOTLPFormat {
+		return errors.Newf("unsupported format %q: OTLP sinks only support format %q",
+			*oc.Format, DefaultOTLPFormat)
+	}
+	if *oc.Compression != GzipCompression && *oc.Compression != NoneCompression {
+		return errors.New("compression must be 'gzip' or 'none'")
+	}
+	if !oc.Buffering.IsNone() {
+		// The sink assembles the buffered log records into export
+		// requests itself; avoid additional formatting in the buffering
+		// configuration.
+		fmtNone := BufferFmtNone
+		oc.Buffering.Format = &fmtNone
+	}
+
+	// Apply the auditable flag if set.
+	if *oc.Auditable {
+		bt := true
+		oc.Criticality = &bt
+	}
+	oc.Auditable = nil
+
+	return c.ValidateCommonSinkConfig(oc.CommonSinkConfig)
+}
+
 func normalizeDir(dir **string) error {
 	if *dir == nil {
 		return nil
@@ -512,6 +595,10 @@ func propagateHTTPDefaults(target *HTTPDefaults, source HTTPDefaults) {
 	propagateDefaults(target, source)
 }
 
+func propagateOTLPDefaults(target *OTLPDefaults, source OTLPDefaults) {
+	propagateDefaults(target, source)
+}
+
 // propagateDefaults takes (target *T, source T) where T is a struct
 // and sets zero-valued exported fields in target to the values
 // from source (recursively for struct-valued fields).
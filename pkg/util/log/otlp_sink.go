@@ -0,0 +1,329 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// otlpHTTPLogsPath is the default path of the OTLP/HTTP logs endpoint.
+const otlpHTTPLogsPath = "/v1/logs"
+
+// otlpLogsField is the field number of the log records in an
+// InstrumentationLibraryLogs message.
+var otlpLogsField = (&logspb.InstrumentationLibraryLogs{}).ProtoReflect().
+	Descriptor().Fields().ByName("logs").Number()
+
+// formatOTLP converts log entries to OTLP log records.
+//
+// Each entry is encoded as one occurrence of the repeated log records
+// field of an InstrumentationLibraryLogs message. This way, any
+// concatenation of formatted entries, as produced by a bufferedSink
+// with the "none" buffer format, is itself a valid encoding of an
+// InstrumentationLibraryLogs message that otlpSink can decode.
+type formatOTLP struct{}
+
+func (formatOTLP) setOption(k string, _ string) error {
+	return errors.Newf("unknown option: %q", redact.Safe(k))
+}
+func (formatOTLP) formatterName() string { return logconfig.DefaultOTLPFormat }
+func (formatOTLP) doc() string           { return "internal only" }
+func (formatOTLP) contentType() string   { return "application/x-protobuf" }
+func (formatOTLP) formatEntry(entry logEntry) *buffer {
+	buf := getBuffer()
+	rec, err := proto.Marshal(otlpLogRecord(entry))
+	if err != nil {
+		rec, _ = proto.Marshal(&logspb.LogRecord{
+			TimeUnixNano: uint64(entry.ts),
+			Body:         otlpStringValue(fmt.Sprintf("unable to format entry: %v", err)),
+		})
+	}
+	b := protowire.AppendTag(buf.tmp[:0], otlpLogsField, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(len(rec)))
+	buf.Write(b)
+	buf.Write(rec)
+	return buf
+}
+
+// otlpLogRecord converts a log entry to an OTLP log record. The
+// severity is reported using the OTLP severity fields; the channel,
+// redactability, tags, source location and server identifiers are
+// reported as attributes.
+func otlpLogRecord(entry logEntry) *logspb.LogRecord {
+	rec := &logspb.LogRecord{
+		TimeUnixNano:   uint64(entry.ts),
+		SeverityNumber: otlpSeverityNumber(entry.sev),
+		SeverityText:   entry.sev.String(),
+	}
+	if entry.structured {
+		rec.Body = otlpStringValue("{" + entry.payload.message + "}") // Already JSON.
+	} else {
+		rec.Body = otlpStringValue(entry.payload.message)
+	}
+
+	attrs := []*commonpb.KeyValue{
+		otlpStringAttribute("channel", entry.ch.String()),
+		otlpBoolAttribute("redactable", entry.payload.redactable),
+	}
+	if entry.payload.tags != nil {
+		fi := formattableTagsIterator{tags: []byte(entry.payload.tags)}
+		for {
+			key, val, done := fi.next()
+			if done {
+				break
+			}
+			attrs = append(attrs, otlpStringAttribute("tags."+string(key), string(val)))
+		}
+	}
+	attrs = append(attrs,
+		otlpStringAttribute("file", entry.file),
+		otlpIntAttribute("line", int64(entry.line)),
+		otlpIntAttribute("goroutine", entry.gid),
+		otlpIntAttribute("entry_counter", int64(entry.counter)),
+	)
+	for _, id := range []struct{ key, val string }{
+		{"cluster_id", entry.ClusterID},
+		{"node_id", entry.NodeID},
+		{"tenant_id", entry.TenantID},
+		{"tenant_name", entry.TenantName},
+		{"instance_id", entry.SQLInstanceID},
+		{"version", entry.version},
+	} {
+		if id.val != "" {
+			attrs = append(attrs, otlpStringAttribute(id.key, id.val))
+		}
+	}
+	if len(entry.stacks) > 0 {
+		attrs = append(attrs, otlpStringAttribute("stacks", string(entry.stacks)))
+	}
+	rec.Attributes = attrs
+	return rec
+}
+
+// otlpSeverityNumber maps a logging severity to the corresponding
+// OTLP severity number.
+func otlpSeverityNumber(sev Severity) logspb.SeverityNumber {
+	switch sev {
+	case severity.INFO:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case severity.WARNING:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case severity.ERROR:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case severity.FATAL:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+func otlpStringValue(v string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+}
+
+func otlpStringAttribute(k, v string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: otlpStringValue(v)}
+}
+
+func otlpIntAttribute(k string, v int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{
+		Value: &commonpb.AnyValue_IntValue{IntValue: v},
+	}}
+}
+
+func otlpBoolAttribute(k string, v bool) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{
+		Value: &commonpb.AnyValue_BoolValue{BoolValue: v},
+	}}
+}
+
+// otlpSink sends log entries to an OpenTelemetry collector, over
+// either OTLP/gRPC or OTLP/HTTP.
+type otlpSink struct {
+	config   *logconfig.OTLPSinkConfig
+	address  string
+	resource *resourcepb.Resource
+
+	// conn is the connection to the collector in grpc mode.
+	conn *grpc.ClientConn
+	// client is used to send requests to the collector in http mode.
+	client http.Client
+}
+
+func newOTLPSink(c logconfig.OTLPSinkConfig) (*otlpSink, error) {
+	s := &otlpSink{
+		config:  &c,
+		address: *c.Address,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{otlpStringAttribute("service.name", "cockroachdb")},
+		},
+	}
+	switch *c.Mode {
+	case logconfig.OTLPModeGRPC:
+		creds := credentials.NewTLS(&tls.Config{})
+		if *c.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		// Dialing does not block: the connection is established in the
+		// background, and re-established as needed.
+		conn, err := grpc.Dial(s.address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+	case logconfig.OTLPModeHTTP:
+		u, err := url.Parse(s.address)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing OTLP/HTTP address")
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = otlpHTTPLogsPath
+		}
+		s.address = u.String()
+		s.client.Timeout = *c.Timeout
+	default:
+		return nil, errors.AssertionFailedf("unknown OTLP mode: %q", *c.Mode)
+	}
+	return s, nil
+}
+
+// close releases the connection to the collector.
+func (s *otlpSink) close() {
+	if s.conn == nil {
+		return
+	}
+	if err := s.conn.Close(); err != nil {
+		fmt.Fprintf(OrigStderr, "error closing OTLP logger: %v\n", err)
+	}
+}
+
+// output emits some formatted bytes to this sink. The bytes are the
+// concatenation of one or more entries formatted by formatOTLP.
+//
+// The parent logger's outputMu is held during this operation: log
+// sinks must not recursively call into logging when implementing
+// this method.
+func (s *otlpSink) output(b []byte, opt sinkOutputOptions) error {
+	if len(b) == 0 {
+		return nil
+	}
+	logs := &logspb.InstrumentationLibraryLogs{}
+	if err := proto.Unmarshal(b, logs); err != nil {
+		return errors.Wrap(err, "decoding OTLP log records")
+	}
+	req := &collectorpb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource:                   s.resource,
+			InstrumentationLibraryLogs: []*logspb.InstrumentationLibraryLogs{logs},
+		}},
+	}
+	if s.conn != nil {
+		return s.exportGRPC(req)
+	}
+	return s.exportHTTP(req)
+}
+
+func (s *otlpSink) exportGRPC(req *collectorpb.ExportLogsServiceRequest) error {
+	ctx := context.Background()
+	if *s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *s.config.Timeout)
+		defer cancel()
+	}
+	for k, v := range s.config.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	var opts []grpc.CallOption
+	if *s.config.Compression == logconfig.GzipCompression {
+		opts = append(opts, grpc.UseCompressor(grpcgzip.Name))
+	}
+	_, err := collectorpb.NewLogsServiceClient(s.conn).Export(ctx, req, opts...)
+	return err
+}
+
+func (s *otlpSink) exportHTTP(req *collectorpb.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if *s.config.Compression == logconfig.GzipCompression {
+		g := gzip.NewWriter(&buf)
+		if _, err := g.Write(body); err != nil {
+			return err
+		}
+		if err := g.Close(); err != nil {
+			return err
+		}
+	} else {
+		buf.Write(body)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, s.address, &buf)
+	if err != nil {
+		return err
+	}
+	if *s.config.Compression == logconfig.GzipCompression {
+		httpReq.Header.Add(httputil.ContentEncodingHeader, httputil.GzipEncoding)
+	}
+	for k, v := range s.config.Headers {
+		httpReq.Header.Add(k, v)
+	}
+	httpReq.Header.Add(httputil.ContentTypeHeader, formatOTLP{}.contentType())
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return errors.Newf("OTLP collector at %s returned %s", s.address, resp.Status)
+	}
+	return nil
+}
+
+// active returns true if this sink is currently active.
+func (*otlpSink) active() bool {
+	return true
+}
+
+// attachHints attaches some hints about the location of the message
+// to the stack message.
+func (*otlpSink) attachHints(stacks []byte) []byte {
+	return stacks
+}
+
+// exitCode returns the exit code to use if the logger decides
+// to terminate because of an error in output().
+func (*otlpSink) exitCode() exit.Code {
+	return exit.LoggingNetCollectorUnavailable()
+}
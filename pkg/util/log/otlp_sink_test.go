@@ -0,0 +1,221 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log/channel"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/logtags"
+	"github.com/stretchr/testify/require"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// testOTLPLogsCollector stands in for an OpenTelemetry collector,
+// recording the log records it receives.
+type testOTLPLogsCollector struct {
+	collectorpb.UnimplementedLogsServiceServer
+
+	mu struct {
+		syncutil.Mutex
+		resourceAttrs map[string]string
+		records       []*logspb.LogRecord
+	}
+}
+
+func (c *testOTLPLogsCollector) receive(req *collectorpb.ExportLogsServiceRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rl := range req.ResourceLogs {
+		c.mu.resourceAttrs = map[string]string{}
+		for _, kv := range rl.Resource.Attributes {
+			c.mu.resourceAttrs[kv.Key] = kv.Value.GetStringValue()
+		}
+		for _, ill := range rl.InstrumentationLibraryLogs {
+			c.mu.records = append(c.mu.records, ill.Logs...)
+		}
+	}
+}
+
+// Export implements the LogsServiceServer interface.
+func (c *testOTLPLogsCollector) Export(
+	_ context.Context, req *collectorpb.ExportLogsServiceRequest,
+) (*collectorpb.ExportLogsServiceResponse, error) {
+	c.receive(req)
+	return &collectorpb.ExportLogsServiceResponse{}, nil
+}
+
+// ServeHTTP implements the OTLP/HTTP endpoint.
+func (c *testOTLPLogsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != otlpHTTPLogsPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		g, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = g
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &collectorpb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.receive(req)
+	w.WriteHeader(http.StatusOK)
+}
+
+// waitForRecord waits until the collector has received a record with
+// the given body, and returns it along with its attributes.
+func (c *testOTLPLogsCollector) waitForRecord(
+	t *testing.T, body string,
+) (*logspb.LogRecord, map[string]interface{}) {
+	for deadline := timeutil.Now().Add(10 * time.Second); timeutil.Now().Before(deadline); {
+		if rec := func() *logspb.LogRecord {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for _, rec := range c.mu.records {
+				if rec.Body.GetStringValue() == body {
+					require.Equal(t, map[string]string{"service.name": "cockroachdb"}, c.mu.resourceAttrs)
+					return rec
+				}
+			}
+			return nil
+		}(); rec != nil {
+			attrs := map[string]interface{}{}
+			for _, kv := range rec.Attributes {
+				switch v := kv.Value.Value.(type) {
+				case *commonpb.AnyValue_StringValue:
+					attrs[kv.Key] = v.StringValue
+				case *commonpb.AnyValue_IntValue:
+					attrs[kv.Key] = v.IntValue
+				case *commonpb.AnyValue_BoolValue:
+					attrs[kv.Key] = v.BoolValue
+				}
+			}
+			return rec, attrs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("collector did not receive a record with body %q", body)
+	return nil, nil
+}
+
+func TestOTLPSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	run := func(t *testing.T, c *testOTLPLogsCollector, defaults logconfig.OTLPDefaults) {
+		sc := ScopeWithoutShowLogs(t)
+		defer sc.Close(t)
+
+		// Flush the buffered entries quickly, so that several entries
+		// are sent in each export request.
+		staleness := 50 * time.Millisecond
+		defaults.Buffering.MaxStaleness = &staleness
+
+		cfg := logconfig.DefaultConfig()
+		cfg.Sinks.OTLPServers = map[string]*logconfig.OTLPSinkConfig{
+			"ops": {
+				OTLPDefaults: defaults,
+				Channels:     logconfig.SelectChannels(channel.OPS, channel.SENSITIVE_ACCESS),
+			},
+		}
+		require.NoError(t, cfg.Validate(&sc.logDir))
+
+		TestingResetActive()
+		cleanup, err := ApplyConfig(cfg, nil /* fileSinkMetricsForDir */, nil /* fatalOnLogStall */)
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx := logtags.AddTag(context.Background(), "n", 1)
+		Ops.Infof(ctx, "hello %s", "world")
+		Ops.Warning(ctx, "second")
+
+		rec, attrs := c.waitForRecord(t, "hello ‹world›")
+		require.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, rec.SeverityNumber)
+		require.Equal(t, "INFO", rec.SeverityText)
+		require.NotZero(t, rec.TimeUnixNano)
+		require.Equal(t, "OPS", attrs["channel"])
+		require.Equal(t, true, attrs["redactable"])
+		require.Equal(t, "1", attrs["tags.n"])
+		require.Contains(t, attrs["file"], "otlp_sink_test.go")
+		require.NotZero(t, attrs["line"])
+
+		rec, _ = c.waitForRecord(t, "second")
+		require.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, rec.SeverityNumber)
+		require.Equal(t, "WARNING", rec.SeverityText)
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		c := &testOTLPLogsCollector{}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv := grpc.NewServer()
+		collectorpb.RegisterLogsServiceServer(srv, c)
+		go func() { _ = srv.Serve(lis) }()
+		defer srv.Stop()
+
+		address := lis.Addr().String()
+		mode := logconfig.OTLPModeGRPC
+		insecure := true
+		run(t, c, logconfig.OTLPDefaults{Address: &address, Mode: &mode, Insecure: &insecure})
+	})
+
+	t.Run("http", func(t *testing.T) {
+		c := &testOTLPLogsCollector{}
+		srv := httptest.NewServer(c)
+		defer srv.Close()
+
+		mode := logconfig.OTLPModeHTTP
+		run(t, c, logconfig.OTLPDefaults{Address: &srv.URL, Mode: &mode})
+	})
+}
+
+func TestFormatOTLPConcatenation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// The concatenation of formatted entries must decode as a single
+	// InstrumentationLibraryLogs message.
+	var b []byte
+	for _, msg := range []string{"a", "b", "c"} {
+		entry := makeUnstructuredEntry(context.Background(), severity.INFO, channel.DEV,
+			0 /* depth */, true /* redactable */, msg)
+		buf := formatOTLP{}.formatEntry(entry)
+		b = append(b, buf.Bytes()...)
+		putBuffer(buf)
+	}
+	logs := &logspb.InstrumentationLibraryLogs{}
+	require.NoError(t, proto.Unmarshal(b, logs))
+	require.Len(t, logs.Logs, 3)
+	for i, msg := range []string{"a", "b", "c"} {
+		require.Equal(t, msg, logs.Logs[i].Body.GetStringValue())
+	}
+}
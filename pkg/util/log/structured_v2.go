@@ -23,6 +23,16 @@ var (
 		EventType: "stmt_stats",
 		Version:   "0.1",
 	}
+	SQL_TOP_STATEMENTS = StructuredLogMeta{
+		EventType: "sql_top_statements",
+		Version:   "0.1",
+		Channel:   logpb.Channel_TELEMETRY,
+	}
+	SQL_USER_ACTIVITY = StructuredLogMeta{
+		EventType: "sql_user_activity",
+		Version:   "0.1",
+		Channel:   logpb.Channel_TELEMETRY,
+	}
 )
 
 // StructuredLogMeta is a metadata object that accompanies each event emitted via Structured.
@@ -30,6 +40,8 @@ var (
 type StructuredLogMeta struct {
 	EventType EventType `json:"event_type"`
 	Version   string    `json:"version"`
+	// Channel is the channel the events are emitted to, DEV by default.
+	Channel logpb.Channel `json:"-"`
 }
 
 var _ redact.SafeFormatter = (*StructuredLogMeta)(nil)
@@ -58,7 +70,8 @@ type StructuredPayload struct {
 	Payload  any               `json:"payload"`
 }
 
-// Structured emits a structured JSON payload to the DEV channel, along with the included metadata.
+// Structured emits a structured JSON payload to the channel of the metadata, along with the included
+// metadata.
 // TODO(abarganier): Redaction is not considered here yet. Enable redaction via struct tags.
 // TODO(abarganier): StructuredEvent() is a similar API. We should consider how to reconcile or perhaps
 // combine the two.
@@ -84,7 +97,7 @@ func Structured(ctx context.Context, meta StructuredLogMeta, payload any) {
 	payloadBytes = bytes.TrimPrefix(payloadBytes, trimPrefix)
 	payloadBytes = bytes.TrimSuffix(payloadBytes, trimSuffix)
 
-	entry := makeEntry(ctx, severity.INFO, logpb.Channel_DEV, 0 /*depth*/)
+	entry := makeEntry(ctx, severity.INFO, meta.Channel, 0 /*depth*/)
 	entry.structured = true
 	// TODO(abarganier): Once redaction is in place, we shouldn't need to cast to RedactableString here.
 	entry.payload = makeRedactablePayload(ctx, redact.RedactableString(payloadBytes))
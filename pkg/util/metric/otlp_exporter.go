@@ -0,0 +1,382 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	prometheusgo "github.com/prometheus/client_model/go"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLPProtocol is the transport used to push metrics to an OpenTelemetry
+// collector.
+type OTLPProtocol int
+
+const (
+	// OTLPProtocolGRPC pushes metrics with the OTLP/gRPC MetricsService.
+	OTLPProtocolGRPC OTLPProtocol = iota
+	// OTLPProtocolHTTP pushes metrics as binary protobuf with OTLP/HTTP.
+	OTLPProtocolHTTP
+)
+
+// String implements fmt.Stringer.
+func (p OTLPProtocol) String() string {
+	switch p {
+	case OTLPProtocolGRPC:
+		return "grpc"
+	case OTLPProtocolHTTP:
+		return "http"
+	default:
+		return fmt.Sprintf("OTLPProtocol(%d)", int(p))
+	}
+}
+
+// otlpHTTPMetricsPath is the default URL path of the OTLP/HTTP metrics
+// endpoint, used when the configured endpoint has no path.
+const otlpHTTPMetricsPath = "/v1/metrics"
+
+var errNoOTLPEndpoint = errors.New("external.otlp.metrics.endpoint is not set")
+
+// OTLPExporterOptions configures a single push of an OTLPExporter.
+type OTLPExporterOptions struct {
+	// Endpoint is host:port of the collector for OTLP/gRPC, or its URL for
+	// OTLP/HTTP.
+	Endpoint string
+	Protocol OTLPProtocol
+	// BatchSize is the maximum number of metrics sent in one export request.
+	// If zero, all metrics are sent in a single request.
+	BatchSize int
+	// Timeout bounds each export request.
+	Timeout time.Duration
+	// Retry controls how failed export requests that may succeed when retried
+	// are retried.
+	Retry retry.Options
+	// ResourceAttributes describe the node that the metrics come from, such as
+	// its node ID, tenant and locality.
+	ResourceAttributes map[string]string
+}
+
+// OTLPExporter scrapes PrometheusExporter for metrics and pushes them to an
+// OpenTelemetry collector using the OpenTelemetry Protocol (OTLP).
+//
+// Counters are exported as cumulative monotonic sums, gauges as gauges and
+// histograms as cumulative explicit-bucket histograms. The labels of each
+// metric become the attributes of its data point.
+//
+// An OTLPExporter keeps a connection to the collector open across pushes, so
+// it is not safe for concurrent use and must be closed when no longer needed.
+type OTLPExporter struct {
+	pm *PrometheusExporter
+	// startTime is the start time of the cumulative data points, which
+	// accumulate from the time the process started exporting.
+	startTime time.Time
+
+	conn struct {
+		endpoint string
+		cc       *grpc.ClientConn
+	}
+	httpClient http.Client
+}
+
+// MakeOTLPExporter returns an initialized OTLP exporter.
+func MakeOTLPExporter(pm *PrometheusExporter) OTLPExporter {
+	return OTLPExporter{pm: pm, startTime: timeutil.Now()}
+}
+
+// Push metrics scraped from registry to an OpenTelemetry collector.
+func (oe *OTLPExporter) Push(ctx context.Context, opts OTLPExporterOptions) error {
+	if opts.Endpoint == "" {
+		return errNoOTLPEndpoint
+	}
+	// As with the Graphite exporter, only the latest metrics are pushed, so
+	// clear them regardless of whether the push succeeds.
+	defer oe.pm.clearMetrics()
+
+	families, err := oe.pm.Gather()
+	if err != nil {
+		return err
+	}
+	metrics := otlpMetricsFromFamilies(families, oe.startTime, timeutil.Now())
+	if len(metrics) == 0 {
+		return nil
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(metrics)
+	}
+	resource := otlpResource(opts.ResourceAttributes)
+	for len(metrics) > 0 {
+		n := batchSize
+		if n > len(metrics) {
+			n = len(metrics)
+		}
+		req := &collectorpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricspb.ResourceMetrics{{
+				Resource: resource,
+				InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+					InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: "cockroachdb"},
+					Metrics:                metrics[:n],
+				}},
+			}},
+		}
+		if err := oe.exportWithRetry(ctx, opts, req); err != nil {
+			return err
+		}
+		metrics = metrics[n:]
+	}
+	return nil
+}
+
+// Close closes the connection to the collector, if any.
+func (oe *OTLPExporter) Close() error {
+	oe.httpClient.CloseIdleConnections()
+	if oe.conn.cc == nil {
+		return nil
+	}
+	err := oe.conn.cc.Close()
+	oe.conn.cc = nil
+	oe.conn.endpoint = ""
+	return err
+}
+
+// exportWithRetry sends an export request, retrying it for as long as it
+// fails in a way that the OTLP specification allows to be retried.
+func (oe *OTLPExporter) exportWithRetry(
+	ctx context.Context, opts OTLPExporterOptions, req *collectorpb.ExportMetricsServiceRequest,
+) error {
+	var err error
+	for r := retry.StartWithCtx(ctx, opts.Retry); r.Next(); {
+		if err = oe.export(ctx, opts, req); err == nil || !errors.HasType(err, (*otlpRetryableError)(nil)) {
+			return err
+		}
+		log.VEventf(ctx, 1, "retrying OTLP metrics export after error: %v", err)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+func (oe *OTLPExporter) export(
+	ctx context.Context, opts OTLPExporterOptions, req *collectorpb.ExportMetricsServiceRequest,
+) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	switch opts.Protocol {
+	case OTLPProtocolGRPC:
+		return oe.exportGRPC(ctx, opts.Endpoint, req)
+	case OTLPProtocolHTTP:
+		return oe.exportHTTP(ctx, opts.Endpoint, req)
+	default:
+		return errors.AssertionFailedf("unknown OTLP protocol %d", opts.Protocol)
+	}
+}
+
+func (oe *OTLPExporter) exportGRPC(
+	ctx context.Context, endpoint string, req *collectorpb.ExportMetricsServiceRequest,
+) error {
+	if oe.conn.cc != nil && oe.conn.endpoint != endpoint {
+		if err := oe.Close(); err != nil {
+			log.Warningf(ctx, "closing connection to OTLP collector: %v", err)
+		}
+	}
+	if oe.conn.cc == nil {
+		cc, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return err
+		}
+		oe.conn.cc, oe.conn.endpoint = cc, endpoint
+	}
+	_, err := collectorpb.NewMetricsServiceClient(oe.conn.cc).Export(ctx, req)
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return &otlpRetryableError{cause: err}
+	}
+	return err
+}
+
+func (oe *OTLPExporter) exportHTTP(
+	ctx context.Context, endpoint string, req *collectorpb.ExportMetricsServiceRequest,
+) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.Wrap(err, "parsing OTLP/HTTP endpoint")
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpHTTPMetricsPath
+	}
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := oe.httpClient.Do(httpReq)
+	if err != nil {
+		return &otlpRetryableError{cause: err}
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		return nil
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return &otlpRetryableError{cause: errors.Newf("OTLP collector returned %s", resp.Status)}
+	default:
+		return errors.Newf("OTLP collector returned %s", resp.Status)
+	}
+}
+
+// otlpRetryableError is returned for export requests that failed in a way
+// that the OTLP specification allows to be retried.
+type otlpRetryableError struct {
+	cause error
+}
+
+func (e *otlpRetryableError) Error() string { return e.cause.Error() }
+func (e *otlpRetryableError) Cause() error  { return e.cause }
+func (e *otlpRetryableError) Unwrap() error { return e.cause }
+
+// otlpResource returns the OTLP resource with the given attributes.
+func otlpResource(attrs map[string]string) *resourcepb.Resource {
+	keys := make([]string, 0, len(attrs)+1)
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{otlpStringAttribute("service.name", "cockroachdb")},
+	}
+	for _, k := range keys {
+		res.Attributes = append(res.Attributes, otlpStringAttribute(k, attrs[k]))
+	}
+	return res
+}
+
+func otlpStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func otlpAttributes(labels []*prometheusgo.LabelPair) []*commonpb.KeyValue {
+	attrs := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, otlpStringAttribute(l.GetName(), l.GetValue()))
+	}
+	return attrs
+}
+
+// otlpMetricsFromFamilies converts the scraped metric families into OTLP
+// metrics, sorted by name. Cumulative data points accumulate from start.
+func otlpMetricsFromFamilies(
+	families []*prometheusgo.MetricFamily, start, now time.Time,
+) []*metricspb.Metric {
+	startNanos, nowNanos := uint64(start.UnixNano()), uint64(now.UnixNano())
+	numberPoint := func(m *prometheusgo.Metric, v float64) *metricspb.NumberDataPoint {
+		return &metricspb.NumberDataPoint{
+			Attributes:        otlpAttributes(m.Label),
+			StartTimeUnixNano: startNanos,
+			TimeUnixNano:      nowNanos,
+			Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: v},
+		}
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(families))
+	for _, family := range families {
+		out := &metricspb.Metric{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case prometheusgo.MetricType_COUNTER:
+			sum := &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}
+			for _, m := range family.Metric {
+				sum.DataPoints = append(sum.DataPoints, numberPoint(m, m.GetCounter().GetValue()))
+			}
+			out.Data = &metricspb.Metric_Sum{Sum: sum}
+		case prometheusgo.MetricType_GAUGE:
+			gauge := &metricspb.Gauge{}
+			for _, m := range family.Metric {
+				gauge.DataPoints = append(gauge.DataPoints, numberPoint(m, m.GetGauge().GetValue()))
+			}
+			out.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+		case prometheusgo.MetricType_HISTOGRAM:
+			hist := &metricspb.Histogram{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}
+			for _, m := range family.Metric {
+				p := otlpHistogramPoint(m.GetHistogram())
+				p.Attributes = otlpAttributes(m.Label)
+				p.StartTimeUnixNano, p.TimeUnixNano = startNanos, nowNanos
+				hist.DataPoints = append(hist.DataPoints, p)
+			}
+			out.Data = &metricspb.Metric_Histogram{Histogram: hist}
+		default:
+			log.VEventf(context.Background(), 2,
+				"metric %s of type %s cannot be exported with OTLP", family.GetName(), family.GetType())
+			continue
+		}
+		metrics = append(metrics, out)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+// otlpHistogramPoint converts a prometheus histogram, whose buckets hold
+// cumulative counts, into an OTLP histogram data point, whose buckets hold the
+// count of each bucket alone and which has an implicit +Inf bucket.
+func otlpHistogramPoint(h *prometheusgo.Histogram) *metricspb.HistogramDataPoint {
+	p := &metricspb.HistogramDataPoint{
+		Count: h.GetSampleCount(),
+		Sum:   h.GetSampleSum(),
+	}
+	var prev uint64
+	for _, b := range h.Bucket {
+		if math.IsInf(b.GetUpperBound(), +1) {
+			break
+		}
+		p.ExplicitBounds = append(p.ExplicitBounds, b.GetUpperBound())
+		p.BucketCounts = append(p.BucketCounts, b.GetCumulativeCount()-prev)
+		prev = b.GetCumulativeCount()
+	}
+	p.BucketCounts = append(p.BucketCounts, p.Count-prev)
+	return p
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/stretchr/testify/require"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testOTLPCollector stands in for an OpenTelemetry collector, recording the
+// export requests it receives. It fails the first failures requests.
+type testOTLPCollector struct {
+	collectorpb.UnimplementedMetricsServiceServer
+
+	mu struct {
+		sync.Mutex
+		failures int
+		requests []*collectorpb.ExportMetricsServiceRequest
+	}
+}
+
+func (c *testOTLPCollector) receive(req *collectorpb.ExportMetricsServiceRequest) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.failures > 0 {
+		c.mu.failures--
+		return false
+	}
+	c.mu.requests = append(c.mu.requests, req)
+	return true
+}
+
+// Export implements the MetricsServiceServer interface.
+func (c *testOTLPCollector) Export(
+	_ context.Context, req *collectorpb.ExportMetricsServiceRequest,
+) (*collectorpb.ExportMetricsServiceResponse, error) {
+	if !c.receive(req) {
+		return nil, status.Error(codes.Unavailable, "collector unavailable")
+	}
+	return &collectorpb.ExportMetricsServiceResponse{}, nil
+}
+
+// ServeHTTP implements the OTLP/HTTP endpoint.
+func (c *testOTLPCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != otlpHTTPMetricsPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &collectorpb.ExportMetricsServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !c.receive(req) {
+		http.Error(w, "collector unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// metrics returns the metrics received by the collector, by name.
+func (c *testOTLPCollector) metrics(t *testing.T) (int, map[string]*metricspb.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metrics := map[string]*metricspb.Metric{}
+	for _, req := range c.mu.requests {
+		require.Len(t, req.ResourceMetrics, 1)
+		attrs := map[string]string{}
+		for _, kv := range req.ResourceMetrics[0].Resource.Attributes {
+			attrs[kv.Key] = kv.Value.GetStringValue()
+		}
+		require.Equal(t, map[string]string{
+			"service.name":        "cockroachdb",
+			"cockroachdb.node_id": "7",
+		}, attrs)
+		for _, lib := range req.ResourceMetrics[0].InstrumentationLibraryMetrics {
+			for _, m := range lib.Metrics {
+				metrics[m.Name] = m
+			}
+		}
+	}
+	return len(c.mu.requests), metrics
+}
+
+func TestOTLPExporter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	r := NewRegistry()
+	r.AddLabel("node_id", "7")
+	counter := NewCounter(Metadata{Name: "test.counter", Help: "A counter."})
+	counter.Inc(5)
+	r.AddMetric(counter)
+	gauge := NewGauge(Metadata{Name: "test.gauge"})
+	gauge.Update(-3)
+	r.AddMetric(gauge)
+	hist := NewHistogram(HistogramOptions{
+		Mode:     HistogramModePrometheus,
+		Metadata: Metadata{Name: "test.histogram"},
+		Duration: time.Hour,
+		Buckets:  []float64{1, 10, 100},
+	})
+	for _, v := range []int64{0, 5, 7, 50, 1000} {
+		hist.RecordValue(v)
+	}
+	r.AddMetric(hist)
+
+	checkMetrics := func(t *testing.T, metrics map[string]*metricspb.Metric) {
+		require.Len(t, metrics, 3)
+
+		sum := metrics["test_counter"].GetSum()
+		require.NotNil(t, sum)
+		require.True(t, sum.IsMonotonic)
+		require.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			sum.AggregationTemporality)
+		require.Len(t, sum.DataPoints, 1)
+		require.Equal(t, 5.0, sum.DataPoints[0].GetAsDouble())
+		require.Equal(t, "A counter", metrics["test_counter"].Description)
+		require.Len(t, sum.DataPoints[0].Attributes, 1)
+		require.Equal(t, "node_id", sum.DataPoints[0].Attributes[0].Key)
+		require.Equal(t, "7", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+		require.LessOrEqual(t, sum.DataPoints[0].StartTimeUnixNano, sum.DataPoints[0].TimeUnixNano)
+
+		g := metrics["test_gauge"].GetGauge()
+		require.NotNil(t, g)
+		require.Len(t, g.DataPoints, 1)
+		require.Equal(t, -3.0, g.DataPoints[0].GetAsDouble())
+
+		h := metrics["test_histogram"].GetHistogram()
+		require.NotNil(t, h)
+		require.Len(t, h.DataPoints, 1)
+		p := h.DataPoints[0]
+		require.Equal(t, uint64(5), p.Count)
+		require.Equal(t, 1062.0, p.Sum)
+		require.Equal(t, []float64{1, 10, 100}, p.ExplicitBounds)
+		require.Equal(t, []uint64{1, 2, 1, 1}, p.BucketCounts)
+	}
+
+	// Failed export requests are retried once.
+	opts := OTLPExporterOptions{
+		BatchSize: 2,
+		Timeout:   10 * time.Second,
+		Retry: retry.Options{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			MaxRetries:     1,
+		},
+		ResourceAttributes: map[string]string{"cockroachdb.node_id": "7"},
+	}
+	push := func(t *testing.T, c *testOTLPCollector, opts OTLPExporterOptions) {
+		pm := MakePrometheusExporter()
+		e := MakeOTLPExporter(&pm)
+		defer func() { require.NoError(t, e.Close()) }()
+
+		// The collector fails the first request, which is retried.
+		c.mu.Lock()
+		c.mu.failures = 1
+		c.mu.Unlock()
+		pm.ScrapeRegistry(r, false /* includeChildMetrics */)
+		require.NoError(t, e.Push(ctx, opts))
+		requests, metrics := c.metrics(t)
+		// The batch size of 2 splits the 3 metrics into two requests.
+		require.Equal(t, 2, requests)
+		checkMetrics(t, metrics)
+
+		// The exporter gives up once the retries run out.
+		c.mu.Lock()
+		c.mu.failures = 2
+		c.mu.Unlock()
+		pm.ScrapeRegistry(r, false /* includeChildMetrics */)
+		require.Error(t, e.Push(ctx, opts))
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		c := &testOTLPCollector{}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv := grpc.NewServer()
+		collectorpb.RegisterMetricsServiceServer(srv, c)
+		go func() { _ = srv.Serve(lis) }()
+		defer srv.Stop()
+
+		opts := opts
+		opts.Protocol = OTLPProtocolGRPC
+		opts.Endpoint = lis.Addr().String()
+		push(t, c, opts)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		c := &testOTLPCollector{}
+		srv := httptest.NewServer(c)
+		defer srv.Close()
+
+		opts := opts
+		opts.Protocol = OTLPProtocolHTTP
+		opts.Endpoint = srv.URL
+		push(t, c, opts)
+	})
+
+	t.Run("no endpoint", func(t *testing.T) {
+		pm := MakePrometheusExporter()
+		e := MakeOTLPExporter(&pm)
+		require.ErrorIs(t, e.Push(ctx, OTLPExporterOptions{}), errNoOTLPEndpoint)
+	})
+}
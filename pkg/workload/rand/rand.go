@@ -386,7 +386,7 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return d.Time, nil
 	case *tree.DInterval:
 		return d.Duration.String(), nil
-	case *tree.DBitArray:
+	case *tree.DBitArray, *tree.DMacAddr, *tree.DMoney:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	case *tree.DInt:
 		return int64(*d), nil
@@ -427,6 +427,8 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return geo.SpatialObjectToEWKT(d.Geometry.SpatialObject(), 2)
 	case *tree.DPGLSN:
 		return d.LSN.String(), nil
+	case *tree.DJsonpath:
+		return d.Jsonpath.String(), nil
 	case *tree.DTSQuery:
 		return d.String(), nil
 	case *tree.DTSVector: